@@ -39,6 +39,17 @@ func (c *Client) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsRes
 	return results, err
 }
 
+// FindActions returns the actions in the environment selected by the
+// filter, oldest first.
+func (c *Client) FindActions(arg params.ActionsFilter) (params.ActionResults, error) {
+	results := params.ActionResults{}
+	err := c.facade.FacadeCall("FindActions", arg, &results)
+	if params.IsCodeNotImplemented(err) {
+		return results, errors.NotImplementedf("filtering actions")
+	}
+	return results, err
+}
+
 // Enqueue takes a list of Actions and queues them up to be executed by
 // the designated ActionReceiver, returning the params.Action for each
 // queued Action, or an error if there was a problem queueing up the
@@ -49,6 +60,29 @@ func (c *Client) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	return results, err
 }
 
+// EnqueueGroups queues each of the given actions on the selected units
+// of a service as one group, returning the id of the group and the
+// queued actions for each.
+func (c *Client) EnqueueGroups(arg params.ActionGroups) (params.ActionGroupResults, error) {
+	results := params.ActionGroupResults{}
+	err := c.facade.FacadeCall("EnqueueGroups", arg, &results)
+	if params.IsCodeNotImplemented(err) {
+		return results, errors.NotImplementedf("queueing actions on a service")
+	}
+	return results, err
+}
+
+// ActionGroups returns the actions queued in each of the given action
+// groups.
+func (c *Client) ActionGroups(arg params.ActionGroupIds) (params.ActionGroupResults, error) {
+	results := params.ActionGroupResults{}
+	err := c.facade.FacadeCall("ActionGroups", arg, &results)
+	if params.IsCodeNotImplemented(err) {
+		return results, errors.NotImplementedf("action groups")
+	}
+	return results, err
+}
+
 // ListAll takes a list of Entities representing ActionReceivers and returns
 // all of the Actions that have been queued or run by each of those
 // Entities.
@@ -76,13 +110,45 @@ func (c *Client) ListCompleted(arg params.Entities) (params.ActionsByReceivers,
 	return results, err
 }
 
-// Cancel attempts to cancel a queued up Action from running.
-func (c *Client) Cancel(arg params.Actions) (params.ActionResults, error) {
+// Cancel attempts to cancel queued up or running Actions, given by
+// tag.
+func (c *Client) Cancel(arg params.Entities) (params.ActionResults, error) {
 	results := params.ActionResults{}
 	err := c.facade.FacadeCall("Cancel", arg, &results)
 	return results, err
 }
 
+// AddSchedules adds the given action schedules, on which the state
+// server queues actions, returning each schedule as added.
+func (c *Client) AddSchedules(arg params.ActionSchedules) (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	err := c.facade.FacadeCall("AddSchedules", arg, &results)
+	if params.IsCodeNotImplemented(err) {
+		return results, errors.NotImplementedf("action schedules")
+	}
+	return results, err
+}
+
+// Schedules returns all the action schedules in the environment.
+func (c *Client) Schedules() (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	err := c.facade.FacadeCall("Schedules", nil, &results)
+	if params.IsCodeNotImplemented(err) {
+		return results, errors.NotImplementedf("action schedules")
+	}
+	return results, err
+}
+
+// RemoveSchedules removes the action schedules with the given ids.
+func (c *Client) RemoveSchedules(arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	err := c.facade.FacadeCall("RemoveSchedules", arg, &results)
+	if params.IsCodeNotImplemented(err) {
+		return results, errors.NotImplementedf("action schedules")
+	}
+	return results, err
+}
+
 // servicesCharmActions is a batched query for the charm.Actions for a slice
 // of services by Entity.
 func (c *Client) servicesCharmActions(arg params.Entities) (params.ServicesCharmActionsResults, error) {
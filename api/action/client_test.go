@@ -4,8 +4,7 @@
 package action_test
 
 import (
-	"errors"
-
+	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -114,6 +113,141 @@ func (s *actionSuite) TestServiceCharmActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestFindActions(c *gc.C) {
+	filter := params.ActionsFilter{
+		Receivers: []string{names.NewServiceTag("foo").String()},
+		Statuses:  []string{params.ActionCompleted},
+	}
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "FindActions")
+			c.Assert(paramsIn, jc.DeepEquals, filter)
+			result := resp.(*params.ActionResults)
+			result.Results = []params.ActionResult{{Status: params.ActionCompleted}}
+			return nil
+		},
+	)
+	defer cleanup()
+	results, err := s.client.FindActions(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ActionResult{{Status: params.ActionCompleted}})
+}
+
+func (s *actionSuite) TestFindActionsNotImplemented(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			return &params.Error{Code: params.CodeNotImplemented, Message: "no such request"}
+		},
+	)
+	defer cleanup()
+	_, err := s.client.FindActions(params.ActionsFilter{})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *actionSuite) TestEnqueueGroups(c *gc.C) {
+	groups := params.ActionGroups{Groups: []params.ActionGroup{{
+		Service:    names.NewServiceTag("mysql").String(),
+		LeaderOnly: true,
+		Name:       "backup",
+	}}}
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "EnqueueGroups")
+			c.Assert(paramsIn, jc.DeepEquals, groups)
+			result := resp.(*params.ActionGroupResults)
+			result.Results = []params.ActionGroupResult{{Group: "some-group"}}
+			return nil
+		},
+	)
+	defer cleanup()
+	results, err := s.client.EnqueueGroups(groups)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ActionGroupResult{{Group: "some-group"}})
+}
+
+func (s *actionSuite) TestActionGroups(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "ActionGroups")
+			c.Assert(paramsIn, jc.DeepEquals, params.ActionGroupIds{Ids: []string{"some-group"}})
+			result := resp.(*params.ActionGroupResults)
+			result.Results = []params.ActionGroupResult{{Group: "some-group"}}
+			return nil
+		},
+	)
+	defer cleanup()
+	results, err := s.client.ActionGroups(params.ActionGroupIds{Ids: []string{"some-group"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ActionGroupResult{{Group: "some-group"}})
+}
+
+func (s *actionSuite) TestCancel(c *gc.C) {
+	tag := names.NewActionTag("f47ac10b-58cc-4372-a567-0e02b2c3d479").String()
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "Cancel")
+			c.Assert(paramsIn, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: tag}}})
+			result := resp.(*params.ActionResults)
+			result.Results = []params.ActionResult{{Status: params.ActionCancelled}}
+			return nil
+		},
+	)
+	defer cleanup()
+	results, err := s.client.Cancel(params.Entities{Entities: []params.Entity{{Tag: tag}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ActionResult{{Status: params.ActionCancelled}})
+}
+
+func (s *actionSuite) TestSchedules(c *gc.C) {
+	var calls []string
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			calls = append(calls, req)
+			switch req {
+			case "AddSchedules":
+				c.Assert(paramsIn, jc.DeepEquals, params.ActionSchedules{
+					Schedules: []params.ActionSchedule{{Spec: "@daily", Receiver: "service-foo", Name: "backup"}},
+				})
+				result := resp.(*params.ActionScheduleResults)
+				result.Results = []params.ActionScheduleResult{{Schedule: &params.ActionSchedule{Id: "0"}}}
+			case "Schedules":
+				c.Assert(paramsIn, gc.IsNil)
+				result := resp.(*params.ActionScheduleResults)
+				result.Results = []params.ActionScheduleResult{{Schedule: &params.ActionSchedule{Id: "0"}}}
+			case "RemoveSchedules":
+				c.Assert(paramsIn, jc.DeepEquals, params.ActionScheduleIds{Ids: []string{"0"}})
+				result := resp.(*params.ErrorResults)
+				result.Results = []params.ErrorResult{{}}
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+	added, err := s.client.AddSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{Spec: "@daily", Receiver: "service-foo", Name: "backup"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added.Results[0].Schedule.Id, gc.Equals, "0")
+	listed, err := s.client.Schedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(listed.Results, gc.HasLen, 1)
+	removed, err := s.client.RemoveSchedules(params.ActionScheduleIds{Ids: []string{"0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed.Results, gc.HasLen, 1)
+	c.Assert(calls, jc.DeepEquals, []string{"AddSchedules", "Schedules", "RemoveSchedules"})
+}
+
+func (s *actionSuite) TestSchedulesNotImplemented(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			return &params.Error{Code: params.CodeNotImplemented}
+		},
+	)
+	defer cleanup()
+	_, err := s.client.Schedules()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 // replace "ServicesCharmActions" facade call with required results and error
 // if desired
 func patchServiceCharmActions(c *gc.C, apiCli *action.Client, patchResults []params.ServiceCharmActionsResult, err string) func() {
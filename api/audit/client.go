@@ -0,0 +1,33 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the audit API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the audit API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Audit")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// List returns the audit entries for the current environment that
+// match the given filter, most recent first.
+func (c *Client) List(filter params.AuditLogFilter) ([]params.AuditEntry, error) {
+	var result params.AuditEntriesResult
+	if err := c.facade.FacadeCall("List", filter, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
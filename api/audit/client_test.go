@@ -0,0 +1,76 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/audit"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditMockSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&auditMockSuite{})
+
+func (s *auditMockSuite) TestList(c *gc.C) {
+	var called bool
+	from := time.Date(2015, 5, 1, 10, 0, 0, 0, time.UTC)
+	filter := params.AuditLogFilter{
+		User:   "user-bob",
+		Entity: "service-mysql",
+		From:   &from,
+	}
+	entry := params.AuditEntry{
+		Timestamp: from.Add(time.Minute),
+		User:      "bob@local",
+		Facade:    "Client",
+		Method:    "ServiceDestroy",
+		Outcome:   "success",
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Audit")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "List")
+			c.Check(a, jc.DeepEquals, filter)
+
+			result, ok := response.(*params.AuditEntriesResult)
+			c.Assert(ok, jc.IsTrue)
+			result.Entries = []params.AuditEntry{entry}
+			return nil
+		})
+	client := audit.NewClient(apiCaller)
+	found, err := client.List(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(found, jc.DeepEquals, []params.AuditEntry{entry})
+}
+
+func (s *auditMockSuite) TestListError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			return errors.New("boom")
+		})
+	client := audit.NewClient(apiCaller)
+	_, err := client.List(params.AuditLogFilter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
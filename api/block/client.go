@@ -6,6 +6,7 @@ package block
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -53,14 +54,7 @@ func (c *Client) SwitchBlockOn(blockType, msg string) error {
 		Type:    blockType,
 		Message: msg,
 	}
-	result := params.ErrorResult{}
-	if err := c.facade.FacadeCall("SwitchBlockOn", args, &result); err != nil {
-		return errors.Trace(err)
-	}
-	if result.Error != nil {
-		return result.Error
-	}
-	return nil
+	return c.switchBlock("SwitchBlockOn", args)
 }
 
 // SwitchBlockOff switches desired block off for the current environment.
@@ -69,8 +63,50 @@ func (c *Client) SwitchBlockOff(blockType string) error {
 	args := params.BlockSwitchParams{
 		Type: blockType,
 	}
+	return c.switchBlock("SwitchBlockOff", args)
+}
+
+// SwitchEntityBlockOn switches desired block on for the given service,
+// unit or machine. Valid block types are "BlockRemove" and "BlockChange".
+func (c *Client) SwitchEntityBlockOn(blockType string, entity names.Tag, msg string) error {
+	if err := c.checkEntityBlocksSupported(); err != nil {
+		return err
+	}
+	args := params.BlockSwitchParams{
+		Type:    blockType,
+		Message: msg,
+		Entity:  entity.String(),
+	}
+	return c.switchBlock("SwitchBlockOn", args)
+}
+
+// SwitchEntityBlockOff switches desired block off for the given service,
+// unit or machine. Valid block types are "BlockRemove" and "BlockChange".
+func (c *Client) SwitchEntityBlockOff(blockType string, entity names.Tag) error {
+	if err := c.checkEntityBlocksSupported(); err != nil {
+		return err
+	}
+	args := params.BlockSwitchParams{
+		Type:   blockType,
+		Entity: entity.String(),
+	}
+	return c.switchBlock("SwitchBlockOff", args)
+}
+
+// checkEntityBlocksSupported returns an error if the API server does
+// not support blocks on individual entities. Earlier versions of the
+// facade ignore the entity, and would switch the environment block
+// instead.
+func (c *Client) checkEntityBlocksSupported() error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("blocks on services, units and machines by this API server")
+	}
+	return nil
+}
+
+func (c *Client) switchBlock(request string, args params.BlockSwitchParams) error {
 	result := params.ErrorResult{}
-	if err := c.facade.FacadeCall("SwitchBlockOff", args, &result); err != nil {
+	if err := c.facade.FacadeCall(request, args, &result); err != nil {
 		return errors.Trace(err)
 	}
 	if result.Error != nil {
@@ -5,6 +5,7 @@ package block_test
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -164,3 +165,76 @@ func (s *blockMockSuite) TestList(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, errmsg)
 	c.Assert(found, gc.HasLen, 1)
 }
+
+// versionedCaller is an APICallerFunc that reports the given
+// facade version as the best available.
+type versionedCaller struct {
+	basetesting.APICallerFunc
+	version int
+}
+
+func (c versionedCaller) BestFacadeVersion(facade string) int {
+	return c.version
+}
+
+func (s *blockMockSuite) TestSwitchEntityBlockOn(c *gc.C) {
+	called := false
+	apiCaller := versionedCaller{basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Block")
+			c.Check(request, gc.Equals, "SwitchBlockOn")
+			c.Check(a, jc.DeepEquals, params.BlockSwitchParams{
+				Type:    state.RemoveBlock.String(),
+				Message: "protect mysql",
+				Entity:  "service-mysql",
+			})
+			return nil
+		}), 2}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchEntityBlockOn(state.RemoveBlock.String(), names.NewServiceTag("mysql"), "protect mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *blockMockSuite) TestSwitchEntityBlockOff(c *gc.C) {
+	called := false
+	apiCaller := versionedCaller{basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(request, gc.Equals, "SwitchBlockOff")
+			c.Check(a, jc.DeepEquals, params.BlockSwitchParams{
+				Type:   state.ChangeBlock.String(),
+				Entity: "machine-0",
+			})
+			return nil
+		}), 2}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchEntityBlockOff(state.ChangeBlock.String(), names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *blockMockSuite) TestSwitchEntityBlockNotSupported(c *gc.C) {
+	apiCaller := versionedCaller{basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		}), 1}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchEntityBlockOn(state.ChangeBlock.String(), names.NewServiceTag("mysql"), "")
+	c.Assert(err, gc.ErrorMatches, "blocks on services, units and machines by this API server not supported")
+	c.Assert(errors.IsNotSupported(err), jc.IsTrue)
+}
@@ -164,6 +164,9 @@ type Status struct {
 	Services        map[string]ServiceStatus
 	Networks        map[string]NetworkStatus
 	Relations       []RelationStatus
+	// Blocks maps the tag of each blocked service, unit or
+	// machine to the types of the blocks on it.
+	Blocks map[string][]string
 }
 
 // Status returns the status of the juju environment.
@@ -195,6 +198,20 @@ func (c *Client) UnitStatusHistory(kind params.HistoryKind, unitName string, siz
 	return &results, nil
 }
 
+// StatusHistory returns the past statuses of the units and machines
+// selected by filter, most recent first.
+func (c *Client) StatusHistory(filter params.StatusHistoryFilter) ([]params.StatusHistoryEntry, error) {
+	var results params.StatusHistoryResults
+	err := c.facade.FacadeCall("StatusHistory", filter, &results)
+	if err != nil {
+		if params.IsCodeNotImplemented(err) {
+			return nil, errors.NotImplementedf("StatusHistory")
+		}
+		return nil, errors.Trace(err)
+	}
+	return results.Entries, nil
+}
+
 // LegacyMachineStatus holds just the instance-id of a machine.
 type LegacyMachineStatus struct {
 	InstanceId string // Not type instance.Id just to match original api.
@@ -257,6 +274,31 @@ func (c *Client) RetryProvisioning(machines ...names.MachineTag) ([]params.Error
 	return results.Results, err
 }
 
+// WorkerReports returns the reports of the workers run by the agent
+// of the given machine, as last published by the agent, and the time
+// they were published.
+func (c *Client) WorkerReports(machine names.MachineTag) (params.WorkerReportsResult, error) {
+	var results params.WorkerReportsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: machine.String()}},
+	}
+	err := c.facade.FacadeCall("WorkerReports", args, &results)
+	if err != nil {
+		if params.IsCodeNotImplemented(err) {
+			return params.WorkerReportsResult{}, errors.NotImplementedf("WorkerReports")
+		}
+		return params.WorkerReportsResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.WorkerReportsResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.WorkerReportsResult{}, result.Error
+	}
+	return result, nil
+}
+
 // PublicAddress returns the public address of the specified
 // machine or unit. For a machine, target is an id not a tag.
 func (c *Client) PublicAddress(target string) (string, error) {
@@ -432,6 +474,81 @@ func (c *Client) ServiceSetCharm(serviceName string, charmUrl string, force bool
 	return c.facade.FacadeCall("ServiceSetCharm", args, nil)
 }
 
+// ServiceSetCharmRolling sets the charm for a given service, releasing
+// its units to upgrade batchSize at a time once the units already
+// released are healthy. If rollback is true, the service is set back to
+// its current charm if a unit fails; otherwise the upgrade pauses.
+func (c *Client) ServiceSetCharmRolling(serviceName string, charmUrl string, force bool, batchSize int, rollback bool) error {
+	args := params.ServiceSetCharmRolling{
+		ServiceName: serviceName,
+		CharmUrl:    charmUrl,
+		Force:       force,
+		BatchSize:   batchSize,
+		Rollback:    rollback,
+	}
+	err := c.facade.FacadeCall("ServiceSetCharmRolling", args, nil)
+	if params.IsCodeNotImplemented(err) {
+		return errors.NotImplementedf("ServiceSetCharmRolling")
+	}
+	return err
+}
+
+// ServiceResumeRollingUpgrade resumes the paused rolling charm upgrade
+// of the given service.
+func (c *Client) ServiceResumeRollingUpgrade(serviceName string) error {
+	args := params.ServiceResumeRollingUpgrade{ServiceName: serviceName}
+	err := c.facade.FacadeCall("ServiceResumeRollingUpgrade", args, nil)
+	if params.IsCodeNotImplemented(err) {
+		return errors.NotImplementedf("ServiceResumeRollingUpgrade")
+	}
+	return err
+}
+
+// ServiceRollbackCharm sets the given service back to the charm and
+// config settings it used before its charm was last changed. If force
+// is true, units are rolled back even if they are in an error state.
+func (c *Client) ServiceRollbackCharm(serviceName string, force bool) error {
+	args := params.ServiceRollbackCharm{
+		ServiceName: serviceName,
+		Force:       force,
+	}
+	err := c.facade.FacadeCall("ServiceRollbackCharm", args, nil)
+	if params.IsCodeNotImplemented(err) {
+		return errors.NotImplementedf("ServiceRollbackCharm")
+	}
+	return err
+}
+
+// ServiceSetHookTimeout sets how long the units of the given service
+// may run a hook before it is killed. A zero timeout means hooks are
+// never killed.
+func (c *Client) ServiceSetHookTimeout(serviceName string, timeout time.Duration) error {
+	args := params.ServiceSetHookTimeout{
+		ServiceName: serviceName,
+		Timeout:     timeout,
+	}
+	err := c.facade.FacadeCall("ServiceSetHookTimeout", args, nil)
+	if params.IsCodeNotImplemented(err) {
+		return errors.NotImplementedf("ServiceSetHookTimeout")
+	}
+	return err
+}
+
+// ServiceHookStats returns statistics on the recorded runs of hooks,
+// actions and commands by the units of the given service.
+func (c *Client) ServiceHookStats(serviceName string) ([]params.HookStats, error) {
+	args := params.ServiceHookStats{ServiceName: serviceName}
+	var result params.HookStatsResults
+	err := c.facade.FacadeCall("ServiceHookStats", args, &result)
+	if params.IsCodeNotImplemented(err) {
+		return nil, errors.NotImplementedf("ServiceHookStats")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result.Results, nil
+}
+
 // ServiceGetCharmURL returns the charm URL the given service is
 // running at present.
 func (c *Client) ServiceGetCharmURL(serviceName string) (*charm.URL, error) {
@@ -456,6 +573,20 @@ func (c *Client) AddServiceUnits(service string, numUnits int, machineSpec strin
 	return results.Units, err
 }
 
+// AddServiceUnitsWithStorage adds a single unit to a service, attaching
+// the detached storage instances with the specified IDs to it.
+func (c *Client) AddServiceUnitsWithStorage(service string, numUnits int, machineSpec string, attachStorage []string) ([]string, error) {
+	args := params.AddServiceUnits{
+		ServiceName:   service,
+		NumUnits:      numUnits,
+		ToMachineSpec: machineSpec,
+		AttachStorage: attachStorage,
+	}
+	results := new(params.AddServiceUnitsResults)
+	err := c.facade.FacadeCall("AddServiceUnits", args, results)
+	return results.Units, err
+}
+
 // DestroyServiceUnits decreases the number of units dedicated to a service.
 func (c *Client) DestroyServiceUnits(unitNames ...string) error {
 	params := params.DestroyServiceUnits{unitNames}
@@ -548,12 +679,21 @@ func (c *Client) EnvironmentUUID() string {
 
 // ShareEnvironment allows the given users access to the environment.
 func (c *Client) ShareEnvironment(users ...names.UserTag) error {
+	return c.ShareEnvironmentWithAccess("", users...)
+}
+
+// ShareEnvironmentWithAccess allows the given users the given level of
+// access to the environment. If a user already has access to the
+// environment, their access level is changed. An empty access level
+// gives new users the server's default level of access.
+func (c *Client) ShareEnvironmentWithAccess(access params.EnvironAccess, users ...names.UserTag) error {
 	var args params.ModifyEnvironUsers
 	for _, user := range users {
 		if &user != nil {
 			args.Changes = append(args.Changes, params.ModifyEnvironUser{
 				UserTag: user.String(),
 				Action:  params.AddEnvUser,
+				Access:  access,
 			})
 		}
 	}
@@ -1035,6 +1175,19 @@ type DebugLogParams struct {
 	// Replay tells the server to start at the start of the log file rather
 	// than the end. If replay is true, backlog is ignored.
 	Replay bool
+	// StartTime, if set, tells the server to only send lines logged at or
+	// after this time. If StartTime is set, backlog is ignored.
+	StartTime time.Time
+	// EndTime, if set, tells the server to only send lines logged at or
+	// before this time, and to close the connection once they have been
+	// sent.
+	EndTime time.Time
+	// JSON tells the server to send each line as a JSON-encoded
+	// params.LogRecord.
+	//
+	// StartTime, EndTime and JSON are only supported by servers that
+	// store logs in the database.
+	JSON bool
 }
 
 // WatchDebugLog returns a ReadCloser that the caller can read the log
@@ -1067,6 +1220,15 @@ func (c *Client) WatchDebugLog(args DebugLogParams) (io.ReadCloser, error) {
 	if args.Level != loggo.UNSPECIFIED {
 		attrs.Set("level", fmt.Sprint(args.Level))
 	}
+	if !args.StartTime.IsZero() {
+		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
+	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
+	if args.JSON {
+		attrs.Set("format", "json")
+	}
 	attrs["includeEntity"] = args.IncludeEntity
 	attrs["includeModule"] = args.IncludeModule
 	attrs["excludeEntity"] = args.ExcludeEntity
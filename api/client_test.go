@@ -14,6 +14,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -202,6 +203,32 @@ func (s *clientSuite) TestShareEnvironmentExistingUser(c *gc.C) {
 	c.Assert(c.GetTestLog(), jc.Contains, logMsg)
 }
 
+func (s *clientSuite) TestShareEnvironmentWithAccess(c *gc.C) {
+	client := s.APIState.Client()
+	user := names.NewUserTag("foo@bar")
+	var called bool
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, paramsIn interface{}, response interface{}) error {
+			c.Assert(request, gc.Equals, "ShareEnvironment")
+			c.Assert(paramsIn, jc.DeepEquals, params.ModifyEnvironUsers{
+				Changes: []params.ModifyEnvironUser{{
+					UserTag: user.String(),
+					Action:  params.AddEnvUser,
+					Access:  params.EnvironReadAccess,
+				}},
+			})
+			called = true
+			*(response.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{}}}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	err := client.ShareEnvironmentWithAccess(params.EnvironReadAccess, user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *clientSuite) TestDestroyEnvironment(c *gc.C) {
 	client := s.APIState.Client()
 	var called bool
@@ -383,6 +410,9 @@ func (s *clientSuite) TestParamsEncoded(c *gc.C) {
 		Backlog:       200,
 		Level:         loggo.ERROR,
 		Replay:        true,
+		StartTime:     time.Date(2015, 6, 1, 10, 0, 0, 0, time.UTC),
+		EndTime:       time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC),
+		JSON:          true,
 	}
 
 	client := s.APIState.Client()
@@ -400,6 +430,9 @@ func (s *clientSuite) TestParamsEncoded(c *gc.C) {
 		"backlog":       {"200"},
 		"level":         {"ERROR"},
 		"replay":        {"true"},
+		"startTime":     {"2015-06-01T10:00:00Z"},
+		"endTime":       {"2015-06-01T12:00:00Z"},
+		"format":        {"json"},
 	})
 }
 
@@ -15,8 +15,9 @@ var facadeVersions = map[string]int{
 	"Agent":                        1,
 	"AllWatcher":                   0,
 	"Annotations":                  1,
+	"Audit":                        1,
 	"Backups":                      0,
-	"Block":                        1,
+	"Block":                        2,
 	"Charms":                       1,
 	"CharmRevisionUpdater":         0,
 	"Client":                       0,
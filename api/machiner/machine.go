@@ -70,6 +70,22 @@ func (m *Machine) SetMachineAddresses(addresses []network.Address) error {
 	return result.OneError()
 }
 
+// SetWorkerReports publishes the reports of the workers run by the
+// machine's agent.
+func (m *Machine) SetWorkerReports(reports []params.WorkerReport) error {
+	var result params.ErrorResults
+	args := params.SetWorkerReports{
+		Entities: []params.EntityWorkerReports{
+			{Tag: m.tag.String(), Reports: reports},
+		},
+	}
+	err := m.st.facade.FacadeCall("SetWorkerReports", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // EnsureDead sets the machine lifecycle to Dead if it is Alive or
 // Dying. It does nothing otherwise.
 func (m *Machine) EnsureDead() error {
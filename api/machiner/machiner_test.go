@@ -182,3 +182,25 @@ func (s *machinerSuite) TestWatch(c *gc.C) {
 	statetesting.AssertStop(c, w)
 	wc.AssertClosed()
 }
+
+func (s *machinerSuite) TestSetWorkerReports(c *gc.C) {
+	machine, err := s.machiner.Machine(names.NewMachineTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetWorkerReports([]params.WorkerReport{{
+		Name:       "machiner",
+		State:      "starting",
+		StartCount: 4,
+		LastError:  "boom",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, _, err := s.machine.WorkerReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, []state.WorkerReport{{
+		Name:       "machiner",
+		State:      "starting",
+		StartCount: 4,
+		LastError:  "boom",
+	}})
+}
@@ -101,3 +101,105 @@ func (c *Client) ListVolumes(machines []string) ([]params.VolumeItem, error) {
 	}
 	return found.Results, nil
 }
+
+// CreateVolumeSnapshots requests snapshots of the specified volumes.
+// The snapshots are taken asynchronously by the storage provisioner.
+func (c *Client) CreateVolumeSnapshots(volumes []string) ([]params.VolumeSnapshotResult, error) {
+	entities := make([]params.Entity, len(volumes))
+	for i, one := range volumes {
+		entities[i] = params.Entity{Tag: names.NewVolumeTag(one).String()}
+	}
+	found := params.VolumeSnapshotResults{}
+	if err := c.facade.FacadeCall("CreateVolumeSnapshots", params.Entities{Entities: entities}, &found); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(found.Results) != len(volumes) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(volumes), len(found.Results))
+	}
+	return found.Results, nil
+}
+
+// ListVolumeSnapshots lists snapshots of the specified volumes.
+// If no volumes are provided, the snapshots of all volumes are returned.
+func (c *Client) ListVolumeSnapshots(volumes []string) ([]params.VolumeSnapshotResult, error) {
+	tags := make([]string, len(volumes))
+	for i, one := range volumes {
+		tags[i] = names.NewVolumeTag(one).String()
+	}
+	args := params.VolumeSnapshotFilter{Volumes: tags}
+	found := params.VolumeSnapshotResults{}
+	if err := c.facade.FacadeCall("ListVolumeSnapshots", args, &found); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return found.Results, nil
+}
+
+// DestroyVolumeSnapshots destroys the volume snapshots with the
+// specified IDs.
+func (c *Client) DestroyVolumeSnapshots(ids []string) error {
+	args := params.VolumeSnapshotIds{Ids: ids}
+	found := params.ErrorResults{}
+	if err := c.facade.FacadeCall("DestroyVolumeSnapshots", args, &found); err != nil {
+		return errors.Trace(err)
+	}
+	return found.Combine()
+}
+
+// Resize requests that the storage instance with the specified ID be
+// grown to the specified size, in MiB. The storage is grown
+// asynchronously by the storage provisioner.
+func (c *Client) Resize(storageId string, size uint64) error {
+	args := params.StoragesResize{
+		Storages: []params.StorageResize{{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			Size:       size,
+		}},
+	}
+	found := params.ErrorResults{}
+	if err := c.facade.FacadeCall("Resize", args, &found); err != nil {
+		return errors.Trace(err)
+	}
+	return found.OneError()
+}
+
+// Detach detaches the storage instances with the specified IDs from
+// the units that own them. Detached storage may subsequently be
+// attached to a new unit of the same service.
+func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
+	entities := make([]params.Entity, len(storageIds))
+	for i, id := range storageIds {
+		entities[i] = params.Entity{Tag: names.NewStorageTag(id).String()}
+	}
+	found := params.ErrorResults{}
+	if err := c.facade.FacadeCall("Detach", params.Entities{Entities: entities}, &found); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(found.Results) != len(storageIds) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(storageIds), len(found.Results))
+	}
+	return found.Results, nil
+}
+
+// Import records the volume with the specified provider ID, in the
+// specified storage pool, as a detached storage instance with the
+// given storage name. The tag of the new storage instance is returned.
+func (c *Client) Import(pool, providerId, storageName string) (names.StorageTag, error) {
+	args := params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Pool:        pool,
+			ProviderId:  providerId,
+			StorageName: storageName,
+		}},
+	}
+	found := params.ImportStorageResults{}
+	if err := c.facade.FacadeCall("Import", args, &found); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if len(found.Results) != 1 {
+		return names.StorageTag{}, errors.Errorf("expected 1 result, got %d", len(found.Results))
+	}
+	if err := found.Results[0].Error; err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.ParseStorageTag(found.Results[0].StorageTag)
+}
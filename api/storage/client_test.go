@@ -390,3 +390,232 @@ func (s *storageMockSuite) TestListVolumesFacadeCallError(c *gc.C) {
 	_, err := storageClient.ListVolumes(nil)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
+
+func (s *storageMockSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CreateVolumeSnapshots")
+
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "volume-0"}, {Tag: "volume-1-2"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotResults{})
+			results := result.(*params.VolumeSnapshotResults)
+			results.Results = []params.VolumeSnapshotResult{
+				{Result: params.VolumeSnapshot{Id: "0@0", VolumeTag: "volume-0"}},
+				{Error: common.ServerError(errors.New("boom"))},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	found, err := storageClient.CreateVolumeSnapshots([]string{"0", "1/2"})
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, gc.HasLen, 2)
+	c.Assert(found[0].Result.Id, gc.Equals, "0@0")
+	c.Assert(found[1].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestListVolumeSnapshots(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListVolumeSnapshots")
+
+			c.Assert(a, jc.DeepEquals, params.VolumeSnapshotFilter{
+				Volumes: []string{"volume-0"},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotResults{})
+			results := result.(*params.VolumeSnapshotResults)
+			results.Results = []params.VolumeSnapshotResult{
+				{Result: params.VolumeSnapshot{Id: "0@0", VolumeTag: "volume-0"}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	found, err := storageClient.ListVolumeSnapshots([]string{"0"})
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, gc.HasLen, 1)
+	c.Assert(found[0].Result.Id, gc.Equals, "0@0")
+}
+
+func (s *storageMockSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "DestroyVolumeSnapshots")
+
+			c.Assert(a, jc.DeepEquals, params.VolumeSnapshotIds{
+				Ids: []string{"0@0", "0@1"},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{
+				{},
+				{Error: common.ServerError(errors.New("boom"))},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.DestroyVolumeSnapshots([]string{"0@0", "0@1"})
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Resize")
+
+			c.Assert(a, jc.DeepEquals, params.StoragesResize{
+				Storages: []params.StorageResize{{
+					StorageTag: "storage-data-0",
+					Size:       2048,
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{
+				{Error: common.ServerError(errors.New("boom"))},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.Resize("data/0", 2048)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestDetach(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Detach")
+
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{"storage-data-0"}, {"storage-data-1"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{
+				{},
+				{Error: common.ServerError(errors.New("boom"))},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Detach([]string{"data/0", "data/1"})
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Assert(results[1].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestDetachFacadeCallError(c *gc.C) {
+	msg := "facade failure"
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(request, gc.Equals, "Detach")
+			return errors.New(msg)
+		})
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Detach([]string{"data/0"})
+	c.Assert(err, gc.ErrorMatches, msg)
+}
+
+func (s *storageMockSuite) TestImport(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Import")
+
+			c.Assert(a, jc.DeepEquals, params.BulkImportStorageParams{
+				Storage: []params.ImportStorageParams{{
+					Pool:        "ebs",
+					ProviderId:  "vol-123",
+					StorageName: "data",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ImportStorageResults{})
+			results := result.(*params.ImportStorageResults)
+			results.Results = []params.ImportStorageResult{
+				{StorageTag: "storage-data-0"},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	tag, err := storageClient.Import("ebs", "vol-123", "data")
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, names.NewStorageTag("data/0"))
+}
+
+func (s *storageMockSuite) TestImportError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(request, gc.Equals, "Import")
+			results := result.(*params.ImportStorageResults)
+			results.Results = []params.ImportStorageResult{
+				{Error: common.ServerError(errors.New("boom"))},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Import("ebs", "vol-123", "data")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -72,6 +72,30 @@ func (st *State) WatchFilesystems() (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchFilesystems")
 }
 
+// WatchVolumeSnapshots watches for changes to snapshots of volumes
+// scoped to the entity with the tag passed to NewState. If the API
+// server does not support volume snapshots, an error satisfying
+// errors.IsNotImplemented is returned.
+func (st *State) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	w, err := st.watchStorageEntities("WatchVolumeSnapshots")
+	if params.IsCodeNotImplemented(err) {
+		return nil, errors.NotImplementedf("WatchVolumeSnapshots")
+	}
+	return w, err
+}
+
+// WatchVolumeResizes watches for requests to grow volumes scoped to
+// the entity with the tag passed to NewState. If the API server does
+// not support resizing volumes, an error satisfying
+// errors.IsNotImplemented is returned.
+func (st *State) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	w, err := st.watchStorageEntities("WatchVolumeResizes")
+	if params.IsCodeNotImplemented(err) {
+		return nil, errors.NotImplementedf("WatchVolumeResizes")
+	}
+	return w, err
+}
+
 func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
@@ -292,6 +316,71 @@ func (st *State) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, e
 	return results.Results, nil
 }
 
+// VolumeSnapshotParams returns the parameters for taking, or destroying,
+// the volume snapshots with the specified IDs.
+func (st *State) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	args := params.VolumeSnapshotIds{Ids: ids}
+	var results params.VolumeSnapshotParamsResults
+	err := st.facade.FacadeCall("VolumeSnapshotParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (st *State) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshots{Snapshots: snapshots}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumeSnapshotInfo", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshots) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(snapshots), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// RemoveVolumeSnapshots removes the specified destroyed volume
+// snapshots from state.
+func (st *State) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshotIds{Ids: ids}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("RemoveVolumeSnapshots", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// VolumeResizeParams returns the parameters for growing the volumes
+// with the specified tags.
+func (st *State) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeResizeParamsResults
+	err := st.facade.FacadeCall("VolumeResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (st *State) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	args := params.Filesystems{Filesystems: filesystems}
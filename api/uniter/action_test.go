@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -64,6 +66,50 @@ func (s *actionSuite) TestAction(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestActionTimeout(c *gc.C) {
+	a, err := s.uniterSuite.wordpressUnit.AddActionWithTimeout("fakeaction", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	retrievedAction, err := s.uniter.Action(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retrievedAction.Timeout(), gc.Equals, time.Minute)
+}
+
+func (s *actionSuite) TestActionStatus(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.uniter.ActionStatus(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, params.ActionRunning)
+
+	_, err = s.uniterSuite.wordpressUnit.CancelAction(action)
+	c.Assert(err, jc.ErrorIsNil)
+	status, err = s.uniter.ActionStatus(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, params.ActionCancelled)
+}
+
+func (s *actionSuite) TestLogActionMessage(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.LogActionMessage(action.ActionTag(), "too soon")
+	c.Assert(err, gc.ErrorMatches, "cannot log message to action .*: action is not running")
+
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.LogActionMessage(action.ActionTag(), "migrating table 1 of 2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "migrating table 1 of 2")
+}
+
 func (s *actionSuite) TestActionNotFound(c *gc.C) {
 	_, err := s.uniter.Action(names.NewActionTag("feedface-0123-4567-8901-2345deadbeef"))
 	c.Assert(err, gc.NotNil)
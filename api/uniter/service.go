@@ -5,7 +5,9 @@ package uniter
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/names"
 	"gopkg.in/juju/charm.v5"
 
@@ -114,6 +116,31 @@ func (s *Service) CharmURL() (*charm.URL, bool, error) {
 	return nil, false, fmt.Errorf("%q has no charm url set", s.tag)
 }
 
+// HookTimeout returns how long the service's units may run a hook
+// before it is killed. Zero means hooks are never killed.
+func (s *Service) HookTimeout() (time.Duration, error) {
+	if s.st.BestAPIVersion() < 2 {
+		// HookTimeouts() was introduced in UniterAPIV2.
+		return 0, errors.NotImplementedf("HookTimeouts() (need V2+)")
+	}
+	var results params.DurationResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("HookTimeouts", args, &results)
+	if err != nil {
+		return 0, err
+	}
+	if len(results.Results) != 1 {
+		return 0, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.Result, nil
+}
+
 // OwnerTag returns the service's owner user tag.
 func (s *Service) OwnerTag() (names.UserTag, error) {
 	if s.st.BestAPIVersion() > 0 {
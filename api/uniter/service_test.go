@@ -4,6 +4,9 @@
 package uniter_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -118,6 +121,25 @@ func (s *serviceSuite) TestCharmURL(c *gc.C) {
 	c.Assert(force, jc.IsFalse)
 }
 
+func (s *serviceSuite) TestHookTimeout(c *gc.C) {
+	timeout, err := s.apiService.HookTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, time.Duration(0))
+
+	err = s.wordpressService.SetHookTimeout(time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	timeout, err = s.apiService.HookTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, time.Hour)
+}
+
+func (s *serviceSuite) TestHookTimeoutV1(c *gc.C) {
+	s.patchNewState(c, uniter.NewStateV1)
+
+	_, err := s.apiService.HookTimeout()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *serviceSuite) TestOwnerTagV0(c *gc.C) {
 	s.patchNewState(c, uniter.NewStateV0)
 
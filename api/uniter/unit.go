@@ -163,6 +163,24 @@ func (u *Unit) AddMetricBatches(batches []params.MetricBatch) (map[string]error,
 	return batchResults, nil
 }
 
+// AddHookRuns records the given runs of hooks, actions and commands by
+// the unit.
+func (u *Unit) AddHookRuns(runs []params.HookRun) error {
+	if u.st.BestAPIVersion() < 2 {
+		// AddHookRuns() was introduced in UniterAPIV2.
+		return errors.NotImplementedf("AddHookRuns() (need V2+)")
+	}
+	var result params.ErrorResults
+	args := params.HookRunsParams{
+		Units: []params.UnitHookRuns{{Tag: u.tag.String(), Runs: runs}},
+	}
+	err := u.st.facade.FacadeCall("AddHookRuns", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // EnsureDead sets the unit lifecycle to Dead if it is Alive or
 // Dying. It does nothing otherwise.
 func (u *Unit) EnsureDead() error {
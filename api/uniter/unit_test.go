@@ -716,6 +716,39 @@ func (s *unitSuite) TestAddMetricsResultError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "error adding metrics")
 }
 
+func (s *unitSuite) TestAddHookRuns(c *gc.C) {
+	started := time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC)
+	err := s.apiUnit.AddHookRuns([]params.HookRun{{
+		Kind:     "hook",
+		Name:     "install",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		Status:   "completed",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	runs, err := s.wordpressService.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, jc.DeepEquals, []state.HookRun{{
+		Unit:     "wordpress/0",
+		Kind:     state.HookRunHook,
+		Name:     "install",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		Status:   state.HookRunCompleted,
+	}})
+
+	err = s.apiUnit.AddHookRuns([]params.HookRun{{Kind: "hook", Status: "completed"}})
+	c.Assert(err, gc.ErrorMatches, `cannot add hook runs for unit "wordpress/0": hook run without a name not valid`)
+}
+
+func (s *unitSuite) TestAddHookRunsV1(c *gc.C) {
+	s.patchNewState(c, uniter.NewStateV1)
+
+	err := s.apiUnit.AddHookRuns(nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *unitSuite) TestMeterStatus(c *gc.C) {
 	uniter.PatchUnitResponse(s, s.apiUnit, "GetMeterStatus",
 		func(results interface{}) error {
@@ -221,11 +221,54 @@ func (st *State) Action(tag names.ActionTag) (*Action, error) {
 		return nil, err
 	}
 	return &Action{
-		name:   result.Action.Action.Name,
-		params: result.Action.Action.Parameters,
+		name:    result.Action.Action.Name,
+		params:  result.Action.Action.Parameters,
+		timeout: result.Action.Action.Timeout,
 	}, nil
 }
 
+// ActionStatus returns the status of the Action with the given tag.
+func (st *State) ActionStatus(tag names.ActionTag) (string, error) {
+	if st.BestAPIVersion() < 2 {
+		// ActionStatus() was introduced in UniterAPIV2.
+		return "", errors.NotImplementedf("ActionStatus() (need V2+)")
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	err := st.facade.FacadeCall("ActionStatus", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// LogActionMessage records a progress message against the running
+// Action with the given tag.
+func (st *State) LogActionMessage(tag names.ActionTag, message string) error {
+	if st.BestAPIVersion() < 2 {
+		// LogActionsMessages() was introduced in UniterAPIV2.
+		return errors.NotImplementedf("LogActionsMessages() (need V2+)")
+	}
+	var results params.ErrorResults
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{{Tag: tag.String(), Value: message}},
+	}
+	err := st.facade.FacadeCall("LogActionsMessages", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
 // ActionBegin marks an action as running.
 func (st *State) ActionBegin(tag names.ActionTag) error {
 	var outcome params.ErrorResults
@@ -0,0 +1,96 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
+)
+
+// accessRoot restricts the API calls made by an environment user to
+// those permitted by the user's level of access to the environment.
+type accessRoot struct {
+	rpc.MethodFinder
+	access state.EnvironmentAccess
+}
+
+// newAccessRoot returns a new accessRoot.
+func newAccessRoot(finder rpc.MethodFinder, access state.EnvironmentAccess) *accessRoot {
+	return &accessRoot{
+		MethodFinder: finder,
+		access:       access,
+	}
+}
+
+// adminOnlyCalls holds, for each client facade, the calls that only
+// users with admin access to the environment may make.
+var adminOnlyCalls = map[string]set.Strings{
+	"Backups": set.NewStrings(
+		"Create",
+		"FinishRestore",
+		"PrepareRestore",
+		"Remove",
+		"Restore",
+	),
+	"Block": set.NewStrings(
+		"SwitchBlockOff",
+		"SwitchBlockOn",
+	),
+	"Client": set.NewStrings(
+		"AbortCurrentUpgrade",
+		"DestroyEnvironment",
+		"EnsureAvailability",
+		"SetEnvironAgentVersion",
+		"ShareEnvironment",
+	),
+	"EnvironmentManager": set.NewStrings(
+		"CreateEnvironment",
+	),
+	"UserManager": set.NewStrings(
+		"AddUser",
+		"DisableUser",
+		"EnableUser",
+	),
+}
+
+// anyAccessCalls holds, for each client facade, the calls that change
+// only the calling user, and so may be made with any level of access.
+var anyAccessCalls = map[string]set.Strings{
+	"UserManager": set.NewStrings(
+		"SetPassword",
+	),
+}
+
+// isCallPermitted reports whether a user with the given level of access
+// to the environment may make the given call.
+func isCallPermitted(access state.EnvironmentAccess, rootName, methodName string) bool {
+	switch access {
+	case state.EnvironmentAdminAccess:
+		return true
+	case state.EnvironmentWriteAccess:
+		return !adminOnlyCalls[rootName].Contains(methodName)
+	case state.EnvironmentReadAccess:
+		return isCallReadOnly(rootName, methodName) || anyAccessCalls[rootName].Contains(methodName)
+	}
+	return false
+}
+
+// FindMethod returns a permission denied error if the user's level of
+// access to the environment does not permit the call.
+func (r *accessRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	// The lookup of the name is done first to return a not found error if the
+	// user is looking for a method that we just don't have.
+	caller, err := r.MethodFinder.FindMethod(rootName, version, methodName)
+	if err != nil {
+		return nil, err
+	}
+	if !isCallPermitted(r.access, rootName, methodName) {
+		return nil, common.ErrPerm
+	}
+	return caller, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type accessRootSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&accessRootSuite{})
+
+func (s *accessRootSuite) assertMethodAllowed(c *gc.C, access state.EnvironmentAccess, rootName string, version int, method string) {
+	root := apiserver.TestingAccessApiHandler(nil, access)
+	caller, err := root.FindMethod(rootName, version, method)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(caller, gc.NotNil)
+}
+
+func (s *accessRootSuite) assertMethodDenied(c *gc.C, access state.EnvironmentAccess, rootName string, version int, method string) {
+	root := apiserver.TestingAccessApiHandler(nil, access)
+	caller, err := root.FindMethod(rootName, version, method)
+	c.Check(err, gc.ErrorMatches, "permission denied")
+	c.Check(caller, gc.IsNil)
+}
+
+func (s *accessRootSuite) TestReadAccess(c *gc.C) {
+	s.assertMethodAllowed(c, state.EnvironmentReadAccess, "Client", 0, "FullStatus")
+	s.assertMethodAllowed(c, state.EnvironmentReadAccess, "Client", 0, "EnvironmentGet")
+	s.assertMethodAllowed(c, state.EnvironmentReadAccess, "Client", 0, "WatchAll")
+	s.assertMethodAllowed(c, state.EnvironmentReadAccess, "UserManager", 0, "SetPassword")
+
+	s.assertMethodDenied(c, state.EnvironmentReadAccess, "Client", 0, "ServiceDeploy")
+	s.assertMethodDenied(c, state.EnvironmentReadAccess, "Client", 0, "DestroyMachines")
+	s.assertMethodDenied(c, state.EnvironmentReadAccess, "Client", 0, "Run")
+	s.assertMethodDenied(c, state.EnvironmentReadAccess, "Client", 0, "ShareEnvironment")
+}
+
+func (s *accessRootSuite) TestWriteAccess(c *gc.C) {
+	s.assertMethodAllowed(c, state.EnvironmentWriteAccess, "Client", 0, "FullStatus")
+	s.assertMethodAllowed(c, state.EnvironmentWriteAccess, "Client", 0, "ServiceDeploy")
+	s.assertMethodAllowed(c, state.EnvironmentWriteAccess, "Client", 0, "Run")
+
+	s.assertMethodDenied(c, state.EnvironmentWriteAccess, "Client", 0, "ShareEnvironment")
+	s.assertMethodDenied(c, state.EnvironmentWriteAccess, "Client", 0, "DestroyEnvironment")
+}
+
+func (s *accessRootSuite) TestAdminAccess(c *gc.C) {
+	s.assertMethodAllowed(c, state.EnvironmentAdminAccess, "Client", 0, "ServiceDeploy")
+	s.assertMethodAllowed(c, state.EnvironmentAdminAccess, "Client", 0, "ShareEnvironment")
+	s.assertMethodAllowed(c, state.EnvironmentAdminAccess, "Client", 0, "DestroyEnvironment")
+}
+
+func (s *accessRootSuite) TestUnknownAccessDenied(c *gc.C) {
+	s.assertMethodDenied(c, "", "Client", 0, "FullStatus")
+}
+
+func (s *accessRootSuite) TestFindNonExistentMethod(c *gc.C) {
+	root := apiserver.TestingAccessApiHandler(nil, state.EnvironmentReadAccess)
+	caller, err := root.FindMethod("Client", 0, "Bar")
+	c.Assert(err, gc.ErrorMatches, `no such request - method Client\(0\).Bar is not implemented`)
+	c.Assert(caller, gc.IsNil)
+}
@@ -4,16 +4,24 @@
 package action
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/leadership"
+	"github.com/juju/juju/lease"
 	"github.com/juju/juju/state"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.action")
 
+// isLeader reports whether the unit with the given name is the leader
+// of the service with the given name. It is a variable so it can be
+// replaced in tests.
+var isLeader = leadership.NewLeadershipManager(lease.Manager()).Leader
+
 func init() {
 	common.RegisterStandardFacade("Action", 0, NewActionAPI)
 }
@@ -84,6 +92,55 @@ func (a *ActionAPI) FindActionTagsByPrefix(arg params.FindTags) (params.FindTags
 	return response, nil
 }
 
+// FindActions returns the actions in the environment selected by the
+// filter, oldest first.
+func (a *ActionAPI) FindActions(arg params.ActionsFilter) (params.ActionResults, error) {
+	filter := state.ActionsFilter{Names: arg.Names}
+	for _, receiver := range arg.Receivers {
+		tag, err := names.ParseTag(receiver)
+		if err != nil {
+			return params.ActionResults{}, common.ErrBadId
+		}
+		switch tag := tag.(type) {
+		case names.ServiceTag:
+			filter.Services = append(filter.Services, tag.Id())
+		case names.UnitTag:
+			filter.Units = append(filter.Units, tag.Id())
+		default:
+			return params.ActionResults{}, common.ErrBadId
+		}
+	}
+	for _, status := range arg.Statuses {
+		switch status {
+		case params.ActionPending, params.ActionRunning, params.ActionCompleted,
+			params.ActionFailed, params.ActionCancelled, params.ActionTimedOut:
+		default:
+			return params.ActionResults{}, errors.NotValidf("action status %q", status)
+		}
+		filter.Statuses = append(filter.Statuses, state.ActionStatus(status))
+	}
+	if arg.EnqueuedAfter != nil {
+		filter.EnqueuedAfter = *arg.EnqueuedAfter
+	}
+	if arg.EnqueuedBefore != nil {
+		filter.EnqueuedBefore = *arg.EnqueuedBefore
+	}
+	actions, err := a.state.FindActions(filter)
+	if err != nil {
+		return params.ActionResults{}, errors.Trace(err)
+	}
+	response := params.ActionResults{Results: make([]params.ActionResult, len(actions))}
+	for i, action := range actions {
+		receiverTag, err := names.ActionReceiverTag(action.Receiver())
+		if err != nil {
+			response.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		response.Results[i] = makeActionResult(receiverTag, action)
+	}
+	return response, nil
+}
+
 // Enqueue takes a list of Actions and queues them up to be executed by
 // the designated ActionReceiver, returning the params.Action for each
 // enqueued Action, or an error if there was a problem enqueueing the
@@ -97,7 +154,7 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		enqueued, err := receiver.AddActionWithTimeout(action.Name, action.Parameters, action.Timeout)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -108,6 +165,107 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	return response, nil
 }
 
+// EnqueueGroups queues each of the given actions on the selected units
+// of a service as one group, returning the id of the group and the
+// queued actions for each.
+func (a *ActionAPI) EnqueueGroups(arg params.ActionGroups) (params.ActionGroupResults, error) {
+	response := params.ActionGroupResults{Results: make([]params.ActionGroupResult, len(arg.Groups))}
+	for i, group := range arg.Groups {
+		currentResult := &response.Results[i]
+		units, err := a.groupUnits(group)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		groupId, actions, err := a.state.EnqueueActionGroup(units, group.Name, group.Parameters, group.Timeout)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		currentResult.Group = groupId
+		for j, action := range actions {
+			currentResult.Actions = append(currentResult.Actions, makeActionResult(units[j].Tag(), action))
+		}
+	}
+	return response, nil
+}
+
+// groupUnits returns the units of a service selected by the group.
+func (a *ActionAPI) groupUnits(group params.ActionGroup) ([]*state.Unit, error) {
+	serviceTag, err := names.ParseServiceTag(group.Service)
+	if err != nil {
+		return nil, common.ErrBadId
+	}
+	service, err := a.state.Service(serviceTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := service.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(group.Units) > 0 {
+		byName := make(map[string]*state.Unit)
+		for _, unit := range units {
+			byName[unit.Name()] = unit
+		}
+		units = nil
+		for _, tag := range group.Units {
+			unitTag, err := names.ParseUnitTag(tag)
+			if err != nil {
+				return nil, common.ErrBadId
+			}
+			unit, ok := byName[unitTag.Id()]
+			if !ok {
+				return nil, errors.NotFoundf("unit %q of service %q", unitTag.Id(), service.Name())
+			}
+			units = append(units, unit)
+		}
+	}
+	if group.LeaderOnly {
+		var leaders []*state.Unit
+		for _, unit := range units {
+			if isLeader(service.Name(), unit.Name()) {
+				leaders = append(leaders, unit)
+			}
+		}
+		if len(leaders) == 0 {
+			return nil, errors.NotFoundf("leader of service %q", service.Name())
+		}
+		units = leaders
+	}
+	if len(units) == 0 {
+		return nil, errors.Errorf("service %q has no units", service.Name())
+	}
+	return units, nil
+}
+
+// ActionGroups returns the actions queued in each of the given action
+// groups.
+func (a *ActionAPI) ActionGroups(arg params.ActionGroupIds) (params.ActionGroupResults, error) {
+	response := params.ActionGroupResults{Results: make([]params.ActionGroupResult, len(arg.Ids))}
+	for i, id := range arg.Ids {
+		currentResult := &response.Results[i]
+		actions, err := a.state.ActionGroup(id)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		currentResult.Group = id
+		for _, action := range actions {
+			receiverTag, err := names.ActionReceiverTag(action.Receiver())
+			if err != nil {
+				currentResult.Actions = append(currentResult.Actions, params.ActionResult{
+					Error: common.ServerError(err),
+				})
+				continue
+			}
+			currentResult.Actions = append(currentResult.Actions, makeActionResult(receiverTag, action))
+		}
+	}
+	return response, nil
+}
+
 // ListAll takes a list of Entities representing ActionReceivers and
 // returns all of the Actions that have been enqueued or run by each of
 // those Entities.
@@ -136,7 +294,8 @@ func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceiver
 	return a.internalList(arg, completedActions)
 }
 
-// Cancel attempts to cancel enqueued Actions from running.
+// Cancel attempts to cancel enqueued Actions from running. Running
+// Actions are cancelled too, and killed by the unit running them.
 func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Entities))}
 	for i, entity := range arg.Entities {
@@ -156,6 +315,10 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
+		if status := action.Status(); status != state.ActionPending && status != state.ActionRunning {
+			currentResult.Error = common.ServerError(errors.Errorf("action %s has already finished: %s", actionTag.Id(), status))
+			continue
+		}
 		result, err := action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
@@ -199,6 +362,90 @@ func (a *ActionAPI) ServicesCharmActions(args params.Entities) (params.ServicesC
 	return result, nil
 }
 
+// AddSchedules adds the given action schedules, on which the state
+// server queues actions, returning each schedule as added.
+func (a *ActionAPI) AddSchedules(arg params.ActionSchedules) (params.ActionScheduleResults, error) {
+	response := params.ActionScheduleResults{Results: make([]params.ActionScheduleResult, len(arg.Schedules))}
+	for i, schedule := range arg.Schedules {
+		currentResult := &response.Results[i]
+		receiverTag, err := names.ParseTag(schedule.Receiver)
+		if err != nil {
+			currentResult.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		added, err := a.state.AddActionSchedule(schedule.Spec, receiverTag, schedule.Name, schedule.Parameters, schedule.Timeout)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		currentResult.Schedule, err = makeActionSchedule(added)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+		}
+	}
+	return response, nil
+}
+
+// Schedules returns all the action schedules in the environment.
+func (a *ActionAPI) Schedules() (params.ActionScheduleResults, error) {
+	schedules, err := a.state.AllActionSchedules()
+	if err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	response := params.ActionScheduleResults{Results: make([]params.ActionScheduleResult, len(schedules))}
+	for i, schedule := range schedules {
+		result, err := makeActionSchedule(schedule)
+		if err != nil {
+			response.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		response.Results[i].Schedule = result
+	}
+	return response, nil
+}
+
+// RemoveSchedules removes the action schedules with the given ids.
+// Actions already queued by the schedules are not affected.
+func (a *ActionAPI) RemoveSchedules(arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	response := params.ErrorResults{Results: make([]params.ErrorResult, len(arg.Ids))}
+	for i, id := range arg.Ids {
+		if err := a.state.RemoveActionSchedule(id); err != nil {
+			response.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return response, nil
+}
+
+// makeActionSchedule converts a *state.ActionSchedule to a
+// params.ActionSchedule.
+func makeActionSchedule(schedule *state.ActionSchedule) (*params.ActionSchedule, error) {
+	receiver, err := schedule.Receiver()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	next, err := schedule.Next()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &params.ActionSchedule{
+		Id:         schedule.Id(),
+		Spec:       schedule.Spec(),
+		Receiver:   receiver.String(),
+		Name:       schedule.Name(),
+		Parameters: schedule.Parameters(),
+		Timeout:    schedule.Timeout(),
+		Created:    schedule.Created(),
+		LastError:  schedule.LastError(),
+	}
+	if lastRun := schedule.LastRun(); !lastRun.IsZero() {
+		result.LastRun = &lastRun
+	}
+	if !next.IsZero() {
+		result.Next = &next
+	}
+	return result, nil
+}
+
 // internalList takes a list of Entities representing ActionReceivers
 // and returns all of the Actions the extractorFn can get out of the
 // ActionReceiver.
@@ -307,16 +554,26 @@ func convertActions(ar state.ActionReceiver, fn getActionsFn) ([]params.ActionRe
 // to params.ActionResult.
 func makeActionResult(actionReceiverTag names.Tag, action *state.Action) params.ActionResult {
 	output, message := action.Results()
+	var log []params.ActionMessage
+	for _, m := range action.Messages() {
+		log = append(log, params.ActionMessage{
+			Timestamp: m.Timestamp,
+			Message:   m.Message,
+		})
+	}
 	return params.ActionResult{
 		Action: &params.Action{
 			Receiver:   actionReceiverTag.String(),
 			Tag:        action.ActionTag().String(),
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		},
 		Status:    string(action.Status()),
 		Message:   message,
 		Output:    output,
+		Log:       log,
+		LogCount:  action.LogCount(),
 		Enqueued:  action.Enqueued(),
 		Started:   action.Started(),
 		Completed: action.Completed(),
@@ -6,6 +6,7 @@ package action_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
@@ -157,6 +158,106 @@ func (s *actionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	c.Assert(entities[0].Tag, gc.Equals, actionTag.String())
 }
 
+func (s *actionSuite) TestFindActions(c *gc.C) {
+	// NOTE: full testing of the filters is done in the state package.
+	arg := params.Actions{Actions: []params.Action{
+		{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction", Parameters: map[string]interface{}{}},
+		{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction", Parameters: map[string]interface{}{}},
+		{Receiver: s.mysqlUnit.Tag().String(), Name: "otheraction", Parameters: map[string]interface{}{}},
+	}}
+	r, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, len(arg.Actions))
+
+	found, err := s.action.FindActions(params.ActionsFilter{
+		Receivers: []string{s.mysql.Tag().String()},
+		Names:     []string{"fakeaction"},
+		Statuses:  []string{params.ActionPending},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Error, gc.IsNil)
+	c.Assert(found.Results[0].Action.Tag, gc.Equals, r.Results[1].Action.Tag)
+	c.Assert(found.Results[0].Action.Receiver, gc.Equals, s.mysqlUnit.Tag().String())
+	c.Assert(found.Results[0].Status, gc.Equals, params.ActionPending)
+
+	found, err = s.action.FindActions(params.ActionsFilter{
+		Receivers: []string{s.wordpressUnit.Tag().String()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Action.Tag, gc.Equals, r.Results[0].Action.Tag)
+}
+
+func (s *actionSuite) TestFindActionsInvalidFilter(c *gc.C) {
+	_, err := s.action.FindActions(params.ActionsFilter{
+		Receivers: []string{s.machine0.Tag().String()},
+	})
+	c.Assert(err, gc.ErrorMatches, "id not found")
+	_, err = s.action.FindActions(params.ActionsFilter{
+		Statuses: []string{"exploded"},
+	})
+	c.Assert(err, gc.ErrorMatches, `action status "exploded" not valid`)
+}
+
+func (s *actionSuite) TestEnqueueGroups(c *gc.C) {
+	factory := jujuFactory.NewFactory(s.State)
+	wordpressUnit2 := factory.MakeUnit(c, &jujuFactory.UnitParams{
+		Service: s.wordpress,
+		Machine: s.machine1,
+	})
+	s.PatchValue(action.IsLeader, func(serviceId, unitId string) bool {
+		return unitId == wordpressUnit2.Name()
+	})
+
+	wordpressTag := s.wordpress.Tag().String()
+	r, err := s.action.EnqueueGroups(params.ActionGroups{Groups: []params.ActionGroup{
+		{Service: wordpressTag, Name: "fakeaction"},
+		{Service: wordpressTag, Name: "fakeaction", Units: []string{s.wordpressUnit.Tag().String()}},
+		{Service: wordpressTag, Name: "fakeaction", LeaderOnly: true},
+		{Service: wordpressTag, Name: "fakeaction", Units: []string{s.mysqlUnit.Tag().String()}},
+		{Service: s.mysql.Tag().String(), Name: "fakeaction", LeaderOnly: true},
+		{Service: "service-unknown", Name: "fakeaction"},
+		{Service: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 7)
+
+	receivers := func(result params.ActionGroupResult) []string {
+		var tags []string
+		for _, action := range result.Actions {
+			c.Check(action.Status, gc.Equals, params.ActionPending)
+			tags = append(tags, action.Action.Receiver)
+		}
+		return tags
+	}
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[0].Group, gc.Not(gc.Equals), "")
+	c.Assert(receivers(r.Results[0]), jc.SameContents, []string{
+		s.wordpressUnit.Tag().String(), wordpressUnit2.Tag().String(),
+	})
+	c.Assert(r.Results[1].Error, gc.IsNil)
+	c.Assert(receivers(r.Results[1]), jc.DeepEquals, []string{s.wordpressUnit.Tag().String()})
+	c.Assert(r.Results[2].Error, gc.IsNil)
+	c.Assert(receivers(r.Results[2]), jc.DeepEquals, []string{wordpressUnit2.Tag().String()})
+	c.Assert(r.Results[3].Error, gc.ErrorMatches, `unit "mysql/0" of service "wordpress" not found`)
+	c.Assert(r.Results[4].Error, gc.ErrorMatches, `leader of service "mysql" not found`)
+	c.Assert(r.Results[5].Error, gc.ErrorMatches, `service "unknown" not found`)
+	c.Assert(r.Results[6].Error, gc.ErrorMatches, "id not found")
+
+	// The queued groups can be fetched by id.
+	groups, err := s.action.ActionGroups(params.ActionGroupIds{Ids: []string{
+		r.Results[0].Group, "deadbeef-0000-4000-8000-feedfacebeef",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups.Results, gc.HasLen, 2)
+	c.Assert(groups.Results[0].Error, gc.IsNil)
+	c.Assert(groups.Results[0].Group, gc.Equals, r.Results[0].Group)
+	c.Assert(receivers(groups.Results[0]), jc.SameContents, receivers(r.Results[0]))
+	c.Assert(groups.Results[1].Error, gc.ErrorMatches, `action group "deadbeef-0000-4000-8000-feedfacebeef" not found`)
+	c.Assert(groups.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
 func (s *actionSuite) TestEnqueue(c *gc.C) {
 	// Make sure no Actions already exist on wordpress Unit.
 	actions, err := s.wordpressUnit.Actions()
@@ -574,6 +675,118 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestEnqueueWithTimeoutAndCancelRunning(c *gc.C) {
+	results, err := s.action.Enqueue(params.Actions{
+		Actions: []params.Action{{
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+			Timeout:  time.Minute,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Action.Timeout, gc.Equals, time.Minute)
+
+	// Start the action, as the unit would.
+	actionTag, err := names.ParseActionTag(results.Results[0].Action.Tag)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := s.State.ActionByTag(actionTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err = s.action.Cancel(params.Entities{
+		Entities: []params.Entity{{Tag: actionTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Status, gc.Equals, params.ActionCancelled)
+	c.Assert(results.Results[0].Message, gc.Equals, "action cancelled via the API")
+
+	// Cancelling it again fails, as it is no longer running.
+	results, err = s.action.Cancel(params.Entities{
+		Entities: []params.Entity{{Tag: actionTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "action .* has already finished: cancelled")
+}
+
+func (s *actionSuite) TestActionsLog(c *gc.C) {
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = action.Log("migrating table 1 of 2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.Actions(params.Entities{
+		Entities: []params.Entity{{Tag: action.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	log := results.Results[0].Log
+	c.Assert(log, gc.HasLen, 1)
+	c.Assert(log[0].Message, gc.Equals, "migrating table 1 of 2")
+	c.Assert(log[0].Timestamp.IsZero(), jc.IsFalse)
+}
+
+func (s *actionSuite) TestSchedules(c *gc.C) {
+	results, err := s.action.AddSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Spec:       "0 3 * * *",
+			Receiver:   s.dummy.Tag().String(),
+			Name:       "snapshot",
+			Parameters: map[string]interface{}{"outfile": "backup.bz2"},
+			Timeout:    time.Hour,
+		}, {
+			Spec:     "0 3 * * *",
+			Receiver: "machine-0",
+			Name:     "snapshot",
+		}, {
+			Spec:     "0 3 * *",
+			Receiver: s.dummy.Tag().String(),
+			Name:     "snapshot",
+		}, {
+			Spec:     "0 3 * * *",
+			Receiver: "bad-tag",
+			Name:     "snapshot",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	added := results.Results[0].Schedule
+	c.Assert(added.Id, gc.Equals, "0")
+	c.Assert(added.Receiver, gc.Equals, s.dummy.Tag().String())
+	c.Assert(added.Timeout, gc.Equals, time.Hour)
+	c.Assert(added.LastRun, gc.IsNil)
+	c.Assert(added.Next, gc.NotNil)
+	c.Assert(added.Next.Hour(), gc.Equals, 3)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot add action schedule: action schedule receiver "machine-0" not valid`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `cannot add action schedule: invalid cron expression .*`)
+	c.Assert(results.Results[3].Error, gc.DeepEquals, &params.Error{
+		Code:    params.CodeNotFound,
+		Message: common.ErrBadId.Error(),
+	})
+
+	results, err = s.action.Schedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Schedule, jc.DeepEquals, added)
+
+	removed, err := s.action.RemoveSchedules(params.ActionScheduleIds{Ids: []string{"0", "0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed.Results, gc.HasLen, 2)
+	c.Assert(removed.Results[0].Error, gc.IsNil)
+	c.Assert(removed.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	results, err = s.action.Schedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+}
+
 func (s *actionSuite) TestServicesCharmActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
@@ -50,6 +50,8 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 
 	// authedApi is the API method finder we'll use after getting logged in.
 	var authedApi rpc.MethodFinder = newApiRoot(a.root.state, a.root.closeState, a.root.resources, a.root)
+	// Record every mutating call made by a client in the audit log.
+	authedApi = newAuditingRoot(authedApi, a.root.state, a.root.state.EnvironUUID(), a.root)
 
 	// Use the login validation function, if one was specified.
 	if a.srv.validator != nil {
@@ -104,10 +106,12 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 		// can then check the credentials against the state server environment
 		// machine.
 		if kind != names.MachineTagKind {
+			loginFailures.Inc()
 			return fail, err
 		}
 		entity, err = a.checkCredsOfStateServerMachine(req)
 		if err != nil {
+			loginFailures.Inc()
 			return fail, err
 		}
 		// If we are here, then the entity will refer to a state server
@@ -118,6 +122,16 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 	}
 	a.root.entity = entity
 
+	// Restrict users to the calls permitted by their level of access
+	// to the environment.
+	if user, ok := entity.(*state.User); ok && !serverOnlyLogin {
+		envUser, err := a.root.state.EnvironmentUser(user.UserTag())
+		if err != nil {
+			return fail, errors.Wrap(err, common.ErrBadCreds)
+		}
+		authedApi = newAccessRoot(authedApi, envUser.Access())
+	}
+
 	if a.reqNotifier != nil {
 		a.reqNotifier.login(entity.Tag().String())
 	}
@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/utils/prometheus"
 )
 
 var logger = loggo.GetLogger("juju.apiserver")
@@ -233,6 +234,9 @@ type requestNotifier struct {
 	id    int64
 	start time.Time
 
+	// logging holds whether requests and replies are logged.
+	logging bool
+
 	mu   sync.Mutex
 	tag_ string
 }
@@ -261,6 +265,9 @@ func (n *requestNotifier) tag() (tag string) {
 }
 
 func (n *requestNotifier) ServerRequest(hdr *rpc.Header, body interface{}) {
+	if !n.logging {
+		return
+	}
 	if hdr.Request.Type == "Pinger" && hdr.Request.Action == "Ping" {
 		return
 	}
@@ -275,6 +282,16 @@ func (n *requestNotifier) ServerRequest(hdr *rpc.Header, body interface{}) {
 }
 
 func (n *requestNotifier) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}, timeSpent time.Duration) {
+	facade, method := req.Type, req.Action
+	if hdr.ErrorCode == rpc.CodeNotImplemented {
+		// Calls to unknown facades and methods are counted together,
+		// so that clients cannot create an unbounded number of series.
+		facade, method = "unknown", "unknown"
+	}
+	observeRPC(facade, method, hdr.Error != "", timeSpent.Seconds())
+	if !n.logging {
+		return
+	}
 	if req.Type == "Pinger" && req.Action == "Ping" {
 		return
 	}
@@ -289,10 +306,12 @@ func (n *requestNotifier) ServerReply(req rpc.Request, hdr *rpc.Header, body int
 }
 
 func (n *requestNotifier) join(req *http.Request) {
+	apiConnections.Inc()
 	logger.Infof("[%X] API connection from %s", n.id, req.RemoteAddr)
 }
 
 func (n *requestNotifier) leave() {
+	apiConnections.Dec()
 	logger.Infof("[%X] %s API connection terminated after %v", n.id, n.tag(), time.Since(n.start))
 }
 
@@ -311,6 +330,21 @@ func handleAll(mux *pat.PatternServeMux, pattern string, handler http.Handler) {
 	mux.Options(pattern, handler)
 }
 
+// newDebugLogHandler returns the handler for debug log requests. If
+// logs are being written to the database, they are served from there,
+// otherwise they are served from the consolidated log file.
+func (srv *Server) newDebugLogHandler() http.Handler {
+	if featureflag.Enabled(feature.DbLog) {
+		return &debugLogDBHandler{
+			httpHandler: httpHandler{ssState: srv.state},
+		}
+	}
+	return &debugLogHandler{
+		httpHandler: httpHandler{ssState: srv.state},
+		logDir:      srv.logDir,
+	}
+}
+
 func (srv *Server) run(lis net.Listener) {
 	defer srv.tomb.Done()
 	defer srv.wg.Wait() // wait for any outstanding requests to complete.
@@ -330,12 +364,8 @@ func (srv *Server) run(lis net.Listener) {
 	// registered, first match wins. So more specific ones have to be
 	// registered first.
 	mux := pat.New()
-	// For backwards compatibility we register all the old paths
-	handleAll(mux, "/environment/:envuuid/log",
-		&debugLogHandler{
-			httpHandler: httpHandler{ssState: srv.state},
-			logDir:      srv.logDir},
-	)
+	debugLogHandler := srv.newDebugLogHandler()
+	handleAll(mux, "/environment/:envuuid/log", debugLogHandler)
 	if featureflag.Enabled(feature.DbLog) {
 		handleAll(mux, "/environment/:envuuid/logsink",
 			&logSinkHandler{
@@ -369,16 +399,22 @@ func (srv *Server) run(lis net.Listener) {
 			stateServerEnvOnly: true,
 		}},
 	)
+	handleAll(mux, "/environment/:envuuid/metrics",
+		&metricsHandler{
+			httpHandler: httpHandler{
+				ssState:            srv.state,
+				strictValidation:   true,
+				stateServerEnvOnly: true,
+			},
+			registry: prometheus.DefaultRegistry,
+		},
+	)
 	handleAll(mux, "/environment/:envuuid/api", http.HandlerFunc(srv.apiHandler))
 	handleAll(mux, "/environment/:envuuid/images/:kind/:series/:arch/:filename",
 		&imagesDownloadHandler{httpHandler{ssState: srv.state}},
 	)
 	// For backwards compatibility we register all the old paths
-	handleAll(mux, "/log",
-		&debugLogHandler{
-			httpHandler: httpHandler{ssState: srv.state},
-			logDir:      srv.logDir},
-	)
+	handleAll(mux, "/log", debugLogHandler)
 	handleAll(mux, "/charms",
 		&charmsHandler{
 			httpHandler: httpHandler{ssState: srv.state},
@@ -434,13 +470,11 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, reqNotifier *requestNotifie
 	if loggo.GetLogger("juju.rpc.jsoncodec").EffectiveLogLevel() <= loggo.TRACE {
 		codec.SetLogging(true)
 	}
-	var notifier rpc.RequestNotifier
-	if logger.EffectiveLogLevel() <= loggo.DEBUG {
-		// Incur request monitoring overhead only if we
-		// know we'll need it.
-		notifier = reqNotifier
-	}
-	conn := rpc.NewConn(codec, notifier)
+	// Requests are always observed to record metrics, but
+	// incur the overhead of logging them only if we know
+	// we'll need it.
+	reqNotifier.logging = logger.EffectiveLogLevel() <= loggo.DEBUG
+	conn := rpc.NewConn(codec, reqNotifier)
 
 	var h *apiHandler
 	st, _, err := validateEnvironUUID(validateArgs{st: srv.state, envUUID: envUUID})
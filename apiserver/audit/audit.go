@@ -0,0 +1,112 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package audit provides the API facade used to query the audit log
+// of an environment.
+package audit
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("Audit", 1, NewAPI)
+}
+
+// Audit defines the methods on the audit API end point.
+type Audit interface {
+	// List returns the audit entries for this environment that
+	// match the given filter.
+	List(params.AuditLogFilter) (params.AuditEntriesResult, error)
+}
+
+// API implements Audit interface and is the concrete
+// implementation of the api end point.
+type API struct {
+	access     auditAccess
+	authorizer common.Authorizer
+}
+
+var _ Audit = (*API)(nil)
+
+func createAPI(
+	st auditAccess,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		access:     st,
+		authorizer: authorizer,
+	}, nil
+}
+
+// NewAPI returns a new audit API facade.
+func NewAPI(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*API, error) {
+	return createAPI(getState(st), resources, authorizer)
+}
+
+var getState = func(st *state.State) auditAccess {
+	return stateShim{st}
+}
+
+// List implements Audit.List().
+func (a *API) List(args params.AuditLogFilter) (params.AuditEntriesResult, error) {
+	filter := state.AuditFilter{Limit: args.Limit}
+	if args.User != "" {
+		tag, err := names.ParseUserTag(args.User)
+		if err != nil {
+			return params.AuditEntriesResult{}, common.ServerError(err)
+		}
+		filter.User = tag.Username()
+	}
+	if args.Entity != "" {
+		tag, err := names.ParseTag(args.Entity)
+		if err != nil {
+			return params.AuditEntriesResult{}, common.ServerError(err)
+		}
+		filter.Entity = tag.String()
+	}
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		err := errors.NotValidf("time range ending before it starts")
+		return params.AuditEntriesResult{}, common.ServerError(err)
+	}
+	entries, err := a.access.AuditEntries(filter)
+	if err != nil {
+		return params.AuditEntriesResult{}, common.ServerError(err)
+	}
+	result := params.AuditEntriesResult{
+		Entries: make([]params.AuditEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditEntry{
+			Timestamp: entry.Timestamp,
+			User:      entry.User,
+			Facade:    entry.Facade,
+			Version:   entry.Version,
+			Method:    entry.Method,
+			Args:      entry.Args,
+			Entities:  entry.Entities,
+			Outcome:   string(entry.Outcome),
+			Error:     entry.Error,
+		}
+	}
+	return result, nil
+}
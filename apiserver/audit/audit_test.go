@@ -0,0 +1,129 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiaudit "github.com/juju/juju/apiserver/audit"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditSuite struct {
+	coretesting.BaseSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	state      *mockState
+	api        *apiaudit.API
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	s.state = &mockState{}
+	var err error
+	s.api, err = apiaudit.CreateAPI(s.state, common.NewResources(), s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *auditSuite) TestNewAPIRefusesNonClient(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")}
+	_, err := apiaudit.CreateAPI(s.state, common.NewResources(), auth)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *auditSuite) TestList(c *gc.C) {
+	ts := time.Date(2015, 5, 1, 10, 0, 0, 0, time.UTC)
+	s.state.entries = []audit.AuditEntry{{
+		Timestamp: ts,
+		EnvUUID:   coretesting.EnvironmentTag.Id(),
+		User:      "bob@local",
+		Facade:    "Client",
+		Method:    "ServiceDestroy",
+		Args:      `{"ServiceName":"mysql"}`,
+		Entities:  []string{"service-mysql"},
+		Outcome:   audit.Failure,
+		Error:     "boom",
+	}}
+	from := ts.Add(-time.Hour)
+	result, err := s.api.List(params.AuditLogFilter{
+		User:   "user-bob",
+		Entity: "service-mysql",
+		From:   &from,
+		Limit:  10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.state.filter, jc.DeepEquals, state.AuditFilter{
+		User:   "bob@local",
+		Entity: "service-mysql",
+		From:   from,
+		Limit:  10,
+	})
+	c.Assert(result, jc.DeepEquals, params.AuditEntriesResult{
+		Entries: []params.AuditEntry{{
+			Timestamp: ts,
+			User:      "bob@local",
+			Facade:    "Client",
+			Method:    "ServiceDestroy",
+			Args:      `{"ServiceName":"mysql"}`,
+			Entities:  []string{"service-mysql"},
+			Outcome:   "failure",
+			Error:     "boom",
+		}},
+	})
+}
+
+func (s *auditSuite) TestListLocalUser(c *gc.C) {
+	for _, user := range []string{"user-bob", "user-bob@local"} {
+		_, err := s.api.List(params.AuditLogFilter{User: user})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s.state.filter.User, gc.Equals, "bob@local")
+	}
+}
+
+func (s *auditSuite) TestListInvalidUser(c *gc.C) {
+	_, err := s.api.List(params.AuditLogFilter{User: "machine-0"})
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+}
+
+func (s *auditSuite) TestListInvalidEntity(c *gc.C) {
+	_, err := s.api.List(params.AuditLogFilter{Entity: "mysql"})
+	c.Assert(err, gc.ErrorMatches, `"mysql" is not a valid tag`)
+}
+
+func (s *auditSuite) TestListInvalidTimeRange(c *gc.C) {
+	from := time.Now()
+	to := from.Add(-time.Hour)
+	_, err := s.api.List(params.AuditLogFilter{From: &from, To: &to})
+	c.Assert(err, gc.ErrorMatches, "time range ending before it starts not valid")
+}
+
+func (s *auditSuite) TestListError(c *gc.C) {
+	s.state.err = errors.New("boom")
+	_, err := s.api.List(params.AuditLogFilter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockState struct {
+	filter  state.AuditFilter
+	entries []audit.AuditEntry
+	err     error
+}
+
+func (st *mockState) AuditEntries(filter state.AuditFilter) ([]audit.AuditEntry, error) {
+	st.filter = filter
+	return st.entries, st.err
+}
@@ -0,0 +1,17 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+type auditAccess interface {
+	AuditEntries(filter state.AuditFilter) ([]audit.AuditEntry, error)
+}
+
+type stateShim struct {
+	*state.State
+}
@@ -0,0 +1,217 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/juju/names"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+// maxAuditArgsLen is the maximum length of the argument summary
+// recorded for an audited call.
+const maxAuditArgsLen = 1024
+
+// redactedAuditCalls are the calls whose arguments may contain secrets
+// and so are never recorded in the audit log.
+var redactedAuditCalls = map[string]set.Strings{
+	"Client":      set.NewStrings("AddCharmWithAuthorization", "EnvironmentSet", "ProvisioningScript"),
+	"Service":     set.NewStrings("SetMetricCredentials"),
+	"UserManager": set.NewStrings("AddUser", "SetPassword"),
+}
+
+// auditFieldTags maps the names of argument fields that hold entity
+// names, rather than tags, to functions that build the tag. The
+// functions return nil if the name is not valid.
+var auditFieldTags = map[string]func(string) names.Tag{
+	"ServiceName": func(name string) names.Tag {
+		if !names.IsValidService(name) {
+			return nil
+		}
+		return names.NewServiceTag(name)
+	},
+	"UnitName": func(name string) names.Tag {
+		if !names.IsValidUnit(name) {
+			return nil
+		}
+		return names.NewUnitTag(name)
+	},
+	"MachineId": func(id string) names.Tag {
+		if !names.IsValidMachine(id) {
+			return nil
+		}
+		return names.NewMachineTag(id)
+	},
+}
+
+// auditingRoot records an audit entry for every call made by a client
+// user that may change the environment.
+type auditingRoot struct {
+	rpc.MethodFinder
+	writer     audit.EntryWriter
+	envUUID    string
+	authorizer common.Authorizer
+}
+
+// newAuditingRoot returns a new auditingRoot which records entries
+// for the environment with the given UUID using writer.
+func newAuditingRoot(finder rpc.MethodFinder, writer audit.EntryWriter, envUUID string, authorizer common.Authorizer) *auditingRoot {
+	return &auditingRoot{
+		MethodFinder: finder,
+		writer:       writer,
+		envUUID:      envUUID,
+		authorizer:   authorizer,
+	}
+}
+
+// FindMethod returns a MethodCaller which records the outcome of the
+// call in the audit log, unless the call is read-only or is not made
+// by a client user.
+func (r *auditingRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	caller, err := r.MethodFinder.FindMethod(rootName, version, methodName)
+	if err != nil {
+		return nil, err
+	}
+	if !r.authorizer.AuthClient() || isCallReadOnly(rootName, methodName) {
+		return caller, nil
+	}
+	return &auditingCaller{
+		MethodCaller: caller,
+		root:         r,
+		facade:       rootName,
+		version:      version,
+		method:       methodName,
+	}, nil
+}
+
+// auditingCaller wraps a MethodCaller, recording each call made
+// through it.
+type auditingCaller struct {
+	rpcreflect.MethodCaller
+	root    *auditingRoot
+	facade  string
+	version int
+	method  string
+}
+
+// Call implements rpcreflect.MethodCaller.
+func (c *auditingCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+	result, err := c.MethodCaller.Call(objId, arg)
+	var user string
+	if tag, ok := c.root.authorizer.GetAuthTag().(names.UserTag); ok {
+		user = tag.Username()
+	}
+	entry := audit.AuditEntry{
+		Timestamp: time.Now(),
+		EnvUUID:   c.root.envUUID,
+		User:      user,
+		Facade:    c.facade,
+		Version:   c.version,
+		Method:    c.method,
+		Outcome:   audit.Success,
+	}
+	if redactedAuditCalls[c.facade].Contains(c.method) {
+		entry.Args = "<redacted>"
+	} else {
+		entry.Args = auditArgsSummary(arg)
+		entry.Entities = auditArgsEntities(arg)
+	}
+	if callErr := auditCallError(result, err); callErr != nil {
+		entry.Outcome = audit.Failure
+		entry.Error = callErr.Error()
+	}
+	if err := audit.Put(c.root.writer, entry); err != nil {
+		logger.Errorf("cannot record audit entry for %s.%s: %v", c.facade, c.method, err)
+	}
+	return result, err
+}
+
+// auditArgsSummary returns a JSON representation of the call
+// arguments, truncated to maxAuditArgsLen.
+func auditArgsSummary(arg reflect.Value) string {
+	if !arg.IsValid() || !arg.CanInterface() {
+		return ""
+	}
+	data, err := json.Marshal(arg.Interface())
+	if err != nil {
+		return "<unknown>"
+	}
+	if len(data) > maxAuditArgsLen {
+		return string(data[:maxAuditArgsLen]) + "..."
+	}
+	return string(data)
+}
+
+// auditArgsEntities returns the tags of all entities named in the
+// call arguments, either directly by tag or through one of the
+// fields in auditFieldTags.
+func auditArgsEntities(arg reflect.Value) []string {
+	found := set.NewStrings()
+	var walk func(v reflect.Value, field string)
+	walk = func(v reflect.Value, field string) {
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			if !v.IsNil() {
+				walk(v.Elem(), field)
+			}
+		case reflect.Struct:
+			t := v.Type()
+			for i := 0; i < v.NumField(); i++ {
+				if t.Field(i).PkgPath != "" {
+					continue
+				}
+				walk(v.Field(i), t.Field(i).Name)
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i), field)
+			}
+		case reflect.String:
+			s := v.String()
+			if tag, err := names.ParseTag(s); err == nil {
+				found.Add(tag.String())
+			} else if toTag, ok := auditFieldTags[field]; ok {
+				if tag := toTag(s); tag != nil {
+					found.Add(tag.String())
+				}
+			}
+		}
+	}
+	if arg.IsValid() {
+		walk(arg, "")
+	}
+	if found.IsEmpty() {
+		return nil
+	}
+	return found.SortedValues()
+}
+
+// auditCallError returns the error reported by a call, including
+// errors returned in a params.ErrorResult or params.ErrorResults.
+func auditCallError(result reflect.Value, err error) error {
+	if err != nil {
+		return err
+	}
+	if !result.IsValid() || !result.CanInterface() {
+		return nil
+	}
+	switch r := result.Interface().(type) {
+	case params.ErrorResult:
+		if r.Error != nil {
+			return r.Error
+		}
+	case params.ErrorResults:
+		return r.Combine()
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/testing"
+)
+
+const auditEnvUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+type auditingRootSuite struct {
+	testing.BaseSuite
+
+	finder *fakeFinder
+	writer *fakeEntryWriter
+}
+
+var _ = gc.Suite(&auditingRootSuite{})
+
+func (s *auditingRootSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.finder = &fakeFinder{}
+	s.writer = &fakeEntryWriter{}
+}
+
+func (s *auditingRootSuite) call(c *gc.C, tag names.Tag, rootName, methodName string, arg interface{}) {
+	auth := apiservertesting.FakeAuthorizer{Tag: tag}
+	root := apiserver.TestingAuditingRoot(s.finder, s.writer, auditEnvUUID, auth)
+	caller, err := root.FindMethod(rootName, 0, methodName)
+	c.Assert(err, jc.ErrorIsNil)
+	caller.Call("", reflect.ValueOf(arg))
+}
+
+func (s *auditingRootSuite) TestMutatingClientCallAudited(c *gc.C) {
+	arg := params.DestroyServiceUnits{UnitNames: []string{"mysql/0"}}
+	s.call(c, names.NewUserTag("bob"), "Client", "DestroyServiceUnits", arg)
+
+	c.Assert(s.writer.entries, gc.HasLen, 1)
+	entry := s.writer.entries[0]
+	c.Assert(entry.Timestamp.IsZero(), jc.IsFalse)
+	c.Assert(entry.EnvUUID, gc.Equals, auditEnvUUID)
+	c.Assert(entry.User, gc.Equals, "bob@local")
+	c.Assert(entry.Facade, gc.Equals, "Client")
+	c.Assert(entry.Method, gc.Equals, "DestroyServiceUnits")
+	c.Assert(entry.Args, gc.Equals, `{"UnitNames":["mysql/0"]}`)
+	c.Assert(entry.Entities, jc.DeepEquals, []string{"unit-mysql-0"})
+	c.Assert(entry.Outcome, gc.Equals, audit.Success)
+	c.Assert(entry.Error, gc.Equals, "")
+}
+
+func (s *auditingRootSuite) TestFailedCallAudited(c *gc.C) {
+	s.finder.err = errors.New("boom")
+	s.call(c, names.NewUserTag("bob"), "Client", "ServiceDestroy", params.ServiceDestroy{ServiceName: "mysql"})
+
+	c.Assert(s.writer.entries, gc.HasLen, 1)
+	entry := s.writer.entries[0]
+	c.Assert(entry.Entities, jc.DeepEquals, []string{"service-mysql"})
+	c.Assert(entry.Outcome, gc.Equals, audit.Failure)
+	c.Assert(entry.Error, gc.Equals, "boom")
+}
+
+func (s *auditingRootSuite) TestErrorResultsAudited(c *gc.C) {
+	s.finder.result = params.ErrorResults{Results: []params.ErrorResult{{
+		Error: &params.Error{Message: "not found"},
+	}}}
+	s.call(c, names.NewUserTag("bob"), "Client", "DestroyMachines", params.DestroyMachines{MachineNames: []string{"1"}})
+
+	c.Assert(s.writer.entries, gc.HasLen, 1)
+	c.Assert(s.writer.entries[0].Outcome, gc.Equals, audit.Failure)
+	c.Assert(s.writer.entries[0].Error, gc.Equals, "not found")
+}
+
+func (s *auditingRootSuite) TestSecretsRedacted(c *gc.C) {
+	arg := params.EntityPasswords{Changes: []params.EntityPassword{{
+		Tag:      "user-bob",
+		Password: "sekrit",
+	}}}
+	s.call(c, names.NewUserTag("bob"), "UserManager", "SetPassword", arg)
+
+	c.Assert(s.writer.entries, gc.HasLen, 1)
+	c.Assert(s.writer.entries[0].Args, gc.Equals, "<redacted>")
+	c.Assert(s.writer.entries[0].Entities, gc.HasLen, 0)
+}
+
+func (s *auditingRootSuite) TestReadOnlyCallNotAudited(c *gc.C) {
+	s.call(c, names.NewUserTag("bob"), "Client", "FullStatus", params.StatusParams{})
+	c.Assert(s.writer.entries, gc.HasLen, 0)
+}
+
+func (s *auditingRootSuite) TestAgentCallNotAudited(c *gc.C) {
+	s.call(c, names.NewMachineTag("0"), "Machiner", "SetStatus", params.SetStatus{})
+	c.Assert(s.writer.entries, gc.HasLen, 0)
+}
+
+func (s *auditingRootSuite) TestWriterErrorIgnored(c *gc.C) {
+	s.writer.err = errors.New("disk full")
+	s.finder.result = params.ErrorResult{}
+	s.call(c, names.NewUserTag("bob"), "Client", "ServiceExpose", params.ServiceExpose{ServiceName: "mysql"})
+	c.Assert(s.writer.entries, gc.HasLen, 0)
+}
+
+func (s *auditingRootSuite) TestIsCallReadOnly(c *gc.C) {
+	c.Assert(apiserver.IsCallReadOnly("Client", "FullStatus"), jc.IsTrue)
+	c.Assert(apiserver.IsCallReadOnly("AllWatcher", "Next"), jc.IsTrue)
+	c.Assert(apiserver.IsCallReadOnly("Client", "ServiceHookStats"), jc.IsTrue)
+	c.Assert(apiserver.IsCallReadOnly("Storage", "ListVolumeSnapshots"), jc.IsTrue)
+	c.Assert(apiserver.IsCallReadOnly("Client", "ServiceDeploy"), jc.IsFalse)
+	c.Assert(apiserver.IsCallReadOnly("Unknown", "Get"), jc.IsFalse)
+}
+
+type fakeFinder struct {
+	result interface{}
+	err    error
+}
+
+func (f *fakeFinder) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	return &fakeCaller{f}, nil
+}
+
+type fakeCaller struct {
+	finder *fakeFinder
+}
+
+func (c *fakeCaller) ParamsType() reflect.Type {
+	return nil
+}
+
+func (c *fakeCaller) ResultType() reflect.Type {
+	return nil
+}
+
+func (c *fakeCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+	if c.finder.result == nil {
+		return reflect.Value{}, c.finder.err
+	}
+	return reflect.ValueOf(c.finder.result), c.finder.err
+}
+
+type fakeEntryWriter struct {
+	entries []audit.AuditEntry
+	err     error
+}
+
+func (w *fakeEntryWriter) AddAuditEntry(entry audit.AuditEntry) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.entries = append(w.entries, entry)
+	return nil
+}
@@ -5,6 +5,7 @@ package block
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
@@ -12,7 +13,9 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("Block", 1, NewAPI)
+	common.RegisterStandardFacade("Block", 1, NewAPIV1)
+	// Version 2 adds blocks on individual services, units and machines.
+	common.RegisterStandardFacade("Block", 2, NewAPI)
 }
 
 // Block defines the methods on the block API end point.
@@ -21,11 +24,11 @@ type Block interface {
 	List() (params.BlockResults, error)
 
 	// SwitchBlockOn switches desired block type on for this
-	// environment.
+	// environment, or for an entity within it.
 	SwitchBlockOn(params.BlockSwitchParams) params.ErrorResult
 
 	// SwitchBlockOff switches desired block type off for this
-	// environment.
+	// environment, or for an entity within it.
 	SwitchBlockOff(params.BlockSwitchParams) params.ErrorResult
 }
 
@@ -52,6 +55,25 @@ func NewAPI(
 	}, nil
 }
 
+// APIV1 implements version 1 of the Block facade, which predates
+// blocks on individual entities.
+type APIV1 struct {
+	*API
+}
+
+// NewAPIV1 returns a new block API facade for version 1 clients.
+func NewAPIV1(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*APIV1, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV1{api}, nil
+}
+
 var getState = func(st *state.State) blockAccess {
 	return stateShim{st}
 }
@@ -69,6 +91,26 @@ func (a *API) List() (params.BlockResults, error) {
 	return params.BlockResults{Results: found}, nil
 }
 
+// List implements Block.List(), omitting blocks on individual
+// entities, which version 1 clients cannot tell apart from
+// environment blocks.
+func (a *APIV1) List() (params.BlockResults, error) {
+	all, err := a.access.AllBlocks()
+	if err != nil {
+		return params.BlockResults{}, common.ServerError(err)
+	}
+	var found []params.BlockResult
+	for _, one := range all {
+		if tag, err := one.Tag(); err == nil {
+			if _, ok := tag.(names.EnvironTag); !ok {
+				continue
+			}
+		}
+		found = append(found, convertBlock(one))
+	}
+	return params.BlockResults{Results: found}, nil
+}
+
 func convertBlock(b state.Block) params.BlockResult {
 	result := params.BlockResult{}
 	tag, err := b.Tag()
@@ -87,12 +129,30 @@ func convertBlock(b state.Block) params.BlockResult {
 
 // SwitchBlockOn implements Block.SwitchBlockOn().
 func (a *API) SwitchBlockOn(args params.BlockSwitchParams) params.ErrorResult {
-	err := a.access.SwitchBlockOn(state.ParseBlockType(args.Type), args.Message)
+	t := state.ParseBlockType(args.Type)
+	if args.Entity == "" {
+		err := a.access.SwitchBlockOn(t, args.Message)
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	tag, err := names.ParseTag(args.Entity)
+	if err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	err = a.access.SwitchEntityBlockOn(t, tag, args.Message)
 	return params.ErrorResult{Error: common.ServerError(err)}
 }
 
 // SwitchBlockOff implements Block.SwitchBlockOff().
 func (a *API) SwitchBlockOff(args params.BlockSwitchParams) params.ErrorResult {
-	err := a.access.SwitchBlockOff(state.ParseBlockType(args.Type))
+	t := state.ParseBlockType(args.Type)
+	if args.Entity == "" {
+		err := a.access.SwitchBlockOff(t)
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	tag, err := names.ParseTag(args.Entity)
+	if err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	err = a.access.SwitchEntityBlockOff(t, tag)
 	return params.ErrorResult{Error: common.ServerError(err)}
 }
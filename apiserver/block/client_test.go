@@ -79,3 +79,60 @@ func (s *blockSuite) TestSwitchBlockOff(c *gc.C) {
 	c.Assert(err.Error, gc.IsNil)
 	s.assertBlockList(c, 0)
 }
+
+func (s *blockSuite) TestSwitchEntityBlockOnAndOff(c *gc.C) {
+	svc := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	on := params.BlockSwitchParams{
+		Type:    state.RemoveBlock.String(),
+		Message: "for TestSwitchEntityBlockOnAndOff",
+		Entity:  svc.Tag().String(),
+	}
+	err := s.api.SwitchBlockOn(on)
+	c.Assert(err.Error, gc.IsNil)
+
+	all, listErr := s.api.List()
+	c.Assert(listErr, jc.ErrorIsNil)
+	c.Assert(all.Results, gc.HasLen, 1)
+	c.Assert(all.Results[0].Result.Tag, gc.Equals, "service-wordpress")
+	c.Assert(all.Results[0].Result.Type, gc.Equals, state.RemoveBlock.String())
+
+	off := params.BlockSwitchParams{
+		Type:   state.RemoveBlock.String(),
+		Entity: svc.Tag().String(),
+	}
+	err = s.api.SwitchBlockOff(off)
+	c.Assert(err.Error, gc.IsNil)
+	s.assertBlockList(c, 0)
+}
+
+func (s *blockSuite) TestListV1OmitsEntityBlocks(c *gc.C) {
+	svc := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := s.State.SwitchEntityBlockOn(state.RemoveBlock, svc.Tag(), "for TestListV1OmitsEntityBlocks")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SwitchBlockOn(state.ChangeBlock, "for TestListV1OmitsEntityBlocks")
+	c.Assert(err, jc.ErrorIsNil)
+
+	auth := testing.FakeAuthorizer{
+		Tag:            s.AdminUserTag(c),
+		EnvironManager: true,
+	}
+	api, err := block.NewAPIV1(s.State, common.NewResources(), auth)
+	c.Assert(err, jc.ErrorIsNil)
+	all, err := api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all.Results, gc.HasLen, 1)
+	c.Assert(all.Results[0].Result.Tag, gc.Equals, s.State.EnvironTag().String())
+	c.Assert(all.Results[0].Result.Type, gc.Equals, state.ChangeBlock.String())
+
+	s.assertBlockList(c, 2)
+}
+
+func (s *blockSuite) TestSwitchEntityBlockInvalidTag(c *gc.C) {
+	on := params.BlockSwitchParams{
+		Type:   state.ChangeBlock.String(),
+		Entity: "wordpress",
+	}
+	err := s.api.SwitchBlockOn(on)
+	c.Assert(err.Error, gc.ErrorMatches, `"wordpress" is not a valid tag`)
+	s.assertBlockList(c, 0)
+}
@@ -45,7 +45,7 @@ type API struct {
 // Client serves client-specific API methods.
 type Client struct {
 	api   *API
-	check *common.BlockChecker
+	check *common.EntityBlockChecker
 }
 
 // NewClient creates a new instance of the Client Facade.
@@ -62,7 +62,7 @@ func NewClient(st *state.State, resources *common.Resources, authorizer common.A
 			statusSetter: common.NewStatusSetter(st, common.AuthAlways()),
 			toolsFinder:  common.NewToolsFinder(st, st, urlGetter),
 		},
-		check: common.NewBlockChecker(st)}, nil
+		check: common.NewEntityBlockChecker(st)}, nil
 }
 
 func (c *Client) WatchAll() (params.AllWatcherId, error) {
@@ -78,7 +78,7 @@ func (c *Client) WatchAll() (params.AllWatcherId, error) {
 // (Deprecated) Use NewServiceSetForClientAPI instead, to preserve values set to
 // an empty string, and use ServiceUnset to unset values.
 func (c *Client) ServiceSet(p params.ServiceSet) error {
-	if err := c.check.ChangeAllowed(); err != nil {
+	if err := c.check.ChangeAllowedFor(names.NewServiceTag(p.ServiceName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := c.api.state.Service(p.ServiceName)
@@ -97,6 +97,9 @@ func (c *Client) ServiceSet(p params.ServiceSet) error {
 // when the GUI handles the new behavior.
 // TODO(mattyw, all): This api call should be move to the new service facade. The client api version will then need bumping.
 func (c *Client) NewServiceSetForClientAPI(p params.ServiceSet) error {
+	if err := c.check.ChangeAllowedFor(names.NewServiceTag(p.ServiceName)); err != nil {
+		return errors.Trace(err)
+	}
 	svc, err := c.api.state.Service(p.ServiceName)
 	if err != nil {
 		return err
@@ -107,7 +110,7 @@ func (c *Client) NewServiceSetForClientAPI(p params.ServiceSet) error {
 // ServiceUnset implements the server side of Client.ServiceUnset.
 // TODO(mattyw, all): This api call should be move to the new service facade. The client api version will then need bumping.
 func (c *Client) ServiceUnset(p params.ServiceUnset) error {
-	if err := c.check.ChangeAllowed(); err != nil {
+	if err := c.check.ChangeAllowedFor(names.NewServiceTag(p.ServiceName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := c.api.state.Service(p.ServiceName)
@@ -124,7 +127,7 @@ func (c *Client) ServiceUnset(p params.ServiceUnset) error {
 // ServiceSetYAML implements the server side of Client.ServerSetYAML.
 // TODO(mattyw, all): This api call should be move to the new service facade. The client api version will then need bumping.
 func (c *Client) ServiceSetYAML(p params.ServiceSetYAML) error {
-	if err := c.check.ChangeAllowed(); err != nil {
+	if err := c.check.ChangeAllowedFor(names.NewServiceTag(p.ServiceName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := c.api.state.Service(p.ServiceName)
@@ -155,7 +158,7 @@ func (c *Client) ServiceCharmRelations(p params.ServiceCharmRelations) (params.S
 
 // Resolved implements the server side of Client.Resolved.
 func (c *Client) Resolved(p params.Resolved) error {
-	if err := c.check.ChangeAllowed(); err != nil {
+	if err := c.check.ChangeAllowedFor(unitTags([]string{p.UnitName})...); err != nil {
 		return errors.Trace(err)
 	}
 	unit, err := c.api.state.Unit(p.UnitName)
@@ -225,7 +228,7 @@ func (c *Client) PrivateAddress(p params.PrivateAddress) (results params.Private
 // were also explicitly marked by units as open.
 // TODO(mattyw, all): This api call should be move to the new service facade. The client api version will then need bumping.
 func (c *Client) ServiceExpose(args params.ServiceExpose) error {
-	if err := c.check.ChangeAllowed(); err != nil {
+	if err := c.check.ChangeAllowedFor(names.NewServiceTag(args.ServiceName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := c.api.state.Service(args.ServiceName)
@@ -239,7 +242,7 @@ func (c *Client) ServiceExpose(args params.ServiceExpose) error {
 // were also explicitly marked by units as open.
 // TODO(mattyw, all): This api call should be move to the new service facade. The client api version will then need bumping.
 func (c *Client) ServiceUnexpose(args params.ServiceUnexpose) error {
-	if err := c.check.ChangeAllowed(); err != nil {
+	if err := c.check.ChangeAllowedFor(names.NewServiceTag(args.ServiceName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := c.api.state.Service(args.ServiceName)
@@ -273,7 +276,7 @@ func (c *Client) ServiceDeployWithNetworks(args params.ServiceDeploy) error {
 // All parameters in params.ServiceUpdate except the service name are optional.
 func (c *Client) ServiceUpdate(args params.ServiceUpdate) error {
 	if !args.ForceCharmUrl {
-		if err := c.check.ChangeAllowed(); err != nil {
+		if err := c.check.ChangeAllowedFor(names.NewServiceTag(args.ServiceName)); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -390,7 +393,7 @@ func newServiceSetSettingsStringsForClientAPI(service *state.Service, settings m
 func (c *Client) ServiceSetCharm(args params.ServiceSetCharm) error {
 	// when forced, don't block
 	if !args.Force {
-		if err := c.check.ChangeAllowed(); err != nil {
+		if err := c.check.ChangeAllowedFor(names.NewServiceTag(args.ServiceName)); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -401,6 +404,124 @@ func (c *Client) ServiceSetCharm(args params.ServiceSetCharm) error {
 	return c.serviceSetCharm(service, args.CharmUrl, args.Force)
 }
 
+// ServiceSetCharmRolling sets the charm for a given service, releasing
+// its units to upgrade in batches. The charm must already have been
+// added to the environment.
+func (c *Client) ServiceSetCharmRolling(args params.ServiceSetCharmRolling) error {
+	// when forced, don't block
+	if !args.Force {
+		if err := c.check.ChangeAllowedFor(names.NewServiceTag(args.ServiceName)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	service, err := c.api.state.Service(args.ServiceName)
+	if err != nil {
+		return err
+	}
+	curl, err := charm.ParseURL(args.CharmUrl)
+	if err != nil {
+		return err
+	}
+	sch, err := c.api.state.Charm(curl)
+	if err != nil {
+		return err
+	}
+	return service.SetCharmRolling(sch, args.Force, args.BatchSize, args.Rollback)
+}
+
+// ServiceResumeRollingUpgrade resumes the paused rolling charm upgrade
+// of a given service.
+func (c *Client) ServiceResumeRollingUpgrade(args params.ServiceResumeRollingUpgrade) error {
+	if err := c.check.ChangeAllowedFor(names.NewServiceTag(args.ServiceName)); err != nil {
+		return errors.Trace(err)
+	}
+	service, err := c.api.state.Service(args.ServiceName)
+	if err != nil {
+		return err
+	}
+	return service.ResumeRollingUpgrade()
+}
+
+// ServiceRollbackCharm sets a given service back to the charm and
+// config settings it used before its charm was last changed.
+func (c *Client) ServiceRollbackCharm(args params.ServiceRollbackCharm) error {
+	// when forced, don't block
+	if !args.Force {
+		if err := c.check.ChangeAllowedFor(names.NewServiceTag(args.ServiceName)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	service, err := c.api.state.Service(args.ServiceName)
+	if err != nil {
+		return err
+	}
+	return service.RollbackCharm(args.Force)
+}
+
+// ServiceSetHookTimeout sets how long the units of a given service may
+// run a hook before it is killed and the unit is put into an error
+// state. A zero timeout means hooks are never killed.
+func (c *Client) ServiceSetHookTimeout(args params.ServiceSetHookTimeout) error {
+	if err := c.check.ChangeAllowedFor(names.NewServiceTag(args.ServiceName)); err != nil {
+		return errors.Trace(err)
+	}
+	service, err := c.api.state.Service(args.ServiceName)
+	if err != nil {
+		return err
+	}
+	return service.SetHookTimeout(args.Timeout)
+}
+
+// ServiceHookStats returns statistics on the recorded runs of hooks,
+// actions and commands by the units of a given service.
+func (c *Client) ServiceHookStats(args params.ServiceHookStats) (params.HookStatsResults, error) {
+	service, err := c.api.state.Service(args.ServiceName)
+	if err != nil {
+		return params.HookStatsResults{}, err
+	}
+	stats, err := service.HookStats()
+	if err != nil {
+		return params.HookStatsResults{}, err
+	}
+	results := make([]params.HookStats, len(stats))
+	for i, s := range stats {
+		results[i] = params.HookStats{
+			Kind:   string(s.Kind),
+			Name:   s.Name,
+			Runs:   s.Runs,
+			Failed: s.Failed,
+			Min:    s.Min,
+			P50:    s.P50,
+			P90:    s.P90,
+			P99:    s.P99,
+			Max:    s.Max,
+		}
+	}
+	return params.HookStatsResults{Results: results}, nil
+}
+
+// unitTags returns the tags of the named units. Invalid names are
+// skipped, and left to be reported when the units are looked up.
+func unitTags(unitNames []string) []names.Tag {
+	var tags []names.Tag
+	for _, name := range unitNames {
+		if names.IsValidUnit(name) {
+			tags = append(tags, names.NewUnitTag(name))
+		}
+	}
+	return tags
+}
+
+// endpointServiceTags returns the tags of the services of the given
+// endpoints.
+func endpointServiceTags(eps []state.Endpoint) []names.Tag {
+	tags := make([]names.Tag, len(eps))
+	for i, ep := range eps {
+		tags[i] = names.NewServiceTag(ep.ServiceName)
+	}
+	return tags
+}
+
 // addServiceUnits adds a given number of units to a service.
 func addServiceUnits(state *state.State, args params.AddServiceUnits) ([]*state.Unit, error) {
 	service, err := state.Service(args.ServiceName)
@@ -420,12 +541,29 @@ func addServiceUnits(state *state.State, args params.AddServiceUnits) ([]*state.
 			return nil, errors.Annotatef(err, `cannot add units for service "%v" to machine %v`, args.ServiceName, args.ToMachineSpec)
 		}
 	}
-	return jjj.AddUnits(state, service, args.NumUnits, args.ToMachineSpec)
+	if len(args.AttachStorage) == 0 {
+		return jjj.AddUnits(state, service, args.NumUnits, args.ToMachineSpec)
+	}
+	if args.NumUnits > 1 {
+		return nil, fmt.Errorf("cannot attach storage to multiple units")
+	}
+	attachStorage := make([]names.StorageTag, len(args.AttachStorage))
+	for i, id := range args.AttachStorage {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		attachStorage[i] = names.NewStorageTag(id)
+	}
+	return jjj.AddUnitsWithStorage(state, service, args.NumUnits, args.ToMachineSpec, attachStorage)
 }
 
 // AddServiceUnits adds a given number of units to a service.
 func (c *Client) AddServiceUnits(args params.AddServiceUnits) (params.AddServiceUnitsResults, error) {
-	if err := c.check.ChangeAllowed(); err != nil {
+	targets := []names.Tag{names.NewServiceTag(args.ServiceName)}
+	if names.IsValidMachine(args.ToMachineSpec) {
+		targets = append(targets, names.NewMachineTag(args.ToMachineSpec))
+	}
+	if err := c.check.ChangeAllowedFor(targets...); err != nil {
 		return params.AddServiceUnitsResults{}, errors.Trace(err)
 	}
 	units, err := addServiceUnits(c.api.state, args)
@@ -441,7 +579,7 @@ func (c *Client) AddServiceUnits(args params.AddServiceUnits) (params.AddService
 
 // DestroyServiceUnits removes a given set of service units.
 func (c *Client) DestroyServiceUnits(args params.DestroyServiceUnits) error {
-	if err := c.check.RemoveAllowed(); err != nil {
+	if err := c.check.RemoveAllowedFor(unitTags(args.UnitNames)...); err != nil {
 		return errors.Trace(err)
 	}
 	var errs []string
@@ -475,6 +613,19 @@ func (c *Client) ServiceDestroy(args params.ServiceDestroy) error {
 	if err != nil {
 		return err
 	}
+	// Destroying the service destroys its units, so blocks on the
+	// units apply too.
+	units, err := svc.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tags := []names.Tag{svc.Tag()}
+	for _, unit := range units {
+		tags = append(tags, unit.Tag())
+	}
+	if err := c.check.RemoveAllowedFor(tags...); err != nil {
+		return errors.Trace(err)
+	}
 	return svc.Destroy()
 }
 
@@ -501,7 +652,7 @@ func (c *Client) GetEnvironmentConstraints() (params.GetConstraintsResults, erro
 // SetServiceConstraints sets the constraints for a given service.
 // TODO(mattyw, all): This api call should be move to the new service facade. The client api version will then need bumping.
 func (c *Client) SetServiceConstraints(args params.SetConstraints) error {
-	if err := c.check.ChangeAllowed(); err != nil {
+	if err := c.check.ChangeAllowedFor(names.NewServiceTag(args.ServiceName)); err != nil {
 		return errors.Trace(err)
 	}
 	svc, err := c.api.state.Service(args.ServiceName)
@@ -528,6 +679,9 @@ func (c *Client) AddRelation(args params.AddRelation) (params.AddRelationResults
 	if err != nil {
 		return params.AddRelationResults{}, err
 	}
+	if err := c.check.ChangeAllowedFor(endpointServiceTags(inEps)...); err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
 	rel, err := c.api.state.AddRelation(inEps...)
 	if err != nil {
 		return params.AddRelationResults{}, err
@@ -552,6 +706,9 @@ func (c *Client) DestroyRelation(args params.DestroyRelation) error {
 	if err != nil {
 		return err
 	}
+	if err := c.check.RemoveAllowedFor(endpointServiceTags(eps)...); err != nil {
+		return errors.Trace(err)
+	}
 	rel, err := c.api.state.EndpointsRelation(eps...)
 	if err != nil {
 		return err
@@ -703,7 +860,7 @@ func (c *Client) DestroyMachines(args params.DestroyMachines) error {
 			continue
 		default:
 			{
-				if err := c.check.RemoveAllowed(); err != nil {
+				if err := c.check.RemoveAllowedFor(machine.Tag()); err != nil {
 					return errors.Trace(err)
 				}
 				err = machine.Destroy()
@@ -783,7 +940,7 @@ func (c *Client) ShareEnvironment(args params.ModifyEnvironUsers) (result params
 		}
 		switch arg.Action {
 		case params.AddEnvUser:
-			_, err := c.api.state.AddEnvironmentUser(user, createdBy, "")
+			err := c.addEnvironmentUser(user, createdBy, arg.Access)
 			if err != nil {
 				err = errors.Annotate(err, "could not share environment")
 				result.Results[i].Error = common.ServerError(err)
@@ -801,6 +958,25 @@ func (c *Client) ShareEnvironment(args params.ModifyEnvironUsers) (result params
 	return result, nil
 }
 
+// addEnvironmentUser gives the user the requested level of access to the
+// environment, or write access if none was requested. If the user already
+// has access and a level was requested, their access level is changed.
+func (c *Client) addEnvironmentUser(user, createdBy names.UserTag, access params.EnvironAccess) error {
+	envAccess := state.EnvironmentWriteAccess
+	if access != "" {
+		envAccess = state.EnvironmentAccess(access)
+	}
+	_, err := c.api.state.AddEnvironmentUserWithAccess(user, createdBy, "", envAccess)
+	if !errors.IsAlreadyExists(err) || access == "" {
+		return err
+	}
+	envUser, err := c.api.state.EnvironmentUser(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return envUser.SetAccess(envAccess)
+}
+
 // EnvUserInfo returns information on all users in the environment.
 func (c *Client) EnvUserInfo() (params.EnvUserInfoResults, error) {
 	var results params.EnvUserInfoResults
@@ -821,6 +997,7 @@ func (c *Client) EnvUserInfo() (params.EnvUserInfoResults, error) {
 				CreatedBy:      user.CreatedBy(),
 				DateCreated:    user.DateCreated(),
 				LastConnection: user.LastConnection(),
+				Access:         string(user.Access()),
 			},
 		})
 	}
@@ -1009,6 +1186,34 @@ func (c *Client) RetryProvisioning(p params.Entities) (params.ErrorResults, erro
 	})
 }
 
+// WorkerReports returns the reports of the workers run by the agents
+// of the given machines, as last published by the agents.
+func (c *Client) WorkerReports(args params.Entities) (params.WorkerReportsResults, error) {
+	results := params.WorkerReportsResults{
+		Results: make([]params.WorkerReportsResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		machine, err := c.api.state.Machine(tag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		reports, updated, err := machine.WorkerReports()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Reports = common.WorkerReportsFromState(reports)
+		results.Results[i].Updated = updated
+	}
+	return results, nil
+}
+
 // APIHostPorts returns the API host/port addresses stored in state.
 func (c *Client) APIHostPorts() (result params.APIHostPortsResult, err error) {
 	var servers [][]network.HostPort
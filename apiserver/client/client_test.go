@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -143,6 +144,7 @@ func (s *serverSuite) TestEnvUsersInfo(c *gc.C) {
 					CreatedBy:      owner.UserName(),
 					DateCreated:    owner.DateCreated(),
 					LastConnection: owner.LastConnection(),
+					Access:         "admin",
 				},
 			}, {
 				Result: &params.EnvUserInfo{
@@ -151,6 +153,7 @@ func (s *serverSuite) TestEnvUsersInfo(c *gc.C) {
 					CreatedBy:      owner.UserName(),
 					DateCreated:    localUser1.DateCreated(),
 					LastConnection: localUser1.LastConnection(),
+					Access:         "admin",
 				},
 			}, {
 				Result: &params.EnvUserInfo{
@@ -159,6 +162,7 @@ func (s *serverSuite) TestEnvUsersInfo(c *gc.C) {
 					CreatedBy:      owner.UserName(),
 					DateCreated:    localUser2.DateCreated(),
 					LastConnection: localUser2.LastConnection(),
+					Access:         "admin",
 				},
 			}, {
 				Result: &params.EnvUserInfo{
@@ -167,6 +171,7 @@ func (s *serverSuite) TestEnvUsersInfo(c *gc.C) {
 					CreatedBy:      owner.UserName(),
 					DateCreated:    remoteUser1.DateCreated(),
 					LastConnection: remoteUser1.LastConnection(),
+					Access:         "admin",
 				},
 			}, {
 				Result: &params.EnvUserInfo{
@@ -175,6 +180,7 @@ func (s *serverSuite) TestEnvUsersInfo(c *gc.C) {
 					CreatedBy:      owner.UserName(),
 					DateCreated:    remoteUser2.DateCreated(),
 					LastConnection: remoteUser2.LastConnection(),
+					Access:         "admin",
 				},
 			}},
 	}
@@ -274,6 +280,57 @@ func (s *serverSuite) TestShareEnvironmentAddLocalUser(c *gc.C) {
 	c.Assert(envUser.UserName(), gc.Equals, user.UserTag().Username())
 	c.Assert(envUser.CreatedBy(), gc.Equals, dummy.AdminUserTag().Username())
 	c.Assert(envUser.LastConnection(), gc.IsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.EnvironmentWriteAccess)
+}
+
+func (s *serverSuite) TestShareEnvironmentAddUserWithAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoEnvUser: true})
+	args := params.ModifyEnvironUsers{
+		Changes: []params.ModifyEnvironUser{{
+			UserTag: user.Tag().String(),
+			Action:  params.AddEnvUser,
+			Access:  params.EnvironReadAccess,
+		}}}
+
+	result, err := s.client.ShareEnvironment(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.IsNil)
+
+	envUser, err := s.State.EnvironmentUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.EnvironmentReadAccess)
+}
+
+func (s *serverSuite) TestShareEnvironmentChangesAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
+	args := params.ModifyEnvironUsers{
+		Changes: []params.ModifyEnvironUser{{
+			UserTag: user.Tag().String(),
+			Action:  params.AddEnvUser,
+			Access:  params.EnvironReadAccess,
+		}}}
+
+	result, err := s.client.ShareEnvironment(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.IsNil)
+
+	envUser, err := s.State.EnvironmentUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.EnvironmentReadAccess)
+}
+
+func (s *serverSuite) TestShareEnvironmentInvalidAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoEnvUser: true})
+	args := params.ModifyEnvironUsers{
+		Changes: []params.ModifyEnvironUser{{
+			UserTag: user.Tag().String(),
+			Action:  params.AddEnvUser,
+			Access:  "superuser",
+		}}}
+
+	result, err := s.client.ShareEnvironment(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `could not share environment: environment access "superuser" not valid`)
 }
 
 func (s *serverSuite) TestShareEnvironmentAddRemoteUser(c *gc.C) {
@@ -675,6 +732,32 @@ func (s *serverSuite) TestBlockChangesServiceSet(c *gc.C) {
 	s.assertServiceSetBlocked(c, dummy, "TestBlockChangesServiceSet")
 }
 
+func (s *clientSuite) TestBlockChangesNewServiceSetForClientAPI(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestBlockChangesNewServiceSetForClientAPI")
+	err := s.APIState.Client().ServiceSet("dummy", map[string]string{"title": "foobar"})
+	s.AssertBlocked(c, err, "TestBlockChangesNewServiceSetForClientAPI")
+}
+
+func (s *clientSuite) TestBlockEntityChangesNewServiceSetForClientAPI(c *gc.C) {
+	dummy := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	other := s.AddTestingService(c, "other", s.AddTestingCharm(c, "dummy"))
+	s.BlockEntityChanges(c, dummy.Tag(), "TestBlockEntityChangesNewServiceSetForClientAPI")
+
+	err := s.APIState.Client().ServiceSet("dummy", map[string]string{"title": "foobar"})
+	s.AssertBlocked(c, err, "TestBlockEntityChangesNewServiceSetForClientAPI")
+	settings, err := dummy.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+
+	// Services without a block can still be configured.
+	err = s.APIState.Client().ServiceSet("other", map[string]string{"title": "foobar"})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = other.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, charm.Settings{"title": "foobar"})
+}
+
 func (s *clientSuite) TestClientServerUnset(c *gc.C) {
 	dummy := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
 
@@ -886,6 +969,24 @@ func (s *clientSuite) TestClientAddServiceUnitsToNewContainer(c *gc.C) {
 	c.Assert(mid, gc.Equals, machine.Id()+"/lxc/0")
 }
 
+func (s *clientSuite) TestClientAddServiceUnitsAttachStorageMultipleUnits(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	_, err := s.APIState.Client().AddServiceUnitsWithStorage("dummy", 2, "", []string{"data/0"})
+	c.Assert(err, gc.ErrorMatches, "cannot attach storage to multiple units")
+}
+
+func (s *clientSuite) TestClientAddServiceUnitsAttachStorageInvalidId(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	_, err := s.APIState.Client().AddServiceUnitsWithStorage("dummy", 1, "", []string{"data"})
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *clientSuite) TestClientAddServiceUnitsAttachStorageNotFound(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	_, err := s.APIState.Client().AddServiceUnitsWithStorage("dummy", 1, "", []string{"data/0"})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit 1/1 to service "dummy": .*storage instance "data/0" not found`)
+}
+
 func (s *clientSuite) assertAddServiceUnits(c *gc.C) {
 	units, err := s.APIState.Client().AddServiceUnits("dummy", 3, "")
 	c.Assert(err, jc.ErrorIsNil)
@@ -1377,6 +1478,29 @@ func (s *clientSuite) TestClientServiceDestroy(c *gc.C) {
 	c.Assert(service.Life(), gc.Not(gc.Equals), state.Alive)
 }
 
+func (s *clientSuite) TestBlockEntityRemoveServiceDestroy(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	s.AddTestingService(c, "other-service", s.AddTestingCharm(c, "dummy"))
+	s.BlockEntityRemove(c, names.NewServiceTag("dummy-service"), "TestBlockEntityRemoveServiceDestroy")
+
+	err := s.APIState.Client().ServiceDestroy("dummy-service")
+	s.AssertBlocked(c, err, "TestBlockEntityRemoveServiceDestroy")
+	// Services without a block can still be destroyed.
+	err = s.APIState.Client().ServiceDestroy("other-service")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestBlockEntityRemoveUnitServiceDestroy(c *gc.C) {
+	service := s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.BlockEntityRemove(c, unit.Tag(), "TestBlockEntityRemoveUnitServiceDestroy")
+
+	err = s.APIState.Client().ServiceDestroy("dummy-service")
+	s.AssertBlocked(c, err, "TestBlockEntityRemoveUnitServiceDestroy")
+	assertLife(c, service, state.Alive)
+}
+
 func assertLife(c *gc.C, entity state.Living, life state.Life) {
 	err := entity.Refresh()
 	c.Assert(err, jc.ErrorIsNil)
@@ -2179,6 +2303,125 @@ func (s *clientRepoSuite) TestBlockServiceSetCharmForce(c *gc.C) {
 	s.assertServiceSetCharm(c, true)
 }
 
+func (s *clientRepoSuite) TestClientServiceSetCharmRolling(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	err := service.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{URL: "cs:precise/wordpress-3"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.APIState.Client().ServiceSetCharmRolling(
+		"service", "cs:precise/wordpress-3", false, 2, true,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	service, err := s.State.Service("service")
+	c.Assert(err, jc.ErrorIsNil)
+	charm, _, err := service.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charm.URL().String(), gc.Equals, "cs:precise/wordpress-3")
+	rolling := service.RollingUpgrade()
+	c.Assert(rolling, gc.NotNil)
+	c.Assert(rolling.FromCharmURL.String(), gc.Equals, "cs:precise/dummy-0")
+	c.Assert(rolling.BatchSize, gc.Equals, 2)
+	c.Assert(rolling.Rollback, jc.IsTrue)
+	c.Assert(rolling.Status, gc.Equals, state.RollingUpgradeRunning)
+
+	err = s.APIState.Client().ServiceResumeRollingUpgrade("service")
+	c.Assert(err, gc.ErrorMatches, `cannot resume rolling upgrade of service "service": rolling upgrade is not paused`)
+	err = service.PauseRollingUpgrade("paused for testing")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.APIState.Client().ServiceResumeRollingUpgrade("service")
+	c.Assert(err, jc.ErrorIsNil)
+	err = service.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.RollingUpgrade().Status, gc.Equals, state.RollingUpgradeRunning)
+}
+
+func (s *clientRepoSuite) TestBlockChangesServiceSetCharmRolling(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	s.BlockAllChanges(c, "TestBlockChangesServiceSetCharmRolling")
+	err := s.APIState.Client().ServiceSetCharmRolling(
+		"service", "cs:precise/wordpress-3", false, 1, false,
+	)
+	s.AssertBlocked(c, err, "TestBlockChangesServiceSetCharmRolling")
+}
+
+func (s *clientRepoSuite) TestClientServiceRollbackCharm(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	err := s.APIState.Client().ServiceRollbackCharm("service", false)
+	c.Assert(err, gc.ErrorMatches, `cannot roll back charm of service "service": service has no previous charm`)
+
+	s.assertServiceSetCharm(c, false)
+	err = s.APIState.Client().ServiceRollbackCharm("service", true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	service, err := s.State.Service("service")
+	c.Assert(err, jc.ErrorIsNil)
+	charm, force, err := service.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charm.URL().String(), gc.Equals, "cs:precise/dummy-0")
+	c.Assert(force, jc.IsTrue)
+}
+
+func (s *clientRepoSuite) TestBlockChangesServiceRollbackCharm(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	s.assertServiceSetCharm(c, false)
+	s.BlockAllChanges(c, "TestBlockChangesServiceRollbackCharm")
+	err := s.APIState.Client().ServiceRollbackCharm("service", false)
+	s.AssertBlocked(c, err, "TestBlockChangesServiceRollbackCharm")
+}
+
+func (s *clientSuite) TestClientServiceSetHookTimeout(c *gc.C) {
+	service := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err := s.APIState.Client().ServiceSetHookTimeout("dummy", 20*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = service.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.HookTimeout(), gc.Equals, 20*time.Minute)
+
+	err = s.APIState.Client().ServiceSetHookTimeout("dummy", -time.Minute)
+	c.Assert(err, gc.ErrorMatches, "negative hook timeout not valid")
+	err = s.APIState.Client().ServiceSetHookTimeout("unknown", time.Minute)
+	c.Assert(err, gc.ErrorMatches, `service "unknown" not found`)
+}
+
+func (s *clientSuite) TestBlockChangesServiceSetHookTimeout(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestBlockChangesServiceSetHookTimeout")
+	err := s.APIState.Client().ServiceSetHookTimeout("dummy", time.Minute)
+	s.AssertBlocked(c, err, "TestBlockChangesServiceSetHookTimeout")
+}
+
+func (s *clientSuite) TestClientServiceHookStats(c *gc.C) {
+	service := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	started := time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC)
+	err = unit.AddHookRuns([]state.HookRun{{
+		Kind:     state.HookRunHook,
+		Name:     "install",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		Status:   state.HookRunFailed,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	stats, err := s.APIState.Client().ServiceHookStats("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stats, jc.DeepEquals, []params.HookStats{{
+		Kind:   "hook",
+		Name:   "install",
+		Runs:   1,
+		Failed: 1,
+		Min:    time.Minute,
+		P50:    time.Minute,
+		P90:    time.Minute,
+		P99:    time.Minute,
+		Max:    time.Minute,
+	}})
+
+	_, err = s.APIState.Client().ServiceHookStats("unknown")
+	c.Assert(err, gc.ErrorMatches, `service "unknown" not found`)
+}
+
 func (s *clientSuite) TestClientServiceSetCharmInvalidService(c *gc.C) {
 	err := s.APIState.Client().ServiceSetCharm(
 		"badservice", "cs:precise/wordpress-3", true,
@@ -3319,6 +3562,41 @@ func (s *clientSuite) TestRetryProvisioning(c *gc.C) {
 	c.Assert(statusInfo.Data["transient"], jc.IsTrue)
 }
 
+func (s *clientSuite) TestWorkerReports(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetWorkerReports([]state.WorkerReport{{
+		Name:       "machiner",
+		State:      "started",
+		StartCount: 2,
+		LastError:  "boom",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.APIState.Client().WorkerReports(machine.Tag().(names.MachineTag))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Reports, jc.DeepEquals, []params.WorkerReport{{
+		Name:       "machiner",
+		State:      "started",
+		StartCount: 2,
+		LastError:  "boom",
+	}})
+	c.Assert(result.Updated.IsZero(), jc.IsFalse)
+}
+
+func (s *clientSuite) TestWorkerReportsNotPublished(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.APIState.Client().WorkerReports(machine.Tag().(names.MachineTag))
+	c.Assert(err, gc.ErrorMatches, "worker reports for machine 0 not found")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *clientSuite) TestWorkerReportsMachineNotFound(c *gc.C) {
+	_, err := s.APIState.Client().WorkerReports(names.NewMachineTag("42"))
+	c.Assert(err, gc.ErrorMatches, "machine 42 not found")
+}
+
 func (s *clientSuite) setupRetryProvisioning(c *gc.C) *state.Machine {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
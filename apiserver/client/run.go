@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"github.com/juju/utils"
 	"github.com/juju/utils/set"
 
@@ -91,6 +92,18 @@ func (c *Client) Run(run params.RunParams) (results params.RunResults, err error
 	if err != nil {
 		return results, err
 	}
+	var targets []names.Tag
+	for _, unit := range units {
+		targets = append(targets, unit.UnitTag())
+	}
+	for _, machineId := range run.Machines {
+		if names.IsValidMachine(machineId) {
+			targets = append(targets, names.NewMachineTag(machineId))
+		}
+	}
+	if err := c.check.ChangeAllowedFor(targets...); err != nil {
+		return params.RunResults{}, errors.Trace(err)
+	}
 	// We want to create a RemoteExec for each unit and each machine.
 	// If we have both a unit and a machine request, we run it twice,
 	// once for the unit inside the exec context using juju-run, and
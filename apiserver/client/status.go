@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v5"
 	"gopkg.in/juju/charm.v5/hooks"
@@ -106,6 +107,71 @@ func (c *Client) UnitStatusHistory(args params.StatusHistory) (api.UnitStatusHis
 	return statuses, nil
 }
 
+// StatusHistory returns the past statuses of the units and machines
+// selected by the given filter, most recent first.
+func (c *Client) StatusHistory(args params.StatusHistoryFilter) (params.StatusHistoryResults, error) {
+	var noResults params.StatusHistoryResults
+	if args.Offset < 0 || args.Limit < 0 {
+		return noResults, errors.NotValidf("negative offset or limit")
+	}
+	filter := state.StatusHistoryFilter{
+		Offset: args.Offset,
+		Limit:  args.Limit,
+	}
+	switch args.Kind {
+	case "", params.KindCombined:
+	case params.KindAgent:
+		filter.Kind = state.StatusHistoryAgent
+	case params.KindWorkload:
+		filter.Kind = state.StatusHistoryWorkload
+	case params.KindMachine:
+		filter.Kind = state.StatusHistoryMachine
+	default:
+		return noResults, errors.NotValidf("status history kind %q", args.Kind)
+	}
+	for _, entity := range args.Entities {
+		tag, err := names.ParseTag(entity)
+		if err != nil {
+			return noResults, errors.Trace(err)
+		}
+		filter.Entities = append(filter.Entities, tag)
+	}
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+	if args.From != nil && args.To != nil && args.To.Before(*args.From) {
+		return noResults, errors.NotValidf("time range ending before it starts")
+	}
+	entries, err := c.api.state.StatusHistoryEntries(filter)
+	if err != nil {
+		return noResults, errors.Trace(err)
+	}
+	results := params.StatusHistoryResults{
+		Entries: make([]params.StatusHistoryEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		kind := params.KindMachine
+		switch entry.Kind {
+		case state.StatusHistoryAgent:
+			kind = params.KindAgent
+		case state.StatusHistoryWorkload:
+			kind = params.KindWorkload
+		}
+		results.Entries[i] = params.StatusHistoryEntry{
+			Entity: entry.Entity.String(),
+			Kind:   kind,
+			Status: params.Status(entry.Status),
+			Info:   entry.Message,
+			Data:   entry.Data,
+			Since:  entry.Since,
+		}
+	}
+	return results, nil
+}
+
 // FullStatus gives the information needed for juju status over the api
 func (c *Client) FullStatus(args params.StatusParams) (api.Status, error) {
 	cfg, err := c.api.state.EnvironConfig()
@@ -123,6 +189,8 @@ func (c *Client) FullStatus(args params.StatusParams) (api.Status, error) {
 		return noStatus, errors.Annotate(err, "could not fetch relations")
 	} else if context.networks, err = fetchNetworks(c.api.state); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch networks")
+	} else if context.blocks, err = fetchEntityBlocks(c.api.state); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch blocks")
 	}
 
 	logger.Debugf("Services: %v", context.services)
@@ -204,6 +272,7 @@ func (c *Client) FullStatus(args params.StatusParams) (api.Status, error) {
 		Services:        context.processServices(),
 		Networks:        context.processNetworks(),
 		Relations:       context.processRelations(),
+		Blocks:          context.blocks,
 	}, nil
 }
 
@@ -234,6 +303,8 @@ type statusContext struct {
 	units        map[string]map[string]*state.Unit
 	networks     map[string]*state.Network
 	latestCharms map[charm.URL]string
+	// blocks: entity tag -> types of the blocks on that entity.
+	blocks map[string][]string
 }
 
 // fetchMachines returns a map from top level machine id to machines, where machines[0] is the host
@@ -370,6 +441,30 @@ func fetchNetworks(st *state.State) (map[string]*state.Network, error) {
 	return out, nil
 }
 
+// fetchEntityBlocks returns a map from the tag of each blocked service,
+// unit or machine to the types of the blocks on it.
+func fetchEntityBlocks(st *state.State) (map[string][]string, error) {
+	blocks, err := st.AllBlocks()
+	if err != nil {
+		return nil, err
+	}
+	var out map[string][]string
+	for _, b := range blocks {
+		tag, err := b.Tag()
+		if err != nil {
+			return nil, err
+		}
+		if tag.Kind() == names.EnvironTagKind {
+			continue
+		}
+		if out == nil {
+			out = make(map[string][]string)
+		}
+		out[tag.String()] = append(out[tag.String()], b.Type().String())
+	}
+	return out, nil
+}
+
 type machineAndContainers map[string][]*state.Machine
 
 func (m machineAndContainers) HostForMachineId(id string) *state.Machine {
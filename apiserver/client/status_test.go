@@ -4,10 +4,13 @@
 package client_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/client"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing/factory"
@@ -61,6 +64,69 @@ func (s *statusSuite) TestLegacyStatus(c *gc.C) {
 	c.Check(resultMachine.InstanceId, gc.Equals, instanceId)
 }
 
+func (s *statusSuite) TestStatusHistory(c *gc.C) {
+	machine := s.addMachine(c)
+	err := machine.SetStatus(state.StatusStarted, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetStatus(state.StatusError, "boom", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	entries, err := client.StatusHistory(params.StatusHistoryFilter{
+		Entities: []string{machine.Tag().String()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 2)
+	c.Check(entries[0].Entity, gc.Equals, machine.Tag().String())
+	c.Check(entries[0].Kind, gc.Equals, params.KindMachine)
+	c.Check(entries[0].Status, gc.Equals, params.StatusStarted)
+	c.Check(entries[1].Status, gc.Equals, params.StatusPending)
+
+	entries, err = client.StatusHistory(params.StatusHistoryFilter{
+		Entities: []string{machine.Tag().String()},
+		Kind:     params.KindWorkload,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+
+	entries, err = client.StatusHistory(params.StatusHistoryFilter{
+		Offset: 1,
+		Limit:  1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Status, gc.Equals, params.StatusPending)
+}
+
+func (s *statusSuite) TestStatusHistoryInvalidFilter(c *gc.C) {
+	now := time.Now()
+	before := now.Add(-time.Hour)
+	client := s.APIState.Client()
+	for i, test := range []struct {
+		filter params.StatusHistoryFilter
+		err    string
+	}{{
+		filter: params.StatusHistoryFilter{Entities: []string{"mysql"}},
+		err:    `"mysql" is not a valid tag`,
+	}, {
+		filter: params.StatusHistoryFilter{Kind: "bogus"},
+		err:    `status history kind "bogus" not valid`,
+	}, {
+		filter: params.StatusHistoryFilter{Limit: -1},
+		err:    `negative offset or limit not valid`,
+	}, {
+		filter: params.StatusHistoryFilter{From: &now, To: &before},
+		err:    `time range ending before it starts not valid`,
+	}, {
+		filter: params.StatusHistoryFilter{Entities: []string{"user-bob"}},
+		err:    `status history of "user-bob" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := client.StatusHistory(test.filter)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 var _ = gc.Suite(&statusUnitTestSuite{})
 
 type statusUnitTestSuite struct {
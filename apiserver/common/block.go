@@ -5,6 +5,7 @@ package common
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names"
 
 	"github.com/juju/juju/state"
 )
@@ -67,3 +68,67 @@ func (c *BlockChecker) checkBlock(blockType state.BlockType) error {
 	}
 	return nil
 }
+
+// EntityBlockGetter returns blocks on individual entities as well as
+// environment-wide blocks.
+type EntityBlockGetter interface {
+	BlockGetter
+	GetBlockForEntity(t state.BlockType, tag names.Tag) (state.Block, bool, error)
+}
+
+// EntityBlockChecker checks for current environment-wide blocks, and
+// for blocks on individual services, units and machines.
+type EntityBlockChecker struct {
+	BlockChecker
+	getter EntityBlockGetter
+}
+
+func NewEntityBlockChecker(s EntityBlockGetter) *EntityBlockChecker {
+	return &EntityBlockChecker{BlockChecker{s}, s}
+}
+
+// ChangeAllowedFor checks if change block is in place for the
+// environment or for any of the given entities.
+func (c *EntityBlockChecker) ChangeAllowedFor(tags ...names.Tag) error {
+	if err := c.ChangeAllowed(); err != nil {
+		return err
+	}
+	return c.checkEntityBlocks(tags, state.ChangeBlock)
+}
+
+// RemoveAllowedFor checks if remove or change block is in place
+// for the environment or for any of the given entities.
+func (c *EntityBlockChecker) RemoveAllowedFor(tags ...names.Tag) error {
+	if err := c.RemoveAllowed(); err != nil {
+		return err
+	}
+	return c.checkEntityBlocks(tags, state.RemoveBlock, state.ChangeBlock)
+}
+
+// checkEntityBlocks checks if a block of any of the given types is in
+// place for any of the given entities. A block on a service also
+// applies to its units.
+func (c *EntityBlockChecker) checkEntityBlocks(tags []names.Tag, blockTypes ...state.BlockType) error {
+	for _, tag := range tags {
+		targets := []names.Tag{tag}
+		if unitTag, ok := tag.(names.UnitTag); ok {
+			serviceName, err := names.UnitService(unitTag.Id())
+			if err != nil {
+				return errors.Trace(err)
+			}
+			targets = append(targets, names.NewServiceTag(serviceName))
+		}
+		for _, target := range targets {
+			for _, blockType := range blockTypes {
+				aBlock, isEnabled, err := c.getter.GetBlockForEntity(blockType, target)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				if isEnabled {
+					return ErrOperationBlocked(aBlock.Message())
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -95,3 +95,68 @@ func (s *blockCheckerSuite) assertErrorBlocked(c *gc.C, blocked bool, err error,
 		c.Assert(errors.Cause(err), jc.ErrorIsNil)
 	}
 }
+
+type entityBlockCheckerSuite struct {
+	testing.BaseSuite
+	envBlock     state.Block
+	entityBlocks map[string]state.Block
+
+	blockchecker *common.EntityBlockChecker
+}
+
+var _ = gc.Suite(&entityBlockCheckerSuite{})
+
+func (s *entityBlockCheckerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.envBlock = nil
+	s.entityBlocks = make(map[string]state.Block)
+	s.blockchecker = common.NewEntityBlockChecker(s)
+}
+
+func (mock *entityBlockCheckerSuite) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	if mock.envBlock != nil && mock.envBlock.Type() == t {
+		return mock.envBlock, true, nil
+	}
+	return nil, false, nil
+}
+
+func (mock *entityBlockCheckerSuite) GetBlockForEntity(t state.BlockType, tag names.Tag) (state.Block, bool, error) {
+	if b, ok := mock.entityBlocks[tag.String()]; ok && b.Type() == t {
+		return b, true, nil
+	}
+	return nil, false, nil
+}
+
+func (s *entityBlockCheckerSuite) TestEnvironmentBlock(c *gc.C) {
+	s.envBlock = mockBlock{t: state.ChangeBlock, m: "env change"}
+	err := s.blockchecker.ChangeAllowedFor(names.NewServiceTag("mysql"))
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "env change")
+}
+
+func (s *entityBlockCheckerSuite) TestServiceBlock(c *gc.C) {
+	s.entityBlocks["service-mysql"] = mockBlock{t: state.RemoveBlock, m: "protect mysql"}
+
+	err := s.blockchecker.RemoveAllowedFor(names.NewServiceTag("mysql"))
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "protect mysql")
+
+	// The service block applies to its units.
+	err = s.blockchecker.RemoveAllowedFor(names.NewUnitTag("wordpress/0"), names.NewUnitTag("mysql/1"))
+	c.Assert(err, gc.ErrorMatches, "protect mysql")
+
+	// Other entities, and changes, are not blocked.
+	err = s.blockchecker.RemoveAllowedFor(names.NewServiceTag("wordpress"), names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.blockchecker.ChangeAllowedFor(names.NewServiceTag("mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *entityBlockCheckerSuite) TestChangeBlockPreventsRemoval(c *gc.C) {
+	s.entityBlocks["machine-0"] = mockBlock{t: state.ChangeBlock, m: "protect machine 0"}
+
+	err := s.blockchecker.ChangeAllowedFor(names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "protect machine 0")
+	err = s.blockchecker.RemoveAllowedFor(names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "protect machine 0")
+}
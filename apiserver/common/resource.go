@@ -7,8 +7,28 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/utils/prometheus"
+)
+
+var activeWatchers = prometheus.NewGaugeVec(
+	"juju_apiserver_watchers",
+	"Number of watchers held for API connections.",
 )
 
+func init() {
+	prometheus.MustRegister(activeWatchers)
+}
+
+// trackWatcher updates the count of active watchers by delta if the
+// given resource is a watcher.
+func trackWatcher(r Resource, delta float64) {
+	if _, ok := r.(state.Watcher); ok {
+		activeWatchers.Add(delta)
+	}
+}
+
 // Resource represents any resource that should be cleaned up when an
 // API connection terminates. The Stop method will be called when
 // that happens.
@@ -52,6 +72,7 @@ func (rs *Resources) Register(r Resource) string {
 	id := strconv.FormatUint(rs.maxId, 10)
 	rs.resources[id] = r
 	rs.stack = append(rs.stack, id)
+	trackWatcher(r, 1)
 	logger.Tracef("registered unnamed resource: %s", id)
 	return id
 }
@@ -74,6 +95,7 @@ func (rs *Resources) RegisterNamed(name string, r Resource) error {
 	}
 	rs.resources[name] = r
 	rs.stack = append(rs.stack, name)
+	trackWatcher(r, 1)
 	logger.Tracef("registered named resource: %s", name)
 	return nil
 }
@@ -97,6 +119,11 @@ func (rs *Resources) Stop(id string) error {
 	err := r.Stop()
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
+	if _, ok := rs.resources[id]; !ok {
+		// Stopped concurrently.
+		return err
+	}
+	trackWatcher(r, -1)
 	delete(rs.resources, id)
 	for pos := 0; pos < len(rs.stack); pos++ {
 		if rs.stack[pos] == id {
@@ -118,6 +145,7 @@ func (rs *Resources) StopAll() {
 		if err := r.Stop(); err != nil {
 			logger.Errorf("error stopping %T resource: %v", r, err)
 		}
+		trackWatcher(r, -1)
 	}
 	rs.resources = make(map[string]Resource)
 	rs.stack = nil
@@ -47,6 +47,12 @@ type StorageInterface interface {
 	// WatchVolumeAttachment watches for changes to the volume attachment
 	// corresponding to the identfified machien and volume.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+
+	// WatchFilesystem watches for changes to the specified filesystem.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
+	// WatchVolume watches for changes to the specified volume.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
 }
 
 // StorageAttachmentInfo returns the StorageAttachmentInfo for the specified
@@ -102,6 +108,7 @@ func volumeStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindBlock,
 		devicePath,
+		volumeInfo.Size,
 	}, nil
 }
 
@@ -115,6 +122,10 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem")
 	}
+	filesystemInfo, err := filesystem.Info()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem info")
+	}
 	filesystemAttachment, err := st.FilesystemAttachment(machineTag, filesystem.FilesystemTag())
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment")
@@ -126,12 +137,13 @@ func filesystemStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindFilesystem,
 		filesystemAttachmentInfo.MountPoint,
+		filesystemInfo.Size,
 	}, nil
 }
 
 // WatchStorageAttachment returns a state.NotifyWatcher that reacts to changes
 // to the VolumeAttachmentInfo or FilesystemAttachmentInfo corresponding to the tags
-// specified.
+// specified, or to the size of the underlying volume or filesystem.
 func WatchStorageAttachment(
 	st StorageInterface,
 	storageTag names.StorageTag,
@@ -142,7 +154,7 @@ func WatchStorageAttachment(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting storage instance")
 	}
-	var w state.NotifyWatcher
+	var w, w2 state.NotifyWatcher
 	switch storageInstance.Kind() {
 	case state.StorageKindBlock:
 		volume, err := st.StorageInstanceVolume(storageTag)
@@ -150,17 +162,19 @@ func WatchStorageAttachment(
 			return nil, errors.Annotate(err, "getting storage volume")
 		}
 		w = st.WatchVolumeAttachment(machineTag, volume.VolumeTag())
+		w2 = st.WatchVolume(volume.VolumeTag())
 	case state.StorageKindFilesystem:
 		filesystem, err := st.StorageInstanceFilesystem(storageTag)
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage filesystem")
 		}
 		w = st.WatchFilesystemAttachment(machineTag, filesystem.FilesystemTag())
+		w2 = st.WatchFilesystem(filesystem.FilesystemTag())
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
 	}
-	w2 := st.WatchStorageAttachment(storageTag, unitTag)
-	return newMultiNotifyWatcher(w, w2), nil
+	w3 := st.WatchStorageAttachment(storageTag, unitTag)
+	return newMultiNotifyWatcher(w, w2, w3), nil
 }
 
 var errNoDevicePath = errors.New("cannot determine device path: no serial or persistent device name")
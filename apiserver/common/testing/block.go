@@ -6,6 +6,7 @@ package testing
 import (
 	"fmt"
 
+	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -53,6 +54,28 @@ func (s BlockHelper) BlockRemoveObject(c *gc.C, msg string) {
 	s.on(c, multiwatcher.BlockRemove, msg)
 }
 
+// BlockEntityRemove blocks all operations that remove the given
+// service, unit or machine.
+func (s BlockHelper) BlockEntityRemove(c *gc.C, entity names.Tag, msg string) {
+	c.Assert(
+		s.client.SwitchEntityBlockOn(
+			fmt.Sprintf("%v", multiwatcher.BlockRemove),
+			entity,
+			msg),
+		gc.IsNil)
+}
+
+// BlockEntityChanges blocks all operations that change the given
+// service, unit or machine.
+func (s BlockHelper) BlockEntityChanges(c *gc.C, entity names.Tag, msg string) {
+	c.Assert(
+		s.client.SwitchEntityBlockOn(
+			fmt.Sprintf("%v", multiwatcher.BlockChange),
+			entity,
+			msg),
+		gc.IsNil)
+}
+
 func (s BlockHelper) Close() {
 	s.client.Close()
 	s.ApiState.Close()
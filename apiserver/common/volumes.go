@@ -179,3 +179,19 @@ func ParseVolumeAttachmentIds(stringIds []string) ([]params.MachineStorageId, er
 	}
 	return ids, nil
 }
+
+// VolumeSnapshotFromState converts a state.VolumeSnapshot to
+// params.VolumeSnapshot.
+func VolumeSnapshotFromState(s state.VolumeSnapshot) params.VolumeSnapshot {
+	result := params.VolumeSnapshot{
+		Id:        s.Id(),
+		VolumeTag: s.Volume().String(),
+		Life:      params.Life(s.Life().String()),
+	}
+	if info, err := s.Info(); err == nil {
+		result.SnapshotId = info.SnapshotId
+		result.Size = info.Size
+		result.Created = info.Created
+	}
+	return result
+}
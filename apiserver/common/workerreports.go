@@ -0,0 +1,43 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// StateWorkerReports translates worker reports received over the API
+// to state.WorkerReports.
+func StateWorkerReports(in []params.WorkerReport) []state.WorkerReport {
+	var out []state.WorkerReport
+	for _, r := range in {
+		out = append(out, state.WorkerReport{
+			Name:       r.Name,
+			Inputs:     r.Inputs,
+			State:      r.State,
+			StartCount: r.StartCount,
+			LastError:  r.LastError,
+			Workers:    StateWorkerReports(r.Workers),
+		})
+	}
+	return out
+}
+
+// WorkerReportsFromState translates state.WorkerReports to worker
+// reports suitable for sending over the API.
+func WorkerReportsFromState(in []state.WorkerReport) []params.WorkerReport {
+	var out []params.WorkerReport
+	for _, r := range in {
+		out = append(out, params.WorkerReport{
+			Name:       r.Name,
+			Inputs:     r.Inputs,
+			State:      r.State,
+			StartCount: r.StartCount,
+			LastError:  r.LastError,
+			Workers:    WorkerReportsFromState(r.Workers),
+		})
+	}
+	return out
+}
@@ -14,7 +14,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
 	"github.com/juju/utils/tailer"
@@ -48,6 +50,8 @@ var maxLinesReached = fmt.Errorf("max lines reached")
 //      - has no meaning if 'replay' is true
 //   level -> string one of [TRACE, DEBUG, INFO, WARNING, ERROR]
 //   replay -> string - one of [true, false], if true, start the file from the start
+// The startTime, endTime and format arguments are only supported when
+// the log is served from the database; see debugLogDBHandler.
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	server := websocket.Server{
 		Handler: func(socket *websocket.Conn) {
@@ -114,58 +118,122 @@ func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	server.ServeHTTP(w, req)
 }
 
-func newLogStream(queryMap url.Values) (*logStream, error) {
-	maxLines := uint(0)
+// debugLogParams holds the arguments of a request to watch the
+// debug log.
+type debugLogParams struct {
+	maxLines      uint
+	fromTheStart  bool
+	backlog       uint
+	filterLevel   loggo.Level
+	includeEntity []string
+	includeModule []string
+	excludeEntity []string
+	excludeModule []string
+	startTime     time.Time
+	endTime       time.Time
+	jsonFormat    bool
+}
+
+// readDebugLogParams returns the debug log arguments held in the
+// given request query values.
+func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
+	args := &debugLogParams{
+		filterLevel:   loggo.UNSPECIFIED,
+		includeEntity: queryMap["includeEntity"],
+		includeModule: queryMap["includeModule"],
+		excludeEntity: queryMap["excludeEntity"],
+		excludeModule: queryMap["excludeModule"],
+	}
+
 	if value := queryMap.Get("maxLines"); value != "" {
 		num, err := strconv.ParseUint(value, 10, 64)
 		if err != nil {
 			return nil, fmt.Errorf("maxLines value %q is not a valid unsigned number", value)
 		}
-		maxLines = uint(num)
+		args.maxLines = uint(num)
 	}
 
-	fromTheStart := false
 	if value := queryMap.Get("replay"); value != "" {
 		replay, err := strconv.ParseBool(value)
 		if err != nil {
 			return nil, fmt.Errorf("replay value %q is not a valid boolean", value)
 		}
-		fromTheStart = replay
+		args.fromTheStart = replay
 	}
 
-	backlog := uint(0)
 	if value := queryMap.Get("backlog"); value != "" {
 		num, err := strconv.ParseUint(value, 10, 64)
 		if err != nil {
 			return nil, fmt.Errorf("backlog value %q is not a valid unsigned number", value)
 		}
-		backlog = uint(num)
+		args.backlog = uint(num)
 	}
 
-	level := loggo.UNSPECIFIED
 	if value := queryMap.Get("level"); value != "" {
-		var ok bool
-		level, ok = loggo.ParseLevel(value)
+		level, ok := loggo.ParseLevel(value)
 		if !ok || level < loggo.TRACE || level > loggo.ERROR {
 			return nil, fmt.Errorf("level value %q is not one of %q, %q, %q, %q, %q",
 				value, loggo.TRACE, loggo.DEBUG, loggo.INFO, loggo.WARNING, loggo.ERROR)
 		}
+		args.filterLevel = level
+	}
+
+	if value := queryMap.Get("startTime"); value != "" {
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("startTime value %q is not a valid RFC3339 time", value)
+		}
+		args.startTime = t
+	}
+
+	if value := queryMap.Get("endTime"); value != "" {
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("endTime value %q is not a valid RFC3339 time", value)
+		}
+		args.endTime = t
+	}
+
+	switch value := queryMap.Get("format"); value {
+	case "", "text":
+	case "json":
+		args.jsonFormat = true
+	default:
+		return nil, fmt.Errorf("format value %q is not one of %q, %q", value, "text", "json")
+	}
+
+	return args, nil
+}
+
+func newLogStream(queryMap url.Values) (*logStream, error) {
+	args, err := readDebugLogParams(queryMap)
+	if err != nil {
+		return nil, err
+	}
+	if !args.startTime.IsZero() || !args.endTime.IsZero() || args.jsonFormat {
+		return nil, errors.New("time ranges and JSON records require logs to be stored in the database")
 	}
 
 	return &logStream{
-		includeEntity: queryMap["includeEntity"],
-		includeModule: queryMap["includeModule"],
-		excludeEntity: queryMap["excludeEntity"],
-		excludeModule: queryMap["excludeModule"],
-		maxLines:      maxLines,
-		fromTheStart:  fromTheStart,
-		backlog:       backlog,
-		filterLevel:   level,
+		includeEntity: args.includeEntity,
+		includeModule: args.includeModule,
+		excludeEntity: args.excludeEntity,
+		excludeModule: args.excludeModule,
+		maxLines:      args.maxLines,
+		fromTheStart:  args.fromTheStart,
+		backlog:       args.backlog,
+		filterLevel:   args.filterLevel,
 	}, nil
 }
 
 // sendError sends a JSON-encoded error response.
 func (h *debugLogHandler) sendError(w io.Writer, err error) error {
+	return sendDebugLogError(w, err)
+}
+
+// sendDebugLogError sends a JSON-encoded error response to a
+// debug log request.
+func sendDebugLogError(w io.Writer, err error) error {
 	response := &params.ErrorResult{}
 	if err != nil {
 		response.Error = &params.Error{Message: fmt.Sprint(err)}
@@ -0,0 +1,155 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"golang.org/x/net/websocket"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// debugLogDBHandler takes requests to watch the debug log, and serves
+// them from the logs collection in the database rather than from the
+// consolidated log file. It is used when the db-log feature is on.
+type debugLogDBHandler struct {
+	httpHandler
+}
+
+// ServeHTTP will serve up connections as a websocket. It accepts the
+// same arguments as debugLogHandler, and also:
+//   startTime -> string - RFC3339 time, only show lines logged at or after it
+//      - backlog has no meaning if startTime is set
+//   endTime -> string - RFC3339 time, only show lines logged at or before it
+//      - if set, the connection is closed once the matching lines are sent
+//   format -> string - one of [text, json], if json, each line sent is
+//      a JSON-encoded params.LogRecord
+func (h *debugLogDBHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	server := websocket.Server{
+		Handler: func(socket *websocket.Conn) {
+			defer socket.Close()
+			logger.Infof("debug log handler starting")
+			// Validate before authenticate because the authentication is
+			// dependent on the state connection that is determined during the
+			// validation.
+			stateWrapper, err := h.validateEnvironUUID(req)
+			if err != nil {
+				sendDebugLogError(socket, err)
+				return
+			}
+			defer stateWrapper.cleanup()
+			if err := stateWrapper.authenticateUser(req); err != nil {
+				sendDebugLogError(socket, fmt.Errorf("auth failed: %v", err))
+				return
+			}
+			args, err := readDebugLogParams(req.URL.Query())
+			if err != nil {
+				sendDebugLogError(socket, err)
+				return
+			}
+			tailer := state.NewLogTailer(stateWrapper.state, args.tailerParams())
+			defer tailer.Stop()
+
+			// If we get to here, no more errors to report, so we report a nil
+			// error.  This way the first line of the socket is always a json
+			// formatted simple error.
+			if err := sendDebugLogError(socket, nil); err != nil {
+				logger.Errorf("could not send good log stream start")
+				return
+			}
+
+			// The client sends nothing, so reading only returns when
+			// the client closes the connection.
+			clientGone := make(chan struct{})
+			go func() {
+				defer close(clientGone)
+				io.Copy(ioutil.Discard, socket)
+			}()
+			if err := streamLogRecords(socket, tailer, args, clientGone); err != nil {
+				logger.Errorf("debug-log handler error: %v", err)
+			}
+		}}
+	server.ServeHTTP(w, req)
+}
+
+// tailerParams returns the parameters for a state.LogTailer which
+// returns the log records selected by the debug log arguments.
+func (args *debugLogParams) tailerParams() *state.LogTailerParams {
+	tailerArgs := &state.LogTailerParams{
+		StartTime:     args.startTime,
+		EndTime:       args.endTime,
+		MinLevel:      args.filterLevel,
+		Replay:        args.fromTheStart,
+		IncludeEntity: args.includeEntity,
+		ExcludeEntity: args.excludeEntity,
+		IncludeModule: args.includeModule,
+		ExcludeModule: args.excludeModule,
+	}
+	if !args.fromTheStart && args.startTime.IsZero() {
+		tailerArgs.InitialLines = int(args.backlog)
+	}
+	return tailerArgs
+}
+
+// streamLogRecords writes the records returned by the tailer until
+// maxLines records have been written, the tailer stops, or the client
+// goes away.
+func streamLogRecords(w io.Writer, tailer state.LogTailer, args *debugLogParams, clientGone <-chan struct{}) error {
+	var count uint
+	for {
+		select {
+		case <-clientGone:
+			return nil
+		case rec, ok := <-tailer.Logs():
+			if !ok {
+				return errors.Annotate(tailer.Err(), "tailer stopped")
+			}
+			if err := writeLogRecord(w, rec, args.jsonFormat); err != nil {
+				return errors.Trace(err)
+			}
+			count++
+			if args.maxLines > 0 && count >= args.maxLines {
+				return nil
+			}
+		}
+	}
+}
+
+// writeLogRecord writes a single log record, either as a JSON-encoded
+// params.LogRecord or as a line in the format of the consolidated
+// log file.
+func writeLogRecord(w io.Writer, rec *state.LogRecord, asJSON bool) error {
+	if asJSON {
+		data, err := json.Marshal(params.LogRecord{
+			Time:     rec.Time,
+			Entity:   rec.Entity,
+			Module:   rec.Module,
+			Location: rec.Location,
+			Level:    rec.Level.String(),
+			Message:  rec.Message,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s: %s %s %s %s %s\n",
+		rec.Entity,
+		rec.Time.In(time.UTC).Format("2006-01-02 15:04:05"),
+		rec.Level,
+		rec.Module,
+		rec.Location,
+		rec.Message,
+	)
+	return err
+}
@@ -0,0 +1,134 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/state"
+)
+
+type debugLogDBSuite struct {
+	userAuthHttpSuite
+	now time.Time
+}
+
+var _ = gc.Suite(&debugLogDBSuite{})
+
+func (s *debugLogDBSuite) SetUpTest(c *gc.C) {
+	s.SetInitialFeatureFlags(feature.DbLog)
+	s.userAuthHttpSuite.SetUpTest(c)
+	// MongoDB only stores timestamps with ms precision.
+	s.now = time.Date(2015, 6, 1, 10, 0, 0, 0, time.UTC)
+	s.log(c, names.NewMachineTag("0"), s.now, "juju.worker", loggo.INFO, "machine message")
+	s.log(c, names.NewUnitTag("mysql/0"), s.now.Add(time.Minute), "juju.worker.uniter", loggo.DEBUG, "mysql debug")
+	s.log(c, names.NewUnitTag("mysql/0"), s.now.Add(2*time.Minute), "juju.worker.uniter", loggo.ERROR, "mysql error")
+}
+
+func (s *debugLogDBSuite) log(c *gc.C, tag names.Tag, t time.Time, module string, level loggo.Level, msg string) {
+	logger := state.NewDbLogger(s.State, tag)
+	defer logger.Close()
+	err := logger.Log(t, module, "file.go:42", level, msg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *debugLogDBSuite) openWebsocket(c *gc.C, values url.Values) *bufio.Reader {
+	server := s.makeURL(c, "wss", "/environment/"+s.State.EnvironUUID()+"/log", values)
+	header := utils.BasicAuthHeader(s.userTag.String(), s.password)
+	conn := s.dialWebsocketFromURL(c, server.String(), header)
+	s.AddCleanup(func(_ *gc.C) { conn.Close() })
+	return bufio.NewReader(conn)
+}
+
+func (s *debugLogDBSuite) readLines(c *gc.C, reader *bufio.Reader, count int) []string {
+	var lines []string
+	for len(lines) < count {
+		line, err := reader.ReadString('\n')
+		c.Assert(err, jc.ErrorIsNil)
+		lines = append(lines, line[:len(line)-1])
+	}
+	return lines
+}
+
+func (s *debugLogDBSuite) TestBadParams(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"startTime": {"yesterday"}})
+	assertJSONError(c, reader, `startTime value "yesterday" is not a valid RFC3339 time`)
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogDBSuite) TestBacklog(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"backlog": {"2"}, "maxLines": {"2"}})
+	errResult := readJSONErrorLine(c, reader)
+	c.Assert(errResult.Error, gc.IsNil)
+	c.Assert(s.readLines(c, reader, 2), jc.DeepEquals, []string{
+		"unit-mysql-0: 2015-06-01 10:01:00 DEBUG juju.worker.uniter file.go:42 mysql debug",
+		"unit-mysql-0: 2015-06-01 10:02:00 ERROR juju.worker.uniter file.go:42 mysql error",
+	})
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogDBSuite) TestFiltersAndTimeRange(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{
+		"startTime":     {s.now.Format(time.RFC3339)},
+		"endTime":       {s.now.Add(2 * time.Minute).Format(time.RFC3339)},
+		"includeEntity": {"mysql/*"},
+		"level":         {"INFO"},
+	})
+	errResult := readJSONErrorLine(c, reader)
+	c.Assert(errResult.Error, gc.IsNil)
+	c.Assert(s.readLines(c, reader, 1), jc.DeepEquals, []string{
+		"unit-mysql-0: 2015-06-01 10:02:00 ERROR juju.worker.uniter file.go:42 mysql error",
+	})
+	// With an end time, the stream finishes once the matching
+	// records have been sent.
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogDBSuite) TestJSONFormat(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{
+		"replay":        {"true"},
+		"includeModule": {"juju.worker"},
+		"excludeEntity": {"unit-mysql-0"},
+		"format":        {"json"},
+		"maxLines":      {"1"},
+	})
+	errResult := readJSONErrorLine(c, reader)
+	c.Assert(errResult.Error, gc.IsNil)
+	line, err := reader.ReadSlice('\n')
+	c.Assert(err, jc.ErrorIsNil)
+	var rec params.LogRecord
+	err = json.Unmarshal(line, &rec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rec.Time.Equal(s.now), jc.IsTrue)
+	rec.Time = time.Time{}
+	c.Assert(rec, jc.DeepEquals, params.LogRecord{
+		Entity:   "machine-0",
+		Module:   "juju.worker",
+		Location: "file.go:42",
+		Level:    "INFO",
+		Message:  "machine message",
+	})
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogDBSuite) TestTailing(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"maxLines": {"1"}})
+	errResult := readJSONErrorLine(c, reader)
+	c.Assert(errResult.Error, gc.IsNil)
+	s.log(c, names.NewMachineTag("1"), time.Now(), "juju", loggo.WARNING, "new message")
+	lines := s.readLines(c, reader, 1)
+	c.Assert(lines[0], gc.Matches, `machine-1: .* WARNING juju file.go:42 new message`)
+	s.assertWebsocketClosed(c, reader)
+}
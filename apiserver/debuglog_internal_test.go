@@ -16,6 +16,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
 
@@ -348,6 +349,42 @@ func (s *debugInternalSuite) TestNewLogStream(c *gc.C) {
 
 	_, err = newLogStream(url.Values{"level": []string{"foo"}})
 	c.Assert(err, gc.ErrorMatches, `level value "foo" is not one of "TRACE", "DEBUG", "INFO", "WARNING", "ERROR"`)
+
+	_, err = newLogStream(url.Values{"format": []string{"json"}})
+	c.Assert(err, gc.ErrorMatches, `time ranges and JSON records require logs to be stored in the database`)
+}
+
+func (s *debugInternalSuite) TestTailerParams(c *gc.C) {
+	args, err := readDebugLogParams(url.Values{
+		"includeEntity": []string{"machine-1*"},
+		"excludeModule": []string{"juju.provisioner"},
+		"backlog":       []string{"100"},
+		"level":         []string{"INFO"},
+		"endTime":       []string{"2015-06-01T10:00:00Z"},
+		"format":        []string{"json"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args.jsonFormat, jc.IsTrue)
+	c.Assert(args.tailerParams(), jc.DeepEquals, &state.LogTailerParams{
+		EndTime:       time.Date(2015, 6, 1, 10, 0, 0, 0, time.UTC),
+		MinLevel:      loggo.INFO,
+		InitialLines:  100,
+		IncludeEntity: []string{"machine-1*"},
+		ExcludeModule: []string{"juju.provisioner"},
+	})
+
+	// The backlog is ignored when a start time is given.
+	args, err = readDebugLogParams(url.Values{
+		"backlog":   []string{"100"},
+		"startTime": []string{"2015-06-01T10:00:00Z"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args.tailerParams(), jc.DeepEquals, &state.LogTailerParams{
+		StartTime: time.Date(2015, 6, 1, 10, 0, 0, 0, time.UTC),
+	})
+
+	_, err = readDebugLogParams(url.Values{"format": []string{"xml"}})
+	c.Assert(err, gc.ErrorMatches, `format value "xml" is not one of "text", "json"`)
 }
 
 type agentMatchTest struct {
@@ -449,3 +449,10 @@ unit-ubuntu-1: 2014-03-24 22:36:28 DEBUG juju.worker.rsyslog worker.go:76 starti
 `[1:], "\n")
 	logLineCount = len(logLines)
 )
+
+func (s *debugLogSuite) TestTimeRangeRequiresDbLog(c *gc.C) {
+	s.ensureLogFile(c)
+	reader := s.openWebsocket(c, url.Values{"startTime": {"2015-06-01T10:00:00Z"}})
+	assertJSONError(c, reader, `time ranges and JSON records require logs to be stored in the database`)
+	s.assertWebsocketClosed(c, reader)
+}
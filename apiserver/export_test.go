@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/state"
 )
@@ -28,6 +29,7 @@ var (
 	NewBackups            = &newBackups
 	ParseLogLine          = parseLogLine
 	AgentMatchesFilter    = agentMatchesFilter
+	IsCallReadOnly        = isCallReadOnly
 )
 
 func ApiHandlerWithEntity(entity state.Entity) *apiHandler {
@@ -91,6 +93,19 @@ func TestingUpgradingRoot(st *state.State) rpc.MethodFinder {
 	return newUpgradingRoot(r)
 }
 
+// TestingAuditingRoot returns an auditingRoot wrapping the given
+// finder, which records entries using writer.
+func TestingAuditingRoot(finder rpc.MethodFinder, writer audit.EntryWriter, envUUID string, authorizer common.Authorizer) rpc.MethodFinder {
+	return newAuditingRoot(finder, writer, envUUID, authorizer)
+}
+
+// TestingAccessApiHandler returns an srvRoot restricted to the calls
+// permitted by the given level of access to the environment.
+func TestingAccessApiHandler(st *state.State, access state.EnvironmentAccess) rpc.MethodFinder {
+	r := TestingApiRoot(st)
+	return newAccessRoot(r, access)
+}
+
 // TestingRestrictedApiHandler returns a restricted srvRoot as if accessed
 // from the root of the API path with a recent (verison > 1) login.
 func TestingRestrictedApiHandler(st *state.State) rpc.MethodFinder {
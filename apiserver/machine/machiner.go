@@ -135,3 +135,34 @@ func (api *MachinerAPI) Jobs(args params.Entities) (params.JobsResults, error) {
 	}
 	return result, nil
 }
+
+// SetWorkerReports records the reports of the workers run by the
+// given machine agents.
+func (api *MachinerAPI) SetWorkerReports(args params.SetWorkerReports) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canModify, err := api.getCanModify()
+	if err != nil {
+		return results, err
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canModify(tag) {
+			var m *state.Machine
+			m, err = api.getMachine(tag)
+			if err == nil {
+				err = m.SetWorkerReports(common.StateWorkerReports(arg.Reports))
+			} else if errors.IsNotFound(err) {
+				err = common.ErrPerm
+			}
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -4,6 +4,7 @@
 package machine_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -184,6 +185,51 @@ func (s *machinerSuite) TestSetMachineAddresses(c *gc.C) {
 	c.Assert(s.machine0.MachineAddresses(), gc.HasLen, 0)
 }
 
+func (s *machinerSuite) TestSetWorkerReports(c *gc.C) {
+	reports := []params.WorkerReport{{
+		Name:       "api",
+		State:      "started",
+		StartCount: 3,
+		LastError:  "connection is shut down",
+		Workers: []params.WorkerReport{{
+			Name:       "machiner",
+			State:      "started",
+			StartCount: 1,
+		}},
+	}}
+	args := params.SetWorkerReports{Entities: []params.EntityWorkerReports{
+		{Tag: "machine-1", Reports: reports},
+		{Tag: "machine-0", Reports: reports},
+		{Tag: "machine-42", Reports: reports},
+	}}
+
+	result, err := s.machiner.SetWorkerReports(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	stateReports, _, err := s.machine1.WorkerReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stateReports, jc.DeepEquals, []state.WorkerReport{{
+		Name:       "api",
+		State:      "started",
+		StartCount: 3,
+		LastError:  "connection is shut down",
+		Workers: []state.WorkerReport{{
+			Name:       "machiner",
+			State:      "started",
+			StartCount: 1,
+		}},
+	}})
+	_, _, err = s.machine0.WorkerReports()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *machinerSuite) TestJobs(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "machine-1"},
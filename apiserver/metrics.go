@@ -0,0 +1,123 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	apihttp "github.com/juju/juju/apiserver/http"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/utils/prometheus"
+)
+
+var (
+	rpcRequests = prometheus.NewCounterVec(
+		"juju_apiserver_rpc_requests_total",
+		"Number of RPC requests served, by facade, method and outcome.",
+		"facade", "method", "outcome",
+	)
+	rpcRequestDuration = prometheus.NewHistogramVec(
+		"juju_apiserver_rpc_request_duration_seconds",
+		"Time taken to serve RPC requests, by facade and method.",
+		prometheus.DefaultBuckets,
+		"facade", "method",
+	)
+	apiConnections = prometheus.NewGaugeVec(
+		"juju_apiserver_connections",
+		"Number of active API connections.",
+	)
+	loginFailures = prometheus.NewCounterVec(
+		"juju_apiserver_login_failures_total",
+		"Number of failed API logins.",
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		rpcRequests,
+		rpcRequestDuration,
+		apiConnections,
+		loginFailures,
+	)
+}
+
+// metricsHandler serves the metrics of the API server in the
+// Prometheus text format to environment administrators.
+type metricsHandler struct {
+	httpHandler
+	registry *prometheus.Registry
+}
+
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	stateWrapper, err := h.validateEnvironUUID(r)
+	if err != nil {
+		h.sendError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	defer stateWrapper.cleanup()
+
+	if err := h.authenticateAdmin(stateWrapper, r); err != nil {
+		h.authError(w, h)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		w.Header().Set("Content-Type", prometheus.ContentType)
+		if err := h.registry.WriteText(w); err != nil {
+			logger.Errorf("cannot write metrics: %v", err)
+		}
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, fmt.Sprintf("unsupported method: %q", r.Method))
+	}
+}
+
+// authenticateAdmin checks that the request was made by a user with
+// admin access to the environment.
+func (h *metricsHandler) authenticateAdmin(stateWrapper *httpStateWrapper, r *http.Request) error {
+	tag, err := stateWrapper.authenticate(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	userTag, ok := tag.(names.UserTag)
+	if !ok {
+		return common.ErrBadCreds
+	}
+	envUser, err := stateWrapper.state.EnvironmentUser(userTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if envUser.Access() != state.EnvironmentAdminAccess {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *metricsHandler) sendError(w http.ResponseWriter, statusCode int, message string) {
+	body, err := json.Marshal(&params.Error{Message: message})
+	if err != nil {
+		logger.Errorf("cannot marshal error response: %v", err)
+		return
+	}
+	w.Header().Set("Content-Type", apihttp.CTypeJSON)
+	w.WriteHeader(statusCode)
+	w.Write(body)
+}
+
+// observeRPC records the outcome and duration of an RPC request.
+func observeRPC(facade, method string, failed bool, seconds float64) {
+	outcome := "success"
+	if failed {
+		outcome = "error"
+	}
+	rpcRequests.Inc(facade, method, outcome)
+	rpcRequestDuration.Observe(seconds, facade, method)
+}
@@ -0,0 +1,121 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apihttp "github.com/juju/juju/apiserver/http"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/utils/prometheus"
+)
+
+type metricsSuite struct {
+	userAuthHttpSuite
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) metricsURL(c *gc.C) string {
+	uri := s.baseURL(c)
+	uri.Path = fmt.Sprintf("/environment/%s/metrics", s.envUUID)
+	return uri.String()
+}
+
+func (s *metricsSuite) assertErrorResponse(c *gc.C, resp *http.Response, statusCode int, msg string) {
+	defer resp.Body.Close()
+	c.Check(resp.StatusCode, gc.Equals, statusCode)
+	c.Check(resp.Header.Get("Content-Type"), gc.Equals, apihttp.CTypeJSON)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	var failure params.Error
+	err = json.Unmarshal(body, &failure)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(&failure, gc.ErrorMatches, msg)
+}
+
+func (s *metricsSuite) TestRequiresAuth(c *gc.C) {
+	resp, err := s.sendRequest(c, "", "", "GET", s.metricsURL(c), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(resp.Header.Get("WWW-Authenticate"), gc.Equals, `Basic realm="juju"`)
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "unauthorized")
+}
+
+func (s *metricsSuite) TestRequiresAdmin(c *gc.C) {
+	envUser := s.Factory.MakeEnvUser(c, &factory.EnvUserParams{
+		Access: state.EnvironmentReadAccess,
+	})
+	user, err := s.State.User(envUser.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = user.SetPassword("password")
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp, err := s.sendRequest(c, user.Tag().String(), "password", "GET", s.metricsURL(c), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "unauthorized")
+}
+
+func (s *metricsSuite) TestRequiresStateServerEnvironment(c *gc.C) {
+	s.setupOtherEnvironment(c)
+	resp, err := s.authRequest(c, "GET", s.metricsURL(c), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertErrorResponse(c, resp, http.StatusNotFound, `requested environment ".*" is not the state server environment`)
+}
+
+func (s *metricsSuite) TestRejectsPost(c *gc.C) {
+	resp, err := s.authRequest(c, "POST", s.metricsURL(c), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "POST"`)
+}
+
+func (s *metricsSuite) TestMetrics(c *gc.C) {
+	// The test suite's own API connection has made calls.
+	_, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp, err := s.authRequest(c, "GET", s.metricsURL(c), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, prometheus.ContentType)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, expected := range []string{
+		"\n# TYPE juju_apiserver_rpc_requests_total counter\n",
+		`juju_apiserver_rpc_requests_total{facade="Client",method="FullStatus",outcome="success"} `,
+		`juju_apiserver_rpc_request_duration_seconds_count{facade="Client",method="FullStatus"} `,
+		"\n# TYPE juju_apiserver_connections gauge\n",
+		"\n# TYPE juju_apiserver_login_failures_total counter\n",
+		"\n# TYPE juju_apiserver_watchers gauge\n",
+		"\n# TYPE juju_state_txn_retries_total counter\n",
+		"\n# TYPE juju_worker_restarts_total counter\n",
+	} {
+		c.Check(string(body), jc.Contains, expected)
+	}
+}
+
+func (s *metricsSuite) TestMetricsUnknownMethods(c *gc.C) {
+	err := s.APIState.APICall("NoSuchFacade", 0, "", "NoSuchMethod", nil, nil)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotImplemented)
+	err = s.APIState.APICall("Client", 0, "", "NoSuchMethod", nil, nil)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotImplemented)
+
+	resp, err := s.authRequest(c, "GET", s.metricsURL(c), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(body), jc.Contains, `juju_apiserver_rpc_requests_total{facade="unknown",method="unknown",outcome="error"} `)
+	c.Check(string(body), gc.Not(jc.Contains), "NoSuchFacade")
+	c.Check(string(body), gc.Not(jc.Contains), "NoSuchMethod")
+}
@@ -12,7 +12,7 @@ import (
 
 const (
 	// ActionCancelled is the status for an Action that has been
-	// cancelled prior to execution.
+	// cancelled before it finished running.
 	ActionCancelled string = "cancelled"
 
 	// ActionCompleted is the status of an Action that has completed
@@ -30,6 +30,10 @@ const (
 	// ActionRunning is the status of an Action that has been started but
 	// not completed yet.
 	ActionRunning string = "running"
+
+	// ActionTimedOut is the status of an Action that was killed because
+	// it ran for longer than its timeout.
+	ActionTimedOut string = "timed-out"
 )
 
 // Actions is a slice of Action for bulk requests.
@@ -43,6 +47,10 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// Timeout holds how long the action may run for before it is
+	// killed; zero means that it may run for as long as it takes.
+	Timeout time.Duration `json:"timeout,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
@@ -59,7 +67,147 @@ type ActionResult struct {
 	Status    string                 `json:"status,omitempty"`
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
+	Log       []ActionMessage        `json:"log,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
+
+	// LogCount is the number of messages logged by the action. It
+	// is greater than len(Log) if older messages were discarded.
+	LogCount int `json:"log-count,omitempty"`
+}
+
+// ActionMessage is a progress message logged by a running action.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// ActionMessageParams holds the progress messages to be logged to
+// running actions.
+type ActionMessageParams struct {
+	Messages []EntityString `json:"messages"`
+}
+
+// EntityString holds a string value for an entity, such as a message
+// to be logged to an action.
+type EntityString struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// ActionGroups holds the actions to be queued on several units of
+// services at once.
+type ActionGroups struct {
+	Groups []ActionGroup `json:"groups,omitempty"`
+}
+
+// ActionGroup describes an action to be queued on the units of a
+// service as one group.
+type ActionGroup struct {
+	// Service holds the tag of the service whose units the action
+	// is queued on.
+	Service string `json:"service"`
+
+	// Units holds the tags of the units of the service the action
+	// is queued on. When empty, the action is queued on every unit
+	// of the service.
+	Units []string `json:"units,omitempty"`
+
+	// LeaderOnly restricts the units the action is queued on to the
+	// leader of the service.
+	LeaderOnly bool `json:"leader-only,omitempty"`
+
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// Timeout holds how long each action may run for before it is
+	// killed; zero means that it may run for as long as it takes.
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// ActionGroupIds holds the ids of action groups.
+type ActionGroupIds struct {
+	Ids []string `json:"ids,omitempty"`
+}
+
+// ActionGroupResults holds a slice of ActionGroupResult for bulk
+// requests.
+type ActionGroupResults struct {
+	Results []ActionGroupResult `json:"results,omitempty"`
+}
+
+// ActionGroupResult holds the id of an action group and the actions
+// queued in it, one per unit.
+type ActionGroupResult struct {
+	Group   string         `json:"group,omitempty"`
+	Actions []ActionResult `json:"actions,omitempty"`
+	Error   *Error         `json:"error,omitempty"`
+}
+
+// ActionSchedules holds action schedules for bulk requests.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules,omitempty"`
+}
+
+// ActionSchedule describes a cron schedule on which an action is
+// queued by the state server.
+type ActionSchedule struct {
+	Id string `json:"id,omitempty"`
+
+	// Spec holds the cron expression describing when the action is
+	// queued, evaluated in UTC.
+	Spec string `json:"spec"`
+
+	// Receiver holds the tag of the unit the action is queued on, or
+	// of the service on all of whose units the action is queued as
+	// one group.
+	Receiver string `json:"receiver"`
+
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Timeout    time.Duration          `json:"timeout,omitempty"`
+
+	// The following fields are set by the server.
+	Created   time.Time  `json:"created,omitempty"`
+	LastRun   *time.Time `json:"last-run,omitempty"`
+	LastError string     `json:"last-error,omitempty"`
+	Next      *time.Time `json:"next,omitempty"`
+}
+
+// ActionScheduleIds holds the ids of action schedules.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids,omitempty"`
+}
+
+// ActionScheduleResults holds a slice of ActionScheduleResult for
+// bulk requests.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results,omitempty"`
+}
+
+// ActionScheduleResult holds an action schedule or an error.
+type ActionScheduleResult struct {
+	Schedule *ActionSchedule `json:"schedule,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// ActionsFilter holds the parameters of a query for actions.
+type ActionsFilter struct {
+	// Receivers holds the tags of the services and units whose
+	// actions are selected. A service selects the actions of all
+	// its units. When empty, the actions of every unit in the
+	// environment are selected.
+	Receivers []string `json:"receivers,omitempty"`
+
+	// Names selects actions with the given names.
+	Names []string `json:"names,omitempty"`
+
+	// Statuses selects actions with the given statuses.
+	Statuses []string `json:"statuses,omitempty"`
+
+	// EnqueuedAfter and EnqueuedBefore select actions enqueued
+	// within the time range.
+	EnqueuedAfter  *time.Time `json:"enqueued-after,omitempty"`
+	EnqueuedBefore *time.Time `json:"enqueued-before,omitempty"`
 }
 
 // ActionsByReceivers wrap a slice of Actions for API calls.
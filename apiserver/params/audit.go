@@ -0,0 +1,44 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AuditLogFilter holds the criteria used to select audit log
+// entries. Zero valued fields do not restrict the selection.
+type AuditLogFilter struct {
+	// User holds the tag of the user whose entries are selected.
+	// Local users may be given with or without the "@local" suffix.
+	User string `json:"user,omitempty"`
+
+	// Entity holds the tag of an entity named in the selected
+	// entries.
+	Entity string `json:"entity,omitempty"`
+
+	// From and To select entries recorded within the time range.
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+
+	// Limit restricts the number of entries returned.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditEntry describes a single audited API call.
+type AuditEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	User      string    `json:"user"`
+	Facade    string    `json:"facade"`
+	Version   int       `json:"version"`
+	Method    string    `json:"method"`
+	Args      string    `json:"args,omitempty"`
+	Entities  []string  `json:"entities,omitempty"`
+	Outcome   string    `json:"outcome"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// AuditEntriesResult holds the audit entries selected by
+// an AuditLogFilter, most recent first.
+type AuditEntriesResult struct {
+	Entries []AuditEntry `json:"entries"`
+}
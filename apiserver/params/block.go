@@ -31,6 +31,11 @@ type BlockSwitchParams struct {
 	// Message is a descriptive or an explanatory message
 	// that accompanies the switch.
 	Message string `json:"message,omitempty"`
+
+	// Entity optionally holds the tag of a service, unit or
+	// machine that the block applies to. If it is empty, the
+	// block applies to the whole environment.
+	Entity string `json:"entity,omitempty"`
 }
 
 // BlockResult holds the result of an API call to retrieve details
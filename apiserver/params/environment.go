@@ -41,10 +41,23 @@ const (
 	RemoveEnvUser EnvironAction = "remove"
 )
 
+// EnvironAccess is the level of access a user has to an environment.
+type EnvironAccess string
+
+// Levels of access a user can have to an environment.
+const (
+	EnvironReadAccess  EnvironAccess = "read"
+	EnvironWriteAccess EnvironAccess = "write"
+	EnvironAdminAccess EnvironAccess = "admin"
+)
+
 // ModifyEnvironUser stores the parameters used for a Client.ShareEnvironment call.
+// Access is only used when adding a user; if it is empty, the user is
+// given write access.
 type ModifyEnvironUser struct {
 	UserTag string        `json:"user-tag"`
 	Action  EnvironAction `json:"action"`
+	Access  EnvironAccess `json:"access,omitempty"`
 }
 
 // SetEnvironAgentVersion contains the arguments for
@@ -60,6 +73,7 @@ type EnvUserInfo struct {
 	CreatedBy      string     `json:"createdby"`
 	DateCreated    time.Time  `json:"datecreated"`
 	LastConnection *time.Time `json:"lastconnection"`
+	Access         string     `json:"access"`
 }
 
 // EnvUserInfoResult holds the result of an EnvUserInfo call.
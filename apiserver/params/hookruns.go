@@ -0,0 +1,57 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// HookRun records a single run of a hook, action or juju-run commands
+// by a unit.
+type HookRun struct {
+	Kind     string    `json:"kind"`
+	Name     string    `json:"name"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	Status   string    `json:"status"`
+}
+
+// UnitHookRuns holds runs of hooks, actions and commands by the unit
+// with the given tag.
+type UnitHookRuns struct {
+	Tag  string    `json:"tag"`
+	Runs []HookRun `json:"runs"`
+}
+
+// HookRunsParams holds the runs of hooks, actions and commands to be
+// recorded for units.
+type HookRunsParams struct {
+	Units []UnitHookRuns `json:"units"`
+}
+
+// ServiceHookStats holds the parameters for getting statistics on
+// the runs of hooks, actions and commands by a service's units.
+type ServiceHookStats struct {
+	ServiceName string `json:"service-name"`
+}
+
+// HookStats summarizes the runs of a single hook, action or commands
+// by the units of a service.
+type HookStats struct {
+	Kind   string        `json:"kind"`
+	Name   string        `json:"name"`
+	Runs   int           `json:"runs"`
+	Failed int           `json:"failed"`
+	Min    time.Duration `json:"min"`
+	P50    time.Duration `json:"p50"`
+	P90    time.Duration `json:"p90"`
+	P99    time.Duration `json:"p99"`
+	Max    time.Duration `json:"max"`
+}
+
+// HookStatsResults holds statistics on the runs of each hook, action
+// and commands by a service's units.
+type HookStatsResults struct {
+	Results []HookStats `json:"results"`
+}
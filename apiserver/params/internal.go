@@ -75,6 +75,18 @@ type StringResults struct {
 	Results []StringResult
 }
 
+// DurationResult holds a time duration or an error.
+type DurationResult struct {
+	Error  *Error
+	Result time.Duration
+}
+
+// DurationResults holds the bulk operation result of an API call
+// that returns a time duration or an error.
+type DurationResults struct {
+	Results []DurationResult
+}
+
 // EnvironmentResult holds the result of an API call returning a name and UUID
 // for an environment.
 type EnvironmentResult struct {
@@ -351,6 +363,7 @@ const (
 	KindCombined HistoryKind = "combined"
 	KindAgent    HistoryKind = "agent"
 	KindWorkload HistoryKind = "workload"
+	KindMachine  HistoryKind = "machine"
 )
 
 // StatusHistory holds the parameters to filter a status history query.
@@ -360,6 +373,45 @@ type StatusHistory struct {
 	Name string
 }
 
+// StatusHistoryFilter holds the parameters of a status history
+// query across services, units and machines.
+type StatusHistoryFilter struct {
+	// Entities holds the tags of the services, units and machines
+	// whose status history is selected. A service selects the
+	// history of all its units. When empty, the history of every
+	// entity in the environment is selected.
+	Entities []string `json:"entities,omitempty"`
+
+	// Kind selects the kind of status history; the combined kind
+	// selects all of them.
+	Kind HistoryKind `json:"kind,omitempty"`
+
+	// From and To select statuses set within the time range.
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+
+	// Offset skips this many of the selected entries, and Limit
+	// restricts the number of entries returned.
+	Offset int `json:"offset,omitempty"`
+	Limit  int `json:"limit,omitempty"`
+}
+
+// StatusHistoryEntry holds a past status of a unit or machine.
+type StatusHistoryEntry struct {
+	Entity string                 `json:"entity" yaml:"entity"`
+	Kind   HistoryKind            `json:"kind" yaml:"kind"`
+	Status Status                 `json:"status" yaml:"status"`
+	Info   string                 `json:"info,omitempty" yaml:"info,omitempty"`
+	Data   map[string]interface{} `json:"data,omitempty" yaml:"data,omitempty"`
+	Since  *time.Time             `json:"since" yaml:"since"`
+}
+
+// StatusHistoryResults holds the status history entries selected
+// by a StatusHistoryFilter, most recent first.
+type StatusHistoryResults struct {
+	Entries []StatusHistoryEntry `json:"entries"`
+}
+
 // StatusResult holds an entity status, extra information, or an
 // error.
 type StatusResult struct {
@@ -614,3 +666,41 @@ type MeterStatusResult struct {
 type MeterStatusResults struct {
 	Results []MeterStatusResult
 }
+
+// WorkerReport describes a worker run by an agent, and the workers it
+// runs in turn.
+type WorkerReport struct {
+	Name       string
+	Inputs     []string
+	State      string
+	StartCount int
+	LastError  string
+	Workers    []WorkerReport
+}
+
+// EntityWorkerReports holds the reports of the workers run by an
+// agent.
+type EntityWorkerReports struct {
+	Tag     string
+	Reports []WorkerReport
+}
+
+// SetWorkerReports holds the parameters for publishing the reports of
+// the workers run by agents.
+type SetWorkerReports struct {
+	Entities []EntityWorkerReports
+}
+
+// WorkerReportsResult holds the reports of the workers run by an
+// agent, as last published, or an error.
+type WorkerReportsResult struct {
+	Reports []WorkerReport
+	Updated time.Time
+	Error   *Error
+}
+
+// WorkerReportsResults holds the results of a bulk worker reports
+// call.
+type WorkerReportsResults struct {
+	Results []WorkerReportsResult
+}
@@ -0,0 +1,17 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// LogRecord holds a single log message as sent by the debug log end
+// point when JSON records are requested.
+type LogRecord struct {
+	Time     time.Time `json:"time"`
+	Entity   string    `json:"entity"`
+	Module   string    `json:"module"`
+	Location string    `json:"location"`
+	Level    string    `json:"level"`
+	Message  string    `json:"message"`
+}
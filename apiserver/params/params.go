@@ -222,6 +222,37 @@ type ServiceSetCharm struct {
 	Force       bool
 }
 
+// ServiceSetCharmRolling sets the charm for a given service, releasing
+// its units to upgrade BatchSize at a time.
+type ServiceSetCharmRolling struct {
+	ServiceName string
+	CharmUrl    string
+	Force       bool
+	BatchSize   int
+	Rollback    bool
+}
+
+// ServiceResumeRollingUpgrade holds the parameters for resuming a
+// paused rolling charm upgrade.
+type ServiceResumeRollingUpgrade struct {
+	ServiceName string
+}
+
+// ServiceRollbackCharm holds the parameters for setting a service back
+// to the charm and config settings it used before its charm was last
+// changed.
+type ServiceRollbackCharm struct {
+	ServiceName string
+	Force       bool
+}
+
+// ServiceSetHookTimeout holds the parameters for setting how long a
+// service's units may run a hook before it is killed.
+type ServiceSetHookTimeout struct {
+	ServiceName string
+	Timeout     time.Duration
+}
+
 // ServiceExpose holds the parameters for making the ServiceExpose call.
 type ServiceExpose struct {
 	ServiceName string
@@ -334,6 +365,11 @@ type AddServiceUnits struct {
 	ServiceName   string
 	NumUnits      int
 	ToMachineSpec string
+
+	// AttachStorage contains the IDs of detached storage instances
+	// to attach to the new unit. Storage may only be attached when
+	// adding a single unit.
+	AttachStorage []string `json:",omitempty"`
 }
 
 // DestroyServiceUnits holds parameters for the DestroyUnits call.
@@ -94,6 +94,10 @@ type StorageAttachment struct {
 	Kind     StorageKind
 	Location string
 	Life     Life
+
+	// Size is the size of the storage attachment's volume or
+	// filesystem in MiB, or zero if it is not yet known.
+	Size uint64
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -312,6 +316,7 @@ type FilesystemAttachmentParams struct {
 	InstanceId    string `json:"instanceid,omitempty"`
 	Provider      string `json:"provider"`
 	MountPoint    string `json:"mountpoint,omitempty"`
+	FilesystemId  string `json:"filesystemid,omitempty"`
 }
 
 // FilesystemAttachmentResult holds the details of a single filesystem attachment,
@@ -527,3 +532,36 @@ type StorageAddParams struct {
 type StoragesAddParams struct {
 	Storages []StorageAddParams `json:"storages"`
 }
+
+// ImportStorageParams holds the parameters for importing a volume,
+// created outside of Juju, as a detached storage instance.
+type ImportStorageParams struct {
+	// Pool is the name of the storage pool whose provider
+	// manages the volume.
+	Pool string `json:"pool"`
+
+	// ProviderId is the provider's ID for the volume.
+	ProviderId string `json:"providerid"`
+
+	// StorageName is the name of the charm storage that
+	// the volume is to be imported as.
+	StorageName string `json:"storagename"`
+}
+
+// BulkImportStorageParams holds the parameters for importing
+// a set of volumes.
+type BulkImportStorageParams struct {
+	Storage []ImportStorageParams `json:"storage"`
+}
+
+// ImportStorageResult holds the tag of an imported storage
+// instance, or an error.
+type ImportStorageResult struct {
+	StorageTag string `json:"storagetag,omitempty"`
+	Error      *Error `json:"error,omitempty"`
+}
+
+// ImportStorageResults holds a set of ImportStorageResults.
+type ImportStorageResults struct {
+	Results []ImportStorageResult `json:"results,omitempty"`
+}
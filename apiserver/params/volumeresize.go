@@ -0,0 +1,41 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// VolumeResizeParams holds the parameters for growing a volume.
+type VolumeResizeParams struct {
+	VolumeTag string `json:"volumetag"`
+	VolumeId  string `json:"volumeid"`
+	Provider  string `json:"provider"`
+
+	// Size is the size, in MiB, that the volume is to be grown to.
+	Size uint64 `json:"size"`
+}
+
+// VolumeResizeParamsResult holds the parameters for growing a volume,
+// or an error.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds a set of VolumeResizeParamsResults.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
+// StorageResize holds the parameters for growing a storage instance.
+type StorageResize struct {
+	StorageTag string `json:"storagetag"`
+
+	// Size is the size, in MiB, that the storage instance is to be
+	// grown to.
+	Size uint64 `json:"size"`
+}
+
+// StoragesResize holds the parameters for growing a set of storage
+// instances.
+type StoragesResize struct {
+	Storages []StorageResize `json:"storages"`
+}
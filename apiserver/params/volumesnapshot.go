@@ -0,0 +1,78 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// VolumeSnapshot describes a snapshot of a volume.
+type VolumeSnapshot struct {
+	// Id is the Juju-assigned ID of the snapshot, which is the volume
+	// name followed by "@" and a sequence number.
+	Id        string `json:"id"`
+	VolumeTag string `json:"volumetag"`
+	Life      Life   `json:"life,omitempty"`
+
+	// SnapshotId is the provider-supplied ID of the snapshot. It, and
+	// the fields below, are empty until the snapshot has been taken.
+	SnapshotId string `json:"snapshotid,omitempty"`
+	// Size is the size of the volume when the snapshot was taken, in MiB.
+	Size    uint64    `json:"size,omitempty"`
+	Created time.Time `json:"created,omitempty"`
+}
+
+// VolumeSnapshots describes a set of volume snapshots.
+type VolumeSnapshots struct {
+	Snapshots []VolumeSnapshot `json:"snapshots"`
+}
+
+// VolumeSnapshotResult holds the details of a single volume snapshot,
+// or an error.
+type VolumeSnapshotResult struct {
+	Result VolumeSnapshot `json:"result"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// VolumeSnapshotResults holds a set of VolumeSnapshotResults.
+type VolumeSnapshotResults struct {
+	Results []VolumeSnapshotResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotIds holds a set of Juju-assigned volume snapshot IDs.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeSnapshotFilter holds a filter for the volume snapshot list
+// API call.
+type VolumeSnapshotFilter struct {
+	// Volumes are volume tags to filter on. If empty, the snapshots
+	// of all volumes are listed.
+	Volumes []string `json:"volumes,omitempty"`
+}
+
+// VolumeSnapshotParams holds the parameters for taking, or destroying,
+// a snapshot of a volume.
+type VolumeSnapshotParams struct {
+	Id        string `json:"id"`
+	VolumeTag string `json:"volumetag"`
+	VolumeId  string `json:"volumeid"`
+	Provider  string `json:"provider"`
+	Life      Life   `json:"life"`
+
+	// SnapshotId is the provider-supplied ID of the snapshot, if
+	// it has been taken.
+	SnapshotId string `json:"snapshotid,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds provisioning parameters for a volume
+// snapshot, or an error.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds a set of VolumeSnapshotParamsResults.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
@@ -0,0 +1,104 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/utils/set"
+)
+
+// readOnlyFacades are the facades that expose no calls that change
+// the environment.
+var readOnlyFacades = set.NewStrings(
+	"AllWatcher",
+	"Pinger",
+)
+
+// readOnlyCalls holds, for each client facade, the calls that do not
+// change the environment. Any call not listed here is considered to
+// be mutating.
+var readOnlyCalls = map[string]set.Strings{
+	"Action": set.NewStrings(
+		"ActionGroups",
+		"Actions",
+		"FindActionTagsByPrefix",
+		"FindActions",
+		"ListAll",
+		"ListCompleted",
+		"ListPending",
+		"ListRunning",
+		"Schedules",
+		"ServicesCharmActions",
+	),
+	"Annotations": set.NewStrings(
+		"Get",
+	),
+	"Audit": set.NewStrings(
+		"List",
+	),
+	"Backups": set.NewStrings(
+		"Info",
+		"List",
+	),
+	"Block": set.NewStrings(
+		"List",
+	),
+	"Charms": set.NewStrings(
+		"CharmInfo",
+		"List",
+	),
+	"Client": set.NewStrings(
+		"APIHostPorts",
+		"AgentVersion",
+		"CharmInfo",
+		"EnvUserInfo",
+		"EnvironmentGet",
+		"EnvironmentInfo",
+		"FindTools",
+		"FullStatus",
+		"GetAnnotations",
+		"GetEnvironmentConstraints",
+		"GetServiceConstraints",
+		"PrivateAddress",
+		"PublicAddress",
+		"ResolveCharms",
+		"ServiceCharmRelations",
+		"ServiceGet",
+		"ServiceGetCharmURL",
+		"ServiceHookStats",
+		"Status",
+		"StatusHistory",
+		"UnitStatusHistory",
+		"WatchAll",
+		"WorkerReports",
+	),
+	"EnvironmentManager": set.NewStrings(
+		"ConfigSkeleton",
+		"ListEnvironments",
+	),
+	"ImageManager": set.NewStrings(
+		"ListImages",
+	),
+	"KeyManager": set.NewStrings(
+		"ListKeys",
+	),
+	"Storage": set.NewStrings(
+		"List",
+		"ListPools",
+		"ListVolumeSnapshots",
+		"ListVolumes",
+		"Show",
+	),
+	"UserManager": set.NewStrings(
+		"UserInfo",
+	),
+}
+
+// isCallReadOnly reports whether the given call leaves the
+// environment unchanged.
+func isCallReadOnly(rootName, methodName string) bool {
+	if readOnlyFacades.Contains(rootName) {
+		return true
+	}
+	return readOnlyCalls[rootName].Contains(methodName)
+}
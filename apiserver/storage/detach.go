@@ -0,0 +1,38 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Detach detaches the specified storage instances from the units
+// that own them, leaving the storage in the environment so that it
+// may be attached to new units of the same services with add-unit.
+// A "CHANGE" block can block this operation.
+func (a *API) Detach(args params.Entities) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	one := func(arg params.Entity) error {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			return errors.Annotatef(err, "parsing storage tag %v", arg.Tag)
+		}
+		return a.storage.DetachStorage(tag)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
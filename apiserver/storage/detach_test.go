@@ -0,0 +1,53 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type detachSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&detachSuite{})
+
+func (s *detachSuite) TestDetach(c *gc.C) {
+	results, err := s.api.Detach(params.Entities{
+		Entities: []params.Entity{{s.storageTag.String()}, {"volume-22"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `parsing storage tag volume-22: "volume-22" is not a valid storage tag`}},
+		},
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, detachStorageCall})
+}
+
+func (s *detachSuite) TestDetachError(c *gc.C) {
+	s.state.detachStorage = func(storage names.StorageTag) error {
+		return errors.NotSupportedf("detaching non-persistent storage")
+	}
+	results, err := s.api.Detach(params.Entities{
+		Entities: []params.Entity{{s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "detaching non-persistent storage not supported")
+}
+
+func (s *detachSuite) TestDetachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDetachBlocked")
+	_, err := s.api.Detach(params.Entities{
+		Entities: []params.Entity{{s.storageTag.String()}},
+	})
+	s.assertBlocked(c, err, "TestDetachBlocked")
+}
@@ -0,0 +1,83 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/registry"
+)
+
+// Import records volumes that were created outside of Juju as
+// detached storage instances. Each volume is described by the
+// storage provider of the specified pool, to ensure that it
+// exists, before it is recorded in state. The storage may then
+// be attached to a new unit.
+// A "CHANGE" block can block this operation.
+func (a *API) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+	results := params.ImportStorageResults{
+		Results: make([]params.ImportStorageResult, len(args.Storage)),
+	}
+	for i, arg := range args.Storage {
+		info, err := a.describeVolume(arg.Pool, arg.ProviderId)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		tag, err := a.storage.ImportStorage(arg.StorageName, info)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].StorageTag = tag.String()
+	}
+	return results, nil
+}
+
+// describeVolume returns information about the volume with the
+// specified provider ID, as reported by the storage provider of
+// the named pool.
+func (a *API) describeVolume(poolName, volumeId string) (state.VolumeInfo, error) {
+	providerType, cfg, err := common.StoragePoolConfig(poolName, a.poolManager)
+	if err != nil {
+		return state.VolumeInfo{}, errors.Trace(err)
+	}
+	provider, err := registry.StorageProvider(providerType)
+	if err != nil {
+		return state.VolumeInfo{}, errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron || !provider.Supports(storage.StorageKindBlock) {
+		return state.VolumeInfo{}, errors.NotSupportedf("importing volumes from %q", providerType)
+	}
+	envConfig, err := a.storage.EnvironConfig()
+	if err != nil {
+		return state.VolumeInfo{}, errors.Trace(err)
+	}
+	source, err := provider.VolumeSource(envConfig, cfg)
+	if err != nil {
+		return state.VolumeInfo{}, errors.Annotate(err, "getting volume source")
+	}
+	volumes, err := source.DescribeVolumes([]string{volumeId})
+	if err != nil {
+		return state.VolumeInfo{}, errors.Annotatef(err, "describing volume %q", volumeId)
+	}
+	if len(volumes) != 1 {
+		return state.VolumeInfo{}, errors.NotFoundf("volume %q", volumeId)
+	}
+	return state.VolumeInfo{
+		Pool:       poolName,
+		VolumeId:   volumes[0].VolumeId,
+		HardwareId: volumes[0].HardwareId,
+		Size:       volumes[0].Size,
+		Persistent: true,
+	}, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	"github.com/juju/juju/storage/provider/registry"
+)
+
+type importSuite struct {
+	baseStorageSuite
+	volumeSource *mockVolumeSource
+}
+
+var _ = gc.Suite(&importSuite{})
+
+func (s *importSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.volumeSource = &mockVolumeSource{
+		describeVolumes: func(volumeIds []string) ([]jujustorage.Volume, error) {
+			return []jujustorage.Volume{{
+				VolumeId:   volumeIds[0],
+				HardwareId: "serial-123",
+				Size:       1024,
+			}}, nil
+		},
+	}
+	registry.RegisterProvider("environscoped", &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		VolumeSourceFunc: func(*config.Config, *jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	})
+	registry.RegisterProvider("machinescoped", &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+	})
+}
+
+func (s *importSuite) TearDownTest(c *gc.C) {
+	registry.RegisterProvider("environscoped", nil)
+	registry.RegisterProvider("machinescoped", nil)
+	s.baseStorageSuite.TearDownTest(c)
+}
+
+func (s *importSuite) TestImport(c *gc.C) {
+	s.state.importStorage = func(storageName string, info state.VolumeInfo) (names.StorageTag, error) {
+		s.calls = append(s.calls, importStorageCall)
+		c.Assert(storageName, gc.Equals, "data")
+		c.Assert(info, jc.DeepEquals, state.VolumeInfo{
+			Pool:       "environscoped",
+			VolumeId:   "vol-123",
+			HardwareId: "serial-123",
+			Size:       1024,
+			Persistent: true,
+		})
+		return s.storageTag, nil
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Pool:        "environscoped",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ImportStorageResults{
+		Results: []params.ImportStorageResult{{StorageTag: "storage-data-0"}},
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, importStorageCall})
+}
+
+func (s *importSuite) TestImportDescribeError(c *gc.C) {
+	s.volumeSource.describeVolumes = func([]string) ([]jujustorage.Volume, error) {
+		return nil, errors.New("no such volume")
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Pool:        "environscoped",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `describing volume "vol-123": no such volume`)
+	s.assertCalls(c, []string{getBlockForTypeCall})
+}
+
+func (s *importSuite) TestImportMachineScoped(c *gc.C) {
+	results, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Pool:        "machinescoped",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `importing volumes from "machinescoped" not supported`)
+	s.assertCalls(c, []string{getBlockForTypeCall})
+}
+
+func (s *importSuite) TestImportBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestImportBlocked")
+	_, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Pool:        "environscoped",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	s.assertBlocked(c, err, "TestImportBlocked")
+}
+
+type mockVolumeSource struct {
+	jujustorage.VolumeSource
+	describeVolumes func([]string) ([]jujustorage.Volume, error)
+}
+
+func (m *mockVolumeSource) DescribeVolumes(volumeIds []string) ([]jujustorage.Volume, error) {
+	return m.describeVolumes(volumeIds)
+}
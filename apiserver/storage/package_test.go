@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/storage"
 	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	jujustorage "github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
@@ -42,6 +43,7 @@ type baseStorageSuite struct {
 	volumeTag        names.VolumeTag
 	volume           state.Volume
 	volumeAttachment state.VolumeAttachment
+	volumeSnapshot   *mockVolumeSnapshot
 	calls            []string
 
 	poolManager *mockPoolManager
@@ -82,6 +84,13 @@ const (
 	allVolumesCall                          = "allVolumes"
 	addStorageForUnitCall                   = "addStorageForUnit"
 	getBlockForTypeCall                     = "getBlockForType"
+	addVolumeSnapshotCall                   = "addVolumeSnapshot"
+	volumeSnapshotsCall                     = "volumeSnapshots"
+	allVolumeSnapshotsCall                  = "allVolumeSnapshots"
+	destroyVolumeSnapshotCall               = "destroyVolumeSnapshot"
+	resizeVolumeCall                        = "resizeVolume"
+	detachStorageCall                       = "detachStorage"
+	importStorageCall                       = "importStorage"
 )
 
 func (s *baseStorageSuite) constructState(c *gc.C) *mockState {
@@ -106,6 +115,7 @@ func (s *baseStorageSuite) constructState(c *gc.C) *mockState {
 		VolumeTag:  s.volumeTag,
 		MachineTag: s.machineTag,
 	}
+	s.volumeSnapshot = &mockVolumeSnapshot{id: "22@0", volume: s.volumeTag}
 
 	s.blocks = make(map[state.BlockType]state.Block)
 	return &mockState{
@@ -173,6 +183,42 @@ func (s *baseStorageSuite) constructState(c *gc.C) *mockState {
 			val, found := s.blocks[t]
 			return val, found, nil
 		},
+		addVolumeSnapshot: func(volume names.VolumeTag) (state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, addVolumeSnapshotCall)
+			c.Assert(volume, gc.DeepEquals, s.volumeTag)
+			return s.volumeSnapshot, nil
+		},
+		volumeSnapshots: func(volume names.VolumeTag) ([]state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, volumeSnapshotsCall)
+			c.Assert(volume, gc.DeepEquals, s.volumeTag)
+			return []state.VolumeSnapshot{s.volumeSnapshot}, nil
+		},
+		allVolumeSnapshots: func() ([]state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, allVolumeSnapshotsCall)
+			return []state.VolumeSnapshot{s.volumeSnapshot}, nil
+		},
+		destroyVolumeSnapshot: func(id string) error {
+			s.calls = append(s.calls, destroyVolumeSnapshotCall)
+			c.Assert(id, gc.Equals, s.volumeSnapshot.id)
+			return nil
+		},
+		resizeVolume: func(volume names.VolumeTag, size uint64) error {
+			s.calls = append(s.calls, resizeVolumeCall)
+			c.Assert(volume, gc.DeepEquals, s.volumeTag)
+			return nil
+		},
+		detachStorage: func(storage names.StorageTag) error {
+			s.calls = append(s.calls, detachStorageCall)
+			c.Assert(storage, gc.DeepEquals, s.storageTag)
+			return nil
+		},
+		importStorage: func(storageName string, info state.VolumeInfo) (names.StorageTag, error) {
+			s.calls = append(s.calls, importStorageCall)
+			return s.storageTag, nil
+		},
+		environConfig: func() (*config.Config, error) {
+			return coretesting.EnvironConfig(c), nil
+		},
 	}
 }
 
@@ -261,6 +307,8 @@ type mockState struct {
 	watchStorageAttachment              func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 	watchFilesystemAttachment           func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment               func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+	watchFilesystem                     func(names.FilesystemTag) state.NotifyWatcher
+	watchVolume                         func(names.VolumeTag) state.NotifyWatcher
 	envName                             string
 	volume                              func(tag names.VolumeTag) (state.Volume, error)
 	machineVolumeAttachments            func(machine names.MachineTag) ([]state.VolumeAttachment, error)
@@ -268,6 +316,14 @@ type mockState struct {
 	allVolumes                          func() ([]state.Volume, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
+	addVolumeSnapshot                   func(volume names.VolumeTag) (state.VolumeSnapshot, error)
+	volumeSnapshots                     func(volume names.VolumeTag) ([]state.VolumeSnapshot, error)
+	allVolumeSnapshots                  func() ([]state.VolumeSnapshot, error)
+	destroyVolumeSnapshot               func(id string) error
+	resizeVolume                        func(volume names.VolumeTag, size uint64) error
+	detachStorage                       func(storage names.StorageTag) error
+	importStorage                       func(storageName string, info state.VolumeInfo) (names.StorageTag, error)
+	environConfig                       func() (*config.Config, error)
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -314,6 +370,14 @@ func (st *mockState) WatchVolumeAttachment(mtag names.MachineTag, v names.Volume
 	return st.watchVolumeAttachment(mtag, v)
 }
 
+func (st *mockState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return st.watchFilesystem(f)
+}
+
+func (st *mockState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return st.watchVolume(v)
+}
+
 func (st *mockState) EnvName() (string, error) {
 	return st.envName, nil
 }
@@ -342,6 +406,38 @@ func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, erro
 	return st.getBlockForType(t)
 }
 
+func (st *mockState) AddVolumeSnapshot(volume names.VolumeTag) (state.VolumeSnapshot, error) {
+	return st.addVolumeSnapshot(volume)
+}
+
+func (st *mockState) VolumeSnapshots(volume names.VolumeTag) ([]state.VolumeSnapshot, error) {
+	return st.volumeSnapshots(volume)
+}
+
+func (st *mockState) AllVolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.allVolumeSnapshots()
+}
+
+func (st *mockState) DestroyVolumeSnapshot(id string) error {
+	return st.destroyVolumeSnapshot(id)
+}
+
+func (st *mockState) ResizeVolume(volume names.VolumeTag, size uint64) error {
+	return st.resizeVolume(volume, size)
+}
+
+func (st *mockState) DetachStorage(storage names.StorageTag) error {
+	return st.detachStorage(storage)
+}
+
+func (st *mockState) ImportStorage(storageName string, info state.VolumeInfo) (names.StorageTag, error) {
+	return st.importStorage(storageName, info)
+}
+
+func (st *mockState) EnvironConfig() (*config.Config, error) {
+	return st.environConfig()
+}
+
 type mockNotifyWatcher struct {
 	state.NotifyWatcher
 	changes chan struct{}
@@ -380,15 +476,53 @@ func (m *mockVolume) Info() (state.VolumeInfo, error) {
 	return state.VolumeInfo{}, errors.NotProvisionedf("%v", m.tag)
 }
 
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id     string
+	volume names.VolumeTag
+	info   *state.VolumeSnapshotInfo
+}
+
+func (m *mockVolumeSnapshot) Id() string {
+	return m.id
+}
+
+func (m *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return m.volume
+}
+
+func (m *mockVolumeSnapshot) Life() state.Life {
+	return state.Alive
+}
+
+func (m *mockVolumeSnapshot) Info() (state.VolumeSnapshotInfo, error) {
+	if m.info == nil {
+		return state.VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", m.id)
+	}
+	return *m.info, nil
+}
+
 type mockFilesystem struct {
 	state.Filesystem
-	tag names.FilesystemTag
+	tag    names.FilesystemTag
+	volume names.VolumeTag
 }
 
 func (m *mockFilesystem) FilesystemTag() names.FilesystemTag {
 	return m.tag
 }
 
+func (m *mockFilesystem) Volume() (names.VolumeTag, error) {
+	if m.volume == (names.VolumeTag{}) {
+		return names.VolumeTag{}, state.ErrNoBackingVolume
+	}
+	return m.volume, nil
+}
+
+func (m *mockFilesystem) Info() (state.FilesystemInfo, error) {
+	return state.FilesystemInfo{}, nil
+}
+
 type mockFilesystemAttachment struct {
 	state.FilesystemAttachment
 	tag names.FilesystemTag
@@ -413,8 +547,8 @@ func (m *mockStorageInstance) Kind() state.StorageKind {
 	return m.kind
 }
 
-func (m *mockStorageInstance) Owner() names.Tag {
-	return m.owner
+func (m *mockStorageInstance) Owner() (names.Tag, bool) {
+	return m.owner, m.owner != nil
 }
 
 func (m *mockStorageInstance) Tag() names.Tag {
@@ -439,7 +573,7 @@ func (m *mockStorageAttachment) StorageInstance() names.StorageTag {
 }
 
 func (m *mockStorageAttachment) Unit() names.UnitTag {
-	return m.storage.Owner().(names.UnitTag)
+	return m.storage.owner.(names.UnitTag)
 }
 
 type mockVolumeAttachment struct {
@@ -0,0 +1,75 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// Resize requests that the volumes backing the specified storage
+// instances be grown to the specified sizes. The volumes are grown
+// by the storage provisioner responsible for each volume, and any
+// filesystems on them are then grown to match.
+// A "CHANGE" block can block this operation.
+func (a *API) Resize(args params.StoragesResize) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	one := func(arg params.StorageResize) error {
+		tag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			return errors.Annotatef(err, "parsing storage tag %v", arg.StorageTag)
+		}
+		volumeTag, err := a.storageInstanceVolumeTag(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return a.storage.ResizeVolume(volumeTag, arg.Size)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Storages)),
+	}
+	for i, arg := range args.Storages {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// storageInstanceVolumeTag returns the tag of the volume backing the
+// specified storage instance, or an error satisfying
+// errors.IsNotSupported if the storage instance is not backed by a
+// volume.
+func (a *API) storageInstanceVolumeTag(tag names.StorageTag) (names.VolumeTag, error) {
+	storageInstance, err := a.storage.StorageInstance(tag)
+	if err != nil {
+		return names.VolumeTag{}, errors.Trace(err)
+	}
+	if storageInstance.Kind() == state.StorageKindBlock {
+		volume, err := a.storage.StorageInstanceVolume(tag)
+		if err != nil {
+			return names.VolumeTag{}, errors.Trace(err)
+		}
+		return volume.VolumeTag(), nil
+	}
+	filesystem, err := a.storage.StorageInstanceFilesystem(tag)
+	if err != nil {
+		return names.VolumeTag{}, errors.Trace(err)
+	}
+	volumeTag, err := filesystem.Volume()
+	if errors.Cause(err) == state.ErrNoBackingVolume {
+		return names.VolumeTag{}, errors.NotSupportedf(
+			"resizing storage %q without a backing volume", tag.Id(),
+		)
+	} else if err != nil {
+		return names.VolumeTag{}, errors.Trace(err)
+	}
+	return volumeTag, nil
+}
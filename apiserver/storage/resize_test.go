@@ -0,0 +1,92 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type resizeSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&resizeSuite{})
+
+func (s *resizeSuite) resize(c *gc.C, size uint64) params.ErrorResults {
+	results, err := s.api.Resize(params.StoragesResize{
+		Storages: []params.StorageResize{{StorageTag: s.storageTag.String(), Size: size}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return results
+}
+
+func (s *resizeSuite) TestResizeBlock(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	var resizedTo uint64
+	s.state.resizeVolume = func(volume names.VolumeTag, size uint64) error {
+		s.calls = append(s.calls, resizeVolumeCall)
+		c.Assert(volume, gc.Equals, s.volumeTag)
+		resizedTo = size
+		return nil
+	}
+	results := s.resize(c, 2048)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	c.Assert(resizedTo, gc.Equals, uint64(2048))
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceVolumeCall,
+		resizeVolumeCall,
+	})
+}
+
+func (s *resizeSuite) TestResizeFilesystem(c *gc.C) {
+	s.state.storageInstanceFilesystem = func(names.StorageTag) (state.Filesystem, error) {
+		s.calls = append(s.calls, storageInstanceFilesystemCall)
+		return &mockFilesystem{volume: s.volumeTag}, nil
+	}
+	results := s.resize(c, 2048)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceFilesystemCall,
+		resizeVolumeCall,
+	})
+}
+
+func (s *resizeSuite) TestResizeFilesystemNoBackingVolume(c *gc.C) {
+	results := s.resize(c, 2048)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`resizing storage "data/0" without a backing volume not supported`,
+	)
+}
+
+func (s *resizeSuite) TestResizeInvalidStorageTag(c *gc.C) {
+	results, err := s.api.Resize(params.StoragesResize{
+		Storages: []params.StorageResize{{StorageTag: "volume-22", Size: 2048}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `parsing storage tag volume-22: .*`)
+	s.assertCalls(c, []string{getBlockForTypeCall})
+}
+
+func (s *resizeSuite) TestResizeBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeBlocked")
+	_, err := s.api.Resize(params.StoragesResize{
+		Storages: []params.StorageResize{{StorageTag: s.storageTag.String(), Size: 2048}},
+	})
+	s.assertBlocked(c, err, "TestResizeBlocked")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/names"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -44,6 +45,12 @@ type storageAccess interface {
 	// WatchVolumeAttachment is required for storage functionality.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
+	// WatchFilesystem is required for storage functionality.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
+	// WatchVolume is required for storage functionality.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
+
 	// EnvName is required for pool functionality.
 	EnvName() (string, error)
 
@@ -62,6 +69,30 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// AddVolumeSnapshot is required for volume snapshot functionality.
+	AddVolumeSnapshot(volume names.VolumeTag) (state.VolumeSnapshot, error)
+
+	// VolumeSnapshots is required for volume snapshot functionality.
+	VolumeSnapshots(volume names.VolumeTag) ([]state.VolumeSnapshot, error)
+
+	// AllVolumeSnapshots is required for volume snapshot functionality.
+	AllVolumeSnapshots() ([]state.VolumeSnapshot, error)
+
+	// DestroyVolumeSnapshot is required for volume snapshot functionality.
+	DestroyVolumeSnapshot(id string) error
+
+	// ResizeVolume is required for storage resize functionality.
+	ResizeVolume(volume names.VolumeTag, size uint64) error
+
+	// DetachStorage is required for storage detach functionality.
+	DetachStorage(storage names.StorageTag) error
+
+	// ImportStorage is required for storage import functionality.
+	ImportStorage(storageName string, info state.VolumeInfo) (names.StorageTag, error)
+
+	// EnvironConfig is required for storage import functionality.
+	EnvironConfig() (*config.Config, error)
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
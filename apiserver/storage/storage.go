@@ -212,12 +212,18 @@ func (api *API) getStorageInstance(tag names.StorageTag) (bool, params.StorageDe
 
 func createParamsStorageInstance(si state.StorageInstance, persistent bool) params.StorageDetails {
 	result := params.StorageDetails{
-		OwnerTag:   si.Owner().String(),
 		StorageTag: si.Tag().String(),
 		Kind:       params.StorageKind(si.Kind()),
 		Status:     "pending",
 		Persistent: persistent,
 	}
+	if owner, ok := si.Owner(); ok {
+		result.OwnerTag = owner.String()
+	} else {
+		// The storage has been detached from its unit, and
+		// may be attached to a new one.
+		result.Status = "detached"
+	}
 	return result
 }
 
@@ -478,7 +484,7 @@ func (a *API) convertStateVolumeToParams(st state.Volume) (params.VolumeInstance
 				storage, volume.VolumeTag)
 			return params.VolumeInstance{}, err
 		}
-		owner := storageInstance.Owner()
+		owner, _ := storageInstance.Owner()
 		// only interested in Unit for now
 		if unitTag, ok := owner.(names.UnitTag); ok {
 			volume.UnitTag = unitTag.String()
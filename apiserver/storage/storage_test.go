@@ -75,6 +75,23 @@ func (s *storageSuite) TestStorageListVolume(c *gc.C) {
 	s.assertInstanceInfoError(c, found.Results[0], wantedDetails, "")
 }
 
+func (s *storageSuite) TestStorageListDetached(c *gc.C) {
+	s.storageInstance.owner = nil
+	s.state.storageInstanceAttachments = func(tag names.StorageTag) ([]state.StorageAttachment, error) {
+		s.calls = append(s.calls, storageInstanceAttachmentsCall)
+		return nil, nil
+	}
+	found, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCalls(c, []string{allStorageInstancesCall, storageInstanceAttachmentsCall})
+
+	c.Assert(found.Results, gc.HasLen, 1)
+	wantedDetails := s.createTestStorageInfo()
+	wantedDetails.OwnerTag = ""
+	wantedDetails.Status = "detached"
+	s.assertInstanceInfoError(c, found.Results[0], wantedDetails, "")
+}
+
 func (s *storageSuite) TestStorageListError(c *gc.C) {
 	msg := "list test error"
 	s.state.allStorageInstances = func() ([]state.StorageInstance, error) {
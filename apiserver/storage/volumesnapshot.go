@@ -0,0 +1,105 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// CreateVolumeSnapshots records new snapshots of the specified volumes.
+// The snapshots are taken by the storage provisioner responsible for
+// each volume.
+// A "CHANGE" block can block this operation.
+func (a *API) CreateVolumeSnapshots(args params.Entities) (params.VolumeSnapshotResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.VolumeSnapshotResults{}, errors.Trace(err)
+	}
+	one := func(arg params.Entity) (params.VolumeSnapshot, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil {
+			return params.VolumeSnapshot{}, errors.Annotatef(err, "parsing volume tag %v", arg.Tag)
+		}
+		snapshot, err := a.storage.AddVolumeSnapshot(tag)
+		if err != nil {
+			return params.VolumeSnapshot{}, errors.Trace(err)
+		}
+		return common.VolumeSnapshotFromState(snapshot), nil
+	}
+	results := params.VolumeSnapshotResults{
+		Results: make([]params.VolumeSnapshotResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		snapshot, err := one(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = snapshot
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots lists the snapshots of the volumes in the filter,
+// or of all volumes if the filter is empty.
+func (a *API) ListVolumeSnapshots(filter params.VolumeSnapshotFilter) (params.VolumeSnapshotResults, error) {
+	if len(filter.Volumes) == 0 {
+		all, err := a.storage.AllVolumeSnapshots()
+		if err != nil {
+			return params.VolumeSnapshotResults{}, common.ServerError(err)
+		}
+		return volumeSnapshotResults(all), nil
+	}
+	var results params.VolumeSnapshotResults
+	for _, volume := range filter.Volumes {
+		tag, err := names.ParseVolumeTag(volume)
+		if err != nil {
+			results.Results = append(results.Results, params.VolumeSnapshotResult{
+				Error: common.ServerError(errors.Annotatef(err, "parsing volume tag %v", volume)),
+			})
+			continue
+		}
+		snapshots, err := a.storage.VolumeSnapshots(tag)
+		if err != nil {
+			results.Results = append(results.Results, params.VolumeSnapshotResult{
+				Error: common.ServerError(errors.Annotatef(err, "getting snapshots of volume %v", tag.Id())),
+			})
+			continue
+		}
+		results.Results = append(results.Results, volumeSnapshotResults(snapshots).Results...)
+	}
+	return results, nil
+}
+
+func volumeSnapshotResults(snapshots []state.VolumeSnapshot) params.VolumeSnapshotResults {
+	results := make([]params.VolumeSnapshotResult, len(snapshots))
+	for i, snapshot := range snapshots {
+		results[i].Result = common.VolumeSnapshotFromState(snapshot)
+	}
+	return params.VolumeSnapshotResults{Results: results}
+}
+
+// DestroyVolumeSnapshots marks the specified volume snapshots as Dying.
+// The snapshots are removed once the storage provisioner has destroyed
+// them.
+// A "CHANGE" block can block this operation.
+func (a *API) DestroyVolumeSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		err := a.storage.DestroyVolumeSnapshot(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
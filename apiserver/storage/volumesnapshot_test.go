@@ -0,0 +1,124 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type volumeSnapshotSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&volumeSnapshotSuite{})
+
+func (s *volumeSnapshotSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	results, err := s.api.CreateVolumeSnapshots(params.Entities{
+		Entities: []params.Entity{{s.volumeTag.String()}, {"machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotResults{
+		Results: []params.VolumeSnapshotResult{{
+			Result: params.VolumeSnapshot{
+				Id:        "22@0",
+				VolumeTag: "volume-22",
+				Life:      "alive",
+			},
+		}, {
+			Error: &params.Error{Message: `parsing volume tag machine-0: "machine-0" is not a valid volume tag`},
+		}},
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, addVolumeSnapshotCall})
+}
+
+func (s *volumeSnapshotSuite) TestCreateVolumeSnapshotsError(c *gc.C) {
+	s.state.addVolumeSnapshot = func(volume names.VolumeTag) (state.VolumeSnapshot, error) {
+		return nil, errors.New("volume not provisioned")
+	}
+	results, err := s.api.CreateVolumeSnapshots(params.Entities{
+		Entities: []params.Entity{{s.volumeTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "volume not provisioned")
+}
+
+func (s *volumeSnapshotSuite) TestCreateVolumeSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateVolumeSnapshotsBlocked")
+	_, err := s.api.CreateVolumeSnapshots(params.Entities{
+		Entities: []params.Entity{{s.volumeTag.String()}},
+	})
+	s.assertBlocked(c, err, "TestCreateVolumeSnapshotsBlocked")
+}
+
+func (s *volumeSnapshotSuite) TestListVolumeSnapshots(c *gc.C) {
+	created := time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC)
+	s.volumeSnapshot.info = &state.VolumeSnapshotInfo{
+		SnapshotId: "snap-22",
+		Size:       1024,
+		Created:    created,
+	}
+	expected := params.VolumeSnapshotResults{
+		Results: []params.VolumeSnapshotResult{{
+			Result: params.VolumeSnapshot{
+				Id:         "22@0",
+				VolumeTag:  "volume-22",
+				Life:       "alive",
+				SnapshotId: "snap-22",
+				Size:       1024,
+				Created:    created,
+			},
+		}},
+	}
+
+	results, err := s.api.ListVolumeSnapshots(params.VolumeSnapshotFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+	s.assertCalls(c, []string{allVolumeSnapshotsCall})
+
+	s.calls = nil
+	results, err = s.api.ListVolumeSnapshots(params.VolumeSnapshotFilter{
+		Volumes: []string{s.volumeTag.String()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+	s.assertCalls(c, []string{volumeSnapshotsCall})
+}
+
+func (s *volumeSnapshotSuite) TestListVolumeSnapshotsInvalidVolume(c *gc.C) {
+	results, err := s.api.ListVolumeSnapshots(params.VolumeSnapshotFilter{
+		Volumes: []string{"unit-mysql-0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `parsing volume tag unit-mysql-0: .*`)
+	s.assertCalls(c, []string{})
+}
+
+func (s *volumeSnapshotSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	results, err := s.api.DestroyVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"22@0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, destroyVolumeSnapshotCall})
+}
+
+func (s *volumeSnapshotSuite) TestDestroyVolumeSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDestroyVolumeSnapshotsBlocked")
+	_, err := s.api.DestroyVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"22@0"},
+	})
+	s.assertBlocked(c, err, "TestDestroyVolumeSnapshotsBlocked")
+}
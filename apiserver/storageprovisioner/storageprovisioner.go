@@ -208,6 +208,18 @@ func (s *StorageProvisionerAPI) WatchFilesystems(args params.Entities) (params.S
 	return s.watchStorageEntities(args, s.st.WatchEnvironFilesystems, s.st.WatchMachineFilesystems)
 }
 
+// WatchVolumeSnapshots watches for changes to snapshots of volumes
+// scoped to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchEnvironVolumeSnapshots, s.st.WatchMachineVolumeSnapshots)
+}
+
+// WatchVolumeResizes watches for requests to grow volumes scoped to
+// the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchEnvironVolumeResizes, s.st.WatchMachineVolumeResizes)
+}
+
 func (s *StorageProvisionerAPI) watchStorageEntities(
 	args params.Entities,
 	watchEnvironStorage func() state.StringsWatcher,
@@ -680,7 +692,7 @@ func (s *StorageProvisionerAPI) FilesystemAttachmentParams(
 		if err != nil {
 			return params.FilesystemAttachmentParams{}, err
 		}
-		var pool string
+		var pool, filesystemId string
 		if filesystemParams, ok := filesystem.Params(); ok {
 			pool = filesystemParams.Pool
 		} else {
@@ -689,28 +701,34 @@ func (s *StorageProvisionerAPI) FilesystemAttachmentParams(
 				return params.FilesystemAttachmentParams{}, err
 			}
 			pool = filesystemInfo.Pool
+			filesystemId = filesystemInfo.FilesystemId
 		}
 		providerType, _, err := common.StoragePoolConfig(pool, poolManager)
 		if err != nil {
 			return params.FilesystemAttachmentParams{}, errors.Trace(err)
 		}
-		filesystemAttachmentParams, ok := filesystemAttachment.Params()
-		if !ok {
-			return params.FilesystemAttachmentParams{}, errors.Errorf(
-				"filesystem %q is already attached to machine %q",
-				filesystemAttachment.Filesystem().Id(),
-				filesystemAttachment.Machine().Id(),
-			)
+		// TODO(axw) dealias MountPoint. We now have
+		// Path, MountPoint and Location in different
+		// parts of the codebase.
+		var mountPoint string
+		if filesystemAttachmentParams, ok := filesystemAttachment.Params(); ok {
+			mountPoint = filesystemAttachmentParams.Location
+		} else {
+			// The filesystem is attached; the parameters are
+			// required for detaching it from the machine.
+			filesystemAttachmentInfo, err := filesystemAttachment.Info()
+			if err != nil {
+				return params.FilesystemAttachmentParams{}, err
+			}
+			mountPoint = filesystemAttachmentInfo.MountPoint
 		}
 		return params.FilesystemAttachmentParams{
 			filesystemAttachment.Filesystem().String(),
 			filesystemAttachment.Machine().String(),
 			string(instanceId),
 			string(providerType),
-			// TODO(axw) dealias MountPoint. We now have
-			// Path, MountPoint and Location in different
-			// parts of the codebase.
-			filesystemAttachmentParams.Location,
+			mountPoint,
+			filesystemId,
 		}, nil
 	}
 	for i, arg := range args.Ids {
@@ -826,6 +844,14 @@ func (s *StorageProvisionerAPI) SetVolumeInfo(args params.Volumes) (params.Error
 		} else if !canAccessVolume(volumeTag) {
 			return common.ErrPerm
 		}
+		// The pool is recorded by state when the volume is first
+		// provisioned. Carry it over when updating the info of a
+		// provisioned volume, e.g. after it has been resized.
+		if volume, err := s.st.Volume(volumeTag); err == nil {
+			if oldInfo, err := volume.Info(); err == nil {
+				volumeInfo.Pool = oldInfo.Pool
+			}
+		}
 		err = s.st.SetVolumeInfo(volumeTag, volumeInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -855,6 +881,14 @@ func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (para
 		} else if !canAccessFilesystem(filesystemTag) {
 			return common.ErrPerm
 		}
+		// The pool is recorded by state when the filesystem is first
+		// provisioned. Carry it over when updating the info of a
+		// provisioned filesystem, e.g. after it has been grown.
+		if filesystem, err := s.st.Filesystem(filesystemTag); err == nil {
+			if oldInfo, err := filesystem.Info(); err == nil {
+				filesystemInfo.Pool = oldInfo.Pool
+			}
+		}
 		err = s.st.SetFilesystemInfo(filesystemTag, filesystemInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -980,3 +1014,206 @@ func (s *StorageProvisionerAPI) AttachmentLife(args params.MachineStorageIds) (p
 	}
 	return results, nil
 }
+
+// RemoveAttachment removes the specified machine storage attachments
+// from state, once they have been detached by the storage provisioner.
+func (s *StorageProvisionerAPI) RemoveAttachment(args params.MachineStorageIds) (params.ErrorResults, error) {
+	canAccess, err := s.getAttachmentAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	one := func(arg params.MachineStorageId) error {
+		machineTag, err := names.ParseMachineTag(arg.MachineTag)
+		if err != nil {
+			return err
+		}
+		attachmentTag, err := names.ParseTag(arg.AttachmentTag)
+		if err != nil {
+			return err
+		}
+		if !canAccess(machineTag, attachmentTag) {
+			return common.ErrPerm
+		}
+		switch attachmentTag := attachmentTag.(type) {
+		case names.VolumeTag:
+			return s.st.RemoveVolumeAttachment(machineTag, attachmentTag)
+		case names.FilesystemTag:
+			return s.st.RemoveFilesystemAttachment(machineTag, attachmentTag)
+		}
+		return common.ErrPerm
+	}
+	for i, arg := range args.Ids {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// oneVolumeSnapshot returns the volume snapshot with the specified ID,
+// if the authenticated entity may access the snapshot's volume.
+func (s *StorageProvisionerAPI) oneVolumeSnapshot(id string, canAccess common.AuthFunc) (state.VolumeSnapshot, error) {
+	volumeTag, err := state.ParseVolumeSnapshotId(id)
+	if err != nil || !canAccess(volumeTag) {
+		return nil, common.ErrPerm
+	}
+	return s.st.VolumeSnapshot(id)
+}
+
+// VolumeSnapshotParams returns the parameters for taking, or destroying,
+// the volume snapshots with the specified IDs.
+func (s *StorageProvisionerAPI) VolumeSnapshotParams(args params.VolumeSnapshotIds) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, common.ServerError(common.ErrPerm)
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Ids)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(id string) (params.VolumeSnapshotParams, error) {
+		snapshot, err := s.oneVolumeSnapshot(id, canAccess)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		volume, err := s.st.Volume(snapshot.Volume())
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		providerType, _, err := common.StoragePoolConfig(volumeInfo.Pool, poolManager)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, errors.Trace(err)
+		}
+		result := params.VolumeSnapshotParams{
+			Id:        snapshot.Id(),
+			VolumeTag: snapshot.Volume().String(),
+			VolumeId:  volumeInfo.VolumeId,
+			Provider:  string(providerType),
+			Life:      params.Life(snapshot.Life().String()),
+		}
+		if info, err := snapshot.Info(); err == nil {
+			result.SnapshotId = info.SnapshotId
+		}
+		return result, nil
+	}
+	for i, id := range args.Ids {
+		var result params.VolumeSnapshotParamsResult
+		snapshotParams, err := one(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshotParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (s *StorageProvisionerAPI) SetVolumeSnapshotInfo(args params.VolumeSnapshots) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Snapshots)),
+	}
+	one := func(arg params.VolumeSnapshot) error {
+		if _, err := s.oneVolumeSnapshot(arg.Id, canAccess); err != nil {
+			return errors.Trace(err)
+		}
+		return s.st.SetVolumeSnapshotInfo(arg.Id, state.VolumeSnapshotInfo{
+			SnapshotId: arg.SnapshotId,
+			Size:       arg.Size,
+			Created:    arg.Created,
+		})
+	}
+	for i, arg := range args.Snapshots {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveVolumeSnapshots removes the specified Dying volume snapshots
+// from state, once they have been destroyed.
+func (s *StorageProvisionerAPI) RemoveVolumeSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	one := func(id string) error {
+		volumeTag, err := state.ParseVolumeSnapshotId(id)
+		if err != nil || !canAccess(volumeTag) {
+			return common.ErrPerm
+		}
+		return s.st.RemoveVolumeSnapshot(id)
+	}
+	for i, id := range args.Ids {
+		err := one(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// VolumeResizeParams returns the parameters for growing the volumes
+// with the specified tags. If a volume is not waiting to be resized,
+// the size in its result will be zero.
+func (s *StorageProvisionerAPI) VolumeResizeParams(args params.Entities) (params.VolumeResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeResizeParamsResults{}, err
+	}
+	results := params.VolumeResizeParamsResults{
+		Results: make([]params.VolumeResizeParamsResult, len(args.Entities)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(arg params.Entity) (params.VolumeResizeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		providerType, _, err := common.StoragePoolConfig(volumeInfo.Pool, poolManager)
+		if err != nil {
+			return params.VolumeResizeParams{}, errors.Trace(err)
+		}
+		desiredSize, _ := volume.DesiredSize()
+		return params.VolumeResizeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  volumeInfo.VolumeId,
+			Provider:  string(providerType),
+			Size:      desiredSize,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
@@ -5,6 +5,7 @@ package storageprovisioner_test
 
 import (
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -429,6 +430,7 @@ func (s *provisionerSuite) TestFilesystemAttachmentParams(c *gc.C) {
 				InstanceId:    "inst-id",
 				Provider:      "machinescoped",
 				MountPoint:    "/srv",
+				FilesystemId:  "abc",
 			}},
 			{Result: params.FilesystemAttachmentParams{
 				MachineTag:    "machine-0",
@@ -860,6 +862,102 @@ func (s *provisionerSuite) TestAttachmentLife(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestRemoveAttachment(c *gc.C) {
+	s.setupVolumes(c)
+	s.authorizer.EnvironManager = true
+
+	err := s.State.DetachVolume(names.NewMachineTag("0"), names.NewVolumeTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.RemoveAttachment(params.MachineStorageIds{
+		Ids: []params.MachineStorageId{{
+			MachineTag:    "machine-0",
+			AttachmentTag: "volume-1",
+		}, {
+			MachineTag:    "machine-0",
+			AttachmentTag: "volume-2",
+		}, {
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-1",
+		}, {
+			MachineTag:    "unit-mysql-0",
+			AttachmentTag: "volume-1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "cannot remove attachment of volume 2 from machine 0: volume attachment is not dying"}},
+			{},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`}},
+		},
+	})
+	_, err = s.State.VolumeAttachment(names.NewMachineTag("0"), names.NewVolumeTag("1"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *provisionerSuite) TestRemoveFilesystemAttachment(c *gc.C) {
+	s.setupFilesystems(c)
+	s.authorizer.EnvironManager = true
+
+	err := s.State.DetachFilesystem(names.NewMachineTag("0"), names.NewFilesystemTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.RemoveAttachment(params.MachineStorageIds{
+		Ids: []params.MachineStorageId{{
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-1",
+		}, {
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-2",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "cannot remove attachment of filesystem 2 from machine 0: filesystem attachment is not dying"}},
+		},
+	})
+	_, err = s.State.FilesystemAttachment(names.NewMachineTag("0"), names.NewFilesystemTag("1"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *provisionerSuite) TestFilesystemAttachmentParamsAttached(c *gc.C) {
+	s.setupFilesystems(c)
+	s.authorizer.EnvironManager = true
+
+	err := s.State.SetFilesystemAttachmentInfo(
+		names.NewMachineTag("0"),
+		names.NewFilesystemTag("2"),
+		state.FilesystemAttachmentInfo{MountPoint: "/srv/def"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The parameters of attached filesystems are
+	// required for detaching them.
+	results, err := s.api.FilesystemAttachmentParams(params.MachineStorageIds{
+		Ids: []params.MachineStorageId{{
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-2",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.FilesystemAttachmentParamsResults{
+		Results: []params.FilesystemAttachmentParamsResult{
+			{Result: params.FilesystemAttachmentParams{
+				MachineTag:    "machine-0",
+				FilesystemTag: "filesystem-2",
+				InstanceId:    "inst-id",
+				Provider:      "environscoped",
+				MountPoint:    "/srv/def",
+				FilesystemId:  "def",
+			}},
+		},
+	})
+}
+
 func (s *provisionerSuite) TestEnsureDead(c *gc.C) {
 	s.setupVolumes(c)
 	args := params.Entities{Entities: []params.Entity{{"volume-0-0"}, {"volume-1"}, {"volume-42"}}}
@@ -922,3 +1020,229 @@ func (b byMachineAndEntity) Less(i, j int) bool {
 func (b byMachineAndEntity) Swap(i, j int) {
 	b[i], b[j] = b[j], b[i]
 }
+
+func (s *provisionerSuite) setupVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *provisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.EnvironTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0@0"}},
+			{StringsWatcherId: "2", Changes: []string{"2@1"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+	defer statetesting.AssertStop(c, s.resources.Get("2"))
+
+	wc := statetesting.NewStringsWatcherC(c, s.State, w.(state.StringsWatcher))
+	wc.AssertNoChange()
+	err = s.State.DestroyVolumeSnapshot("0/0@0")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0@0")
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	err := s.State.SetVolumeSnapshotInfo("2@1", state.VolumeSnapshotInfo{SnapshotId: "snap-def"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{"0/0@0", "2@1", "42@9", "nonsense"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{
+			{Result: params.VolumeSnapshotParams{
+				Id:        "0/0@0",
+				VolumeTag: "volume-0-0",
+				VolumeId:  "abc",
+				Provider:  "machinescoped",
+				Life:      params.Alive,
+			}},
+			{Result: params.VolumeSnapshotParams{
+				Id:         "2@1",
+				VolumeTag:  "volume-2",
+				VolumeId:   "def",
+				Provider:   "environscoped",
+				Life:       params.Alive,
+				SnapshotId: "snap-def",
+			}},
+			{Error: common.ServerError(errors.NotFoundf(`volume snapshot "42@9"`))},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	created := time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC)
+	results, err := s.api.SetVolumeSnapshotInfo(params.VolumeSnapshots{
+		Snapshots: []params.VolumeSnapshot{{
+			Id:         "0/0@0",
+			SnapshotId: "snap-abc",
+			Size:       1024,
+			Created:    created,
+		}, {
+			Id: "2@1",
+		}, {
+			Id:         "nonsense",
+			SnapshotId: "snap-xyz",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `cannot set info for volume snapshot "2@1": snapshot ID not set`}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	snapshot, err := s.State.VolumeSnapshot("0/0@0")
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.SnapshotId, gc.Equals, "snap-abc")
+	c.Assert(info.Size, gc.Equals, uint64(1024))
+}
+
+func (s *provisionerSuite) TestRemoveVolumeSnapshots(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	err := s.State.DestroyVolumeSnapshot("0/0@0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.RemoveVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"0/0@0", "2@1", "nonsense"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `cannot remove volume snapshot "2@1": volume snapshot is not dying`}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	_, err = s.State.VolumeSnapshot("0/0@0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.EnvironTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{}},
+			{StringsWatcherId: "2", Changes: []string{"2"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+	defer statetesting.AssertStop(c, s.resources.Get("2"))
+
+	wc := statetesting.NewStringsWatcherC(c, s.State, w.(state.StringsWatcher))
+	wc.AssertNoChange()
+	err = s.State.ResizeVolume(names.NewVolumeTag("0/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+}
+
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-0-0"},
+			{"volume-2"},
+			{"volume-1"},
+			{"volume-42"},
+			{"machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeResizeParamsResults{
+		Results: []params.VolumeResizeParamsResult{
+			{Result: params.VolumeResizeParams{
+				VolumeTag: "volume-0-0",
+				VolumeId:  "abc",
+				Provider:  "machinescoped",
+			}},
+			{Result: params.VolumeResizeParams{
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Provider:  "environscoped",
+				Size:      8192,
+			}},
+			{Error: common.ServerError(errors.NotProvisionedf(`volume "1"`))},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeInfoResized(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.SetVolumeInfo(params.Volumes{
+		Volumes: []params.Volume{{
+			VolumeTag:  "volume-2",
+			VolumeId:   "def",
+			HardwareId: "456",
+			Size:       8192,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+
+	volume, err := s.State.Volume(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeInfo{
+		HardwareId: "456",
+		VolumeId:   "def",
+		Pool:       "environscoped",
+		Size:       8192,
+	})
+	_, resizing := volume.DesiredSize()
+	c.Assert(resizing, jc.IsFalse)
+}
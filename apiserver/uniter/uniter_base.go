@@ -429,6 +429,11 @@ func (u *uniterBaseAPI) CharmURL(args params.Entities) (params.StringBoolResults
 					CharmURL() (*charm.URL, bool)
 				})
 				curl, ok := charmURLer.CharmURL()
+				if service, isService := unitOrService.(*state.Service); isService {
+					// During a rolling upgrade, the unit may be
+					// held at the charm it is upgrading from.
+					curl, ok = service.UnitCharmURL(u.unit.Name())
+				}
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
@@ -824,6 +829,7 @@ func (u *uniterBaseAPI) Actions(args params.Entities) (params.ActionsQueryResult
 		results.Results[i].Action.Action = &params.Action{
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		}
 	}
 
@@ -904,6 +910,8 @@ func paramsActionExecutionResultsToStateActionResults(arg params.ActionExecution
 		status = state.ActionFailed
 	case params.ActionPending:
 		status = state.ActionPending
+	case params.ActionTimedOut:
+		status = state.ActionTimedOut
 	default:
 		return state.ActionResults{}, errors.Errorf("unrecognized action status '%s'", arg.Status)
 	}
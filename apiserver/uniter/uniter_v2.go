@@ -61,6 +61,106 @@ func (u *UniterAPIV2) AddMetricBatches(args params.MetricBatchParams) (params.Er
 	return result, nil
 }
 
+// ActionStatus returns the status of each of the given actions, so
+// that a unit can tell when an action it is running has been
+// cancelled.
+func (u *UniterAPIV2) ActionStatus(args params.Entities) (params.StringResults, error) {
+	actionFn, err := u.authAndActionFromTagFn()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = string(action.Status())
+	}
+	return results, nil
+}
+
+// LogActionsMessages records the given progress messages against the
+// running actions they are for.
+func (u *UniterAPIV2) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	actionFn, err := u.authAndActionFromTagFn()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Messages)),
+	}
+	for i, arg := range args.Messages {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Error = common.ServerError(action.Log(arg.Value))
+	}
+	return results, nil
+}
+
+// HookTimeouts returns how long the units of each of the given services
+// may run a hook before it is killed. Zero means hooks are never killed.
+func (u *UniterAPIV2) HookTimeouts(args params.Entities) (params.DurationResults, error) {
+	canAccess, err := u.accessService()
+	if err != nil {
+		return params.DurationResults{}, err
+	}
+	results := params.DurationResults{
+		Results: make([]params.DurationResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseServiceTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := u.getService(tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = service.HookTimeout()
+	}
+	return results, nil
+}
+
+// AddHookRuns records the runs of hooks, actions and commands by the
+// specified units.
+func (u *UniterAPIV2) AddHookRuns(args params.HookRunsParams) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Units)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, unitRuns := range args.Units {
+		tag, err := names.ParseUnitTag(unitRuns.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		runs := make([]state.HookRun, len(unitRuns.Runs))
+		for j, run := range unitRuns.Runs {
+			runs[j] = state.HookRun{
+				Kind:     state.HookRunKind(run.Kind),
+				Name:     run.Name,
+				Started:  run.Started,
+				Finished: run.Finished,
+				Status:   state.HookRunStatus(run.Status),
+			}
+		}
+		result.Results[i].Error = common.ServerError(u.unit.AddHookRuns(runs))
+	}
+	return result, nil
+}
+
 // NewUniterAPIV2 creates a new instance of the Uniter API, version 2.
 func NewUniterAPIV2(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*UniterAPIV2, error) {
 	baseAPI, err := NewUniterAPIV1(st, resources, authorizer)
@@ -141,6 +141,188 @@ func (s *uniterV2Suite) TestUnitStatus(c *gc.C) {
 	})
 }
 
+func (s *uniterV2Suite) TestActionStatus(c *gc.C) {
+	running, err := s.wordpressUnit.AddActionWithTimeout("fakeaction", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = running.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	cancelled, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.wordpressUnit.CancelAction(cancelled)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: running.Tag().String()},
+			{Tag: cancelled.Tag().String()},
+			{Tag: other.Tag().String()},
+			{Tag: "invalid"},
+		}}
+	result, err := s.uniter.ActionStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: params.ActionRunning},
+			{Result: params.ActionCancelled},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ServerError(`"invalid" is not a valid tag`)},
+		},
+	})
+}
+
+func (s *uniterV2Suite) TestHookTimeouts(c *gc.C) {
+	err := s.wordpress.SetHookTimeout(5 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "service-wordpress"},
+		{Tag: "service-mysql"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "invalid"},
+	}}
+	result, err := s.uniter.HookTimeouts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.DurationResults{
+		Results: []params.DurationResult{
+			{Result: 5 * time.Minute},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterV2Suite) TestAddHookRuns(c *gc.C) {
+	started := time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC)
+	run := params.HookRun{
+		Kind:     "hook",
+		Name:     "config-changed",
+		Started:  started,
+		Finished: started.Add(3 * time.Second),
+		Status:   "completed",
+	}
+	args := params.HookRunsParams{Units: []params.UnitHookRuns{
+		{Tag: "unit-wordpress-0", Runs: []params.HookRun{run}},
+		{Tag: "unit-mysql-0", Runs: []params.HookRun{run}},
+		{Tag: "unit-wordpress-0", Runs: []params.HookRun{{Kind: "hook", Name: "install", Status: "bad"}}},
+		{Tag: "invalid"},
+	}}
+	result, err := s.uniter.AddHookRuns(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{&params.Error{Message: `cannot add hook runs for unit "wordpress/0": hook run status "bad" not valid`}},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	runs, err := s.wordpress.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, jc.DeepEquals, []state.HookRun{{
+		Unit:     "wordpress/0",
+		Kind:     state.HookRunHook,
+		Name:     "config-changed",
+		Started:  started,
+		Finished: started.Add(3 * time.Second),
+		Status:   state.HookRunCompleted,
+	}})
+}
+
+func (s *uniterV2Suite) TestLogActionsMessages(c *gc.C) {
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	running, err := a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{
+			{Tag: running.Tag().String(), Value: "migrating table 1 of 2"},
+			{Tag: pending.Tag().String(), Value: "too soon"},
+			{Tag: other.Tag().String(), Value: "not mine"},
+			{Tag: "invalid", Value: "invalid"},
+		}}
+	result, err := s.uniter.LogActionsMessages(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, "cannot log message to action .*: action is not running")
+	c.Assert(result.Results[2].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[3].Error, gc.DeepEquals, apiservertesting.ServerError(`"invalid" is not a valid tag`))
+
+	running, err = s.State.Action(running.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := running.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "migrating table 1 of 2")
+}
+
+func (s *uniterV2Suite) TestActionsTimeout(c *gc.C) {
+	action, err := s.wordpressUnit.AddActionWithTimeout("fakeaction", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: action.Tag().String()}},
+	}
+	results, err := s.uniter.Actions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Action.Action.Timeout, gc.Equals, time.Minute)
+}
+
+func (s *uniterV2Suite) TestFinishActionsTimedOut(c *gc.C) {
+	action, err := s.wordpressUnit.AddActionWithTimeout("fakeaction", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.uniter.FinishActions(params.ActionExecutionResults{
+		Results: []params.ActionExecutionResult{{
+			ActionTag: action.Tag().String(),
+			Status:    params.ActionTimedOut,
+			Message:   "action timed out after 1m0s",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+
+	action, err = s.State.ActionByTag(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Status(), gc.Equals, state.ActionTimedOut)
+}
+
+func (s *uniterV2Suite) TestCharmURLRollingUpgrade(c *gc.C) {
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err := s.wordpress.SetCharmRolling(newCharm, false, 1, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "service-wordpress"}}}
+	result, err := s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringBoolResults{
+		Results: []params.StringBoolResult{{Result: s.wpCharm.String(), Ok: false}},
+	})
+
+	// Once released, the unit sees the new charm.
+	err = s.wordpress.ReleaseRollingUpgradeUnits(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringBoolResults{
+		Results: []params.StringBoolResult{{Result: newCharm.String(), Ok: false}},
+	})
+}
+
 type unitMetricBatchesSuite struct {
 	uniterBaseSuite
 	uniter *uniter.UniterAPIV2
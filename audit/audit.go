@@ -6,7 +6,9 @@ package audit
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 )
 
@@ -33,3 +35,91 @@ func Audit(user Tagger, format string, args ...interface{}) {
 	// which incorrectly flags the Logf call.
 	logger.LogCallf(1, loggo.INFO, fmt.Sprintf("%s: %s", user.Tag(), format), args...)
 }
+
+// Outcome describes how an audited operation completed.
+type Outcome string
+
+const (
+	// Success indicates that the audited operation completed
+	// without error.
+	Success Outcome = "success"
+
+	// Failure indicates that the audited operation returned
+	// an error.
+	Failure Outcome = "failure"
+)
+
+// AuditEntry holds the structured record of a single auditable
+// operation.
+type AuditEntry struct {
+	// Timestamp records when the operation completed.
+	Timestamp time.Time
+
+	// EnvUUID identifies the environment the operation was
+	// performed against.
+	EnvUUID string
+
+	// User holds the canonical name of the user that performed the
+	// operation (e.g. "bob@local").
+	User string
+
+	// Facade, Version and Method identify the API call that
+	// was made.
+	Facade  string
+	Version int
+	Method  string
+
+	// Args holds a summary of the arguments passed to the call.
+	Args string
+
+	// Entities holds the tags of the entities that were named in
+	// the call arguments.
+	Entities []string
+
+	// Outcome records whether the operation succeeded.
+	Outcome Outcome
+
+	// Error holds the error message when the operation failed.
+	Error string
+}
+
+// Validate returns an error if the entry is missing any of the
+// information required to record it.
+func (e AuditEntry) Validate() error {
+	if e.User == "" {
+		return errors.NotValidf("audit entry with blank user")
+	}
+	if e.EnvUUID == "" {
+		return errors.NotValidf("audit entry with blank environment UUID")
+	}
+	if e.Facade == "" || e.Method == "" {
+		return errors.NotValidf("audit entry without API call")
+	}
+	if e.Timestamp.IsZero() {
+		return errors.NotValidf("audit entry without timestamp")
+	}
+	switch e.Outcome {
+	case Success, Failure:
+	default:
+		return errors.NotValidf("audit entry outcome %q", e.Outcome)
+	}
+	return nil
+}
+
+// EntryWriter is implemented by types that can persist audit entries.
+type EntryWriter interface {
+	// AddAuditEntry stores the given entry.
+	AddAuditEntry(entry AuditEntry) error
+}
+
+// Put validates the given entry, records it on the audit logger and
+// then stores it using the given writer.
+func Put(w EntryWriter, entry AuditEntry) error {
+	if err := entry.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	logger.LogCallf(1, loggo.INFO, "%s: %s(%d).%s %s (%s)",
+		entry.User, entry.Facade, entry.Version, entry.Method, entry.Args, entry.Outcome,
+	)
+	return errors.Trace(w.AddAuditEntry(entry))
+}
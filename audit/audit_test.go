@@ -5,6 +5,7 @@ package audit
 
 import (
 	"testing"
+	"time"
 
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -56,3 +57,69 @@ func (*auditSuite) TestAuditWithEmptyTagWillPanic(c *gc.C) {
 	f := func() { Audit(&mockUser{}, "should never be written") }
 	c.Assert(f, gc.PanicMatches, "user tag cannot be blank")
 }
+
+type mockWriter struct {
+	entries []AuditEntry
+}
+
+func (w *mockWriter) AddAuditEntry(entry AuditEntry) error {
+	w.entries = append(w.entries, entry)
+	return nil
+}
+
+func validEntry() AuditEntry {
+	return AuditEntry{
+		Timestamp: time.Date(2015, 5, 1, 10, 0, 0, 0, time.UTC),
+		EnvUUID:   "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		User:      "user-agnus",
+		Facade:    "Client",
+		Version:   0,
+		Method:    "ServiceDestroy",
+		Args:      `{"ServiceName":"donut"}`,
+		Entities:  []string{"service-donut"},
+		Outcome:   Success,
+	}
+}
+
+func (*auditSuite) TestPutWritesAndLogsEntry(c *gc.C) {
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("audit-log", &tw, loggo.DEBUG), gc.IsNil)
+
+	var w mockWriter
+	err := Put(&w, validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.entries, jc.DeepEquals, []AuditEntry{validEntry()})
+	c.Check(tw.Log(), jc.LogMatches, []jc.SimpleMessage{
+		{loggo.INFO, `user-agnus: Client\(0\).ServiceDestroy {"ServiceName":"donut"} \(success\)`},
+	})
+}
+
+func (*auditSuite) TestPutRejectsInvalidEntries(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*AuditEntry)
+		err    string
+	}{{
+		mutate: func(e *AuditEntry) { e.User = "" },
+		err:    "audit entry with blank user not valid",
+	}, {
+		mutate: func(e *AuditEntry) { e.EnvUUID = "" },
+		err:    "audit entry with blank environment UUID not valid",
+	}, {
+		mutate: func(e *AuditEntry) { e.Method = "" },
+		err:    "audit entry without API call not valid",
+	}, {
+		mutate: func(e *AuditEntry) { e.Timestamp = time.Time{} },
+		err:    "audit entry without timestamp not valid",
+	}, {
+		mutate: func(e *AuditEntry) { e.Outcome = "meh" },
+		err:    `audit entry outcome "meh" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		entry := validEntry()
+		test.mutate(&entry)
+		var w mockWriter
+		err := Put(&w, entry)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(w.entries, gc.HasLen, 0)
+	}
+}
@@ -32,9 +32,11 @@ func NewSuperCommand() cmd.Command {
 			UsagePrefix: "juju",
 			Purpose:     actionPurpose,
 		})
+	actionCmd.Register(envcmd.Wrap(&CancelCommand{}))
 	actionCmd.Register(envcmd.Wrap(&DefinedCommand{}))
 	actionCmd.Register(envcmd.Wrap(&DoCommand{}))
 	actionCmd.Register(envcmd.Wrap(&FetchCommand{}))
+	actionCmd.Register(newScheduleSuperCommand())
 	actionCmd.Register(envcmd.Wrap(&StatusCommand{}))
 	return actionCmd
 }
@@ -49,6 +51,15 @@ type APIClient interface {
 	// Action.
 	Enqueue(params.Actions) (params.ActionResults, error)
 
+	// EnqueueGroups queues each of the given actions on the selected
+	// units of a service as one group, returning the id of the group
+	// and the queued actions for each.
+	EnqueueGroups(params.ActionGroups) (params.ActionGroupResults, error)
+
+	// ActionGroups returns the actions queued in each of the given
+	// action groups.
+	ActionGroups(params.ActionGroupIds) (params.ActionGroupResults, error)
+
 	// ListAll takes a list of Tags representing ActionReceivers and returns
 	// all of the Actions that have been queued or run by each of those
 	// Entities.
@@ -64,8 +75,9 @@ type APIClient interface {
 	// Entities.
 	ListCompleted(params.Entities) (params.ActionsByReceivers, error)
 
-	// Cancel attempts to cancel a queued up Action from running.
-	Cancel(params.Actions) (params.ActionResults, error)
+	// Cancel attempts to cancel queued up or running Actions, given
+	// by tag.
+	Cancel(params.Entities) (params.ActionResults, error)
 
 	// ServiceCharmActions is a single query which uses ServicesCharmActions to
 	// get the charm.Actions for a single Service by tag.
@@ -78,6 +90,20 @@ type APIClient interface {
 	// FindActionTagsByPrefix takes a list of string prefixes and finds
 	// corresponding ActionTags that match that prefix.
 	FindActionTagsByPrefix(params.FindTags) (params.FindTagsResults, error)
+
+	// FindActions returns the actions in the environment selected by
+	// the filter, oldest first.
+	FindActions(params.ActionsFilter) (params.ActionResults, error)
+
+	// AddSchedules adds the given action schedules, on which the
+	// state server queues actions, returning each schedule as added.
+	AddSchedules(params.ActionSchedules) (params.ActionScheduleResults, error)
+
+	// Schedules returns all the action schedules in the environment.
+	Schedules() (params.ActionScheduleResults, error)
+
+	// RemoveSchedules removes the action schedules with the given ids.
+	RemoveSchedules(params.ActionScheduleIds) (params.ErrorResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
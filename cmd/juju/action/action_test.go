@@ -44,10 +44,12 @@ func (s *ActionCommandSuite) TestHelp(c *gc.C) {
 
 func (s *ActionCommandSuite) checkHelpSubCommands(c *gc.C, ctx *cmd.Context) {
 	var expectedSubCommmands = [][]string{
+		{"cancel", "cancel pending or running actions"},
 		{"defined", "show actions defined for a service"},
 		{"do", "queue an action for execution"},
 		{"fetch", "show results of an action by ID"},
 		{"help", "show help on a command or other topic"},
+		{"schedule", "manage schedules on which actions are queued"},
 		{"status", "show results of all actions filtered by optional ID prefix"},
 	}
 
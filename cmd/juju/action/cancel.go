@@ -0,0 +1,95 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	errors "github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// CancelCommand cancels pending or running Actions by ID.
+type CancelCommand struct {
+	ActionCommandBase
+	out          cmd.Output
+	requestedIds []string
+}
+
+const cancelDoc = `
+Cancel the actions with the given IDs; partial ID prefixes may also be used.
+A pending action is taken off the queue without being run.  A running action
+is killed by the unit running it, along with any processes it started, and
+its results are discarded.  Units check for cancellation every 5 seconds, so
+a running action may continue for up to that long after it is cancelled.
+Actions that have already finished cannot be cancelled.
+
+Examples:
+
+$ juju action cancel 1a2b3c
+actions:
+- id: 1a2b3c4d-...
+  status: cancelled
+  unit: mysql/3
+`
+
+// Set up the output.
+func (c *CancelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+func (c *CancelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "cancel",
+		Args:    "<action ID> [<action ID>...]",
+		Purpose: "cancel pending or running actions",
+		Doc:     cancelDoc,
+	}
+}
+
+// Init checks that at least one action ID was given.
+func (c *CancelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action ID specified")
+	}
+	c.requestedIds = args
+	return nil
+}
+
+// Run cancels the actions matching each of the given IDs.
+func (c *CancelCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	entities := make([]params.Entity, len(c.requestedIds))
+	for i, id := range c.requestedIds {
+		tag, err := getActionTagByPrefix(api, id)
+		if err != nil {
+			return err
+		}
+		entities[i] = params.Entity{Tag: tag.String()}
+	}
+
+	results, err := api.Cancel(params.Entities{Entities: entities})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != len(entities) {
+		return errors.New("illegal number of results returned")
+	}
+
+	items := make([]map[string]interface{}, len(results.Results))
+	for i, result := range results.Results {
+		items[i] = resultToMap(result)
+		if result.Action == nil {
+			// Show which action could not be cancelled.
+			items[i]["id"] = c.requestedIds[i]
+		}
+	}
+	return c.out.Write(ctx, map[string]interface{}{"actions": items})
+}
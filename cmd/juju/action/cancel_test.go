@@ -0,0 +1,93 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type CancelSuite struct {
+	BaseActionSuite
+	subcommand *action.CancelCommand
+}
+
+var _ = gc.Suite(&CancelSuite{})
+
+func (s *CancelSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.subcommand = &action.CancelCommand{}
+}
+
+func (s *CancelSuite) TestHelp(c *gc.C) {
+	s.checkHelp(c, s.subcommand)
+}
+
+func (s *CancelSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(&action.CancelCommand{}, nil)
+	c.Assert(err, gc.ErrorMatches, "no action ID specified")
+
+	cancelCmd := &action.CancelCommand{}
+	err = testing.InitCommand(cancelCmd, []string{"deadbeef", "feedface"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cancelCmd.RequestedIds(), jc.DeepEquals, []string{"deadbeef", "feedface"})
+}
+
+func (s *CancelSuite) TestRun(c *gc.C) {
+	results := []params.ActionResult{{
+		Action: &params.Action{
+			Tag:      validActionTagString,
+			Receiver: "unit-mysql-0",
+		},
+		Status: params.ActionCancelled,
+	}}
+	fakeClient := makeFakeClient(0, 5*time.Second, tagsForIdPrefix("f47ac10b", validActionTagString), results, "")
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, &action.CancelCommand{}, "f47ac10b")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fakeClient.cancelled, jc.DeepEquals, params.Entities{
+		Entities: []params.Entity{{Tag: validActionTagString}},
+	})
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"actions:\n"+
+		"- id: "+validActionId+"\n"+
+		"  status: cancelled\n"+
+		"  unit: mysql/0\n",
+	)
+}
+
+func (s *CancelSuite) TestRunAlreadyFinished(c *gc.C) {
+	results := []params.ActionResult{{
+		Error: &params.Error{Message: "action " + validActionId + " has already finished: completed"},
+	}}
+	fakeClient := makeFakeClient(0, 5*time.Second, tagsForIdPrefix("f47ac10b", validActionTagString), results, "")
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, &action.CancelCommand{}, "f47ac10b")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"actions:\n"+
+		"- error: action "+validActionId+" has already finished: completed\n"+
+		"  id: f47ac10b\n"+
+		"  status: \"\"\n",
+	)
+}
+
+func (s *CancelSuite) TestRunNotFound(c *gc.C) {
+	fakeClient := makeFakeClient(0, 5*time.Second, params.FindTagsResults{}, nil, "")
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	_, err := testing.RunCommand(c, &action.CancelCommand{}, "f47ac10b")
+	c.Assert(err, gc.ErrorMatches, `actions for identifier "f47ac10b" not found`)
+}
@@ -4,6 +4,11 @@
 package action
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -15,6 +20,90 @@ import (
 
 var logger = loggo.GetLogger("juju.cmd.juju.action")
 
+var keyRule = regexp.MustCompile("^[a-z0-9](?:[a-z0-9-]*[a-z0-9])?$")
+
+// parseKeyValueArgs parses action params given on the command line in
+// the key.key.key...=value format, returning them as
+// {..., [key, key, key, key, value], ...}.
+func parseKeyValueArgs(args []string) ([][]string, error) {
+	parsed := make([][]string, 0)
+	for _, arg := range args {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return nil, fmt.Errorf("argument %q must be of the form key...=value", arg)
+		}
+		keySlice := strings.Split(thisArg[0], ".")
+		// check each key for validity
+		for _, key := range keySlice {
+			if valid := keyRule.MatchString(key); !valid {
+				return nil, fmt.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
+			}
+		}
+		parsed = append(parsed, append(keySlice, thisArg[1]))
+	}
+	return parsed, nil
+}
+
+// buildActionParams returns the params of an action, read from the
+// given YAML file, if any, and overridden by the key-value args parsed
+// by parseKeyValueArgs. Values of the args are parsed as YAML unless
+// parseStrings is false.
+func buildActionParams(ctx *cmd.Context, paramsYAML cmd.FileVar, args [][]string, parseStrings bool) (map[string]interface{}, error) {
+	actionParams := map[string]interface{}{}
+
+	if paramsYAML.Path != "" {
+		b, err := paramsYAML.Read(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = yaml.Unmarshal(b, &actionParams)
+		if err != nil {
+			return nil, err
+		}
+
+		conformantParams, err := conform(actionParams)
+		if err != nil {
+			return nil, err
+		}
+
+		betterParams, ok := conformantParams.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("params must contain a YAML map with string keys")
+		}
+
+		actionParams = betterParams
+	}
+
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !parseStrings {
+			err := yaml.Unmarshal([]byte(value), &cleansedValue)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// Insert the value in the map.
+		addValueToMap(keys, cleansedValue, actionParams)
+	}
+
+	conformantParams, err := conform(actionParams)
+	if err != nil {
+		return nil, err
+	}
+
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
+	}
+	return actionParams, nil
+}
+
 // conform ensures all keys of any nested maps are strings.  This is
 // necessary because YAML unmarshals map[interface{}]interface{} in nested
 // maps, which cannot be serialized by bson. Also, handle []interface{}.
@@ -193,3 +282,16 @@ func addValueToMap(keys []string, value interface{}, target map[string]interface
 		next = m
 	}
 }
+
+// parseTimeArg parses a time given on the command line, either as an
+// RFC3339 timestamp or as a duration before now.
+func parseTimeArg(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("invalid time %q, expected RFC3339 timestamp or duration", value)
+	}
+	return now.Add(-d), nil
+}
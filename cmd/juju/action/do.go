@@ -6,24 +6,25 @@ package action
 import (
 	"fmt"
 	"regexp"
-	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/names"
-	yaml "gopkg.in/yaml.v1"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
 )
 
-var keyRule = regexp.MustCompile("^[a-z0-9](?:[a-z0-9-]*[a-z0-9])?$")
-
-// DoCommand enqueues an Action for running on the given unit with given
-// params
+// DoCommand enqueues an Action for running on the given unit, or on
+// the units of the given service, with given params
 type DoCommand struct {
 	ActionCommandBase
 	unitTag      names.UnitTag
+	serviceTag   names.ServiceTag
+	units        []string
+	leaderOnly   bool
+	timeout      time.Duration
 	actionName   string
 	paramsYAML   cmd.FileVar
 	parseStrings bool
@@ -35,6 +36,12 @@ const doDoc = `
 Queue an Action for execution on a given unit, with a given set of params.
 Displays the ID of the Action for use with 'juju kill', 'juju status', etc.
 
+If a service is given instead of a unit, the Action is queued on every
+unit of the service, or on those selected with the --units or --leader
+options, as one group. The ID of the group is displayed instead, and
+'juju action fetch' on that ID shows the status and results of the
+Action on each unit.
+
 Params are validated according to the charm for the unit's service.  The 
 valid params can be seen using "juju action defined <service> --schema".
 Params may be in a yaml file which is passed with the --params flag, or they
@@ -47,6 +54,12 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+If --timeout is given, the Action is killed, along with any processes it
+started, if it runs for longer than that, and its status is "timed-out".
+Otherwise the timeout declared for the Action in the charm's actions.yaml,
+if any, applies.  A running Action may also be killed with 'juju action
+cancel'.
+
 Examples:
 
 $ juju action do mysql/3 backup 
@@ -91,9 +104,28 @@ file:
   quality: high
 ...
 
+$ juju action do mysql backup
+Action group queued with id: <ID>
+
+$ juju action fetch <ID> --wait 1h
+...
+The status and results of the backup on every unit of mysql.
+...
+
+$ juju action do mysql backup --leader
+...
+
+$ juju action do mysql backup --units mysql/0,mysql/3
+...
+
 $ juju action do sleeper/0 pause time=1000
 ...
 
+$ juju action do sleeper/0 pause time=1000 --timeout 10m
+...
+The Action is killed if it is still running after 10 minutes.
+...
+
 $ juju action do sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
@@ -107,18 +139,21 @@ func (c *DoCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.Var(&c.paramsYAML, "params", "path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "use raw string values of CLI args")
+	f.Var(cmd.NewStringsValue(nil, &c.units), "units", "queue the action only on these units of the service")
+	f.BoolVar(&c.leaderOnly, "leader", false, "queue the action only on the leader of the service")
+	f.DurationVar(&c.timeout, "timeout", 0, "kill the action if it runs for longer than this")
 }
 
 func (c *DoCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "do",
-		Args:    "<unit> <action name> [key.key.key...=value]",
+		Args:    "<unit>|<service> <action name> [key.key.key...=value]",
 		Purpose: "queue an action for execution",
 		Doc:     doDoc,
 	}
 }
 
-// Init gets the unit tag, and checks for other correct args.
+// Init gets the unit or service tag, and checks for other correct args.
 func (c *DoCommand) Init(args []string) error {
 	switch len(args) {
 	case 0:
@@ -126,39 +161,51 @@ func (c *DoCommand) Init(args []string) error {
 	case 1:
 		return errors.New("no action specified")
 	default:
-		// Grab and verify the unit and action names.
-		unitName := args[0]
-		if !names.IsValidUnit(unitName) {
-			return errors.Errorf("invalid unit name %q", unitName)
+		// Grab and verify the unit or service and action names.
+		if err := c.initReceiver(args[0]); err != nil {
+			return err
 		}
 		actionName := args[1]
 		if valid := actionNameRule.MatchString(actionName); !valid {
 			return fmt.Errorf("invalid action name %q", actionName)
 		}
-		c.unitTag = names.NewUnitTag(unitName)
 		c.actionName = actionName
+		if c.timeout < 0 {
+			return errors.New("timeout must not be negative")
+		}
 		if len(args) == 2 {
 			return nil
 		}
 		// Parse CLI key-value args if they exist.
-		c.args = make([][]string, 0)
-		for _, arg := range args[2:] {
-			thisArg := strings.SplitN(arg, "=", 2)
-			if len(thisArg) != 2 {
-				return fmt.Errorf("argument %q must be of the form key...=value", arg)
+		var err error
+		c.args, err = parseKeyValueArgs(args[2:])
+		return err
+	}
+}
+
+// initReceiver sets the unit or service the action is queued on, and
+// checks the options selecting the units of a service.
+func (c *DoCommand) initReceiver(name string) error {
+	switch {
+	case names.IsValidUnit(name):
+		c.unitTag = names.NewUnitTag(name)
+		if len(c.units) > 0 || c.leaderOnly {
+			return errors.New("--units and --leader can only be used with a service")
+		}
+	case names.IsValidService(name):
+		c.serviceTag = names.NewServiceTag(name)
+		for _, unitName := range c.units {
+			if !names.IsValidUnit(unitName) {
+				return errors.Errorf("invalid unit name %q", unitName)
 			}
-			keySlice := strings.Split(thisArg[0], ".")
-			// check each key for validity
-			for _, key := range keySlice {
-				if valid := keyRule.MatchString(key); !valid {
-					return fmt.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
-				}
+			if serviceName, _ := names.UnitService(unitName); serviceName != name {
+				return errors.Errorf("unit %q is not a unit of service %q", unitName, name)
 			}
-			// c.args={..., [key, key, key, key, value]}
-			c.args = append(c.args, append(keySlice, thisArg[1]))
 		}
-		return nil
+	default:
+		return errors.Errorf("invalid unit or service name %q", name)
 	}
+	return nil
 }
 
 func (c *DoCommand) Run(ctx *cmd.Context) error {
@@ -168,57 +215,13 @@ func (c *DoCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
-	actionParams := map[string]interface{}{}
-
-	if c.paramsYAML.Path != "" {
-		b, err := c.paramsYAML.Read(ctx)
-		if err != nil {
-			return err
-		}
-
-		err = yaml.Unmarshal(b, &actionParams)
-		if err != nil {
-			return err
-		}
-
-		conformantParams, err := conform(actionParams)
-		if err != nil {
-			return err
-		}
-
-		betterParams, ok := conformantParams.(map[string]interface{})
-		if !ok {
-			return errors.New("params must contain a YAML map with string keys")
-		}
-
-		actionParams = betterParams
-	}
-
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return err
-			}
-		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
-	}
-
-	conformantParams, err := conform(actionParams)
+	actionParams, err := buildActionParams(ctx, c.paramsYAML, c.args, c.parseStrings)
 	if err != nil {
 		return err
 	}
 
-	typedConformantParams, ok := conformantParams.(map[string]interface{})
-	if !ok {
-		return errors.Errorf("params must be a map, got %T", typedConformantParams)
+	if c.serviceTag.Id() != "" {
+		return c.enqueueGroup(ctx, api, actionParams)
 	}
 
 	actionParam := params.Actions{
@@ -226,6 +229,7 @@ func (c *DoCommand) Run(ctx *cmd.Context) error {
 			Receiver:   c.unitTag.String(),
 			Name:       c.actionName,
 			Parameters: actionParams,
+			Timeout:    c.timeout,
 		}},
 	}
 
@@ -255,3 +259,34 @@ func (c *DoCommand) Run(ctx *cmd.Context) error {
 	output := map[string]string{"Action queued with id": tag.Id()}
 	return c.out.Write(ctx, output)
 }
+
+// enqueueGroup queues the action on the selected units of the service
+// as one group.
+func (c *DoCommand) enqueueGroup(ctx *cmd.Context, api APIClient, actionParams map[string]interface{}) error {
+	group := params.ActionGroup{
+		Service:    c.serviceTag.String(),
+		LeaderOnly: c.leaderOnly,
+		Name:       c.actionName,
+		Parameters: actionParams,
+		Timeout:    c.timeout,
+	}
+	for _, unitName := range c.units {
+		group.Units = append(group.Units, names.NewUnitTag(unitName).String())
+	}
+	results, err := api.EnqueueGroups(params.ActionGroups{Groups: []params.ActionGroup{group}})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+
+	result := results.Results[0]
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	output := map[string]string{"Action group queued with id": result.Group}
+	return c.out.Write(ctx, output)
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/names"
@@ -82,7 +83,7 @@ func (s *DoSuite) TestInit(c *gc.C) {
 	}, {
 		should:      "fail with invalid unit tag",
 		args:        []string{invalidUnitId, "valid-action-name"},
-		expectError: "invalid unit name \"something-strange-\"",
+		expectError: "invalid unit or service name \"something-strange-\"",
 	}, {
 		should:      "fail with invalid action name",
 		args:        []string{validUnitId, "BadName"},
@@ -398,3 +399,103 @@ func (s *DoSuite) TestRun(c *gc.C) {
 		}()
 	}
 }
+
+func (s *DoSuite) TestInitService(c *gc.C) {
+	for i, test := range []struct {
+		args         []string
+		expectError  string
+		expectUnits  []string
+		expectLeader bool
+	}{{
+		args: []string{"mysql", "backup"},
+	}, {
+		args:        []string{"mysql", "backup", "--units", "mysql/0,mysql/3"},
+		expectUnits: []string{"mysql/0", "mysql/3"},
+	}, {
+		args:         []string{"mysql", "backup", "--leader"},
+		expectLeader: true,
+	}, {
+		args:        []string{"mysql", "backup", "--units", "wordpress/0"},
+		expectError: `unit "wordpress/0" is not a unit of service "mysql"`,
+	}, {
+		args:        []string{"mysql", "backup", "--units", "mysql"},
+		expectError: `invalid unit name "mysql"`,
+	}, {
+		args:        []string{"mysql/0", "backup", "--leader"},
+		expectError: "--units and --leader can only be used with a service",
+	}} {
+		c.Logf("test %d: juju action do %s", i, strings.Join(test.args, " "))
+		doCmd := &action.DoCommand{}
+		err := testing.InitCommand(doCmd, test.args)
+		if test.expectError != "" {
+			c.Check(err, gc.ErrorMatches, test.expectError)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(doCmd.ServiceTag(), gc.Equals, names.NewServiceTag("mysql"))
+		c.Check(doCmd.Units(), jc.DeepEquals, test.expectUnits)
+		c.Check(doCmd.LeaderOnly(), gc.Equals, test.expectLeader)
+	}
+}
+
+func (s *DoSuite) TestRunService(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionGroups: []params.ActionGroupResult{{Group: "some-group"}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, &action.DoCommand{}, "mysql", "backup", "--units", "mysql/1", "--timeout", "1h", "out=dump.sql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "Action group queued with id: some-group\n")
+	c.Check(fakeClient.enqueuedGroups, jc.DeepEquals, params.ActionGroups{Groups: []params.ActionGroup{{
+		Service:    "service-mysql",
+		Units:      []string{"unit-mysql-1"},
+		Name:       "backup",
+		Parameters: map[string]interface{}{"out": "dump.sql"},
+		Timeout:    time.Hour,
+	}}})
+}
+
+func (s *DoSuite) TestInitTimeout(c *gc.C) {
+	doCmd := &action.DoCommand{}
+	err := testing.InitCommand(doCmd, []string{validUnitId, "backup", "--timeout", "90s"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doCmd.Timeout(), gc.Equals, 90*time.Second)
+
+	err = testing.InitCommand(&action.DoCommand{}, []string{validUnitId, "backup", "--timeout", "-1s"})
+	c.Assert(err, gc.ErrorMatches, "timeout must not be negative")
+}
+
+func (s *DoSuite) TestRunTimeout(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, &action.DoCommand{}, validUnitId, "backup", "--timeout", "10m")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "Action queued with id: "+validActionId+"\n")
+	c.Check(fakeClient.EnqueuedActions(), jc.DeepEquals, params.Actions{Actions: []params.Action{{
+		Receiver:   "unit-mysql-0",
+		Name:       "backup",
+		Parameters: map[string]interface{}{},
+		Timeout:    10 * time.Minute,
+	}}})
+}
+
+func (s *DoSuite) TestRunServiceError(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionGroups: []params.ActionGroupResult{{
+			Error: common.ServerError(errors.New(`leader of service "mysql" not found`)),
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	_, err := testing.RunCommand(c, &action.DoCommand{}, "mysql", "backup", "--leader")
+	c.Assert(err, gc.ErrorMatches, `leader of service "mysql" not found`)
+}
@@ -4,6 +4,10 @@
 package action
 
 import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
 	"github.com/juju/names"
 
 	"github.com/juju/juju/apiserver/params"
@@ -25,6 +29,22 @@ func (c *DoCommand) UnitTag() names.UnitTag {
 	return c.unitTag
 }
 
+func (c *DoCommand) ServiceTag() names.ServiceTag {
+	return c.serviceTag
+}
+
+func (c *DoCommand) Units() []string {
+	return c.units
+}
+
+func (c *DoCommand) LeaderOnly() bool {
+	return c.leaderOnly
+}
+
+func (c *DoCommand) Timeout() time.Duration {
+	return c.timeout
+}
+
 func (c *DoCommand) ActionName() string {
 	return c.actionName
 }
@@ -41,6 +61,53 @@ func (c *DoCommand) ParseStrings() bool {
 	return c.parseStrings
 }
 
+func (c *StatusCommand) Filter() params.ActionsFilter {
+	return c.filter
+}
+
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
+
+func GroupStatus(results []params.ActionResult) string {
+	return groupStatus(results)
+}
+
+func (c *CancelCommand) RequestedIds() []string {
+	return c.requestedIds
+}
+
+func NewScheduleSuperCommand() cmd.Command {
+	return newScheduleSuperCommand()
+}
+
+func (c *ScheduleAddCommand) Spec() string {
+	return c.spec
+}
+
+func (c *ScheduleAddCommand) Receiver() names.Tag {
+	return c.receiver
+}
+
+func (c *ScheduleAddCommand) ActionName() string {
+	return c.actionName
+}
+
+func (c *ScheduleAddCommand) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c *ScheduleAddCommand) KeyValueArgs() [][]string {
+	return c.args
+}
+
+func (c *ScheduleRemoveCommand) Ids() []string {
+	return c.ids
+}
+
+// NewLogFollower returns a function that prints the log messages of the
+// action results it is given, as "juju action fetch --follow" does.
+func NewLogFollower(out io.Writer) (func(params.ActionResult), func(params.ActionGroupResult)) {
+	follower := newLogFollower(out)
+	return follower.print, follower.printGroup
+}
@@ -4,11 +4,14 @@
 package action
 
 import (
+	"fmt"
+	"io"
 	"regexp"
 	"time"
 
 	"github.com/juju/cmd"
 	errors "github.com/juju/errors"
+	"github.com/juju/names"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
@@ -21,6 +24,7 @@ type FetchCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	follow      bool
 }
 
 const fetchDoc = `
@@ -32,12 +36,25 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+The ID of an action group, queued with 'juju action do' on a service, may
+also be given.  The status and results of the action on each unit of the
+group are then shown, along with the status of the group as a whole:
+"pending" or "running" until the action has finished on every unit, then
+"failed" if it failed on any unit, and "completed" otherwise.  With --wait,
+the command blocks until the action has finished on every unit.
+
+With --follow, the progress messages logged by the action with action-log
+are printed as they are logged, and the command blocks until the action
+has finished, as with --wait 0 unless another duration is given.  The
+results are then shown as usual.
 `
 
 // Set up the output.
 func (c *FetchCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "wait for results")
+	f.BoolVar(&c.follow, "follow", false, "print log messages as they are logged, until the action finishes")
 }
 
 func (c *FetchCommand) Info() *cmd.Info {
@@ -87,6 +104,19 @@ func (c *FetchCommand) Run(ctx *cmd.Context) error {
 	tick := time.NewTimer(2 * time.Second)
 	wait := time.NewTimer(0 * time.Second)
 
+	// Following an action waits for it to finish, unless told
+	// otherwise.
+	if c.follow && waitDur < 0 {
+		waitDur = 0
+	}
+
+	var progress func(params.ActionResult)
+	var groupProgress func(params.ActionGroupResult)
+	if c.follow {
+		follower := newLogFollower(ctx.Stdout)
+		progress, groupProgress = follower.print, follower.printGroup
+	}
+
 	switch {
 	case waitDur.Nanoseconds() < 0:
 		// Negative duration signals immediate return.  All is well.
@@ -99,7 +129,22 @@ func (c *FetchCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(waitDur)
 	}
 
-	result, err := timerLoop(api, c.requestedId, wait, tick)
+	// If the ID matches no action, it may be that of an action group.
+	actionTags, err := getActionTagsByPrefix(api, c.requestedId)
+	if err != nil {
+		return err
+	}
+	if len(actionTags) == 0 {
+		group, err := groupTimerLoop(api, c.requestedId, wait, tick, groupProgress)
+		if err == nil {
+			return c.out.Write(ctx, formatActionGroupResult(group))
+		}
+		if !errors.IsNotFound(err) && !errors.IsNotImplemented(err) {
+			return err
+		}
+	}
+
+	result, err := timerLoop(api, c.requestedId, wait, tick, progress)
 	if err != nil {
 		return err
 	}
@@ -109,8 +154,9 @@ func (c *FetchCommand) Run(ctx *cmd.Context) error {
 
 // timerLoop loops indefinitely to query the given API, until "wait" times
 // out, using the "tick" timer to delay the API queries.  It writes the
-// result to the given output.
-func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (params.ActionResult, error) {
+// result to the given output.  If progress is not nil, it is called with
+// each result queried.
+func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer, progress func(params.ActionResult)) (params.ActionResult, error) {
 	var (
 		result params.ActionResult
 		err    error
@@ -123,6 +169,9 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 		if err != nil {
 			return result, err
 		}
+		if progress != nil {
+			progress(result)
+		}
 
 		// Whether or not we're waiting for a result, if a completed
 		// result arrives, we're done.
@@ -143,6 +192,141 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 	}
 }
 
+// groupTimerLoop is like timerLoop, but queries the given API for the
+// actions in the given action group, until the action has finished on
+// every unit.
+func groupTimerLoop(api APIClient, groupId string, wait, tick *time.Timer, progress func(params.ActionGroupResult)) (params.ActionGroupResult, error) {
+	for {
+		result, err := fetchGroupResult(api, groupId)
+		if err != nil {
+			return result, err
+		}
+		if progress != nil {
+			progress(result)
+		}
+
+		switch groupStatus(result.Actions) {
+		case params.ActionRunning, params.ActionPending:
+		default:
+			return result, nil
+		}
+
+		select {
+		case _ = <-wait.C:
+			return result, nil
+
+		case _ = <-tick.C:
+			tick.Reset(2 * time.Second)
+		}
+	}
+}
+
+// logFollower prints the log messages of actions as they are queried,
+// printing each message only once.
+type logFollower struct {
+	out     io.Writer
+	printed map[string]int
+}
+
+func newLogFollower(out io.Writer) *logFollower {
+	return &logFollower{
+		out:     out,
+		printed: make(map[string]int),
+	}
+}
+
+// print prints the messages logged by the given action since they were
+// last printed.
+func (f *logFollower) print(result params.ActionResult) {
+	f.printMessages(result, "")
+}
+
+// printGroup prints the messages logged by each action in the given
+// group since they were last printed, prefixed by the unit the action is
+// running on.
+func (f *logFollower) printGroup(group params.ActionGroupResult) {
+	for _, result := range group.Actions {
+		if result.Action == nil {
+			continue
+		}
+		prefix := result.Action.Receiver
+		if tag, err := names.ParseUnitTag(prefix); err == nil {
+			prefix = tag.Id()
+		}
+		f.printMessages(result, prefix+": ")
+	}
+}
+
+func (f *logFollower) printMessages(result params.ActionResult, prefix string) {
+	var tag string
+	if result.Action != nil {
+		tag = result.Action.Tag
+	}
+	// The oldest messages are discarded once an action has logged
+	// many, so count messages from the first one logged.
+	total := result.LogCount
+	if total < len(result.Log) {
+		total = len(result.Log)
+	}
+	discarded := total - len(result.Log)
+	printed := f.printed[tag]
+	if printed > total {
+		printed = 0
+	}
+	start := printed - discarded
+	if start < 0 {
+		start = 0
+	}
+	for _, m := range result.Log[start:] {
+		fmt.Fprintf(f.out, "%s %s%s\n", m.Timestamp.UTC().Format(time.RFC3339), prefix, m.Message)
+	}
+	f.printed[tag] = total
+}
+
+// fetchGroupResult queries the given API for the actions in the given
+// action group.
+func fetchGroupResult(api APIClient, groupId string) (params.ActionGroupResult, error) {
+	none := params.ActionGroupResult{}
+
+	groups, err := api.ActionGroups(params.ActionGroupIds{Ids: []string{groupId}})
+	if err != nil {
+		return none, err
+	}
+	if len(groups.Results) != 1 {
+		return none, errors.NotFoundf("action group %q", groupId)
+	}
+
+	result := groups.Results[0]
+	if result.Error != nil {
+		if params.IsCodeNotFound(result.Error) {
+			return none, errors.NotFoundf("action group %q", groupId)
+		}
+		return none, result.Error
+	}
+
+	return result, nil
+}
+
+// groupStatus returns the status of an action group as a whole, given
+// the results of the action on each unit.
+func groupStatus(results []params.ActionResult) string {
+	counts := make(map[string]int)
+	for _, result := range results {
+		counts[result.Status]++
+	}
+	switch {
+	case counts[params.ActionRunning] > 0:
+		return params.ActionRunning
+	case counts[params.ActionPending] == len(results):
+		return params.ActionPending
+	case counts[params.ActionPending] > 0:
+		return params.ActionRunning
+	case counts[params.ActionFailed] > 0, counts[params.ActionCancelled] > 0, counts[params.ActionTimedOut] > 0:
+		return params.ActionFailed
+	}
+	return params.ActionCompleted
+}
+
 // fetchResult queries the given API for the given Action ID prefix, and
 // makes sure the results are acceptable, returning an error if they are not.
 func fetchResult(api APIClient, requestedId string) (params.ActionResult, error) {
@@ -187,6 +371,13 @@ func formatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Log) != 0 {
+		log := make([]string, len(result.Log))
+		for i, m := range result.Log {
+			log[i] = fmt.Sprintf("%s %s", m.Timestamp.UTC().Format(time.RFC3339), m.Message)
+		}
+		response["log"] = log
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
@@ -206,3 +397,35 @@ func formatActionResult(result params.ActionResult) map[string]interface{} {
 
 	return response
 }
+
+// formatActionGroupResult returns the status of the given action group,
+// and the results of the action on each unit, in a
+// map[string]interface{} for cmd.Output to write.
+func formatActionGroupResult(group params.ActionGroupResult) map[string]interface{} {
+	summary := make(map[string]int)
+	units := make(map[string]interface{})
+	for _, result := range group.Actions {
+		if result.Action == nil {
+			continue
+		}
+		summary[result.Status]++
+		response := formatActionResult(result)
+		if result.Error != nil {
+			response["error"] = result.Error.Error()
+		}
+		if tag, err := names.ParseActionTag(result.Action.Tag); err == nil {
+			response["id"] = tag.Id()
+		}
+		unit := result.Action.Receiver
+		if tag, err := names.ParseUnitTag(unit); err == nil {
+			unit = tag.Id()
+		}
+		units[unit] = response
+	}
+	return map[string]interface{}{
+		"group":   group.Group,
+		"status":  groupStatus(group.Actions),
+		"summary": summary,
+		"units":   units,
+	}
+}
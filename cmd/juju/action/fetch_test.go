@@ -287,6 +287,160 @@ timing:
 	}
 }
 
+func (s *FetchSuite) TestRunGroup(c *gc.C) {
+	groupId := "0ddba11c-58cc-4372-a567-0e02b2c3d479"
+	client := makeFakeClient(0, 10*time.Second, tagsForIdPrefix(groupId), nil, "")
+	client.actionGroups = []params.ActionGroupResult{{
+		Group: groupId,
+		Actions: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"},
+			Status: params.ActionCompleted,
+			Output: map[string]interface{}{
+				"file": "dump-0.sql",
+			},
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}, {
+			Action:  &params.Action{Tag: "action-5eed5eed-58cc-4372-a567-0e02b2c3d479", Receiver: "unit-mysql-1"},
+			Status:  params.ActionFailed,
+			Message: "disk full",
+		}},
+	}}
+	testRunHelper(c, s, client, "", `
+group: 0ddba11c-58cc-4372-a567-0e02b2c3d479
+status: failed
+summary:
+  completed: 1
+  failed: 1
+units:
+  mysql/0:
+    id: f47ac10b-58cc-4372-a567-0e02b2c3d479
+    results:
+      file: dump-0.sql
+    status: completed
+    timing:
+      completed: 2015-02-14 08:15:30 +0000 UTC
+      enqueued: 2015-02-14 08:13:00 +0000 UTC
+  mysql/1:
+    id: 5eed5eed-58cc-4372-a567-0e02b2c3d479
+    message: disk full
+    status: failed
+`[1:], "", groupId)
+}
+
+func (s *FetchSuite) TestRunGroupNotFound(c *gc.C) {
+	client := makeFakeClient(0, 10*time.Second, tagsForIdPrefix(validActionId), nil, "")
+	client.actionGroups = []params.ActionGroupResult{{
+		Error: &params.Error{Code: params.CodeNotFound, Message: "action group not found"},
+	}}
+	testRunHelper(c, s, client, `actions for identifier "`+validActionId+`" not found`, "", "", validActionId)
+}
+
+func (s *FetchSuite) TestRunFollow(c *gc.C) {
+	client := makeFakeClient(0, 10*time.Second, tagsForIdPrefix(validActionId, validActionTagString), []params.ActionResult{{
+		Status: params.ActionCompleted,
+		Log: []params.ActionMessage{{
+			Timestamp: time.Date(2015, time.February, 14, 8, 14, 0, 0, time.UTC),
+			Message:   "migrating table 1 of 2",
+		}, {
+			Timestamp: time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+			Message:   "migrating table 2 of 2",
+		}},
+		Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+		Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+	}}, "")
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	ctx, err := testing.RunCommand(c, &action.FetchCommand{}, "--follow", validActionId)
+	c.Assert(err, gc.IsNil)
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+2015-02-14T08:14:00Z migrating table 1 of 2
+2015-02-14T08:15:00Z migrating table 2 of 2
+log:
+- 2015-02-14T08:14:00Z migrating table 1 of 2
+- 2015-02-14T08:15:00Z migrating table 2 of 2
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+`[1:])
+}
+
+func (s *FetchSuite) TestLogFollower(c *gc.C) {
+	var out bytes.Buffer
+	printResult, printGroup := action.NewLogFollower(&out)
+	first := params.ActionMessage{
+		Timestamp: time.Date(2015, time.February, 14, 8, 14, 0, 0, time.UTC),
+		Message:   "migrating table 1 of 2",
+	}
+	second := params.ActionMessage{
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+		Message:   "migrating table 2 of 2",
+	}
+	result := params.ActionResult{
+		Action: &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"},
+		Status: params.ActionRunning,
+	}
+
+	// Only messages not already printed are printed.
+	result.Log = []params.ActionMessage{first}
+	printResult(result)
+	result.Log = []params.ActionMessage{first, second}
+	printResult(result)
+	printResult(result)
+	c.Check(out.String(), gc.Equals, `
+2015-02-14T08:14:00Z migrating table 1 of 2
+2015-02-14T08:15:00Z migrating table 2 of 2
+`[1:])
+
+	// Messages discarded by the server are not counted as unprinted.
+	out.Reset()
+	third := params.ActionMessage{
+		Timestamp: time.Date(2015, time.February, 14, 8, 16, 0, 0, time.UTC),
+		Message:   "migration complete",
+	}
+	result.Log = []params.ActionMessage{second, third}
+	result.LogCount = 3
+	printResult(result)
+	c.Check(out.String(), gc.Equals, `
+2015-02-14T08:16:00Z migration complete
+`[1:])
+
+	// Messages from actions in a group are prefixed by their unit.
+	out.Reset()
+	other := params.ActionResult{
+		Action: &params.Action{Tag: "action-5eed5eed-58cc-4372-a567-0e02b2c3d479", Receiver: "unit-mysql-1"},
+		Status: params.ActionRunning,
+		Log:    []params.ActionMessage{first},
+	}
+	printGroup(params.ActionGroupResult{Actions: []params.ActionResult{result, other}})
+	c.Check(out.String(), gc.Equals, `
+2015-02-14T08:14:00Z mysql/1: migrating table 1 of 2
+`[1:])
+}
+
+func (s *FetchSuite) TestGroupStatus(c *gc.C) {
+	for i, test := range []struct {
+		statuses []string
+		expected string
+	}{
+		{[]string{"pending", "pending"}, "pending"},
+		{[]string{"pending", "completed"}, "running"},
+		{[]string{"running", "failed"}, "running"},
+		{[]string{"completed", "cancelled"}, "failed"},
+		{[]string{"completed", "failed"}, "failed"},
+		{[]string{"completed", "completed"}, "completed"},
+	} {
+		c.Logf("test %d: %v", i, test.statuses)
+		var results []params.ActionResult
+		for _, status := range test.statuses {
+			results = append(results, params.ActionResult{Status: status})
+		}
+		c.Check(action.GroupStatus(results), gc.Equals, test.expected)
+	}
+}
+
 func testRunHelper(c *gc.C, s *FetchSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
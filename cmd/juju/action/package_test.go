@@ -136,6 +136,14 @@ type fakeAPIClient struct {
 	actionsByReceivers []params.ActionsByReceiver
 	actionTagMatches   params.FindTagsResults
 	charmActions       *charm.Actions
+	actionsFilter      params.ActionsFilter
+	enqueuedGroups     params.ActionGroups
+	actionGroups       []params.ActionGroupResult
+	cancelled          params.Entities
+	addedSchedules     params.ActionSchedules
+	schedules          []params.ActionScheduleResult
+	removedSchedules   params.ActionScheduleIds
+	removeErrors       []params.ErrorResult
 	apiErr             error
 }
 
@@ -174,7 +182,8 @@ func (c *fakeAPIClient) ListCompleted(args params.Entities) (params.ActionsByRec
 	}, c.apiErr
 }
 
-func (c *fakeAPIClient) Cancel(args params.Actions) (params.ActionResults, error) {
+func (c *fakeAPIClient) Cancel(args params.Entities) (params.ActionResults, error) {
+	c.cancelled = args
 	return params.ActionResults{
 		Results: c.actionResults,
 	}, c.apiErr
@@ -214,3 +223,31 @@ func (c *fakeAPIClient) Actions(args params.Entities) (params.ActionResults, err
 func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsResults, error) {
 	return c.actionTagMatches, c.apiErr
 }
+
+func (c *fakeAPIClient) FindActions(filter params.ActionsFilter) (params.ActionResults, error) {
+	c.actionsFilter = filter
+	return params.ActionResults{Results: c.actionResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) EnqueueGroups(args params.ActionGroups) (params.ActionGroupResults, error) {
+	c.enqueuedGroups = args
+	return params.ActionGroupResults{Results: c.actionGroups}, c.apiErr
+}
+
+func (c *fakeAPIClient) ActionGroups(args params.ActionGroupIds) (params.ActionGroupResults, error) {
+	return params.ActionGroupResults{Results: c.actionGroups}, c.apiErr
+}
+
+func (c *fakeAPIClient) AddSchedules(args params.ActionSchedules) (params.ActionScheduleResults, error) {
+	c.addedSchedules = args
+	return params.ActionScheduleResults{Results: c.schedules}, c.apiErr
+}
+
+func (c *fakeAPIClient) Schedules() (params.ActionScheduleResults, error) {
+	return params.ActionScheduleResults{Results: c.schedules}, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	c.removedSchedules = args
+	return params.ErrorResults{Results: c.removeErrors}, c.apiErr
+}
@@ -0,0 +1,315 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/utils/cron"
+)
+
+const scheduleDoc = `
+"juju action schedule" manages the schedules on which the state server
+queues actions, such as nightly backups. Schedules are given as cron
+expressions, evaluated in UTC.
+`
+
+const schedulePurpose = "manage schedules on which actions are queued"
+
+// newScheduleSuperCommand returns the action schedule super-command.
+func newScheduleSuperCommand() cmd.Command {
+	scheduleCmd := jujucmd.NewSubSuperCommand(cmd.SuperCommandParams{
+		Name:        "schedule",
+		Doc:         scheduleDoc,
+		UsagePrefix: "juju action",
+		Purpose:     schedulePurpose,
+	})
+	scheduleCmd.Register(envcmd.Wrap(&ScheduleAddCommand{}))
+	scheduleCmd.Register(envcmd.Wrap(&ScheduleListCommand{}))
+	scheduleCmd.Register(envcmd.Wrap(&ScheduleRemoveCommand{}))
+	return scheduleCmd
+}
+
+// ScheduleAddCommand adds a schedule on which an action is queued on
+// a unit, or on the units of a service.
+type ScheduleAddCommand struct {
+	ActionCommandBase
+	spec         string
+	receiver     names.Tag
+	timeout      time.Duration
+	actionName   string
+	paramsYAML   cmd.FileVar
+	parseStrings bool
+	out          cmd.Output
+	args         [][]string
+}
+
+const scheduleAddDoc = `
+Add a schedule on which the state server queues an Action on a given unit,
+with a given set of params. If a service is given instead of a unit, the
+Action is queued on every unit of the service as one group, as with
+'juju action do'. Displays the ID of the schedule, for use with
+'juju action schedule remove'.
+
+The schedule is a cron expression, which must be quoted, made up of five
+fields: minute, hour, day of month, month and day of week. Each field may
+be "*", a number, a range such as "1-5", a list such as "1,3,5", and may
+have a step such as "*/15". The shorthands "@hourly", "@daily", "@weekly",
+"@monthly" and "@yearly" may also be used. Schedules are evaluated in UTC.
+If the state server is down when an Action is due, the Action is queued
+once when it comes back up.
+
+Params are given as for 'juju action do', and are validated according to
+the charm when the schedule is added.
+
+Examples:
+
+$ juju action schedule add "30 2 * * *" mysql/0 backup
+Action schedule added with id: 0
+
+$ juju action schedule add @weekly mysql compact --timeout 2h
+...
+Every unit of mysql compacts its database at midnight each Sunday, and
+the Action is killed if it runs for longer than 2 hours.
+...
+
+$ juju action schedule add "0 */6 * * *" mysql/0 backup out=backup.tar.bz2
+...
+`
+
+func (c *ScheduleAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.Var(&c.paramsYAML, "params", "path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "use raw string values of CLI args")
+	f.DurationVar(&c.timeout, "timeout", 0, "kill each action if it runs for longer than this")
+}
+
+func (c *ScheduleAddCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add",
+		Args:    "<schedule> <unit>|<service> <action name> [key.key.key...=value]",
+		Purpose: "add a schedule on which an action is queued",
+		Doc:     scheduleAddDoc,
+	}
+}
+
+// Init checks the schedule, receiver and action name, and parses the
+// params given.
+func (c *ScheduleAddCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no schedule specified")
+	case 1:
+		return errors.New("no unit or service specified")
+	case 2:
+		return errors.New("no action specified")
+	}
+	if _, err := cron.Parse(args[0]); err != nil {
+		return err
+	}
+	c.spec = args[0]
+	switch receiver := args[1]; {
+	case names.IsValidUnit(receiver):
+		c.receiver = names.NewUnitTag(receiver)
+	case names.IsValidService(receiver):
+		c.receiver = names.NewServiceTag(receiver)
+	default:
+		return errors.Errorf("invalid unit or service name %q", receiver)
+	}
+	if !actionNameRule.MatchString(args[2]) {
+		return fmt.Errorf("invalid action name %q", args[2])
+	}
+	c.actionName = args[2]
+	if c.timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	if len(args) == 3 {
+		return nil
+	}
+	var err error
+	c.args, err = parseKeyValueArgs(args[3:])
+	return err
+}
+
+func (c *ScheduleAddCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams, err := buildActionParams(ctx, c.paramsYAML, c.args, c.parseStrings)
+	if err != nil {
+		return err
+	}
+	results, err := api.AddSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Spec:       c.spec,
+			Receiver:   c.receiver.String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+			Timeout:    c.timeout,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.Schedule == nil {
+		return errors.New("action schedule was not added")
+	}
+	output := map[string]string{"Action schedule added with id": result.Schedule.Id}
+	return c.out.Write(ctx, output)
+}
+
+// ScheduleListCommand lists the action schedules in the environment.
+type ScheduleListCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const scheduleListDoc = `
+List the schedules on which the state server queues actions, with the time
+each is next due, and when each last queued its Action. If the Action could
+not be queued the last time, the error is shown too.
+`
+
+func (c *ScheduleListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+func (c *ScheduleListCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list",
+		Purpose: "list schedules on which actions are queued",
+		Doc:     scheduleListDoc,
+	}
+}
+
+func (c *ScheduleListCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *ScheduleListCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Schedules()
+	if err != nil {
+		return err
+	}
+	if len(results.Results) == 0 {
+		fmt.Fprintln(ctx.Stderr, "no action schedules")
+		return nil
+	}
+	items := make([]map[string]interface{}, 0, len(results.Results))
+	for _, result := range results.Results {
+		if result.Error != nil {
+			items = append(items, map[string]interface{}{"error": result.Error.Error()})
+			continue
+		}
+		if result.Schedule != nil {
+			items = append(items, scheduleToMap(*result.Schedule))
+		}
+	}
+	return c.out.Write(ctx, map[string]interface{}{"schedules": items})
+}
+
+// scheduleToMap converts an action schedule to a map for output.
+func scheduleToMap(schedule params.ActionSchedule) map[string]interface{} {
+	item := map[string]interface{}{
+		"id":       schedule.Id,
+		"schedule": schedule.Spec,
+		"action":   schedule.Name,
+	}
+	if tag, err := names.ParseTag(schedule.Receiver); err == nil {
+		item[tag.Kind()] = tag.Id()
+	} else {
+		item["receiver"] = schedule.Receiver
+	}
+	if len(schedule.Parameters) > 0 {
+		item["params"] = schedule.Parameters
+	}
+	if schedule.Timeout > 0 {
+		item["timeout"] = schedule.Timeout.String()
+	}
+	if schedule.Next != nil {
+		item["next"] = schedule.Next.UTC().Format(time.RFC3339)
+	}
+	if schedule.LastRun != nil {
+		item["last-run"] = schedule.LastRun.UTC().Format(time.RFC3339)
+	}
+	if schedule.LastError != "" {
+		item["last-error"] = schedule.LastError
+	}
+	return item
+}
+
+// ScheduleRemoveCommand removes action schedules by ID.
+type ScheduleRemoveCommand struct {
+	ActionCommandBase
+	ids []string
+}
+
+const scheduleRemoveDoc = `
+Remove the action schedules with the given IDs, as shown by
+'juju action schedule list'. Actions already queued by the schedules are
+not affected.
+
+Examples:
+
+$ juju action schedule remove 0 3
+`
+
+func (c *ScheduleRemoveCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove",
+		Args:    "<schedule ID> [<schedule ID>...]",
+		Purpose: "remove schedules on which actions are queued",
+		Doc:     scheduleRemoveDoc,
+	}
+}
+
+func (c *ScheduleRemoveCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule ID specified")
+	}
+	c.ids = args
+	return nil
+}
+
+func (c *ScheduleRemoveCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.RemoveSchedules(params.ActionScheduleIds{Ids: c.ids})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != len(c.ids) {
+		return errors.New("illegal number of results returned")
+	}
+	return results.Combine()
+}
@@ -0,0 +1,215 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) TestHelp(c *gc.C) {
+	ctx, err := testing.RunCommand(c, action.NewScheduleSuperCommand(), "--help")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Matches, "(?s)usage: juju action schedule <command> .+")
+
+	commandHelp := strings.TrimSpace(strings.SplitAfter(testing.Stdout(ctx), "commands:")[1])
+	var found []string
+	for _, line := range strings.Split(commandHelp, "\n") {
+		found = append(found, strings.TrimSpace(line))
+	}
+	c.Check(found, jc.DeepEquals, []string{
+		"add    - add a schedule on which an action is queued",
+		"help   - show help on a command or other topic",
+		"list   - list schedules on which actions are queued",
+		"remove - remove schedules on which actions are queued",
+	})
+}
+
+func (s *ScheduleSuite) TestAddInit(c *gc.C) {
+	for i, test := range []struct {
+		args       []string
+		spec       string
+		receiver   names.Tag
+		actionName string
+		timeout    time.Duration
+		keyValues  [][]string
+		err        string
+	}{{
+		err: "no schedule specified",
+	}, {
+		args: []string{"@daily"},
+		err:  "no unit or service specified",
+	}, {
+		args: []string{"@daily", "mysql/0"},
+		err:  "no action specified",
+	}, {
+		args: []string{"0 3 * *", "mysql/0", "backup"},
+		err:  `invalid cron expression "0 3 \* \*": expected 5 fields, got 4`,
+	}, {
+		args: []string{"@daily", invalidUnitId, "backup"},
+		err:  `invalid unit or service name "something-strange-"`,
+	}, {
+		args: []string{"@daily", "mysql/0", "Backup"},
+		err:  `invalid action name "Backup"`,
+	}, {
+		args: []string{"--timeout", "-1s", "@daily", "mysql/0", "backup"},
+		err:  "timeout must not be negative",
+	}, {
+		args: []string{"@daily", "mysql/0", "backup", "out"},
+		err:  `argument "out" must be of the form key...=value`,
+	}, {
+		args:       []string{"0 3 * * *", "mysql/0", "backup"},
+		spec:       "0 3 * * *",
+		receiver:   names.NewUnitTag("mysql/0"),
+		actionName: "backup",
+	}, {
+		args:       []string{"--timeout", "2h", "@weekly", "mysql", "compact", "level=3", "target.kind=full"},
+		spec:       "@weekly",
+		receiver:   names.NewServiceTag("mysql"),
+		actionName: "compact",
+		timeout:    2 * time.Hour,
+		keyValues:  [][]string{{"level", "3"}, {"target", "kind", "full"}},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		addCmd := &action.ScheduleAddCommand{}
+		err := testing.InitCommand(addCmd, test.args)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(addCmd.Spec(), gc.Equals, test.spec)
+		c.Check(addCmd.Receiver(), gc.Equals, test.receiver)
+		c.Check(addCmd.ActionName(), gc.Equals, test.actionName)
+		c.Check(addCmd.Timeout(), gc.Equals, test.timeout)
+		c.Check(addCmd.KeyValueArgs(), jc.DeepEquals, test.keyValues)
+	}
+}
+
+func (s *ScheduleSuite) TestAddRun(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		schedules: []params.ActionScheduleResult{{
+			Schedule: &params.ActionSchedule{Id: "4"},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, &action.ScheduleAddCommand{},
+		"--timeout", "2h", "@weekly", "mysql", "compact", "level=3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fakeClient.addedSchedules, jc.DeepEquals, params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Spec:       "@weekly",
+			Receiver:   "service-mysql",
+			Name:       "compact",
+			Parameters: map[string]interface{}{"level": 3},
+			Timeout:    2 * time.Hour,
+		}},
+	})
+	c.Check(testing.Stdout(ctx), gc.Equals, "Action schedule added with id: 4\n")
+}
+
+func (s *ScheduleSuite) TestAddRunError(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		schedules: []params.ActionScheduleResult{{
+			Error: &params.Error{Message: `cannot add action schedule: action "compact" not defined on unit "mysql/0"`},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	_, err := testing.RunCommand(c, &action.ScheduleAddCommand{}, "@weekly", "mysql/0", "compact")
+	c.Assert(err, gc.ErrorMatches, `cannot add action schedule: action "compact" not defined on unit "mysql/0"`)
+}
+
+func (s *ScheduleSuite) TestList(c *gc.C) {
+	next := time.Date(2015, 7, 2, 3, 0, 0, 0, time.UTC)
+	lastRun := time.Date(2015, 7, 1, 3, 0, 0, 0, time.UTC)
+	fakeClient := &fakeAPIClient{
+		schedules: []params.ActionScheduleResult{{
+			Schedule: &params.ActionSchedule{
+				Id:         "0",
+				Spec:       "0 3 * * *",
+				Receiver:   "unit-mysql-0",
+				Name:       "backup",
+				Parameters: map[string]interface{}{"out": "backup.tar.bz2"},
+				Next:       &next,
+				LastRun:    &lastRun,
+				LastError:  `unit "mysql/0" not found`,
+			}}, {
+			Schedule: &params.ActionSchedule{
+				Id:       "1",
+				Spec:     "@weekly",
+				Receiver: "service-mysql",
+				Name:     "compact",
+				Timeout:  2 * time.Hour,
+			}},
+		},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, &action.ScheduleListCommand{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"schedules:\n"+
+		"- action: backup\n"+
+		"  id: \"0\"\n"+
+		"  last-error: unit \"mysql/0\" not found\n"+
+		"  last-run: 2015-07-01T03:00:00Z\n"+
+		"  next: 2015-07-02T03:00:00Z\n"+
+		"  params:\n"+
+		"    out: backup.tar.bz2\n"+
+		"  schedule: 0 3 * * *\n"+
+		"  unit: mysql/0\n"+
+		"- action: compact\n"+
+		"  id: \"1\"\n"+
+		"  schedule: '@weekly'\n"+
+		"  service: mysql\n"+
+		"  timeout: 2h0m0s\n",
+	)
+}
+
+func (s *ScheduleSuite) TestListEmpty(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{})
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, &action.ScheduleListCommand{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "")
+	c.Check(testing.Stderr(ctx), gc.Equals, "no action schedules\n")
+}
+
+func (s *ScheduleSuite) TestRemove(c *gc.C) {
+	err := testing.InitCommand(&action.ScheduleRemoveCommand{}, nil)
+	c.Assert(err, gc.ErrorMatches, "no schedule ID specified")
+
+	fakeClient := &fakeAPIClient{
+		removeErrors: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `action schedule "7" not found`}},
+		},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	_, err = testing.RunCommand(c, &action.ScheduleRemoveCommand{}, "0", "7")
+	c.Assert(err, gc.ErrorMatches, `action schedule "7" not found`)
+	c.Check(fakeClient.removedSchedules, jc.DeepEquals, params.ActionScheduleIds{Ids: []string{"0", "7"}})
+}
@@ -4,6 +4,8 @@
 package action
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	errors "github.com/juju/errors"
 	"github.com/juju/names"
@@ -17,15 +19,37 @@ type StatusCommand struct {
 	ActionCommandBase
 	out         cmd.Output
 	requestedId string
+	services    []string
+	units       []string
+	names       []string
+	statuses    []string
+	since       string
+	until       string
+	filter      params.ActionsFilter
 }
 
 const statusDoc = `
 Show the status of Actions matching given ID, partial ID prefix, or all Actions if no ID is supplied.
+
+Instead of an ID, Actions can be selected by the service or unit they
+were queued for, by name, by status, and by the time they were queued.
+The --since and --until options take either an RFC3339 timestamp or a
+duration, which is taken as that long before now.
+
+Examples:
+    juju action status --service mysql --status failed,cancelled
+    juju action status --unit mysql/0 --name backup --since 24h
 `
 
 // Set up the output.
 func (c *StatusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.Var(cmd.NewStringsValue(nil, &c.services), "service", "show only actions queued for units of these services")
+	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "show only actions queued for these units")
+	f.Var(cmd.NewStringsValue(nil, &c.names), "name", "show only actions with these names")
+	f.Var(cmd.NewStringsValue(nil, &c.statuses), "status", "show only actions with these statuses [pending|running|completed|failed|cancelled|timed-out]")
+	f.StringVar(&c.since, "since", "", "show only actions queued at or after this time")
+	f.StringVar(&c.until, "until", "", "show only actions queued at or before this time")
 }
 
 func (c *StatusCommand) Info() *cmd.Info {
@@ -41,13 +65,59 @@ func (c *StatusCommand) Init(args []string) error {
 	switch len(args) {
 	case 0:
 		c.requestedId = ""
-		return nil
 	case 1:
 		c.requestedId = args[0]
-		return nil
 	default:
 		return cmd.CheckEmpty(args[1:])
 	}
+	for _, service := range c.services {
+		if !names.IsValidService(service) {
+			return errors.Errorf("invalid service name %q", service)
+		}
+		c.filter.Receivers = append(c.filter.Receivers, names.NewServiceTag(service).String())
+	}
+	for _, unit := range c.units {
+		if !names.IsValidUnit(unit) {
+			return errors.Errorf("invalid unit name %q", unit)
+		}
+		c.filter.Receivers = append(c.filter.Receivers, names.NewUnitTag(unit).String())
+	}
+	for _, status := range c.statuses {
+		switch status {
+		case params.ActionPending, params.ActionRunning, params.ActionCompleted,
+			params.ActionFailed, params.ActionCancelled, params.ActionTimedOut:
+		default:
+			return errors.Errorf("invalid action status %q", status)
+		}
+	}
+	c.filter.Names = c.names
+	c.filter.Statuses = c.statuses
+	now := time.Now()
+	if c.since != "" {
+		since, err := parseTimeArg(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since")
+		}
+		c.filter.EnqueuedAfter = &since
+	}
+	if c.until != "" {
+		until, err := parseTimeArg(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until")
+		}
+		c.filter.EnqueuedBefore = &until
+	}
+	if c.requestedId != "" && c.filtered() {
+		return errors.New("cannot specify both an action ID and filters")
+	}
+	return nil
+}
+
+// filtered reports whether any filter options were given.
+func (c *StatusCommand) filtered() bool {
+	f := c.filter
+	return len(f.Receivers) > 0 || len(f.Names) > 0 || len(f.Statuses) > 0 ||
+		f.EnqueuedAfter != nil || f.EnqueuedBefore != nil
 }
 
 func (c *StatusCommand) Run(ctx *cmd.Context) error {
@@ -57,6 +127,17 @@ func (c *StatusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
+	if c.filtered() {
+		actions, err := api.FindActions(c.filter)
+		if err != nil {
+			return err
+		}
+		if len(actions.Results) < 1 {
+			return errors.Errorf("no actions found")
+		}
+		return c.out.Write(ctx, resultsToMap(actions.Results))
+	}
+
 	actionTags, err := getActionTagsByPrefix(api, c.requestedId)
 	if err != nil {
 		return err
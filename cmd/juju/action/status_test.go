@@ -70,6 +70,82 @@ func (s *StatusSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *StatusSuite) TestInitFilters(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+		check    func(*gc.C, params.ActionsFilter)
+	}{{
+		args: []string{"--service", "mysql,wordpress", "--unit", "mysql/0"},
+		check: func(c *gc.C, filter params.ActionsFilter) {
+			c.Check(filter.Receivers, jc.DeepEquals, []string{"service-mysql", "service-wordpress", "unit-mysql-0"})
+		},
+	}, {
+		args: []string{"--name", "backup", "--status", "failed,cancelled"},
+		check: func(c *gc.C, filter params.ActionsFilter) {
+			c.Check(filter.Names, jc.DeepEquals, []string{"backup"})
+			c.Check(filter.Statuses, jc.DeepEquals, []string{"failed", "cancelled"})
+		},
+	}, {
+		args: []string{"--since", "2015-06-01T12:00:00Z", "--until", "1h"},
+		check: func(c *gc.C, filter params.ActionsFilter) {
+			c.Check(*filter.EnqueuedAfter, gc.Equals, time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC))
+			c.Check(time.Since(*filter.EnqueuedBefore) >= time.Hour, jc.IsTrue)
+		},
+	}, {
+		args:     []string{"--service", "mysql/0"},
+		errMatch: `invalid service name "mysql/0"`,
+	}, {
+		args:     []string{"--unit", "mysql"},
+		errMatch: `invalid unit name "mysql"`,
+	}, {
+		args:     []string{"--status", "exploded"},
+		errMatch: `invalid action status "exploded"`,
+	}, {
+		args:     []string{"--since", "yesterday"},
+		errMatch: `invalid --since: invalid time "yesterday", expected RFC3339 timestamp or duration`,
+	}, {
+		args:     []string{"--service", "mysql", "deadbeef"},
+		errMatch: "cannot specify both an action ID and filters",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		statusCmd := &action.StatusCommand{}
+		err := testing.InitCommand(statusCmd, test.args)
+		if test.errMatch != "" {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		test.check(c, statusCmd.Filter())
+	}
+}
+
+func (s *StatusSuite) TestRunWithFilter(c *gc.C) {
+	results := []params.ActionResult{{Status: "completed"}, {Status: "failed"}}
+	fakeClient := makeFakeClient(0, 5*time.Second, params.FindTagsResults{}, results, "")
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, &action.StatusCommand{}, "--service", "mysql", "--name", "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fakeClient.actionsFilter, jc.DeepEquals, params.ActionsFilter{
+		Receivers: []string{"service-mysql"},
+		Names:     []string{"backup"},
+	})
+	buf, err := cmd.DefaultFormatters["yaml"](action.ActionResultsToMap(results))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, string(buf)+"\n")
+}
+
+func (s *StatusSuite) TestRunWithFilterNoResults(c *gc.C) {
+	fakeClient := makeFakeClient(0, 5*time.Second, params.FindTagsResults{}, nil, "")
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	_, err := testing.RunCommand(c, &action.StatusCommand{}, "--status", "running")
+	c.Assert(err, gc.ErrorMatches, "no actions found")
+}
+
 func (s *StatusSuite) runTestCase(c *gc.C, tc statusTestCase) {
 	fakeClient := makeFakeClient(
 		0*time.Second, // No API delay
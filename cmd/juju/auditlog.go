@@ -0,0 +1,203 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/audit"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+)
+
+const auditLogDoc = `
+Show the audit log of changes made to the environment.
+
+Every API call made by a user that changes the environment is recorded
+in the audit log, along with its arguments and outcome. Calls with
+arguments that may contain secrets (such as passwords) are recorded
+without their arguments.
+
+The --from and --to options accept either an RFC3339 timestamp or a
+duration counting back from now. The --entity option accepts a service,
+unit or machine name, or any entity tag.
+
+Examples:
+    juju audit-log --user bob --from 24h
+    juju audit-log --entity mysql --from 2015-05-01T00:00:00Z --to 2015-05-08T00:00:00Z
+    juju audit-log -n 10 --format json
+`
+
+// AuditLogCommand shows the audit log of the environment.
+type AuditLogCommand struct {
+	envcmd.EnvCommandBase
+	out    cmd.Output
+	api    AuditLogAPI
+	filter params.AuditLogFilter
+
+	user    string
+	entity  string
+	from    string
+	to      string
+	isoTime bool
+}
+
+// AuditLogAPI defines the API methods that the audit-log command uses.
+type AuditLogAPI interface {
+	Close() error
+	List(filter params.AuditLogFilter) ([]params.AuditEntry, error)
+}
+
+// Info implements Command.Info.
+func (c *AuditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "show the audit log of changes made to the environment",
+		Doc:     auditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *AuditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.user, "user", "", "show only changes made by this user")
+	f.StringVar(&c.entity, "entity", "", "show only changes to this service, unit, machine or tag")
+	f.StringVar(&c.from, "from", "", "show only changes made at or after this time")
+	f.StringVar(&c.to, "to", "", "show only changes made at or before this time")
+	f.IntVar(&c.filter.Limit, "n", 0, "show at most this many entries")
+	f.BoolVar(&c.isoTime, "utc", false, "display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *AuditLogCommand) Init(args []string) error {
+	if err := cmd.CheckEmpty(args); err != nil {
+		return err
+	}
+	if c.filter.Limit < 0 {
+		return errors.Errorf("invalid number of entries %d", c.filter.Limit)
+	}
+	if c.user != "" {
+		if !names.IsValidUser(c.user) {
+			return errors.Errorf("invalid user name %q", c.user)
+		}
+		c.filter.User = names.NewUserTag(c.user).String()
+	}
+	if c.entity != "" {
+		tag, err := auditEntityTag(c.entity)
+		if err != nil {
+			return err
+		}
+		c.filter.Entity = tag.String()
+	}
+	now := time.Now()
+	if c.from != "" {
+		from, err := parseTimeArg(c.from, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --from")
+		}
+		c.filter.From = &from
+	}
+	if c.to != "" {
+		to, err := parseTimeArg(c.to, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --to")
+		}
+		c.filter.To = &to
+	}
+	return nil
+}
+
+// auditEntityTag returns the tag for the entity named on the command
+// line, which may be a service, unit or machine name or a tag.
+func auditEntityTag(entity string) (names.Tag, error) {
+	if tag, err := names.ParseTag(entity); err == nil {
+		return tag, nil
+	}
+	switch {
+	case names.IsValidMachine(entity):
+		return names.NewMachineTag(entity), nil
+	case names.IsValidUnit(entity):
+		return names.NewUnitTag(entity), nil
+	case names.IsValidService(entity):
+		return names.NewServiceTag(entity), nil
+	}
+	return nil, errors.Errorf("invalid entity %q", entity)
+}
+
+func (c *AuditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get API connection")
+	}
+	return audit.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *AuditLogCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	entries, err := api.List(c.filter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("no audit entries found")
+		return nil
+	}
+	return c.out.Write(ctx, entries)
+}
+
+// formatTabular returns a tabular summary of audit entries.
+func (c *AuditLogCommand) formatTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]params.AuditEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, 0, 1, 1, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tUSER\tCALL\tOUTCOME\tENTITIES")
+	for _, entry := range entries {
+		user := entry.User
+		if names.IsValidUser(user) {
+			if tag := names.NewUserTag(user); tag.IsLocal() {
+				user = tag.Name()
+			}
+		}
+		outcome := entry.Outcome
+		if entry.Error != "" {
+			outcome = fmt.Sprintf("%s: %s", outcome, entry.Error)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s.%s\t%s\t%s\n",
+			formatStatusTime(&entry.Timestamp, c.isoTime),
+			user,
+			entry.Facade,
+			entry.Method,
+			outcome,
+			strings.Join(entry.Entities, ","),
+		)
+	}
+	tw.Flush()
+	// cmd.Output appends a newline to the formatted value.
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type AuditLogSuite struct {
+	testing.FakeJujuHomeSuite
+	fake *fakeAuditLogAPI
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuHomeSuite.SetUpTest(c)
+	s.fake = &fakeAuditLogAPI{}
+}
+
+type fakeAuditLogAPI struct {
+	filter  params.AuditLogFilter
+	entries []params.AuditEntry
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	return nil
+}
+
+func (f *fakeAuditLogAPI) List(filter params.AuditLogFilter) ([]params.AuditEntry, error) {
+	f.filter = filter
+	return f.entries, nil
+}
+
+func (s *AuditLogSuite) runAuditLog(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &AuditLogCommand{api: s.fake}
+	return testing.RunCommand(c, envcmd.Wrap(command), args...)
+}
+
+func (s *AuditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--user", "not valid!"},
+		err:  `invalid user name "not valid!"`,
+	}, {
+		args: []string{"--entity", "foo-"},
+		err:  `invalid entity "foo-"`,
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `invalid --from: invalid time "yesterday", expected RFC3339 timestamp or duration`,
+	}, {
+		args: []string{"-n", "-1"},
+		err:  `invalid number of entries -1`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runAuditLog(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditLogSuite) TestFilter(c *gc.C) {
+	before := time.Now()
+	_, err := s.runAuditLog(c,
+		"--user", "bob",
+		"--entity", "mysql/0",
+		"--from", "2h",
+		"--to", "2015-05-01T10:00:00Z",
+		"-n", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.filter.User, gc.Equals, "user-bob")
+	c.Assert(s.fake.filter.Entity, gc.Equals, "unit-mysql-0")
+	c.Assert(s.fake.filter.Limit, gc.Equals, 5)
+	c.Assert(s.fake.filter.From, gc.NotNil)
+	c.Assert(s.fake.filter.From.Before(before.Add(-2*time.Hour)), jc.IsFalse)
+	c.Assert(*s.fake.filter.To, gc.Equals, time.Date(2015, 5, 1, 10, 0, 0, 0, time.UTC))
+}
+
+func (s *AuditLogSuite) TestEntityNames(c *gc.C) {
+	for _, test := range []struct {
+		entity string
+		tag    string
+	}{
+		{"0", "machine-0"},
+		{"0/lxc/1", "machine-0-lxc-1"},
+		{"mysql", "service-mysql"},
+		{"mysql/1", "unit-mysql-1"},
+		{"user-bob", "user-bob"},
+	} {
+		tag, err := auditEntityTag(test.entity)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tag.String(), gc.Equals, test.tag)
+	}
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	s.fake.entries = []params.AuditEntry{{
+		Timestamp: time.Date(2015, 5, 1, 10, 0, 0, 0, time.UTC),
+		User:      "bob@local",
+		Facade:    "Client",
+		Method:    "ServiceDestroy",
+		Entities:  []string{"service-mysql"},
+		Outcome:   "failure",
+		Error:     "boom",
+	}, {
+		Timestamp: time.Date(2015, 5, 1, 9, 0, 0, 0, time.UTC),
+		User:      "admin@local",
+		Facade:    "Client",
+		Method:    "ServiceDeploy",
+		Entities:  []string{"service-mysql", "machine-1"},
+		Outcome:   "success",
+	}}
+	ctx, err := s.runAuditLog(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                 USER  CALL                  OUTCOME       ENTITIES\n"+
+		"2015-05-01T10:00:00Z bob   Client.ServiceDestroy failure: boom service-mysql\n"+
+		"2015-05-01T09:00:00Z admin Client.ServiceDeploy  success       service-mysql,machine-1\n",
+	)
+}
+
+func (s *AuditLogSuite) TestNoEntries(c *gc.C) {
+	ctx, err := s.runAuditLog(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "no audit entries found\n")
+}
+
+func (s *AuditLogSuite) TestParseTimeArg(c *gc.C) {
+	now := time.Date(2015, 5, 1, 10, 0, 0, 0, time.UTC)
+	t, err := parseTimeArg("90m", now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t, gc.Equals, now.Add(-90*time.Minute))
+
+	t, err = parseTimeArg("2015-04-30T10:00:00Z", now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t, gc.Equals, now.Add(-24*time.Hour))
+
+	_, err = parseTimeArg("-1h", now)
+	c.Assert(err, gc.ErrorMatches, `invalid time "-1h", expected RFC3339 timestamp or duration`)
+}
+
+// AuditLogConnSuite checks that calls made by real users are found
+// when filtering the audit log by user name.
+type AuditLogConnSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&AuditLogConnSuite{})
+
+func (s *AuditLogConnSuite) TestFilterByUser(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", Password: "secret"})
+	st := s.OpenAPIAs(c, user.UserTag(), "secret")
+	defer st.Close()
+	err := st.Client().ServiceExpose("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.APIState.Client().ServiceUnexpose("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, name := range []string{"bob", "bob@local"} {
+		c.Logf("user %q", name)
+		ctx, err := testing.RunCommand(c, envcmd.Wrap(&AuditLogCommand{}), "--user", name, "--format", "json")
+		c.Assert(err, jc.ErrorIsNil)
+		var entries []params.AuditEntry
+		err = json.Unmarshal([]byte(testing.Stdout(ctx)), &entries)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(entries, gc.HasLen, 1)
+		c.Check(entries[0].User, gc.Equals, "bob@local")
+		c.Check(entries[0].Facade, gc.Equals, "Client")
+		c.Check(entries[0].Method, gc.Equals, "ServiceExpose")
+	}
+}
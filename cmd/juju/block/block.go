@@ -6,6 +6,7 @@ package block
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/envcmd"
@@ -16,6 +17,11 @@ import (
 type BaseBlockCommand struct {
 	envcmd.EnvCommandBase
 	desc string
+
+	// entity holds the name of the service, unit or machine
+	// to block, if the block does not apply to the whole environment.
+	entity string
+	tag    names.Tag
 }
 
 // Init initializes the command.
@@ -28,6 +34,13 @@ func (c *BaseBlockCommand) Init(args []string) error {
 	if len(args) == 1 {
 		c.desc = args[0]
 	}
+	if c.entity != "" {
+		tag, err := entityTag(c.entity)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.tag = tag
+	}
 	return nil
 }
 
@@ -39,6 +52,9 @@ func (c *BaseBlockCommand) internalRun(operation string) error {
 	}
 	defer client.Close()
 
+	if c.tag != nil {
+		return client.SwitchEntityBlockOn(TypeFromOperation(operation), c.tag, c.desc)
+	}
 	return client.SwitchBlockOn(TypeFromOperation(operation), c.desc)
 }
 
@@ -47,10 +63,17 @@ func (c *BaseBlockCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.EnvCommandBase.SetFlags(f)
 }
 
+// setEntityFlag adds the --entity flag to commands whose
+// blocks may apply to a single service, unit or machine.
+func (c *BaseBlockCommand) setEntityFlag(f *gnuflag.FlagSet) {
+	f.StringVar(&c.entity, "entity", "", "block only this service, unit or machine")
+}
+
 // BlockClientAPI defines the client API methods that block command uses.
 type BlockClientAPI interface {
 	Close() error
 	SwitchBlockOn(blockType, msg string) error
+	SwitchEntityBlockOn(blockType string, entity names.Tag, msg string) error
 }
 
 var getBlockClientAPI = func(p *BaseBlockCommand) (BlockClientAPI, error) {
@@ -111,11 +134,18 @@ To by-pass the block, where available, run desired remove command with --force o
     remove-relation
     remove-service
     remove-unit
+
+With --entity, only the named service, unit or machine is protected.
+A block on a service also protects its units, and prevents the removal
+of relations involving the service.
    
 Examples:
    To prevent the machines, services, units and relations from being removed:
    juju block remove-object
 
+   To prevent only the mysql service and its units from being removed:
+   juju block remove-object --entity mysql
+
 `
 
 // Info provides information about command.
@@ -128,6 +158,12 @@ func (c *RemoveCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *RemoveCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.BaseBlockCommand.SetFlags(f)
+	c.setEntityFlag(f)
+}
+
 // Satisfying Command interface.
 func (c *RemoveCommand) Run(_ *cmd.Context) error {
 	return c.internalRun(c.Info().Name)
@@ -177,11 +213,17 @@ To by-pass the block, where available, run desired remove command with --force o
     user change-password
     user disable
     user enable
+
+With --entity, only changes to the named service, unit or machine are
+blocked. A block on a service also applies to its units.
    
 Examples:
    To prevent changes to the environment:
    juju block all-changes
 
+   To prevent changes to machine 0 only:
+   juju block all-changes --entity 0
+
 `
 
 // Info provides information about command.
@@ -194,6 +236,12 @@ func (c *ChangeCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *ChangeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.BaseBlockCommand.SetFlags(f)
+	c.setEntityFlag(f)
+}
+
 // Satisfying Command interface.
 func (c *ChangeCommand) Run(_ *cmd.Context) error {
 	return c.internalRun(c.Info().Name)
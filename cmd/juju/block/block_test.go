@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -74,6 +75,40 @@ func (s *BlockCommandSuite) TestBlockChangeOperations(c *gc.C) {
 	s.assertBlock(c, command.Info().Name, "TestBlockChangeOperations")
 }
 
+func (s *BlockCommandSuite) TestBlockEntity(c *gc.C) {
+	command := block.RemoveCommand{}
+	_, err := testing.RunCommand(c, envcmd.Wrap(&command), "--entity", "mysql", "keep the data")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertBlock(c, command.Info().Name, "keep the data")
+	c.Assert(s.mockClient.Entity, gc.Equals, names.NewServiceTag("mysql"))
+}
+
+func (s *BlockCommandSuite) TestBlockEntityInvalid(c *gc.C) {
+	_, err := testing.RunCommand(c, envcmd.Wrap(&block.ChangeCommand{}), "--entity", "foo-")
+	c.Assert(err, gc.ErrorMatches, `"foo-" is not a valid service, unit or machine`)
+}
+
+func (s *BlockCommandSuite) TestBlockDestroyNoEntity(c *gc.C) {
+	_, err := testing.RunCommand(c, envcmd.Wrap(&block.DestroyCommand{}), "--entity", "mysql")
+	c.Assert(err, gc.ErrorMatches, `flag provided but not defined: --entity`)
+}
+
+func (s *BlockCommandSuite) TestEntityTag(c *gc.C) {
+	for _, test := range []struct {
+		entity string
+		tag    names.Tag
+	}{
+		{"0", names.NewMachineTag("0")},
+		{"0/lxc/1", names.NewMachineTag("0/lxc/1")},
+		{"mysql", names.NewServiceTag("mysql")},
+		{"mysql/1", names.NewUnitTag("mysql/1")},
+	} {
+		tag, err := block.EntityTag(test.entity)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Equals, test.tag)
+	}
+}
+
 func (s *BlockCommandSuite) processErrorTest(c *gc.C, tstError error, blockType block.Block, expectedError error, expectedWarning string) {
 	if tstError != nil {
 		c.Assert(errors.Cause(block.ProcessBlockedError(tstError, blockType)), gc.Equals, expectedError)
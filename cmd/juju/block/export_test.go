@@ -3,7 +3,11 @@
 
 package block
 
-import "github.com/juju/juju/apiserver/params"
+import (
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+)
 
 var (
 	BlockClient   = &getBlockClientAPI
@@ -11,9 +15,12 @@ var (
 	ListClient    = &getBlockListAPI
 )
 
+var EntityTag = entityTag
+
 type MockBlockClient struct {
 	BlockType string
 	Msg       string
+	Entity    names.Tag
 }
 
 func (c *MockBlockClient) Close() error {
@@ -26,21 +33,37 @@ func (c *MockBlockClient) SwitchBlockOn(blockType, msg string) error {
 	return nil
 }
 
+func (c *MockBlockClient) SwitchEntityBlockOn(blockType string, entity names.Tag, msg string) error {
+	c.BlockType = blockType
+	c.Msg = msg
+	c.Entity = entity
+	return nil
+}
+
 func (c *MockBlockClient) SwitchBlockOff(blockType string) error {
 	c.BlockType = blockType
 	c.Msg = ""
 	return nil
 }
 
+func (c *MockBlockClient) SwitchEntityBlockOff(blockType string, entity names.Tag) error {
+	c.BlockType = blockType
+	c.Msg = ""
+	c.Entity = entity
+	return nil
+}
+
 func (c *MockBlockClient) List() ([]params.Block, error) {
 	if c.BlockType == "" {
 		return []params.Block{}, nil
 	}
 
-	return []params.Block{
-		params.Block{
-			Type:    c.BlockType,
-			Message: c.Msg,
-		},
-	}, nil
+	block := params.Block{
+		Type:    c.BlockType,
+		Message: c.Msg,
+	}
+	if c.Entity != nil {
+		block.Tag = c.Entity.String()
+	}
+	return []params.Block{block}, nil
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
@@ -20,6 +21,8 @@ const listCommandDoc = `
 List blocks for Juju environment.
 This command shows if each block type is enabled. 
 For enabled blocks, block message is shown if it was specified.
+Blocks on individual services, units and machines are listed
+after the environment blocks.
 `
 
 // ListCommand list blocks.
@@ -80,6 +83,7 @@ var getBlockListAPI = func(p *ListCommand) (BlockListAPI, error) {
 // BlockInfo defines the serialization behaviour of the block information.
 type BlockInfo struct {
 	Operation string  `yaml:"block" json:"block"`
+	Entity    string  `yaml:"entity,omitempty" json:"entity,omitempty"`
 	Enabled   bool    `yaml:"enabled" json:"enabled"`
 	Message   *string `yaml:"message,omitempty" json:"message,omitempty"`
 }
@@ -90,14 +94,21 @@ func formatBlockInfo(all []params.Block) []BlockInfo {
 	output := make([]BlockInfo, len(blockArgs))
 
 	info := make(map[string]BlockInfo, len(all))
+	var entityBlocks []BlockInfo
 	// not all block types may be returned from client
 	for _, one := range all {
 		op := OperationFromType(one.Type)
+		message := one.Message
 		bi := BlockInfo{
 			Operation: op,
 			// If client returned it, it means that it is enabled
 			Enabled: true,
-			Message: &one.Message,
+			Message: &message,
+		}
+		if isEntityBlock(one) {
+			bi.Entity = one.Tag
+			entityBlocks = append(entityBlocks, bi)
+			continue
 		}
 		info[op] = bi
 	}
@@ -110,7 +121,17 @@ func formatBlockInfo(all []params.Block) []BlockInfo {
 		output[i] = BlockInfo{Operation: aType}
 	}
 
-	return output
+	return append(output, entityBlocks...)
+}
+
+// isEntityBlock reports whether the block applies to a single
+// service, unit or machine rather than the whole environment.
+func isEntityBlock(b params.Block) bool {
+	tag, err := names.ParseTag(b.Tag)
+	if err != nil {
+		return false
+	}
+	return tag.Kind() != names.EnvironTagKind
 }
 
 // formatBlocks returns block list representation.
@@ -129,7 +150,11 @@ func formatBlocks(value interface{}) ([]byte, error) {
 		if ablock.Enabled {
 			switched = "on"
 		}
-		fmt.Fprintf(tw, "%v\t", ablock.Operation)
+		if tag, err := names.ParseTag(ablock.Entity); err == nil {
+			fmt.Fprintf(tw, "%v (%v)\t", ablock.Operation, names.ReadableString(tag))
+		} else {
+			fmt.Fprintf(tw, "%v\t", ablock.Operation)
+		}
 		if ablock.Message != nil {
 			fmt.Fprintf(tw, "\t=%v, %v", switched, *ablock.Message)
 			continue
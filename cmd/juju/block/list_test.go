@@ -4,6 +4,7 @@
 package block_test
 
 import (
+	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -71,3 +72,33 @@ func (s *listCommandSuite) TestListJson(c *gc.C) {
 	c.Assert(testing.Stdout(ctx), gc.Equals, `[{"block":"destroy-environment","enabled":false},{"block":"remove-object","enabled":true,"message":"Test this one"},{"block":"all-changes","enabled":false}]
 `)
 }
+
+func (s *listCommandSuite) TestListEntity(c *gc.C) {
+	s.mockClient.SwitchEntityBlockOn(string(multiwatcher.BlockRemove), names.NewServiceTag("mysql"), "Test this one")
+	ctx, err := testing.RunCommand(c, envcmd.Wrap(&block.ListCommand{}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+destroy-environment            =off
+remove-object                  =off
+all-changes                    =off
+remove-object (service mysql)  =on, Test this one
+`)
+}
+
+func (s *listCommandSuite) TestListEntityYaml(c *gc.C) {
+	s.mockClient.SwitchEntityBlockOn(string(multiwatcher.BlockRemove), names.NewServiceTag("mysql"), "Test this one")
+	ctx, err := testing.RunCommand(c, envcmd.Wrap(&block.ListCommand{}), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- block: destroy-environment
+  enabled: false
+- block: remove-object
+  enabled: false
+- block: all-changes
+  enabled: false
+- block: remove-object
+  entity: service-mysql
+  enabled: true
+  message: Test this one
+`[1:])
+}
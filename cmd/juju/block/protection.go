@@ -8,7 +8,9 @@ import (
 	//	"strings"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names"
 
 	apiblock "github.com/juju/juju/api/block"
 	"github.com/juju/juju/apiserver/params"
@@ -49,6 +51,20 @@ var OperationFromType = func(blockType string) string {
 	return blockTypes[blockType]
 }
 
+// entityTag returns the tag of the service, unit or machine
+// with the given name.
+func entityTag(entity string) (names.Tag, error) {
+	switch {
+	case names.IsValidMachine(entity):
+		return names.NewMachineTag(entity), nil
+	case names.IsValidUnit(entity):
+		return names.NewUnitTag(entity), nil
+	case names.IsValidService(entity):
+		return names.NewServiceTag(entity), nil
+	}
+	return nil, errors.Errorf("%q is not a valid service, unit or machine", entity)
+}
+
 // getBlockAPI returns a block api for block manipulation.
 func getBlockAPI(c *envcmd.EnvCommandBase) (*apiblock.Client, error) {
 	root, err := c.NewAPIRoot()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/envcmd"
@@ -18,6 +19,8 @@ import (
 type UnblockCommand struct {
 	envcmd.EnvCommandBase
 	operation string
+	entity    string
+	tag       names.Tag
 }
 
 var (
@@ -75,6 +78,10 @@ all-changes includes all alteration commands
     user disable
     user enable
 
+Blocks on individual services, units and machines, made with the --entity
+option of "juju block", are removed by passing the same --entity option.
+Such blocks are also removed when the entity itself is removed.
+
 Examples:
    To allow the environment to be destroyed:
    juju unblock destroy-environment
@@ -85,6 +92,9 @@ Examples:
    To allow changes to the environment:
    juju unblock all-changes
 
+   To allow changes to the mysql service:
+   juju unblock all-changes --entity mysql
+
 See Also:
    juju help block
 `
@@ -133,12 +143,27 @@ func (c *UnblockCommand) Init(args []string) error {
 		return errors.Trace(errors.New("can only specify block type"))
 	}
 
-	return c.assignValidOperation("unblock", args)
+	if err := c.assignValidOperation("unblock", args); err != nil {
+		return err
+	}
+	if c.entity == "" {
+		return nil
+	}
+	if c.operation == "destroy-environment" {
+		return errors.Errorf("cannot specify --entity with %v", c.operation)
+	}
+	tag, err := entityTag(c.entity)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.tag = tag
+	return nil
 }
 
 // SetFlags implements Command.SetFlags.
 func (c *UnblockCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.EnvCommandBase.SetFlags(f)
+	f.StringVar(&c.entity, "entity", "", "unblock only this service, unit or machine")
 }
 
 // Run unblocks previously blocked commands.
@@ -150,6 +175,9 @@ func (c *UnblockCommand) Run(_ *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.tag != nil {
+		return client.SwitchEntityBlockOff(TypeFromOperation(c.operation), c.tag)
+	}
 	return client.SwitchBlockOff(TypeFromOperation(c.operation))
 }
 
@@ -157,6 +185,7 @@ func (c *UnblockCommand) Run(_ *cmd.Context) error {
 type UnblockClientAPI interface {
 	Close() error
 	SwitchBlockOff(blockType string) error
+	SwitchEntityBlockOff(blockType string, entity names.Tag) error
 }
 
 var getUnblockClientAPI = func(p *UnblockCommand) (UnblockClientAPI, error) {
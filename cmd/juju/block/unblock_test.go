@@ -6,6 +6,7 @@ package block_test
 import (
 	"strings"
 
+	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -69,3 +70,15 @@ func (s *UnblockCommandSuite) TestUnblockCmdValidDestroyEnvOperationUpperCase(c
 func (s *UnblockCommandSuite) TestUnblockCmdValidDestroyEnvOperation(c *gc.C) {
 	s.assertRunUnblock(c, "destroy-environment")
 }
+
+func (s *UnblockCommandSuite) TestUnblockEntity(c *gc.C) {
+	err := runUnblockCommand(c, "all-changes", "--entity", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockClient.BlockType, gc.Equals, block.TypeFromOperation("all-changes"))
+	c.Assert(s.mockClient.Entity, gc.Equals, names.NewUnitTag("mysql/0"))
+}
+
+func (s *UnblockCommandSuite) TestUnblockEntityDestroyEnvironment(c *gc.C) {
+	err := runUnblockCommand(c, "destroy-environment", "--entity", "mysql")
+	c.Assert(err, gc.ErrorMatches, "cannot specify --entity with destroy-environment")
+}
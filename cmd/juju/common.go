@@ -270,3 +270,17 @@ func formatStatusTime(t *time.Time, formatISO bool) string {
 		return t.Local().Format("02 Jan 2006 15:04:05 MST")
 	}
 }
+
+// parseTimeArg parses a time given on the command line, either as
+// an RFC3339 timestamp or as a duration (e.g. "2h") counting back
+// from now.
+func parseTimeArg(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("invalid time %q, expected RFC3339 timestamp or duration", value)
+	}
+	return now.Add(-d), nil
+}
@@ -7,10 +7,12 @@ import (
 	"encoding/base64"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/names"
 	"gopkg.in/juju/charm.v5/hooks"
+	"launchpad.net/gnuflag"
 
 	unitdebug "github.com/juju/juju/worker/uniter/runner/debug"
 )
@@ -18,11 +20,32 @@ import (
 // DebugHooksCommand is responsible for launching a ssh shell on a given unit or machine.
 type DebugHooksCommand struct {
 	SSHCommand
-	hooks []string
+	hooks     []string
+	actions   []string
+	relations []string
 }
 
 const debugHooksDoc = `
 Interactively debug a hook remotely on a service unit.
+
+If no hook names are given, every hook and action run on the unit is
+intercepted. Otherwise, only the named hooks are intercepted; "*" names
+all hooks.
+
+Actions are intercepted as hooks are, if they are named with --action;
+"*" names all actions. If actions are named but no hooks are, only the
+actions are intercepted.
+
+Relation hooks can be narrowed down with --relation, giving a relation
+name, optionally followed by a colon and the name of a remote unit. Only
+hooks run for a matching relation, and remote unit if given, are then
+intercepted.
+
+Examples:
+
+$ juju debug-hooks mysql/0 --action backup
+$ juju debug-hooks wordpress/0 --relation db:mysql/1
+$ juju debug-hooks wordpress/0 db-relation-changed --relation db:mysql/1,db:mysql/2
 `
 
 func (c *DebugHooksCommand) Info() *cmd.Info {
@@ -34,6 +57,12 @@ func (c *DebugHooksCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *DebugHooksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SSHCommand.SetFlags(f)
+	f.Var(cmd.NewStringsValue(nil, &c.actions), "action", "one or more action names to debug")
+	f.Var(cmd.NewStringsValue(nil, &c.relations), "relation", "one or more relations, as <relation name>[:<remote unit>], whose hooks to debug")
+}
+
 func (c *DebugHooksCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("no unit name specified")
@@ -47,15 +76,24 @@ func (c *DebugHooksCommand) Init(args []string) error {
 	c.hooks = append([]string{}, args[1:]...)
 	for _, h := range c.hooks {
 		if h == "*" {
-			c.hooks = nil
+			c.hooks = []string{"*"}
 			break
 		}
 	}
+	for _, relation := range c.relations {
+		parts := strings.SplitN(relation, ":", 2)
+		if parts[0] == "" {
+			return fmt.Errorf("invalid relation %q: no relation name", relation)
+		}
+		if len(parts) == 2 && !names.IsValidUnit(parts[1]) {
+			return fmt.Errorf("invalid relation %q: %q is not a valid unit name", relation, parts[1])
+		}
+	}
 	return nil
 }
 
 func (c *DebugHooksCommand) validateHooks() error {
-	if len(c.hooks) == 0 {
+	if len(c.hooks) == 0 && len(c.relations) == 0 {
 		return nil
 	}
 	service, err := names.UnitService(c.Target)
@@ -66,6 +104,16 @@ func (c *DebugHooksCommand) validateHooks() error {
 	if err != nil {
 		return err
 	}
+	validRelations := make(map[string]bool)
+	for _, relation := range relations {
+		validRelations[relation] = true
+	}
+	for _, relation := range c.relations {
+		relationName := strings.SplitN(relation, ":", 2)[0]
+		if !validRelations[relationName] {
+			return fmt.Errorf("unit %q does not contain relation %q", c.Target, relationName)
+		}
+	}
 
 	validHooks := make(map[string]bool)
 	for _, hook := range hooks.UnitHooks() {
@@ -78,7 +126,7 @@ func (c *DebugHooksCommand) validateHooks() error {
 		}
 	}
 	for _, hook := range c.hooks {
-		if !validHooks[hook] {
+		if hook != "*" && !validHooks[hook] {
 			names := make([]string, 0, len(validHooks))
 			for hookName := range validHooks {
 				names = append(names, hookName)
@@ -91,6 +139,39 @@ func (c *DebugHooksCommand) validateHooks() error {
 	return nil
 }
 
+func (c *DebugHooksCommand) validateActions() error {
+	var actionNames []string
+	for _, action := range c.actions {
+		if action != "*" {
+			actionNames = append(actionNames, action)
+		}
+	}
+	if len(actionNames) == 0 {
+		return nil
+	}
+	service, err := names.UnitService(c.Target)
+	if err != nil {
+		return err
+	}
+	curl, err := c.apiClient.ServiceGetCharmURL(service)
+	if err != nil {
+		return err
+	}
+	info, err := c.apiClient.CharmInfo(curl.String())
+	if err != nil {
+		return err
+	}
+	for _, action := range actionNames {
+		if info.Actions == nil {
+			return fmt.Errorf("unit %q does not contain action %q", c.Target, action)
+		}
+		if _, ok := info.Actions.ActionSpecs[action]; !ok {
+			return fmt.Errorf("unit %q does not contain action %q", c.Target, action)
+		}
+	}
+	return nil
+}
+
 // Run ensures c.Target is a unit, and resolves its address,
 // and connects to it via SSH to execute the debug-hooks
 // script.
@@ -105,8 +186,17 @@ func (c *DebugHooksCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	err = c.validateActions()
+	if err != nil {
+		return err
+	}
 	debugctx := unitdebug.NewHooksContext(c.Target)
-	script := base64.StdEncoding.EncodeToString([]byte(unitdebug.ClientScript(debugctx, c.hooks)))
+	filter := unitdebug.Filter{
+		Hooks:     c.hooks,
+		Actions:   c.actions,
+		Relations: c.relations,
+	}
+	script := base64.StdEncoding.EncodeToString([]byte(unitdebug.FilteredClientScript(debugctx, filter)))
 	innercmd := fmt.Sprintf(`F=$(mktemp); echo %s | base64 -d > $F; . $F`, script)
 	args := []string{fmt.Sprintf("sudo /bin/bash -c '%s'", innercmd)}
 	c.Args = args
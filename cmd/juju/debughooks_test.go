@@ -55,6 +55,34 @@ var debugHooksTests = []struct {
 	info:   `relation hooks have the relation name prefixed`,
 	args:   []string{"mysql/0", "juju-info-relation-joined"},
 	result: ".*\n",
+}, {
+	info:   `actions may be debugged`,
+	args:   []string{"--action", "snapshot", "mysql/0"},
+	result: ".*\n",
+}, {
+	info:   `"*" is a valid action name: it means debug all actions`,
+	args:   []string{"--action", "*", "mysql/0", "start"},
+	result: ".*\n",
+}, {
+	info:   `relation hooks may be narrowed down to a relation and remote unit`,
+	args:   []string{"--relation", "juju-info,juju-info:mongodb/1", "mysql/0"},
+	result: ".*\n",
+}, {
+	info:  `invalid action`,
+	args:  []string{"--action", "snapshot,backup", "mysql/0"},
+	error: `unit "mysql/0" does not contain action "backup"`,
+}, {
+	info:  `invalid relation`,
+	args:  []string{"--relation", "db:mongodb/1", "mysql/0"},
+	error: `unit "mysql/0" does not contain relation "db"`,
+}, {
+	info:  `invalid relation remote unit`,
+	args:  []string{"--relation", "juju-info:mongodb", "mysql/0"},
+	error: `invalid relation "juju-info:mongodb": "mongodb" is not a valid unit name`,
+}, {
+	info:  `missing relation name`,
+	args:  []string{"--relation", ":mongodb/1", "mysql/0"},
+	error: `invalid relation ":mongodb/1": no relation name`,
 }, {
 	info:  `invalid unit syntax`,
 	args:  []string{"mysql"},
@@ -88,8 +116,7 @@ func (s *DebugHooksSuite) TestDebugHooksCommand(c *gc.C) {
 		ctx := coretesting.Context(c)
 
 		debugHooksCmd := &DebugHooksCommand{}
-		debugHooksCmd.proxy = true
-		err := envcmd.Wrap(debugHooksCmd).Init(t.args)
+		err := coretesting.InitCommand(envcmd.Wrap(debugHooksCmd), t.args)
 		if err == nil {
 			err = debugHooksCmd.Run(ctx)
 		}
@@ -6,8 +6,10 @@ package main
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"launchpad.net/gnuflag"
 
@@ -19,6 +21,9 @@ type DebugLogCommand struct {
 	envcmd.EnvCommandBase
 
 	level  string
+	since  string
+	until  string
+	format string
 	params api.DebugLogParams
 }
 
@@ -31,6 +36,17 @@ const defaultLineCount = 10
 const debuglogDoc = `
 Stream the consolidated debug log file. This file contains the log messages
 from all nodes in the environment.
+
+When the environment stores its logs in the database (the db-log feature),
+the log can also be limited to a time range, and shown as JSON records.
+The --since and --until options accept either an RFC3339 timestamp or a
+duration counting back from now. With --since, the --lines option is
+ignored; with --until, the command exits once the matching messages have
+been shown.
+
+Examples:
+    juju debug-log --since 2h --format json
+    juju debug-log -i mysql/0 --since 2015-06-01T10:00:00Z --until 30m
 `
 
 func (c *DebugLogCommand) Info() *cmd.Info {
@@ -56,6 +72,9 @@ func (c *DebugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
 	f.UintVar(&c.params.Limit, "limit", 0, "show at most this many lines")
 	f.BoolVar(&c.params.Replay, "replay", false, "start filtering from the start")
+	f.StringVar(&c.since, "since", "", "only show log messages logged at or after this time")
+	f.StringVar(&c.until, "until", "", "only show log messages logged at or before this time")
+	f.StringVar(&c.format, "format", "text", "output format, one of [text, json]")
 }
 
 func (c *DebugLogCommand) Init(args []string) error {
@@ -67,6 +86,28 @@ func (c *DebugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	now := time.Now()
+	if c.since != "" {
+		since, err := parseTimeArg(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since")
+		}
+		c.params.StartTime = since
+	}
+	if c.until != "" {
+		until, err := parseTimeArg(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until")
+		}
+		c.params.EndTime = until
+	}
+	switch c.format {
+	case "text":
+	case "json":
+		c.params.JSON = true
+	default:
+		return errors.Errorf("format value %q is not one of %q, %q", c.format, "text", "json")
+	}
 	return cmd.CheckEmpty(args)
 }
 
@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -85,6 +86,25 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--since", "2015-06-01T10:00:00Z", "--until", "2015-06-01T12:00:00Z"},
+			expected: api.DebugLogParams{
+				Backlog:   10,
+				StartTime: time.Date(2015, 6, 1, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since: invalid time "yesterday", expected RFC3339 timestamp or duration`,
+		}, {
+			args: []string{"--format", "json"},
+			expected: api.DebugLogParams{
+				Backlog: 10,
+				JSON:    true,
+			},
+		}, {
+			args:     []string{"--format", "xml"},
+			errMatch: `format value "xml" is not one of "text", "json"`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -120,6 +140,15 @@ func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	})
 }
 
+func (s *DebugLogSuite) TestSinceDuration(c *gc.C) {
+	command := &DebugLogCommand{}
+	before := time.Now()
+	err := testing.InitCommand(envcmd.Wrap(command), []string{"--since", "2h"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.params.StartTime.Before(before.Add(-2*time.Hour)), jc.IsFalse)
+	c.Assert(command.params.StartTime.After(time.Now().Add(-2*time.Hour)), jc.IsFalse)
+}
+
 func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 	s.PatchValue(&getDebugLogAPI, func(_ *DebugLogCommand) (DebugLogAPI, error) {
 		return &fakeDebugLogAPI{log: "this is the log output"}, nil
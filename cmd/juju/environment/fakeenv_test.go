@@ -7,6 +7,7 @@ import (
 	"github.com/juju/names"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/testing"
 )
 
@@ -31,6 +32,7 @@ type fakeEnvAPI struct {
 	err         error
 	keys        []string
 	addUsers    []names.UserTag
+	access      params.EnvironAccess
 	removeUsers []names.UserTag
 }
 
@@ -52,7 +54,8 @@ func (f *fakeEnvAPI) EnvironmentUnset(keys ...string) error {
 	return f.err
 }
 
-func (f *fakeEnvAPI) ShareEnvironment(users ...names.UserTag) error {
+func (f *fakeEnvAPI) ShareEnvironmentWithAccess(access params.EnvironAccess, users ...names.UserTag) error {
+	f.access = access
 	f.addUsers = users
 	return f.err
 }
@@ -40,6 +40,9 @@ func (s *shareSuite) TestInit(c *gc.C) {
 
 	err = testing.InitCommand(shareCmd, []string{"not valid/0"})
 	c.Assert(err, gc.ErrorMatches, `invalid username: "not valid/0"`)
+
+	err = testing.InitCommand(&environment.ShareCommand{}, []string{"--access", "superuser", "bob"})
+	c.Assert(err, gc.ErrorMatches, `invalid access level "superuser", expected read, write or admin`)
 }
 
 func (s *shareSuite) TestPassesValues(c *gc.C) {
@@ -49,6 +52,14 @@ func (s *shareSuite) TestPassesValues(c *gc.C) {
 	_, err := s.run(c, "sam", "ralph")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(s.fake.addUsers, jc.DeepEquals, []names.UserTag{sam, ralph})
+	c.Assert(s.fake.access, gc.Equals, params.EnvironAccess(""))
+}
+
+func (s *shareSuite) TestPassesAccess(c *gc.C) {
+	_, err := s.run(c, "--access", "read", "sam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.addUsers, jc.DeepEquals, []names.UserTag{names.NewUserTag("sam")})
+	c.Assert(s.fake.access, gc.Equals, params.EnvironReadAccess)
 }
 
 func (s *shareSuite) TestBlockShare(c *gc.C) {
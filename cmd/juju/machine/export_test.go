@@ -24,6 +24,14 @@ func NewRemoveCommand(api RemoveMachineAPI) *RemoveCommand {
 	}
 }
 
+// NewShowWorkersCommand returns a ShowWorkersCommand with the api
+// provided as specified.
+func NewShowWorkersCommand(api ShowWorkersAPI) *ShowWorkersCommand {
+	return &ShowWorkersCommand{
+		api: api,
+	}
+}
+
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
@@ -13,7 +13,8 @@ import (
 var logger = loggo.GetLogger("juju.cmd.juju.machine")
 
 const machineCommandDoc = `
-"juju machine" provides commands to add and remove machines in the Juju environment.
+"juju machine" provides commands to add and remove machines in the Juju environment,
+and to show the state of the workers of their agents.
 `
 
 const machineCommandPurpose = "manage machines"
@@ -29,5 +30,6 @@ func NewSuperCommand() cmd.Command {
 	})
 	machineCmd.Register(envcmd.Wrap(&AddCommand{}))
 	machineCmd.Register(envcmd.Wrap(&RemoveCommand{}))
+	machineCmd.Register(envcmd.Wrap(&ShowWorkersCommand{}))
 	return machineCmd
 }
@@ -23,6 +23,7 @@ var expectedCommmandNames = []string{
 	"add",
 	"help",
 	"remove",
+	"show-workers",
 }
 
 func (s *MachineCommandSuite) TestHelp(c *gc.C) {
@@ -0,0 +1,134 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/introspection"
+)
+
+// ShowWorkersCommand shows the state of the workers run by a
+// machine agent.
+type ShowWorkersCommand struct {
+	envcmd.EnvCommandBase
+	api       ShowWorkersAPI
+	out       cmd.Output
+	MachineId string
+}
+
+const showWorkersDoc = `
+Show the state of the workers run by the agent of a machine, as last
+reported by the agent: whether each worker is running, how many times
+it has been started, and the error it last stopped with. Agents report
+the state of their workers every minute.
+
+A worker that has been started many times, or that shows an error,
+is one that keeps failing and being restarted.
+
+Examples:
+	# Show the workers of the agent of machine 0
+	$ juju machine show-workers 0
+`
+
+func (c *ShowWorkersCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-workers",
+		Args:    "<machine>",
+		Purpose: "show the state of the workers of a machine agent",
+		Doc:     showWorkersDoc,
+	}
+}
+
+func (c *ShowWorkersCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatWorkersTabular,
+	})
+}
+
+func (c *ShowWorkersCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no machine specified")
+	}
+	id, args := args[0], args[1:]
+	if !names.IsValidMachine(id) {
+		return fmt.Errorf("invalid machine id %q", id)
+	}
+	c.MachineId = id
+	return cmd.CheckEmpty(args)
+}
+
+// ShowWorkersAPI defines the API used by the show-workers command.
+type ShowWorkersAPI interface {
+	WorkerReports(machine names.MachineTag) (params.WorkerReportsResult, error)
+	Close() error
+}
+
+func (c *ShowWorkersCommand) getShowWorkersAPI() (ShowWorkersAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// machineWorkers holds the workers of a machine agent, as shown by
+// the show-workers command.
+type machineWorkers struct {
+	Reported string                `json:"reported" yaml:"reported"`
+	Workers  []worker.WorkerReport `json:"workers" yaml:"workers"`
+}
+
+func (c *ShowWorkersCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getShowWorkersAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	result, err := client.WorkerReports(names.NewMachineTag(c.MachineId))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, machineWorkers{
+		Reported: result.Updated.UTC().Format(time.RFC3339),
+		Workers:  workerReports(result.Reports),
+	})
+}
+
+func workerReports(in []params.WorkerReport) []worker.WorkerReport {
+	var out []worker.WorkerReport
+	for _, r := range in {
+		out = append(out, worker.WorkerReport{
+			Name:       r.Name,
+			Inputs:     r.Inputs,
+			State:      r.State,
+			StartCount: r.StartCount,
+			LastError:  r.LastError,
+			Workers:    workerReports(r.Workers),
+		})
+	}
+	return out
+}
+
+func formatWorkersTabular(value interface{}) ([]byte, error) {
+	workers, ok := value.(machineWorkers)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", workers, value)
+	}
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "Reported at %s\n\n", workers.Reported)
+	out.Write(introspection.FormatReports(workers.Workers))
+	return out.Bytes(), nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type ShowWorkersSuite struct {
+	testing.FakeJujuHomeSuite
+	fake *fakeShowWorkersAPI
+}
+
+var _ = gc.Suite(&ShowWorkersSuite{})
+
+func (s *ShowWorkersSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuHomeSuite.SetUpTest(c)
+	s.fake = &fakeShowWorkersAPI{
+		result: params.WorkerReportsResult{
+			Reports: []params.WorkerReport{{
+				Name:       "api",
+				State:      "started",
+				StartCount: 1,
+				Workers: []params.WorkerReport{{
+					Name:       "machiner",
+					State:      "starting",
+					StartCount: 7,
+					LastError:  "cannot set machine addresses",
+				}},
+			}},
+			Updated: time.Date(2015, 6, 1, 12, 30, 0, 0, time.UTC),
+		},
+	}
+}
+
+func (s *ShowWorkersSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	showWorkers := machine.NewShowWorkersCommand(s.fake)
+	return testing.RunCommand(c, envcmd.Wrap(showWorkers), args...)
+}
+
+func (s *ShowWorkersSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		machine     string
+		errorString string
+	}{
+		{
+			errorString: "no machine specified",
+		}, {
+			args:    []string{"1"},
+			machine: "1",
+		}, {
+			args:    []string{"1/lxc/2"},
+			machine: "1/lxc/2",
+		}, {
+			args:        []string{"lxc"},
+			errorString: `invalid machine id "lxc"`,
+		}, {
+			args:        []string{"1", "2"},
+			errorString: `unrecognized args: \["2"\]`,
+		},
+	} {
+		c.Logf("test %d", i)
+		showWorkersCmd := &machine.ShowWorkersCommand{}
+		err := testing.InitCommand(showWorkersCmd, test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(showWorkersCmd.MachineId, gc.Equals, test.machine)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *ShowWorkersSuite) TestShowWorkers(c *gc.C) {
+	ctx, err := s.run(c, "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.machine, gc.Equals, names.NewMachineTag("0"))
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"Reported at 2015-06-01T12:30:00Z\n"+
+		"\n"+
+		"WORKER      STATE     STARTS  INPUTS  LAST ERROR\n"+
+		"api         started   1               \n"+
+		"  machiner  starting  7               cannot set machine addresses\n",
+	)
+}
+
+func (s *ShowWorkersSuite) TestShowWorkersYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"reported: 2015-06-01T12:30:00Z\n"+
+		"workers:\n"+
+		"- name: api\n"+
+		"  state: started\n"+
+		"  start-count: 1\n"+
+		"  workers:\n"+
+		"  - name: machiner\n"+
+		"    state: starting\n"+
+		"    start-count: 7\n"+
+		"    last-error: cannot set machine addresses\n",
+	)
+}
+
+func (s *ShowWorkersSuite) TestShowWorkersError(c *gc.C) {
+	s.fake.err = errors.New("worker reports for machine 0 not found")
+	_, err := s.run(c, "0")
+	c.Assert(err, gc.ErrorMatches, "worker reports for machine 0 not found")
+}
+
+type fakeShowWorkersAPI struct {
+	machine names.MachineTag
+	result  params.WorkerReportsResult
+	err     error
+}
+
+func (f *fakeShowWorkersAPI) Close() error {
+	return nil
+}
+
+func (f *fakeShowWorkersAPI) WorkerReports(machine names.MachineTag) (params.WorkerReportsResult, error) {
+	f.machine = machine
+	return f.result, f.err
+}
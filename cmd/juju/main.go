@@ -131,6 +131,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(wrapEnvCommand(&EndpointCommand{}))
 	r.Register(wrapEnvCommand(&APIInfoCommand{}))
 	r.Register(wrapEnvCommand(&StatusHistoryCommand{}))
+	r.Register(wrapEnvCommand(&AuditLogCommand{}))
 
 	// Error resolution and debugging commands.
 	r.Register(wrapEnvCommand(&RunCommand{}))
@@ -151,6 +152,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(wrapEnvCommand(&UnexposeCommand{}))
 	r.Register(wrapEnvCommand(&UpgradeJujuCommand{}))
 	r.Register(wrapEnvCommand(&UpgradeCharmCommand{}))
+	r.Register(wrapEnvCommand(&RollbackCharmCommand{}))
+	r.Register(wrapEnvCommand(&SetHookTimeoutCommand{}))
+	r.Register(wrapEnvCommand(&ShowHookStatsCommand{}))
 
 	// Charm publishing commands.
 	r.Register(wrapEnvCommand(&PublishCommand{}))
@@ -194,6 +194,7 @@ var commandNames = []string{
 	"add-unit",
 	"api-endpoints",
 	"api-info",
+	"audit-log",
 	"authorised-keys", // alias for authorized-keys
 	"authorized-keys",
 	"backups",
@@ -228,6 +229,7 @@ var commandNames = []string{
 	"remove-unit",     // alias for destroy-unit
 	"resolved",
 	"retry-provisioning",
+	"rollback-charm",
 	"run",
 	"scp",
 	"service",
@@ -235,6 +237,8 @@ var commandNames = []string{
 	"set-constraints",
 	"set-env", // alias for set-environment
 	"set-environment",
+	"set-hook-timeout",
+	"show-hook-stats",
 	"ssh",
 	"stat", // alias for status
 	"status",
@@ -0,0 +1,83 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/block"
+)
+
+// RollbackCharmCommand is responsible for setting a service back to the
+// charm it used before its charm was last changed.
+type RollbackCharmCommand struct {
+	envcmd.EnvCommandBase
+	ServiceName string
+	Force       bool
+}
+
+const rollbackCharmDoc = `
+Sets the service back to the charm it used before its charm was last changed
+by upgrade-charm, and restores the config settings the service had at that
+time. The charm and the settings are changed together. The service's units
+then run their upgrade-charm hooks for the restored charm, as they would after
+any other upgrade.
+
+Running rollback-charm twice returns the service to the charm and settings it
+was rolled back from.
+
+Use of the --force flag is not generally recommended; units rolled back while
+in an error state will not have upgrade-charm hooks executed, and may cause
+unexpected behavior. It may be needed, though, to recover units that went into
+an error state during a bad upgrade.
+
+Examples:
+
+$ juju upgrade-charm wordpress
+$ juju rollback-charm wordpress
+`
+
+func (c *RollbackCharmCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rollback-charm",
+		Args:    "<service>",
+		Purpose: "set a service back to its previous charm and settings",
+		Doc:     rollbackCharmDoc,
+	}
+}
+
+func (c *RollbackCharmCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Force, "force", false, "roll back all units immediately, even if in error state")
+}
+
+func (c *RollbackCharmCommand) Init(args []string) error {
+	switch len(args) {
+	case 1:
+		if !names.IsValidService(args[0]) {
+			return fmt.Errorf("invalid service name %q", args[0])
+		}
+		c.ServiceName = args[0]
+	case 0:
+		return fmt.Errorf("no service specified")
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+	return nil
+}
+
+// Run connects to the specified environment and sets the service back
+// to its previous charm.
+func (c *RollbackCharmCommand) Run(ctx *cmd.Context) error {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return block.ProcessBlockedError(client.ServiceRollbackCharm(c.ServiceName, c.Force), block.BlockChange)
+}
@@ -0,0 +1,86 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v5"
+
+	"github.com/juju/juju/cmd/envcmd"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing"
+)
+
+type RollbackCharmSuite struct {
+	jujutesting.RepoSuite
+	CmdBlockHelper
+	riak *state.Service
+}
+
+var _ = gc.Suite(&RollbackCharmSuite{})
+
+func (s *RollbackCharmSuite) SetUpTest(c *gc.C) {
+	s.RepoSuite.SetUpTest(c)
+	testcharms.Repo.ClonedDirPath(s.SeriesPath, "riak")
+	err := runDeploy(c, "local:riak", "riak")
+	c.Assert(err, jc.ErrorIsNil)
+	s.riak, err = s.State.Service("riak")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.CmdBlockHelper = NewCmdBlockHelper(s.APIState)
+	c.Assert(s.CmdBlockHelper, gc.NotNil)
+	s.AddCleanup(func(*gc.C) { s.CmdBlockHelper.Close() })
+}
+
+func runRollbackCharm(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, envcmd.Wrap(&RollbackCharmCommand{}), args...)
+	return err
+}
+
+func (s *RollbackCharmSuite) assertCharm(c *gc.C, revision int, forced bool) {
+	err := s.riak.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	ch, force, err := s.riak.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.Revision(), gc.Equals, revision)
+	c.Assert(force, gc.Equals, forced)
+}
+
+func (s *RollbackCharmSuite) TestInvalidArgs(c *gc.C) {
+	err := runRollbackCharm(c)
+	c.Assert(err, gc.ErrorMatches, "no service specified")
+	err = runRollbackCharm(c, "invalid:name")
+	c.Assert(err, gc.ErrorMatches, `invalid service name "invalid:name"`)
+	err = runRollbackCharm(c, "foo", "bar")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["bar"\]`)
+}
+
+func (s *RollbackCharmSuite) TestNoPreviousCharm(c *gc.C) {
+	err := runRollbackCharm(c, "riak")
+	c.Assert(err, gc.ErrorMatches, `cannot roll back charm of service "riak": service has no previous charm`)
+}
+
+func (s *RollbackCharmSuite) TestRollbackCharm(c *gc.C) {
+	err := runUpgradeCharm(c, "riak")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCharm(c, 8, false)
+
+	err = runRollbackCharm(c, "riak", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCharm(c, 7, true)
+	c.Assert(s.riak.PreviousCharmURL(), gc.DeepEquals, charm.MustParseURL("local:trusty/riak-8"))
+}
+
+func (s *RollbackCharmSuite) TestBlockRollbackCharm(c *gc.C) {
+	err := runUpgradeCharm(c, "riak")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Block operation
+	s.BlockAllChanges(c, "TestBlockRollbackCharm")
+	err = runRollbackCharm(c, "riak")
+	s.AssertBlocked(c, err, ".*TestBlockRollbackCharm.*")
+}
@@ -6,14 +6,17 @@ package main
 import (
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
 	"github.com/juju/names"
+	"github.com/juju/utils/set"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/envcmd"
 	"github.com/juju/juju/cmd/juju/block"
@@ -29,6 +32,9 @@ type RunCommand struct {
 	services []string
 	units    []string
 	commands string
+
+	stream      bool
+	maxParallel int
 }
 
 const runDoc = `
@@ -57,6 +63,22 @@ the unit.
 in the environment.  If you specify --all you cannot provide additional
 targets.
 
+The --timeout applies to the commands on each target separately.
+
+By default the results are shown once the commands have finished on every
+target.  With --stream, the result for each target is shown as soon as the
+commands finish there, followed by a summary of the exit codes on stderr.
+Streamed results include when the commands started on the target and how
+long they took; with --format=json each result is written as a JSON
+object on its own line.  If the commands fail on any target, the run
+command fails too.
+
+--max-parallel limits how many targets the commands are run on at once,
+for rolling operations, and implies --stream.  Services are expanded to
+their units, so
+  --service mysql --max-parallel 1
+runs the commands on one unit of mysql at a time.
+
 `
 
 func (c *RunCommand) Info() *cmd.Info {
@@ -75,6 +97,8 @@ func (c *RunCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "one or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "service", "one or more service names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "one or more unit ids")
+	f.BoolVar(&c.stream, "stream", false, "show the result for each target as soon as it finishes")
+	f.IntVar(&c.maxParallel, "max-parallel", 0, "run the commands on at most this many targets at once, implies --stream")
 }
 
 func (c *RunCommand) Init(args []string) error {
@@ -120,6 +144,13 @@ func (c *RunCommand) Init(args []string) error {
 			strings.Join(nameErrors, "\n"))
 	}
 
+	if c.maxParallel < 0 {
+		return fmt.Errorf("--max-parallel must not be negative")
+	}
+	if c.maxParallel > 0 {
+		c.stream = true
+	}
+
 	return cmd.CheckEmpty(args)
 }
 
@@ -148,30 +179,34 @@ func ConvertRunResults(runResults []params.RunResult) interface{} {
 	var results = make([]interface{}, len(runResults))
 
 	for i, result := range runResults {
-		// We always want to have a string for stdout, but only show stderr,
-		// code and error if they are there.
-		values := make(map[string]interface{})
-		values["MachineId"] = result.MachineId
-		if result.UnitId != "" {
-			values["UnitId"] = result.UnitId
-
-		}
-		storeOutput(values, "Stdout", result.Stdout)
-		if len(result.Stderr) > 0 {
-			storeOutput(values, "Stderr", result.Stderr)
-		}
-		if result.Code != 0 {
-			values["ReturnCode"] = result.Code
-		}
-		if result.Error != "" {
-			values["Error"] = result.Error
-		}
-		results[i] = values
+		results[i] = convertRunResult(result)
 	}
 
 	return results
 }
 
+func convertRunResult(result params.RunResult) map[string]interface{} {
+	// We always want to have a string for stdout, but only show stderr,
+	// code and error if they are there.
+	values := make(map[string]interface{})
+	values["MachineId"] = result.MachineId
+	if result.UnitId != "" {
+		values["UnitId"] = result.UnitId
+
+	}
+	storeOutput(values, "Stdout", result.Stdout)
+	if len(result.Stderr) > 0 {
+		storeOutput(values, "Stderr", result.Stderr)
+	}
+	if result.Code != 0 {
+		values["ReturnCode"] = result.Code
+	}
+	if result.Error != "" {
+		values["Error"] = result.Error
+	}
+	return values
+}
+
 func (c *RunCommand) Run(ctx *cmd.Context) error {
 	client, err := getRunAPIClient(c)
 	if err != nil {
@@ -179,6 +214,10 @@ func (c *RunCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.stream {
+		return c.runStreaming(ctx, client)
+	}
+
 	var runResults []params.RunResult
 	if c.all {
 		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
@@ -217,6 +256,248 @@ func (c *RunCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// timedRunResult holds the results of running the commands on a
+// single target, and when and for how long they ran.
+type timedRunResult struct {
+	target   params.RunParams
+	results  []params.RunResult
+	err      error
+	started  time.Time
+	duration time.Duration
+}
+
+// runTimeNow is used to time the commands run on each target, and is a
+// variable so that it can be patched in tests.
+var runTimeNow = time.Now
+
+// runStreaming runs the commands on each target separately, at most
+// c.maxParallel at a time, and writes the result for each target as soon
+// as it is known. It fails if the commands fail on any target.
+func (c *RunCommand) runStreaming(ctx *cmd.Context, client RunClient) error {
+	targets, err := c.runTargets(client)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	limit := c.maxParallel
+	if limit == 0 || limit > len(targets) {
+		limit = len(targets)
+	}
+	pending := make(chan params.RunParams, len(targets))
+	for _, target := range targets {
+		pending <- target
+	}
+	close(pending)
+	done := make(chan timedRunResult, len(targets))
+	for i := 0; i < limit; i++ {
+		go func() {
+			for target := range pending {
+				started := runTimeNow()
+				results, err := client.Run(target)
+				done <- timedRunResult{
+					target:   target,
+					results:  results,
+					err:      err,
+					started:  started,
+					duration: runTimeNow().Sub(started),
+				}
+			}
+		}()
+	}
+
+	var all []params.RunResult
+	var blockedErr error
+	for i := 0; i < len(targets); i++ {
+		timed := <-done
+		results := timed.results
+		if timed.err != nil {
+			if params.IsCodeOperationBlocked(timed.err) {
+				blockedErr = timed.err
+			}
+			results = []params.RunResult{failedRunResult(timed.target, timed.err)}
+		}
+		for _, result := range results {
+			all = append(all, result)
+			if len(targets) == 1 && c.out.Name() == "smart" {
+				// As without --stream, pretend we were running it
+				// locally.
+				ctx.Stdout.Write(result.Stdout)
+				ctx.Stderr.Write(result.Stderr)
+				continue
+			}
+			if err := c.writeStreamed(ctx, result, timed); err != nil {
+				return err
+			}
+		}
+	}
+	if blockedErr != nil {
+		return block.ProcessBlockedError(blockedErr, block.BlockChange)
+	}
+	if len(targets) == 1 && c.out.Name() == "smart" && len(all) == 1 {
+		if all[0].Error != "" {
+			return fmt.Errorf("%s", all[0].Error)
+		}
+		if all[0].Code != 0 {
+			return cmd.NewRcPassthroughError(all[0].Code)
+		}
+		return nil
+	}
+
+	summary, failed := summariseRunResults(all)
+	fmt.Fprint(ctx.Stderr, summary)
+	if failed > 0 {
+		return fmt.Errorf("commands failed on %d of %d targets", failed, len(all))
+	}
+	return nil
+}
+
+// writeStreamed writes a single result, along with when and for how long
+// the commands ran. Results in JSON are written one object to a line, and
+// otherwise as YAML list items, so that the output as a whole is a list.
+func (c *RunCommand) writeStreamed(ctx *cmd.Context, result params.RunResult, timed timedRunResult) error {
+	values := convertRunResult(result)
+	values["Started"] = timed.started.UTC().Format(time.RFC3339)
+	values["Duration"] = (timed.duration / time.Millisecond * time.Millisecond).String()
+	var formatted []byte
+	var err error
+	if c.out.Name() == "json" {
+		formatted, err = cmd.FormatJson(values)
+	} else {
+		formatted, err = cmd.FormatYaml([]interface{}{values})
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(ctx.Stdout, "%s\n", formatted)
+	return err
+}
+
+// failedRunResult returns a result recording that the commands could not
+// be run on the given target.
+func failedRunResult(target params.RunParams, err error) params.RunResult {
+	result := params.RunResult{Error: err.Error()}
+	if len(target.Units) == 1 {
+		result.UnitId = target.Units[0]
+	} else if len(target.Machines) == 1 {
+		result.MachineId = target.Machines[0]
+	}
+	return result
+}
+
+// runTargets returns the parameters for running the commands on each
+// target separately. Services are expanded to their units, and --all to
+// every machine in the environment, using the environment's status.
+func (c *RunCommand) runTargets(client RunClient) ([]params.RunParams, error) {
+	var machines []string
+	units := set.NewStrings(c.units...)
+	if c.all || len(c.services) > 0 {
+		status, err := client.Status(nil)
+		if err != nil {
+			return nil, err
+		}
+		if c.all {
+			machines = allMachineIds(status.Machines)
+		}
+		for _, service := range c.services {
+			if _, ok := status.Services[service]; !ok {
+				return nil, fmt.Errorf("service %q not found", service)
+			}
+			for _, unit := range serviceUnitNames(status, service) {
+				units.Add(unit)
+			}
+		}
+	}
+	machines = append(machines, c.machines...)
+
+	var targets []params.RunParams
+	for _, unit := range units.SortedValues() {
+		targets = append(targets, params.RunParams{
+			Commands: c.commands,
+			Timeout:  c.timeout,
+			Units:    []string{unit},
+		})
+	}
+	for _, machineId := range machines {
+		targets = append(targets, params.RunParams{
+			Commands: c.commands,
+			Timeout:  c.timeout,
+			Machines: []string{machineId},
+		})
+	}
+	return targets, nil
+}
+
+// allMachineIds returns the ids of the given machines and all their
+// containers, sorted.
+func allMachineIds(machines map[string]api.MachineStatus) []string {
+	var ids []string
+	for id, machine := range machines {
+		ids = append(ids, id)
+		ids = append(ids, allMachineIds(machine.Containers)...)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// serviceUnitNames returns the names of the units of the given service,
+// including those of a subordinate service, which are reported alongside
+// their principals.
+func serviceUnitNames(status *api.Status, service string) []string {
+	var unitNames []string
+	var addUnits func(units map[string]api.UnitStatus)
+	addUnits = func(units map[string]api.UnitStatus) {
+		for name, unit := range units {
+			if serviceName, err := names.UnitService(name); err == nil && serviceName == service {
+				unitNames = append(unitNames, name)
+			}
+			addUnits(unit.Subordinates)
+		}
+	}
+	for _, serviceStatus := range status.Services {
+		addUnits(serviceStatus.Units)
+	}
+	return unitNames
+}
+
+// summariseRunResults returns a summary of the exit codes and errors of
+// the given results, grouping the targets by outcome, and the number of
+// targets the commands failed on.
+func summariseRunResults(results []params.RunResult) (string, int) {
+	outcomes := make(map[string][]string)
+	failed := 0
+	for _, result := range results {
+		target := result.UnitId
+		if target == "" {
+			target = "machine " + result.MachineId
+		}
+		var outcome string
+		switch {
+		case result.Error != "":
+			outcome = "error: " + result.Error
+		default:
+			outcome = fmt.Sprintf("exit code %d", result.Code)
+		}
+		if result.Error != "" || result.Code != 0 {
+			failed++
+		}
+		outcomes[outcome] = append(outcomes[outcome], target)
+	}
+	var keys []string
+	for outcome := range outcomes {
+		keys = append(keys, outcome)
+	}
+	sort.Strings(keys)
+	var summary string
+	for _, outcome := range keys {
+		targets := outcomes[outcome]
+		sort.Strings(targets)
+		summary += fmt.Sprintf("%s: %s\n", outcome, strings.Join(targets, ", "))
+	}
+	return summary, failed
+}
+
 // In order to be able to easily mock out the API side for testing,
 // the API client is got using a function.
 
@@ -224,6 +505,7 @@ type RunClient interface {
 	Close() error
 	RunOnAllMachines(commands string, timeout time.Duration) ([]params.RunResult, error)
 	Run(run params.RunParams) ([]params.RunResult, error)
+	Status(patterns []string) (*api.Status, error)
 }
 
 // Here we need the signature to be correct for the interface.
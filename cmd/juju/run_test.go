@@ -14,6 +14,7 @@ import (
 	"github.com/juju/utils/exec"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/envcmd"
@@ -153,6 +154,132 @@ func (*RunSuite) TestTimeoutArgParsing(c *gc.C) {
 	}
 }
 
+func (*RunSuite) TestStreamArgParsing(c *gc.C) {
+	for i, test := range []struct {
+		message     string
+		args        []string
+		errMatch    string
+		stream      bool
+		maxParallel int
+	}{{
+		message: "default",
+		args:    []string{"--all", "hostname"},
+	}, {
+		message: "stream",
+		args:    []string{"--stream", "--all", "hostname"},
+		stream:  true,
+	}, {
+		message:     "max parallel implies stream",
+		args:        []string{"--max-parallel=5", "--service=mysql", "hostname"},
+		stream:      true,
+		maxParallel: 5,
+	}, {
+		message:  "negative max parallel",
+		args:     []string{"--max-parallel=-1", "--all", "hostname"},
+		errMatch: "--max-parallel must not be negative",
+	}} {
+		c.Logf("%v: %s", i, test.message)
+		runCmd := &RunCommand{}
+		testing.TestInit(c, envcmd.Wrap(runCmd), test.args, test.errMatch)
+		if test.errMatch == "" {
+			c.Check(runCmd.stream, gc.Equals, test.stream)
+			c.Check(runCmd.maxParallel, gc.Equals, test.maxParallel)
+		}
+	}
+}
+
+func (s *RunSuite) TestSummariseRunResults(c *gc.C) {
+	summary, failed := summariseRunResults([]params.RunResult{
+		makeRunResult(mockResponse{machineId: "1", unitId: "mysql/1", code: 1}),
+		makeRunResult(mockResponse{machineId: "0", unitId: "mysql/0"}),
+		makeRunResult(mockResponse{machineId: "2", error: "command timed out"}),
+		makeRunResult(mockResponse{machineId: "3"}),
+	})
+	c.Check(summary, gc.Equals, ""+
+		"error: command timed out: machine 2\n"+
+		"exit code 0: machine 3, mysql/0\n"+
+		"exit code 1: mysql/1\n",
+	)
+	c.Check(failed, gc.Equals, 2)
+}
+
+func (s *RunSuite) TestStreamServiceUnits(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.status = &api.Status{
+		Services: map[string]api.ServiceStatus{
+			"mysql": {Units: map[string]api.UnitStatus{
+				"mysql/0": {Machine: "1", Subordinates: map[string]api.UnitStatus{
+					"logging/0": {},
+				}},
+				"mysql/1": {Machine: "2"},
+			}},
+			"logging": {},
+		},
+	}
+	mock.setResponse("mysql/0", mockResponse{stdout: "ok", machineId: "1", unitId: "mysql/0"})
+	mock.setResponse("mysql/1", mockResponse{stderr: "oops", code: 1, machineId: "2", unitId: "mysql/1"})
+	mock.setResponse("0", mockResponse{stdout: "ok", machineId: "0"})
+	// With one target at a time, the clock is read when the commands
+	// start and finish on each target in turn.
+	now := time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC)
+	s.PatchValue(&runTimeNow, func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	})
+
+	context, err := testing.RunCommand(c, envcmd.Wrap(&RunCommand{}),
+		"--format=json", "--max-parallel=1", "--service=mysql", "--machine=0", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "commands failed on 1 of 3 targets")
+	c.Check(testing.Stdout(context), gc.Equals, ""+
+		`{"Duration":"1s","MachineId":"1","Started":"2015-07-01T10:00:01Z","Stdout":"ok","UnitId":"mysql/0"}`+"\n"+
+		`{"Duration":"1s","MachineId":"2","ReturnCode":1,"Started":"2015-07-01T10:00:03Z","Stderr":"oops","Stdout":"","UnitId":"mysql/1"}`+"\n"+
+		`{"Duration":"1s","MachineId":"0","Started":"2015-07-01T10:00:05Z","Stdout":"ok"}`+"\n",
+	)
+	c.Check(testing.Stderr(context), gc.Equals, ""+
+		"exit code 0: machine 0, mysql/0\n"+
+		"exit code 1: mysql/1\n",
+	)
+
+	// Subordinate services are expanded to their units too.
+	targets, err := (&RunCommand{services: []string{"logging"}}).runTargets(mock)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(targets, jc.DeepEquals, []params.RunParams{{Units: []string{"logging/0"}}})
+
+	_, err = (&RunCommand{services: []string{"wordpress"}}).runTargets(mock)
+	c.Check(err, gc.ErrorMatches, `service "wordpress" not found`)
+}
+
+func (s *RunSuite) TestStreamAllMachines(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.status = &api.Status{
+		Machines: map[string]api.MachineStatus{
+			"0": {},
+			"1": {Containers: map[string]api.MachineStatus{
+				"1/lxc/0": {},
+			}},
+		},
+	}
+	mock.setResponse("0", mockResponse{stdout: "megatron", machineId: "0"})
+	mock.setResponse("1", mockResponse{stdout: "bumblebee", machineId: "1"})
+	mock.setResponse("1/lxc/0", mockResponse{stdout: "optimus", machineId: "1/lxc/0"})
+
+	context, err := testing.RunCommand(c, envcmd.Wrap(&RunCommand{}),
+		"--format=yaml", "--stream", "--all", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	// The results may arrive in any order.
+	stdout := testing.Stdout(context)
+	for _, expect := range []string{
+		"- Duration: .*\n  MachineId: \"0\"\n  Started: .*\n  Stdout: megatron\n",
+		"- Duration: .*\n  MachineId: \"1\"\n  Started: .*\n  Stdout: bumblebee\n",
+		"- Duration: .*\n  MachineId: 1/lxc/0\n  Started: .*\n  Stdout: optimus\n",
+	} {
+		c.Check(stdout, gc.Matches, "(?s).*"+expect+".*")
+	}
+	c.Check(testing.Stderr(context), gc.Equals, "exit code 0: machine 0, machine 1, machine 1/lxc/0\n")
+}
+
 func (s *RunSuite) TestConvertRunResults(c *gc.C) {
 	for i, test := range []struct {
 		message  string
@@ -390,6 +517,7 @@ type mockRunAPI struct {
 	// machines, services, units
 	machines  map[string]bool
 	responses map[string]params.RunResult
+	status    *api.Status
 	block     bool
 }
 
@@ -484,3 +612,10 @@ func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.RunResult, error)
 
 	return result, nil
 }
+
+func (m *mockRunAPI) Status(patterns []string) (*api.Status, error) {
+	if m.status == nil {
+		return &api.Status{}, nil
+	}
+	return m.status, nil
+}
@@ -74,8 +74,9 @@ var GetClientConfig = func(client ServiceAddUnitAPI) (*config.Config, error) {
 type AddUnitCommand struct {
 	envcmd.EnvCommandBase
 	UnitCommandBase
-	ServiceName string
-	api         ServiceAddUnitAPI
+	ServiceName   string
+	AttachStorage []string
+	api           ServiceAddUnitAPI
 }
 
 const addUnitDoc = `
@@ -87,11 +88,18 @@ By default, services are deployed to newly provisioned machines.  Alternatively,
 service units can be added to a specific existing machine using the --to
 argument.
 
+Storage that has been detached from a unit of the service with
+"juju storage detach" can be attached to a new unit using the
+--attach-storage argument. Storage may only be attached when adding a
+single unit.
+
 Examples:
  juju service add-unit mysql -n 5          (Add 5 mysql units on 5 new machines)
  juju service add-unit mysql --to 23       (Add a mysql unit to machine 23)
  juju service add-unit mysql --to 24/lxc/3 (Add unit to lxc container 3 on host machine 24)
  juju service add-unit mysql --to lxc:25   (Add unit to a new lxc container on host machine 25)
+ juju service add-unit mysql --attach-storage data/0
+                                           (Add a mysql unit with the detached storage data/0)
 `
 
 func (c *AddUnitCommand) Info() *cmd.Info {
@@ -106,6 +114,7 @@ func (c *AddUnitCommand) Info() *cmd.Info {
 func (c *AddUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.UnitCommandBase.SetFlags(f)
 	f.IntVar(&c.NumUnits, "n", 1, "number of service units to add")
+	f.Var(cmd.NewStringsValue(nil, &c.AttachStorage), "attach-storage", "detached storage to attach to the new unit")
 }
 
 func (c *AddUnitCommand) Init(args []string) error {
@@ -118,6 +127,14 @@ func (c *AddUnitCommand) Init(args []string) error {
 	if err := cmd.CheckEmpty(args[1:]); err != nil {
 		return err
 	}
+	if len(c.AttachStorage) > 0 && c.NumUnits > 1 {
+		return errors.New("cannot use --num-units > 1 with --attach-storage")
+	}
+	for _, id := range c.AttachStorage {
+		if !names.IsValidStorage(id) {
+			return fmt.Errorf("invalid storage ID %q", id)
+		}
+	}
 	return c.UnitCommandBase.Init(args)
 }
 
@@ -126,6 +143,7 @@ func (c *AddUnitCommand) Init(args []string) error {
 type ServiceAddUnitAPI interface {
 	Close() error
 	AddServiceUnits(service string, numUnits int, machineSpec string) ([]string, error)
+	AddServiceUnitsWithStorage(service string, numUnits int, machineSpec string, attachStorage []string) ([]string, error)
 	EnvironmentGet() (map[string]interface{}, error)
 }
 
@@ -154,7 +172,13 @@ func (c *AddUnitCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 
-	_, err = apiclient.AddServiceUnits(c.ServiceName, c.NumUnits, c.ToMachineSpec)
+	if len(c.AttachStorage) > 0 {
+		_, err = apiclient.AddServiceUnitsWithStorage(
+			c.ServiceName, c.NumUnits, c.ToMachineSpec, c.AttachStorage,
+		)
+	} else {
+		_, err = apiclient.AddServiceUnits(c.ServiceName, c.NumUnits, c.ToMachineSpec)
+	}
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
 
@@ -23,11 +23,12 @@ type AddUnitSuite struct {
 }
 
 type fakeServiceAddUnitAPI struct {
-	envType     string
-	service     string
-	numUnits    int
-	machineSpec string
-	err         error
+	envType       string
+	service       string
+	numUnits      int
+	machineSpec   string
+	attachStorage []string
+	err           error
 }
 
 func (f *fakeServiceAddUnitAPI) Close() error {
@@ -50,6 +51,14 @@ func (f *fakeServiceAddUnitAPI) AddServiceUnits(service string, numUnits int, ma
 	return nil, nil
 }
 
+func (f *fakeServiceAddUnitAPI) AddServiceUnitsWithStorage(service string, numUnits int, machineSpec string, attachStorage []string) ([]string, error) {
+	if _, err := f.AddServiceUnits(service, numUnits, machineSpec); err != nil {
+		return nil, err
+	}
+	f.attachStorage = attachStorage
+	return nil, nil
+}
+
 func (f *fakeServiceAddUnitAPI) EnvironmentGet() (map[string]interface{}, error) {
 	cfg, err := config.New(config.UseDefaults, map[string]interface{}{
 		"type": f.envType,
@@ -85,6 +94,12 @@ var initAddUnitErrorTests = []struct {
 	}, {
 		args: []string{"some-service-name", "-n", "2", "--to", "123"},
 		err:  `cannot use --num-units > 1 with --to`,
+	}, {
+		args: []string{"some-service-name", "-n", "2", "--attach-storage", "data/0"},
+		err:  `cannot use --num-units > 1 with --attach-storage`,
+	}, {
+		args: []string{"some-service-name", "--attach-storage", "data"},
+		err:  `invalid storage ID "data"`,
 	},
 }
 
@@ -172,3 +187,10 @@ func (s *AddUnitSuite) TestNameChecks(c *gc.C) {
 	assertMachineOrNewContainer("0/lxc/10", true)
 	assertMachineOrNewContainer("0/kvm/4", true)
 }
+
+func (s *AddUnitSuite) TestAttachStorage(c *gc.C) {
+	err := s.runAddUnit(c, "some-service-name", "--attach-storage", "data/0", "--attach-storage", "logs/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.numUnits, gc.Equals, 2)
+	c.Assert(s.fake.attachStorage, jc.DeepEquals, []string{"data/0", "logs/1"})
+}
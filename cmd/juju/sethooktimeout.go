@@ -0,0 +1,80 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/block"
+)
+
+// SetHookTimeoutCommand is responsible for setting how long a service's
+// units may run a hook before it is killed.
+type SetHookTimeoutCommand struct {
+	envcmd.EnvCommandBase
+	ServiceName string
+	Timeout     time.Duration
+}
+
+const setHookTimeoutDoc = `
+Sets how long each unit of the service may run a hook before the hook is
+killed, along with any processes it started. A unit whose hook is killed is
+put into an error state, with a status message saying that the hook timed
+out, and the hook can be retried with "juju resolved --retry" as with any
+other failed hook.
+
+A timeout of 0 means that hooks are never killed, which is the default.
+
+Examples:
+
+$ juju set-hook-timeout mysql 30m
+$ juju set-hook-timeout mysql 0
+`
+
+func (c *SetHookTimeoutCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-hook-timeout",
+		Args:    "<service> <timeout>",
+		Purpose: "set how long a service's units may run a hook",
+		Doc:     setHookTimeoutDoc,
+	}
+}
+
+func (c *SetHookTimeoutCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return fmt.Errorf("no service specified")
+	case 1:
+		return fmt.Errorf("no timeout specified")
+	}
+	if !names.IsValidService(args[0]) {
+		return fmt.Errorf("invalid service name %q", args[0])
+	}
+	c.ServiceName = args[0]
+	timeout, err := time.ParseDuration(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid timeout %q", args[1])
+	}
+	if timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	c.Timeout = timeout
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run connects to the specified environment and sets the service's
+// hook timeout.
+func (c *SetHookTimeoutCommand) Run(ctx *cmd.Context) error {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return block.ProcessBlockedError(client.ServiceSetHookTimeout(c.ServiceName, c.Timeout), block.BlockChange)
+}
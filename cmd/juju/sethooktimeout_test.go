@@ -0,0 +1,87 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/envcmd"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type SetHookTimeoutSuite struct {
+	jujutesting.RepoSuite
+	CmdBlockHelper
+	dummy *state.Service
+}
+
+var _ = gc.Suite(&SetHookTimeoutSuite{})
+
+func (s *SetHookTimeoutSuite) SetUpTest(c *gc.C) {
+	s.RepoSuite.SetUpTest(c)
+	s.dummy = s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+
+	s.CmdBlockHelper = NewCmdBlockHelper(s.APIState)
+	c.Assert(s.CmdBlockHelper, gc.NotNil)
+	s.AddCleanup(func(*gc.C) { s.CmdBlockHelper.Close() })
+}
+
+func runSetHookTimeout(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, envcmd.Wrap(&SetHookTimeoutCommand{}), args...)
+	return err
+}
+
+func (s *SetHookTimeoutSuite) TestInvalidArgs(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no service specified",
+	}, {
+		args: []string{"dummy"},
+		err:  "no timeout specified",
+	}, {
+		args: []string{"invalid:name", "5m"},
+		err:  `invalid service name "invalid:name"`,
+	}, {
+		args: []string{"dummy", "soon"},
+		err:  `invalid timeout "soon"`,
+	}, {
+		args: []string{"dummy", "-5m"},
+		err:  "timeout must not be negative",
+	}, {
+		args: []string{"dummy", "5m", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := runSetHookTimeout(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SetHookTimeoutSuite) TestSetHookTimeout(c *gc.C) {
+	err := runSetHookTimeout(c, "dummy", "30m")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.dummy.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.dummy.HookTimeout(), gc.Equals, 30*time.Minute)
+
+	err = runSetHookTimeout(c, "dummy", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.dummy.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.dummy.HookTimeout(), gc.Equals, time.Duration(0))
+}
+
+func (s *SetHookTimeoutSuite) TestBlockSetHookTimeout(c *gc.C) {
+	// Block operation
+	s.BlockAllChanges(c, "TestBlockSetHookTimeout")
+	err := runSetHookTimeout(c, "dummy", "30m")
+	s.AssertBlocked(c, err, ".*TestBlockSetHookTimeout.*")
+}
@@ -0,0 +1,151 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+)
+
+const showHookStatsDoc = `
+Show how long the units of a service take to run each hook, action and
+juju-run command.
+
+Every unit records when it starts and finishes running a hook, action or
+commands, and whether it failed. For each hook, action and "juju-run", the
+command shows how many runs were recorded and how many failed, along with
+the shortest, longest, and 50th, 90th and 99th percentile run times. Only
+the most recent runs are kept.
+
+Examples:
+    juju show-hook-stats mysql
+    juju show-hook-stats mysql --format yaml
+`
+
+// ShowHookStatsCommand shows statistics on the runs of hooks, actions
+// and commands by a service's units.
+type ShowHookStatsCommand struct {
+	envcmd.EnvCommandBase
+	out         cmd.Output
+	api         ShowHookStatsAPI
+	ServiceName string
+}
+
+// ShowHookStatsAPI defines the API methods that the show-hook-stats
+// command uses.
+type ShowHookStatsAPI interface {
+	Close() error
+	ServiceHookStats(serviceName string) ([]params.HookStats, error)
+}
+
+// Info implements Command.Info.
+func (c *ShowHookStatsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-hook-stats",
+		Args:    "<service>",
+		Purpose: "show how long a service's units take to run hooks",
+		Doc:     showHookStatsDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *ShowHookStatsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatHookStatsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *ShowHookStatsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no service specified")
+	}
+	if !names.IsValidService(args[0]) {
+		return errors.Errorf("invalid service name %q", args[0])
+	}
+	c.ServiceName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *ShowHookStatsCommand) getAPI() (ShowHookStatsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// hookStats holds the statistics shown for a hook, action or commands.
+type hookStats struct {
+	Kind   string `yaml:"kind" json:"kind"`
+	Name   string `yaml:"name" json:"name"`
+	Runs   int    `yaml:"runs" json:"runs"`
+	Failed int    `yaml:"failed" json:"failed"`
+	Min    string `yaml:"min" json:"min"`
+	P50    string `yaml:"p50" json:"p50"`
+	P90    string `yaml:"p90" json:"p90"`
+	P99    string `yaml:"p99" json:"p99"`
+	Max    string `yaml:"max" json:"max"`
+}
+
+// Run implements Command.Run.
+func (c *ShowHookStatsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ServiceHookStats(c.ServiceName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("no hook runs recorded for service %q", c.ServiceName)
+		return nil
+	}
+	stats := make([]hookStats, len(results))
+	for i, result := range results {
+		stats[i] = hookStats{
+			Kind:   result.Kind,
+			Name:   result.Name,
+			Runs:   result.Runs,
+			Failed: result.Failed,
+			Min:    result.Min.String(),
+			P50:    result.P50.String(),
+			P90:    result.P90.String(),
+			P99:    result.P99.String(),
+			Max:    result.Max.String(),
+		}
+	}
+	return c.out.Write(ctx, stats)
+}
+
+// formatHookStatsTabular returns a tabular summary of hook statistics.
+func formatHookStatsTabular(value interface{}) ([]byte, error) {
+	stats, ok := value.([]hookStats)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", stats, value)
+	}
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, 0, 1, 1, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tNAME\tRUNS\tFAILED\tMIN\tP50\tP90\tP99\tMAX")
+	for _, s := range stats {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			s.Kind, s.Name, s.Runs, s.Failed, s.Min, s.P50, s.P90, s.P99, s.Max,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
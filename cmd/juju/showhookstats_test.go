@@ -0,0 +1,136 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/testing"
+)
+
+type ShowHookStatsSuite struct {
+	testing.FakeJujuHomeSuite
+	fake *fakeShowHookStatsAPI
+}
+
+var _ = gc.Suite(&ShowHookStatsSuite{})
+
+func (s *ShowHookStatsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuHomeSuite.SetUpTest(c)
+	s.fake = &fakeShowHookStatsAPI{}
+}
+
+type fakeShowHookStatsAPI struct {
+	serviceName string
+	stats       []params.HookStats
+}
+
+func (f *fakeShowHookStatsAPI) Close() error {
+	return nil
+}
+
+func (f *fakeShowHookStatsAPI) ServiceHookStats(serviceName string) ([]params.HookStats, error) {
+	f.serviceName = serviceName
+	return f.stats, nil
+}
+
+func (s *ShowHookStatsSuite) runShowHookStats(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &ShowHookStatsCommand{api: s.fake}
+	return testing.RunCommand(c, envcmd.Wrap(command), args...)
+}
+
+func (s *ShowHookStatsSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no service specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `invalid service name "mysql/0"`,
+	}, {
+		args: []string{"mysql", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runShowHookStats(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ShowHookStatsSuite) setStats() {
+	s.fake.stats = []params.HookStats{{
+		Kind: "action",
+		Name: "snapshot",
+		Runs: 1,
+		Min:  42 * time.Second,
+		P50:  42 * time.Second,
+		P90:  42 * time.Second,
+		P99:  42 * time.Second,
+		Max:  42 * time.Second,
+	}, {
+		Kind:   "hook",
+		Name:   "config-changed",
+		Runs:   10,
+		Failed: 1,
+		Min:    time.Second,
+		P50:    5 * time.Second,
+		P90:    9 * time.Second,
+		P99:    10 * time.Second,
+		Max:    10 * time.Second,
+	}}
+}
+
+func (s *ShowHookStatsSuite) TestTabular(c *gc.C) {
+	s.setStats()
+	ctx, err := s.runShowHookStats(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.serviceName, gc.Equals, "mysql")
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"KIND   NAME           RUNS FAILED MIN P50 P90 P99 MAX\n"+
+		"action snapshot       1    0      42s 42s 42s 42s 42s\n"+
+		"hook   config-changed 10   1      1s  5s  9s  10s 10s\n"+
+		"\n",
+	)
+}
+
+func (s *ShowHookStatsSuite) TestYaml(c *gc.C) {
+	s.setStats()
+	ctx, err := s.runShowHookStats(c, "mysql", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"- kind: action\n"+
+		"  name: snapshot\n"+
+		"  runs: 1\n"+
+		"  failed: 0\n"+
+		"  min: 42s\n"+
+		"  p50: 42s\n"+
+		"  p90: 42s\n"+
+		"  p99: 42s\n"+
+		"  max: 42s\n"+
+		"- kind: hook\n"+
+		"  name: config-changed\n"+
+		"  runs: 10\n"+
+		"  failed: 1\n"+
+		"  min: 1s\n"+
+		"  p50: 5s\n"+
+		"  p90: 9s\n"+
+		"  p99: 10s\n"+
+		"  max: 10s\n",
+	)
+}
+
+func (s *ShowHookStatsSuite) TestNoRuns(c *gc.C) {
+	ctx, err := s.runShowHookStats(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "no hook runs recorded for service \"mysql\"\n")
+}
@@ -11,11 +11,13 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/network"
@@ -169,6 +171,7 @@ type machineStatus struct {
 	Containers     map[string]machineStatus `json:"containers,omitempty" yaml:"containers,omitempty"`
 	Hardware       string                   `json:"hardware,omitempty" yaml:"hardware,omitempty"`
 	HAStatus       string                   `json:"state-server-member-status,omitempty" yaml:"state-server-member-status,omitempty"`
+	Blocks         []string                 `json:"blocks,omitempty" yaml:"blocks,omitempty"`
 }
 
 // A goyaml bug means we can't declare these types
@@ -205,6 +208,7 @@ type serviceStatus struct {
 	Networks      map[string][]string   `json:"networks,omitempty" yaml:"networks,omitempty"`
 	SubordinateTo []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
 	Units         map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
+	Blocks        []string              `json:"blocks,omitempty" yaml:"blocks,omitempty"`
 }
 
 type serviceStatusNoMarshal serviceStatus
@@ -242,6 +246,7 @@ type unitStatus struct {
 	OpenedPorts   []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
 	PublicAddress string                `json:"public-address,omitempty" yaml:"public-address,omitempty"`
 	Subordinates  map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
+	Blocks        []string              `json:"blocks,omitempty" yaml:"blocks,omitempty"`
 }
 
 type statusInfoContents struct {
@@ -393,6 +398,7 @@ func (sf *statusFormatter) formatMachine(machine api.MachineStatus) machineStatu
 			Hardware:       machine.Hardware,
 		}
 	}
+	out.Blocks = sf.formatBlocks(names.NewMachineTag(machine.Id))
 
 	for k, m := range machine.Containers {
 		out.Containers[k] = sf.formatMachine(m)
@@ -419,6 +425,7 @@ func (sf *statusFormatter) formatService(name string, service api.ServiceStatus)
 		SubordinateTo: service.SubordinateTo,
 		Units:         make(map[string]unitStatus),
 		StatusInfo:    sf.getServiceStatusInfo(service),
+		Blocks:        sf.formatBlocks(names.NewServiceTag(name)),
 	}
 	if len(service.Networks.Enabled) > 0 {
 		out.Networks["enabled"] = service.Networks.Enabled
@@ -427,7 +434,17 @@ func (sf *statusFormatter) formatService(name string, service api.ServiceStatus)
 		out.Networks["disabled"] = service.Networks.Disabled
 	}
 	for k, m := range service.Units {
-		out.Units[k] = sf.formatUnit(m, name)
+		out.Units[k] = sf.formatUnit(k, m, name)
+	}
+	return out
+}
+
+// formatBlocks returns the operations blocked on the given
+// service, unit or machine.
+func (sf *statusFormatter) formatBlocks(tag names.Tag) []string {
+	var out []string
+	for _, blockType := range sf.status.Blocks[tag.String()] {
+		out = append(out, block.OperationFromType(blockType))
 	}
 	return out
 }
@@ -445,7 +462,7 @@ func (sf *statusFormatter) getServiceStatusInfo(service api.ServiceStatus) statu
 	return info
 }
 
-func (sf *statusFormatter) formatUnit(unit api.UnitStatus, serviceName string) unitStatus {
+func (sf *statusFormatter) formatUnit(name string, unit api.UnitStatus, serviceName string) unitStatus {
 	// TODO(Wallyworld) - this should be server side but we still need to support older servers.
 	sf.updateUnitStatusInfo(&unit, serviceName)
 
@@ -457,6 +474,7 @@ func (sf *statusFormatter) formatUnit(unit api.UnitStatus, serviceName string) u
 		PublicAddress:      unit.PublicAddress,
 		Charm:              unit.Charm,
 		Subordinates:       make(map[string]unitStatus),
+		Blocks:             sf.formatBlocks(names.NewUnitTag(name)),
 	}
 
 	// These legacy fields will be dropped for Juju 2.0.
@@ -469,7 +487,7 @@ func (sf *statusFormatter) formatUnit(unit api.UnitStatus, serviceName string) u
 	}
 
 	for k, m := range unit.Subordinates {
-		out.Subordinates[k] = sf.formatUnit(m, serviceName)
+		out.Subordinates[k] = sf.formatUnit(k, m, serviceName)
 	}
 	return out
 }
@@ -3556,3 +3556,26 @@ func (s *StatusSuite) TestIsoTimeFormat(c *gc.C) {
 		ctx.run(c, t.steps)
 	}(statusTimeTest)
 }
+
+func (s *StatusSuite) TestFormatEntityBlocks(c *gc.C) {
+	status := &api.Status{
+		Machines: map[string]api.MachineStatus{
+			"0": {Id: "0"},
+		},
+		Services: map[string]api.ServiceStatus{
+			"mysql": {
+				Units: map[string]api.UnitStatus{
+					"mysql/0": {Machine: "0"},
+				},
+			},
+		},
+		Blocks: map[string][]string{
+			"machine-0":     {string(multiwatcher.BlockChange)},
+			"service-mysql": {string(multiwatcher.BlockRemove)},
+		},
+	}
+	formatted := newStatusFormatter(status, 0, false).format()
+	c.Assert(formatted.Machines["0"].Blocks, jc.DeepEquals, []string{"all-changes"})
+	c.Assert(formatted.Services["mysql"].Blocks, jc.DeepEquals, []string{"remove-object"})
+	c.Assert(formatted.Services["mysql"].Units["mysql/0"].Blocks, gc.HasLen, 0)
+}
@@ -4,63 +4,145 @@
 package main
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
 	"os"
 	"strconv"
+	"text/tabwriter"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/envcmd"
 	"github.com/juju/juju/juju/osenv"
-	"launchpad.net/gnuflag"
 )
 
 type StatusHistoryCommand struct {
 	envcmd.EnvCommandBase
 	out           cmd.Output
+	api           StatusHistoryAPI
 	outputContent string
 	backlogSize   int
+	offset        int
 	isoTime       bool
 	unitName      string
+	service       string
+	machine       string
+	from          string
+	to            string
+	filter        params.StatusHistoryFilter
+}
+
+// StatusHistoryAPI defines the API methods that the status-history
+// command uses.
+type StatusHistoryAPI interface {
+	Close() error
+	UnitStatusHistory(kind params.HistoryKind, unitName string, size int) (*api.UnitStatusHistory, error)
+	StatusHistory(filter params.StatusHistoryFilter) ([]params.StatusHistoryEntry, error)
 }
 
 var statusHistoryDoc = `
 This command will report the history of status changes for
-a given unit.
-The statuses for the unit workload and/or agent are available.
+a given unit, for all units of a service, for a machine or for
+the whole environment.
 -type supports:
     agent: will show statuses for the unit's agent
     workload: will show statuses for the unit's workload
-    combined: will show agent and workload statuses combined
+    machine: will show statuses for the machine
+    combined: will show all of the above combined
  and sorted by time of occurence.
+
+The --from and --to options select statuses set within a time range,
+and accept either an RFC3339 timestamp or a duration counting back
+from now. At most -n statuses are shown; --offset skips that many of
+the most recent statuses, so that older statuses can be paged through.
+
+Statuses may be exported with --format yaml, json or csv.
+
+Examples:
+    juju status-history mysql/0
+    juju status-history --service mysql --from 2h --format csv
+    juju status-history --machine 0 --from 2015-05-01T00:00:00Z --to 2015-05-02T00:00:00Z
+    juju status-history --service mysql -n 50 --offset 50
 `
 
 func (c *StatusHistoryCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "status-history",
-		Args:    "[-n N] <unit>",
-		Purpose: "output past statuses for a unit",
+		Args:    "[-n N] [<unit>]",
+		Purpose: "output past statuses for a unit, service or machine",
 		Doc:     statusHistoryDoc,
 	}
 }
 
 func (c *StatusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
-	f.StringVar(&c.outputContent, "type", "combined", "type of statuses to be displayed [agent|workload|combined].")
+	f.StringVar(&c.outputContent, "type", "combined", "type of statuses to be displayed [agent|workload|machine|combined].")
 	f.IntVar(&c.backlogSize, "n", 20, "size of logs backlog.")
+	f.IntVar(&c.offset, "offset", 0, "skip this many of the most recent statuses")
+	f.StringVar(&c.service, "service", "", "show statuses for all units of this service")
+	f.StringVar(&c.machine, "machine", "", "show statuses for this machine")
+	f.StringVar(&c.from, "from", "", "show only statuses set at or after this time")
+	f.StringVar(&c.to, "to", "", "show only statuses set at or before this time")
 	f.BoolVar(&c.isoTime, "utc", false, "display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"csv":     formatStatusHistoryCSV,
+		"tabular": c.formatTabular,
+	})
 }
 
 func (c *StatusHistoryCommand) Init(args []string) error {
 	switch {
 	case len(args) > 1:
 		return errors.Errorf("unexpected arguments after unit name.")
-	case len(args) == 0:
-		return errors.Errorf("unit name is missing.")
-	default:
+	case len(args) == 1:
 		c.unitName = args[0]
+		if !names.IsValidUnit(c.unitName) {
+			return errors.Errorf("invalid unit name %q", c.unitName)
+		}
+		c.filter.Entities = append(c.filter.Entities, names.NewUnitTag(c.unitName).String())
+	}
+	if c.service != "" {
+		if !names.IsValidService(c.service) {
+			return errors.Errorf("invalid service name %q", c.service)
+		}
+		c.filter.Entities = append(c.filter.Entities, names.NewServiceTag(c.service).String())
+	}
+	if c.machine != "" {
+		if !names.IsValidMachine(c.machine) {
+			return errors.Errorf("invalid machine id %q", c.machine)
+		}
+		c.filter.Entities = append(c.filter.Entities, names.NewMachineTag(c.machine).String())
+	}
+	if c.backlogSize < 1 {
+		return errors.Errorf("invalid number of statuses %d", c.backlogSize)
+	}
+	if c.offset < 0 {
+		return errors.Errorf("invalid offset %d", c.offset)
+	}
+	c.filter.Limit = c.backlogSize
+	c.filter.Offset = c.offset
+	now := time.Now()
+	if c.from != "" {
+		from, err := parseTimeArg(c.from, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --from")
+		}
+		c.filter.From = &from
+	}
+	if c.to != "" {
+		to, err := parseTimeArg(c.to, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --to")
+		}
+		c.filter.To = &to
 	}
 	// If use of ISO time not specified on command line,
 	// check env var.
@@ -75,45 +157,158 @@ func (c *StatusHistoryCommand) Init(args []string) error {
 	}
 	kind := params.HistoryKind(c.outputContent)
 	switch kind {
-	case params.KindCombined, params.KindAgent, params.KindWorkload:
+	case params.KindCombined, params.KindAgent, params.KindWorkload, params.KindMachine:
+		c.filter.Kind = kind
 		return nil
 
 	}
 	return errors.Errorf("unexpected status type %q", c.outputContent)
 }
 
-func (c *StatusHistoryCommand) Run(ctx *cmd.Context) error {
+// isUnitQuery reports whether only the recent statuses of a single
+// unit were asked for, as supported by older API servers.
+func (c *StatusHistoryCommand) isUnitQuery() bool {
+	return c.unitName != "" && len(c.filter.Entities) == 1 &&
+		c.filter.From == nil && c.filter.To == nil && c.filter.Offset == 0 &&
+		c.filter.Kind != params.KindMachine
+}
+
+func (c *StatusHistoryCommand) getAPI() (StatusHistoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
 	apiclient, err := c.NewAPIClient()
 	if err != nil {
-		return fmt.Errorf(connectionError, c.ConnectionName(), err)
+		return nil, fmt.Errorf(connectionError, c.ConnectionName(), err)
+	}
+	return apiclient, nil
+}
+
+func (c *StatusHistoryCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return err
 	}
 	defer apiclient.Close()
-	var statuses *api.UnitStatusHistory
+	var entries []params.StatusHistoryEntry
+	if c.isUnitQuery() {
+		entries, err = c.unitStatusHistory(ctx, apiclient)
+	} else {
+		entries, err = c.statusHistory(apiclient)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 {
+		return errors.Errorf("no status history available")
+	}
+	return c.out.Write(ctx, entries)
+}
+
+// unitStatusHistory returns the recent statuses of a single unit,
+// including its current statuses, oldest first.
+func (c *StatusHistoryCommand) unitStatusHistory(ctx *cmd.Context, apiclient StatusHistoryAPI) ([]params.StatusHistoryEntry, error) {
 	kind := params.HistoryKind(c.outputContent)
-	statuses, err = apiclient.UnitStatusHistory(kind, c.unitName, c.backlogSize)
+	statuses, err := apiclient.UnitStatusHistory(kind, c.unitName, c.backlogSize)
 	if err != nil {
 		if len(statuses.Statuses) == 0 {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		// Display any error, but continue to print status if some was returned
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
-	} else if len(statuses.Statuses) == 0 {
-		return errors.Errorf("no status history available")
 	}
-	table := [][]string{{"TIME", "TYPE", "STATUS", "MESSAGE"}}
-	lengths := []int{1, 1, 1, 1}
-	for _, v := range statuses.Statuses {
-		fields := []string{formatStatusTime(v.Since, c.isoTime), string(v.Kind), string(v.Status), v.Info}
-		for k, v := range fields {
-			if len(v) > lengths[k] {
-				lengths[k] = len(v)
-			}
+	entity := names.NewUnitTag(c.unitName).String()
+	entries := make([]params.StatusHistoryEntry, len(statuses.Statuses))
+	for i, v := range statuses.Statuses {
+		entries[i] = params.StatusHistoryEntry{
+			Entity: entity,
+			Kind:   v.Kind,
+			Status: v.Status,
+			Info:   v.Info,
+			Data:   v.Data,
+			Since:  v.Since,
+		}
+	}
+	return entries, nil
+}
+
+// statusHistory returns the past statuses selected by the command's
+// filter, oldest first.
+func (c *StatusHistoryCommand) statusHistory(apiclient StatusHistoryAPI) ([]params.StatusHistoryEntry, error) {
+	entries, err := apiclient.StatusHistory(c.filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The API returns the most recent statuses first.
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// statusHistoryEntityName returns the name of the unit or machine
+// with the given tag.
+func statusHistoryEntityName(entity string) string {
+	if tag, err := names.ParseTag(entity); err == nil {
+		return tag.Id()
+	}
+	return entity
+}
+
+// formatTabular returns a tabular summary of status history entries.
+// The entity is only shown when statuses of more than one unit may
+// be included.
+func (c *StatusHistoryCommand) formatTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]params.StatusHistoryEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	showEntity := c.unitName == "" || len(c.filter.Entities) > 1
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, 0, 1, 1, ' ', 0)
+	if showEntity {
+		fmt.Fprint(tw, "TIME\tENTITY\tTYPE\tSTATUS\tMESSAGE\n")
+	} else {
+		fmt.Fprint(tw, "TIME\tTYPE\tSTATUS\tMESSAGE\n")
+	}
+	for _, entry := range entries {
+		fmt.Fprintf(tw, "%s\t", formatStatusTime(entry.Since, c.isoTime))
+		if showEntity {
+			fmt.Fprintf(tw, "%s\t", statusHistoryEntityName(entry.Entity))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", entry.Kind, entry.Status, entry.Info)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
+
+// formatStatusHistoryCSV returns status history entries as comma
+// separated values, with times in RFC3339 format.
+func formatStatusHistoryCSV(value interface{}) ([]byte, error) {
+	entries, ok := value.([]params.StatusHistoryEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	var out bytes.Buffer
+	w := csv.NewWriter(&out)
+	w.Write([]string{"time", "entity", "type", "status", "message"})
+	for _, entry := range entries {
+		var since string
+		if entry.Since != nil {
+			since = entry.Since.UTC().Format(time.RFC3339)
 		}
-		table = append(table, fields)
+		w.Write([]string{
+			since,
+			statusHistoryEntityName(entry.Entity),
+			string(entry.Kind),
+			string(entry.Status),
+			entry.Info,
+		})
 	}
-	f := fmt.Sprintf("%%-%ds\t%%-%ds\t%%-%ds\t%%-%ds\n", lengths[0], lengths[1], lengths[2], lengths[3])
-	for _, v := range table {
-		fmt.Printf(f, v[0], v[1], v[2], v[3])
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, errors.Trace(err)
 	}
-	return nil
+	// cmd.Output appends a newline to the formatted value.
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n")), nil
 }
@@ -0,0 +1,200 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/testing"
+)
+
+type StatusHistorySuite struct {
+	testing.FakeJujuHomeSuite
+	fake *fakeStatusHistoryAPI
+}
+
+var _ = gc.Suite(&StatusHistorySuite{})
+
+func (s *StatusHistorySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuHomeSuite.SetUpTest(c)
+	s.PatchEnvironment(osenv.JujuStatusIsoTimeEnvKey, "")
+	s.fake = &fakeStatusHistoryAPI{}
+}
+
+type fakeStatusHistoryAPI struct {
+	unitName string
+	size     int
+	filter   params.StatusHistoryFilter
+	statuses []api.AgentStatus
+	entries  []params.StatusHistoryEntry
+}
+
+func (f *fakeStatusHistoryAPI) Close() error {
+	return nil
+}
+
+func (f *fakeStatusHistoryAPI) UnitStatusHistory(kind params.HistoryKind, unitName string, size int) (*api.UnitStatusHistory, error) {
+	f.unitName = unitName
+	f.size = size
+	return &api.UnitStatusHistory{Statuses: f.statuses}, nil
+}
+
+func (f *fakeStatusHistoryAPI) StatusHistory(filter params.StatusHistoryFilter) ([]params.StatusHistoryEntry, error) {
+	f.filter = filter
+	return f.entries, nil
+}
+
+func (s *StatusHistorySuite) runStatusHistory(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &StatusHistoryCommand{api: s.fake}
+	return testing.RunCommand(c, envcmd.Wrap(command), args...)
+}
+
+func (s *StatusHistorySuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"mysql/0", "extra"},
+		err:  `unexpected arguments after unit name.`,
+	}, {
+		args: []string{"mysql"},
+		err:  `invalid unit name "mysql"`,
+	}, {
+		args: []string{"--service", "mysql/0"},
+		err:  `invalid service name "mysql/0"`,
+	}, {
+		args: []string{"--machine", "foo"},
+		err:  `invalid machine id "foo"`,
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `invalid --from: invalid time "yesterday", expected RFC3339 timestamp or duration`,
+	}, {
+		args: []string{"-n", "0"},
+		err:  `invalid number of statuses 0`,
+	}, {
+		args: []string{"--offset", "-1"},
+		err:  `invalid offset -1`,
+	}, {
+		args: []string{"--type", "bogus"},
+		err:  `unexpected status type "bogus"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runStatusHistory(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *StatusHistorySuite) TestUnit(c *gc.C) {
+	t0 := time.Date(2015, 5, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	s.fake.statuses = []api.AgentStatus{{
+		Status: params.StatusIdle,
+		Kind:   params.KindAgent,
+		Since:  &t0,
+	}, {
+		Status: params.StatusActive,
+		Info:   "ready",
+		Kind:   params.KindWorkload,
+		Since:  &t1,
+	}}
+	ctx, err := s.runStatusHistory(c, "--utc", "-n", "5", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.unitName, gc.Equals, "mysql/0")
+	c.Assert(s.fake.size, gc.Equals, 5)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                 TYPE     STATUS MESSAGE\n"+
+		"2015-05-01T10:00:00Z agent    idle   \n"+
+		"2015-05-01T10:01:00Z workload active ready\n"+
+		"\n",
+	)
+}
+
+func (s *StatusHistorySuite) TestFilter(c *gc.C) {
+	before := time.Now()
+	_, err := s.runStatusHistory(c,
+		"--service", "mysql",
+		"--machine", "0",
+		"--type", "workload",
+		"--from", "2h",
+		"--to", "2015-05-01T10:00:00Z",
+		"-n", "5",
+		"--offset", "10",
+	)
+	c.Assert(err, gc.ErrorMatches, "no status history available")
+	c.Assert(s.fake.unitName, gc.Equals, "")
+	c.Assert(s.fake.filter.Entities, jc.DeepEquals, []string{"service-mysql", "machine-0"})
+	c.Assert(s.fake.filter.Kind, gc.Equals, params.KindWorkload)
+	c.Assert(s.fake.filter.Limit, gc.Equals, 5)
+	c.Assert(s.fake.filter.Offset, gc.Equals, 10)
+	c.Assert(s.fake.filter.From, gc.NotNil)
+	c.Assert(s.fake.filter.From.Before(before.Add(-2*time.Hour)), jc.IsFalse)
+	c.Assert(*s.fake.filter.To, gc.Equals, time.Date(2015, 5, 1, 10, 0, 0, 0, time.UTC))
+}
+
+func (s *StatusHistorySuite) TestUnitWithTimeRange(c *gc.C) {
+	_, err := s.runStatusHistory(c, "--from", "1h", "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "no status history available")
+	c.Assert(s.fake.unitName, gc.Equals, "")
+	c.Assert(s.fake.filter.Entities, jc.DeepEquals, []string{"unit-mysql-0"})
+}
+
+func (s *StatusHistorySuite) setServiceEntries() {
+	t0 := time.Date(2015, 5, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	// The API returns the most recent statuses first.
+	s.fake.entries = []params.StatusHistoryEntry{{
+		Entity: "unit-mysql-1",
+		Kind:   params.KindWorkload,
+		Status: params.StatusBlocked,
+		Info:   "waiting for db, then retry",
+		Since:  &t1,
+	}, {
+		Entity: "unit-mysql-0",
+		Kind:   params.KindAgent,
+		Status: params.StatusIdle,
+		Since:  &t0,
+	}}
+}
+
+func (s *StatusHistorySuite) TestServiceTabular(c *gc.C) {
+	s.setServiceEntries()
+	ctx, err := s.runStatusHistory(c, "--utc", "--service", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                 ENTITY  TYPE     STATUS  MESSAGE\n"+
+		"2015-05-01T10:00:00Z mysql/0 agent    idle    \n"+
+		"2015-05-01T10:01:00Z mysql/1 workload blocked waiting for db, then retry\n"+
+		"\n",
+	)
+}
+
+func (s *StatusHistorySuite) TestServiceCSV(c *gc.C) {
+	s.setServiceEntries()
+	ctx, err := s.runStatusHistory(c, "--service", "mysql", "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"time,entity,type,status,message\n"+
+		"2015-05-01T10:00:00Z,mysql/0,agent,idle,\n"+
+		"2015-05-01T10:01:00Z,mysql/1,workload,blocked,\"waiting for db, then retry\"\n",
+	)
+}
+
+func (s *StatusHistorySuite) TestServiceJSON(c *gc.C) {
+	s.setServiceEntries()
+	ctx, err := s.runStatusHistory(c, "--service", "mysql", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		`[{"entity":"unit-mysql-0","kind":"agent","status":"idle","since":"2015-05-01T10:00:00Z"},`+
+		`{"entity":"unit-mysql-1","kind":"workload","status":"blocked","info":"waiting for db, then retry","since":"2015-05-01T10:01:00Z"}]`+
+		"\n",
+	)
+}
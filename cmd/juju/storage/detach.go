@@ -0,0 +1,107 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const DetachCommandDoc = `
+Detach storage instances from the units that own them.
+
+The "<name>-storage-detaching" hook is run in the unit that owns each
+storage instance, and the volume backing the storage is then detached
+from the unit's machine. The detached storage is not destroyed, and
+may be attached to a new unit of the same service with
+"juju add-unit --attach-storage". Only persistent, volume-backed storage
+may be detached.
+
+options:
+-e, --environment (= "")
+    juju environment to operate in
+<storage-id>
+    ID of a storage instance to detach, e.g. data/0
+
+Example:
+    juju storage detach data/0
+`
+
+// DetachCommand detaches storage instances from their owning units.
+type DetachCommand struct {
+	StorageCommandBase
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *DetachCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("detach requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *DetachCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "detach",
+		Args:    "<storage-id> [...]",
+		Purpose: "detach storage instances from their units",
+		Doc:     DetachCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *DetachCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+}
+
+// Run implements Command.Run.
+func (c *DetachCommand) Run(ctx *cmd.Context) error {
+	api, err := getDetachAPI(c)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Detach(c.storageIds)
+	if err != nil {
+		return err
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "cannot detach storage %s: %v\n", c.storageIds[i], result.Error)
+			failed = true
+		}
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+var getDetachAPI = (*DetachCommand).getDetachAPI
+
+// DetachAPI defines the API methods that the detach command uses.
+type DetachAPI interface {
+	Close() error
+	Detach(storageIds []string) ([]params.ErrorResult, error)
+}
+
+func (c *DetachCommand) getDetachAPI() (DetachAPI, error) {
+	return c.NewStorageAPI()
+}
@@ -0,0 +1,89 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type DetachSuite struct {
+	SubStorageSuite
+	mockAPI *mockDetachAPI
+}
+
+var _ = gc.Suite(&DetachSuite{})
+
+func (s *DetachSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockDetachAPI{}
+	s.PatchValue(storage.GetDetachAPI, func(c *storage.DetachCommand) (storage.DetachAPI, error) {
+		return s.mockAPI, nil
+	})
+}
+
+func runDetach(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, envcmd.Wrap(&storage.DetachCommand{}), args...)
+}
+
+func (s *DetachSuite) TestDetachNoArgs(c *gc.C) {
+	_, err := runDetach(c)
+	c.Assert(err, gc.ErrorMatches, "detach requires at least one storage ID")
+}
+
+func (s *DetachSuite) TestDetachInvalidStorageId(c *gc.C) {
+	_, err := runDetach(c, "data/0", "data")
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *DetachSuite) TestDetach(c *gc.C) {
+	_, err := runDetach(c, "data/0", "data/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.storageIds, jc.DeepEquals, []string{"data/0", "data/1"})
+}
+
+func (s *DetachSuite) TestDetachResultError(c *gc.C) {
+	s.mockAPI.results = []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "not supported"}},
+	}
+	ctx, err := runDetach(c, "data/0", "data/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "cannot detach storage data/1: not supported\n")
+}
+
+func (s *DetachSuite) TestDetachError(c *gc.C) {
+	s.mockAPI.err = errors.New("no way")
+	_, err := runDetach(c, "data/0")
+	c.Assert(err, gc.ErrorMatches, "no way")
+}
+
+type mockDetachAPI struct {
+	storageIds []string
+	results    []params.ErrorResult
+	err        error
+}
+
+func (s *mockDetachAPI) Close() error {
+	return nil
+}
+
+func (s *mockDetachAPI) Detach(storageIds []string) ([]params.ErrorResult, error) {
+	s.storageIds = storageIds
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.results != nil {
+		return s.results, nil
+	}
+	return make([]params.ErrorResult, len(storageIds)), nil
+}
@@ -10,5 +10,13 @@ var (
 	GetPoolCreateAPI  = &getPoolCreateAPI
 	GetVolumeListAPI  = &getVolumeListAPI
 
+	GetSnapshotCreateAPI = &getSnapshotCreateAPI
+	GetSnapshotListAPI   = &getSnapshotListAPI
+	GetSnapshotRemoveAPI = &getSnapshotRemoveAPI
+
+	GetResizeAPI = &getResizeAPI
+	GetDetachAPI = &getDetachAPI
+	GetImportAPI = &getImportAPI
+
 	ConvertToVolumeInfo = convertToVolumeInfo
 )
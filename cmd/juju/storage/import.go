@@ -0,0 +1,105 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+)
+
+const ImportCommandDoc = `
+Import a volume that was created outside of Juju as detached storage.
+
+The volume is described by the storage provider of the specified pool to
+ensure that it exists, and is then recorded as a persistent storage
+instance with the given storage name. The storage is not attached to any
+unit; it may be attached to a new unit of a service whose charm declares
+block storage with that name, using "juju add-unit --attach-storage".
+Only volumes from environment-scoped storage providers, such as ebs and
+cinder, may be imported.
+
+The ID of the new storage instance is printed on success.
+
+options:
+-e, --environment (= "")
+    juju environment to operate in
+<pool>
+    storage pool, or storage provider, that manages the volume
+<provider-volume-id>
+    storage provider's ID for the volume, e.g. vol-0a1b2c3d
+<storage-name>
+    name of the charm storage to import the volume as, e.g. data
+
+Example:
+    juju storage import ebs vol-0a1b2c3d data
+`
+
+// ImportCommand imports a pre-existing volume as detached storage.
+type ImportCommand struct {
+	StorageCommandBase
+	pool        string
+	providerId  string
+	storageName string
+}
+
+// Init implements Command.Init.
+func (c *ImportCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.New("import requires a pool, a provider volume ID and a storage name")
+	}
+	if !names.IsValidStorage(args[2] + "/0") {
+		return errors.NotValidf("storage name %q", args[2])
+	}
+	c.pool = args[0]
+	c.providerId = args[1]
+	c.storageName = args[2]
+	return cmd.CheckEmpty(args[3:])
+}
+
+// Info implements Command.Info.
+func (c *ImportCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import",
+		Args:    "<pool> <provider-volume-id> <storage-name>",
+		Purpose: "import a pre-existing volume as detached storage",
+		Doc:     ImportCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *ImportCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+}
+
+// Run implements Command.Run.
+func (c *ImportCommand) Run(ctx *cmd.Context) error {
+	api, err := getImportAPI(c)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	tag, err := api.Import(c.pool, c.providerId, c.storageName)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(ctx.Stdout, tag.Id())
+	return nil
+}
+
+var getImportAPI = (*ImportCommand).getImportAPI
+
+// ImportAPI defines the API methods that the import command uses.
+type ImportAPI interface {
+	Close() error
+	Import(pool, providerId, storageName string) (names.StorageTag, error)
+}
+
+func (c *ImportCommand) getImportAPI() (ImportAPI, error) {
+	return c.NewStorageAPI()
+}
@@ -0,0 +1,87 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type ImportSuite struct {
+	SubStorageSuite
+	mockAPI *mockImportAPI
+}
+
+var _ = gc.Suite(&ImportSuite{})
+
+func (s *ImportSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockImportAPI{}
+	s.PatchValue(storage.GetImportAPI, func(c *storage.ImportCommand) (storage.ImportAPI, error) {
+		return s.mockAPI, nil
+	})
+}
+
+func runImport(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, envcmd.Wrap(&storage.ImportCommand{}), args...)
+}
+
+func (s *ImportSuite) TestImportNotEnoughArgs(c *gc.C) {
+	_, err := runImport(c, "ebs", "vol-123")
+	c.Assert(err, gc.ErrorMatches, "import requires a pool, a provider volume ID and a storage name")
+}
+
+func (s *ImportSuite) TestImportInvalidStorageName(c *gc.C) {
+	_, err := runImport(c, "ebs", "vol-123", "0data")
+	c.Assert(err, gc.ErrorMatches, `storage name "0data" not valid`)
+}
+
+func (s *ImportSuite) TestImportTooManyArgs(c *gc.C) {
+	_, err := runImport(c, "ebs", "vol-123", "data", "logs")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["logs"\]`)
+}
+
+func (s *ImportSuite) TestImport(c *gc.C) {
+	ctx, err := runImport(c, "ebs", "vol-123", "data")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.pool, gc.Equals, "ebs")
+	c.Assert(s.mockAPI.providerId, gc.Equals, "vol-123")
+	c.Assert(s.mockAPI.storageName, gc.Equals, "data")
+	c.Assert(testing.Stdout(ctx), gc.Equals, "data/0\n")
+}
+
+func (s *ImportSuite) TestImportError(c *gc.C) {
+	s.mockAPI.err = errors.New("no such volume")
+	_, err := runImport(c, "ebs", "vol-123", "data")
+	c.Assert(err, gc.ErrorMatches, "no such volume")
+}
+
+type mockImportAPI struct {
+	pool        string
+	providerId  string
+	storageName string
+	err         error
+}
+
+func (s *mockImportAPI) Close() error {
+	return nil
+}
+
+func (s *mockImportAPI) Import(pool, providerId, storageName string) (names.StorageTag, error) {
+	s.pool = pool
+	s.providerId = providerId
+	s.storageName = storageName
+	if s.err != nil {
+		return names.StorageTag{}, s.err
+	}
+	return names.NewStorageTag(storageName + "/0"), nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils"
+	"launchpad.net/gnuflag"
+)
+
+const ResizeCommandDoc = `
+Grow a storage instance to a new size.
+
+The volume backing the storage instance is grown by its storage provider,
+and any filesystem on it is then grown to fill the volume. Once the storage
+has been grown, the "<name>-storage-resized" hook is run in the unit that
+owns it. Storage cannot be shrunk, and only storage backed by a volume can
+be grown.
+
+The size is specified in the same way as in storage constraints, with an
+optional multiplier suffix of M, G, T or P (the default is M, for MiB).
+
+options:
+-e, --environment (= "")
+    juju environment to operate in
+<storage-id>
+    ID of the storage instance to grow, e.g. data/0
+<size>
+    new size of the storage instance, e.g. 20G
+
+Example:
+    juju storage resize data/0 20G
+`
+
+// ResizeCommand grows a storage instance.
+type ResizeCommand struct {
+	StorageCommandBase
+	storageId string
+	size      uint64
+}
+
+// Init implements Command.Init.
+func (c *ResizeCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("resize requires a storage ID and a size")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotate(err, "cannot parse size")
+	}
+	if size == 0 {
+		return errors.NotValidf("size %q", args[1])
+	}
+	c.storageId = args[0]
+	c.size = size
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Info implements Command.Info.
+func (c *ResizeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize",
+		Args:    "<storage-id> <size>",
+		Purpose: "grow a storage instance",
+		Doc:     ResizeCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *ResizeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+}
+
+// Run implements Command.Run.
+func (c *ResizeCommand) Run(ctx *cmd.Context) error {
+	api, err := getResizeAPI(c)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	return api.Resize(c.storageId, c.size)
+}
+
+var getResizeAPI = (*ResizeCommand).getResizeAPI
+
+// ResizeAPI defines the API methods that the resize command uses.
+type ResizeAPI interface {
+	Close() error
+	Resize(storageId string, size uint64) error
+}
+
+func (c *ResizeCommand) getResizeAPI() (ResizeAPI, error) {
+	return c.NewStorageAPI()
+}
@@ -0,0 +1,86 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type ResizeSuite struct {
+	SubStorageSuite
+	mockAPI *mockResizeAPI
+}
+
+var _ = gc.Suite(&ResizeSuite{})
+
+func (s *ResizeSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockResizeAPI{}
+	s.PatchValue(storage.GetResizeAPI, func(c *storage.ResizeCommand) (storage.ResizeAPI, error) {
+		return s.mockAPI, nil
+	})
+}
+
+func runResize(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, envcmd.Wrap(&storage.ResizeCommand{}), args...)
+}
+
+func (s *ResizeSuite) TestResizeNoArgs(c *gc.C) {
+	_, err := runResize(c, "data/0")
+	c.Assert(err, gc.ErrorMatches, "resize requires a storage ID and a size")
+}
+
+func (s *ResizeSuite) TestResizeInvalidStorageId(c *gc.C) {
+	_, err := runResize(c, "data", "20G")
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *ResizeSuite) TestResizeInvalidSize(c *gc.C) {
+	_, err := runResize(c, "data/0", "lots")
+	c.Assert(err, gc.ErrorMatches, `cannot parse size: .*`)
+	_, err = runResize(c, "data/0", "0")
+	c.Assert(err, gc.ErrorMatches, `size "0" not valid`)
+}
+
+func (s *ResizeSuite) TestResizeTooManyArgs(c *gc.C) {
+	_, err := runResize(c, "data/0", "20G", "30G")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["30G"\]`)
+}
+
+func (s *ResizeSuite) TestResize(c *gc.C) {
+	_, err := runResize(c, "data/0", "20G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.storageId, gc.Equals, "data/0")
+	c.Assert(s.mockAPI.size, gc.Equals, uint64(20*1024))
+}
+
+func (s *ResizeSuite) TestResizeError(c *gc.C) {
+	s.mockAPI.err = errors.New("no way")
+	_, err := runResize(c, "data/0", "2048")
+	c.Assert(err, gc.ErrorMatches, "no way")
+}
+
+type mockResizeAPI struct {
+	storageId string
+	size      uint64
+	err       error
+}
+
+func (s *mockResizeAPI) Close() error {
+	return nil
+}
+
+func (s *mockResizeAPI) Resize(storageId string, size uint64) error {
+	s.storageId = storageId
+	s.size = size
+	return s.err
+}
@@ -0,0 +1,34 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/envcmd"
+)
+
+const snapshotCmdDoc = `
+"juju storage snapshot" is used to manage snapshots of storage
+ volumes in the Juju environment.
+`
+
+const snapshotCmdPurpose = "manage storage volume snapshots"
+
+// NewSnapshotSuperCommand creates the storage snapshot super subcommand
+// and registers the subcommands that it supports.
+func NewSnapshotSuperCommand() cmd.Command {
+	snapshotcmd := Command{
+		SuperCommand: *jujucmd.NewSubSuperCommand(cmd.SuperCommandParams{
+			Name:        "snapshot",
+			Doc:         snapshotCmdDoc,
+			UsagePrefix: "juju storage",
+			Purpose:     snapshotCmdPurpose,
+		})}
+	snapshotcmd.Register(envcmd.Wrap(&SnapshotCreateCommand{}))
+	snapshotcmd.Register(envcmd.Wrap(&SnapshotListCommand{}))
+	snapshotcmd.Register(envcmd.Wrap(&SnapshotRemoveCommand{}))
+	return &snapshotcmd
+}
@@ -0,0 +1,28 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+var expectedSnapshotCommmandNames = []string{
+	"create",
+	"help",
+	"list",
+	"remove",
+}
+
+type snapshotSuite struct {
+	HelpStorageSuite
+}
+
+var _ = gc.Suite(&snapshotSuite{})
+
+func (s *snapshotSuite) TestSnapshotHelp(c *gc.C) {
+	s.command = storage.NewSnapshotSuperCommand().(*storage.Command)
+	s.assertHelp(c, expectedSnapshotCommmandNames)
+}
@@ -0,0 +1,104 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const SnapshotCreateCommandDoc = `
+Create snapshots of storage volumes.
+
+A snapshot is recorded for each volume, and is then taken by the storage
+provider that manages the volume. The ID of each new snapshot is printed;
+use "juju storage snapshot list" to see when the snapshots have been taken.
+
+options:
+-e, --environment (= "")
+    juju environment to operate in
+<volume> [<volume> ...]
+    IDs of the volumes to snapshot
+`
+
+// SnapshotCreateCommand creates volume snapshots.
+type SnapshotCreateCommand struct {
+	StorageCommandBase
+	volumes []string
+}
+
+// Init implements Command.Init.
+func (c *SnapshotCreateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("snapshot create requires at least one volume ID")
+	}
+	for _, id := range args {
+		if !names.IsValidVolume(id) {
+			return errors.NotValidf("volume ID %q", id)
+		}
+	}
+	c.volumes = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *SnapshotCreateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create",
+		Args:    "<volume> [<volume> ...]",
+		Purpose: "create storage volume snapshots",
+		Doc:     SnapshotCreateCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *SnapshotCreateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+}
+
+// Run implements Command.Run.
+func (c *SnapshotCreateCommand) Run(ctx *cmd.Context) error {
+	api, err := getSnapshotCreateAPI(c)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.CreateVolumeSnapshots(c.volumes)
+	if err != nil {
+		return err
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "cannot snapshot volume %s: %v\n", c.volumes[i], result.Error)
+			failed = true
+			continue
+		}
+		fmt.Fprintln(ctx.Stdout, result.Result.Id)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+var getSnapshotCreateAPI = (*SnapshotCreateCommand).getSnapshotCreateAPI
+
+// SnapshotCreateAPI defines the API methods that the snapshot create
+// command uses.
+type SnapshotCreateAPI interface {
+	Close() error
+	CreateVolumeSnapshots(volumes []string) ([]params.VolumeSnapshotResult, error)
+}
+
+func (c *SnapshotCreateCommand) getSnapshotCreateAPI() (SnapshotCreateAPI, error) {
+	return c.NewStorageAPI()
+}
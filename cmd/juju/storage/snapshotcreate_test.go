@@ -0,0 +1,91 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type SnapshotCreateSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotCreateAPI
+}
+
+var _ = gc.Suite(&SnapshotCreateSuite{})
+
+func (s *SnapshotCreateSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockSnapshotCreateAPI{}
+	s.PatchValue(storage.GetSnapshotCreateAPI, func(c *storage.SnapshotCreateCommand) (storage.SnapshotCreateAPI, error) {
+		return s.mockAPI, nil
+	})
+}
+
+func runSnapshotCreate(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, envcmd.Wrap(&storage.SnapshotCreateCommand{}), args...)
+}
+
+func (s *SnapshotCreateSuite) TestSnapshotCreateNoArgs(c *gc.C) {
+	_, err := runSnapshotCreate(c)
+	c.Assert(err, gc.ErrorMatches, "snapshot create requires at least one volume ID")
+}
+
+func (s *SnapshotCreateSuite) TestSnapshotCreateInvalidVolume(c *gc.C) {
+	_, err := runSnapshotCreate(c, "0", "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `volume ID "mysql/0" not valid`)
+}
+
+func (s *SnapshotCreateSuite) TestSnapshotCreate(c *gc.C) {
+	context, err := runSnapshotCreate(c, "0", "1/2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.volumes, jc.DeepEquals, []string{"0", "1/2"})
+	c.Assert(testing.Stdout(context), gc.Equals, "0@0\n1/2@1\n")
+}
+
+func (s *SnapshotCreateSuite) TestSnapshotCreateError(c *gc.C) {
+	s.mockAPI.errVolume = "1/2"
+	context, err := runSnapshotCreate(c, "0", "1/2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stdout(context), gc.Equals, "0@0\n")
+	c.Assert(testing.Stderr(context), gc.Equals, "cannot snapshot volume 1/2: volume not provisioned\n")
+}
+
+type mockSnapshotCreateAPI struct {
+	volumes   []string
+	errVolume string
+}
+
+func (s *mockSnapshotCreateAPI) Close() error {
+	return nil
+}
+
+func (s *mockSnapshotCreateAPI) CreateVolumeSnapshots(volumes []string) ([]params.VolumeSnapshotResult, error) {
+	s.volumes = volumes
+	results := make([]params.VolumeSnapshotResult, len(volumes))
+	for i, volume := range volumes {
+		if volume == s.errVolume {
+			results[i].Error = common.ServerError(errors.New("volume not provisioned"))
+			continue
+		}
+		results[i].Result = params.VolumeSnapshot{
+			Id:        fmt.Sprintf("%s@%d", volume, i),
+			VolumeTag: "volume-" + strings.Replace(volume, "/", "-", -1),
+			Life:      params.Alive,
+		}
+	}
+	return results, nil
+}
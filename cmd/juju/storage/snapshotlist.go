@@ -0,0 +1,146 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const SnapshotListCommandDoc = `
+List snapshots of storage volumes in the environment.
+
+A snapshot without a provider ID has not been taken yet.
+
+options:
+-e, --environment (= "")
+    juju environment to operate in
+-o, --output (= "")
+    specify an output file
+[volume]
+    volume IDs for filtering the list
+`
+
+// SnapshotListCommand lists volume snapshots.
+type SnapshotListCommand struct {
+	StorageCommandBase
+	volumes []string
+	out     cmd.Output
+}
+
+// Init implements Command.Init.
+func (c *SnapshotListCommand) Init(args []string) error {
+	for _, id := range args {
+		if !names.IsValidVolume(id) {
+			return errors.NotValidf("volume ID %q", id)
+		}
+	}
+	c.volumes = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *SnapshotListCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list",
+		Args:    "[<volume> ...]",
+		Purpose: "list storage volume snapshots",
+		Doc:     SnapshotListCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *SnapshotListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *SnapshotListCommand) Run(ctx *cmd.Context) error {
+	api, err := getSnapshotListAPI(c)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	found, err := api.ListVolumeSnapshots(c.volumes)
+	if err != nil {
+		return err
+	}
+	output := make(map[string]SnapshotInfo)
+	for _, one := range found {
+		if one.Error != nil {
+			// display individual error
+			fmt.Fprintf(ctx.Stderr, "%v\n", one.Error)
+			continue
+		}
+		info, err := convertToSnapshotInfo(one.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		output[one.Result.Id] = info
+	}
+	if len(output) == 0 {
+		return nil
+	}
+	return c.out.Write(ctx, output)
+}
+
+// SnapshotInfo defines the serialization behaviour for storage volume
+// snapshots.
+type SnapshotInfo struct {
+	// Volume is the Juju ID of the volume.
+	Volume string `yaml:"volume" json:"volume"`
+
+	// SnapshotId is the provider-supplied ID of the snapshot.
+	SnapshotId string `yaml:"id,omitempty" json:"id,omitempty"`
+
+	// Size is the size of the volume when it was snapshotted, in MiB.
+	Size uint64 `yaml:"size,omitempty" json:"size,omitempty"`
+
+	Created string `yaml:"created,omitempty" json:"created,omitempty"`
+	Life    string `yaml:"life" json:"life"`
+}
+
+func convertToSnapshotInfo(snapshot params.VolumeSnapshot) (SnapshotInfo, error) {
+	volume, err := idFromTag(snapshot.VolumeTag)
+	if err != nil {
+		return SnapshotInfo{}, errors.Trace(err)
+	}
+	info := SnapshotInfo{
+		Volume:     volume,
+		SnapshotId: snapshot.SnapshotId,
+		Size:       snapshot.Size,
+		Life:       string(snapshot.Life),
+	}
+	if !snapshot.Created.IsZero() {
+		info.Created = snapshot.Created.UTC().Format(time.RFC3339)
+	}
+	return info, nil
+}
+
+var getSnapshotListAPI = (*SnapshotListCommand).getSnapshotListAPI
+
+// SnapshotListAPI defines the API methods that the snapshot list command
+// uses.
+type SnapshotListAPI interface {
+	Close() error
+	ListVolumeSnapshots(volumes []string) ([]params.VolumeSnapshotResult, error)
+}
+
+func (c *SnapshotListCommand) getSnapshotListAPI() (SnapshotListAPI, error) {
+	return c.NewStorageAPI()
+}
@@ -0,0 +1,120 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type SnapshotListSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotListAPI
+}
+
+var _ = gc.Suite(&SnapshotListSuite{})
+
+func (s *SnapshotListSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockSnapshotListAPI{}
+	s.PatchValue(storage.GetSnapshotListAPI, func(c *storage.SnapshotListCommand) (storage.SnapshotListAPI, error) {
+		return s.mockAPI, nil
+	})
+}
+
+func runSnapshotList(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, envcmd.Wrap(&storage.SnapshotListCommand{}), args...)
+}
+
+func (s *SnapshotListSuite) TestSnapshotListInvalidVolume(c *gc.C) {
+	_, err := runSnapshotList(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `volume ID "mysql/0" not valid`)
+}
+
+func (s *SnapshotListSuite) TestSnapshotListTabular(c *gc.C) {
+	context, err := runSnapshotList(c, "0", "1/2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.volumes, jc.DeepEquals, []string{"0", "1/2"})
+	c.Assert(testing.Stdout(context), gc.Equals, `
+SNAPSHOT  VOLUME  ID        SIZE    CREATED               LIFE
+0@0       0       snap-0@0  1.0GiB  2015-07-01T10:00:00Z  alive
+1/2@1     1/2                                             dying
+
+`[1:])
+	c.Assert(testing.Stderr(context), gc.Equals, "volume \"3\" not found\n")
+}
+
+func (s *SnapshotListSuite) TestSnapshotListYaml(c *gc.C) {
+	context, err := runSnapshotList(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.volumes, gc.HasLen, 0)
+	var output map[string]storage.SnapshotInfo
+	err = goyaml.Unmarshal([]byte(testing.Stdout(context)), &output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, jc.DeepEquals, map[string]storage.SnapshotInfo{
+		"0@0": {
+			Volume:     "0",
+			SnapshotId: "snap-0@0",
+			Size:       1024,
+			Created:    "2015-07-01T10:00:00Z",
+			Life:       "alive",
+		},
+		"1/2@1": {
+			Volume: "1/2",
+			Life:   "dying",
+		},
+	})
+}
+
+func (s *SnapshotListSuite) TestSnapshotListError(c *gc.C) {
+	s.mockAPI.err = errors.New("no way")
+	_, err := runSnapshotList(c)
+	c.Assert(err, gc.ErrorMatches, "no way")
+}
+
+type mockSnapshotListAPI struct {
+	volumes []string
+	err     error
+}
+
+func (s *mockSnapshotListAPI) Close() error {
+	return nil
+}
+
+func (s *mockSnapshotListAPI) ListVolumeSnapshots(volumes []string) ([]params.VolumeSnapshotResult, error) {
+	s.volumes = volumes
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []params.VolumeSnapshotResult{{
+		Result: params.VolumeSnapshot{
+			Id:         "0@0",
+			VolumeTag:  "volume-0",
+			Life:       params.Alive,
+			SnapshotId: "snap-0@0",
+			Size:       1024,
+			Created:    time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}, {
+		Result: params.VolumeSnapshot{
+			Id:        "1/2@1",
+			VolumeTag: "volume-1-2",
+			Life:      params.Dying,
+		},
+	}, {
+		Error: common.ServerError(errors.NotFoundf("volume %q", "3")),
+	}}, nil
+}
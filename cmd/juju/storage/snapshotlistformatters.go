@@ -0,0 +1,54 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+)
+
+// formatSnapshotListTabular returns a tabular summary of volume snapshots.
+func formatSnapshotListTabular(value interface{}) ([]byte, error) {
+	infos, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("SNAPSHOT", "VOLUME", "ID", "SIZE", "CREATED", "LIFE")
+
+	ids := make([]string, 0, len(infos))
+	for id := range infos {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		info := infos[id]
+		var size string
+		if info.Size > 0 {
+			size = humanize.IBytes(info.Size * humanize.MiByte)
+		}
+		print(id, info.Volume, info.SnapshotId, size, info.Created, info.Life)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
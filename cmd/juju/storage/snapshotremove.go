@@ -0,0 +1,86 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+)
+
+const SnapshotRemoveCommandDoc = `
+Remove storage volume snapshots.
+
+Each snapshot is destroyed by the storage provider that took it, and then
+removed from the environment.
+
+options:
+-e, --environment (= "")
+    juju environment to operate in
+<snapshot> [<snapshot> ...]
+    IDs of the snapshots to remove, as shown by "juju storage snapshot list"
+`
+
+// SnapshotRemoveCommand removes volume snapshots.
+type SnapshotRemoveCommand struct {
+	StorageCommandBase
+	ids []string
+}
+
+// Init implements Command.Init.
+func (c *SnapshotRemoveCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("snapshot remove requires at least one snapshot ID")
+	}
+	for _, id := range args {
+		at := strings.LastIndex(id, "@")
+		if at == -1 || !names.IsValidVolume(id[:at]) {
+			return errors.NotValidf("volume snapshot ID %q", id)
+		}
+	}
+	c.ids = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *SnapshotRemoveCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove",
+		Args:    "<snapshot> [<snapshot> ...]",
+		Purpose: "remove storage volume snapshots",
+		Doc:     SnapshotRemoveCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *SnapshotRemoveCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+}
+
+// Run implements Command.Run.
+func (c *SnapshotRemoveCommand) Run(ctx *cmd.Context) error {
+	api, err := getSnapshotRemoveAPI(c)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	return api.DestroyVolumeSnapshots(c.ids)
+}
+
+var getSnapshotRemoveAPI = (*SnapshotRemoveCommand).getSnapshotRemoveAPI
+
+// SnapshotRemoveAPI defines the API methods that the snapshot remove
+// command uses.
+type SnapshotRemoveAPI interface {
+	Close() error
+	DestroyVolumeSnapshots(ids []string) error
+}
+
+func (c *SnapshotRemoveCommand) getSnapshotRemoveAPI() (SnapshotRemoveAPI, error) {
+	return c.NewStorageAPI()
+}
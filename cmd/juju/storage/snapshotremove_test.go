@@ -0,0 +1,71 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type SnapshotRemoveSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotRemoveAPI
+}
+
+var _ = gc.Suite(&SnapshotRemoveSuite{})
+
+func (s *SnapshotRemoveSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockSnapshotRemoveAPI{}
+	s.PatchValue(storage.GetSnapshotRemoveAPI, func(c *storage.SnapshotRemoveCommand) (storage.SnapshotRemoveAPI, error) {
+		return s.mockAPI, nil
+	})
+}
+
+func runSnapshotRemove(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, envcmd.Wrap(&storage.SnapshotRemoveCommand{}), args...)
+}
+
+func (s *SnapshotRemoveSuite) TestSnapshotRemoveNoArgs(c *gc.C) {
+	_, err := runSnapshotRemove(c)
+	c.Assert(err, gc.ErrorMatches, "snapshot remove requires at least one snapshot ID")
+}
+
+func (s *SnapshotRemoveSuite) TestSnapshotRemoveInvalidId(c *gc.C) {
+	_, err := runSnapshotRemove(c, "0@0", "0")
+	c.Assert(err, gc.ErrorMatches, `volume snapshot ID "0" not valid`)
+}
+
+func (s *SnapshotRemoveSuite) TestSnapshotRemove(c *gc.C) {
+	_, err := runSnapshotRemove(c, "0@0", "1/2@3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.ids, jc.DeepEquals, []string{"0@0", "1/2@3"})
+}
+
+func (s *SnapshotRemoveSuite) TestSnapshotRemoveError(c *gc.C) {
+	s.mockAPI.err = errors.New("no way")
+	_, err := runSnapshotRemove(c, "0@0")
+	c.Assert(err, gc.ErrorMatches, "no way")
+}
+
+type mockSnapshotRemoveAPI struct {
+	ids []string
+	err error
+}
+
+func (s *mockSnapshotRemoveAPI) Close() error {
+	return nil
+}
+
+func (s *mockSnapshotRemoveAPI) DestroyVolumeSnapshots(ids []string) error {
+	s.ids = ids
+	return s.err
+}
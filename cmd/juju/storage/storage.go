@@ -43,6 +43,10 @@ func NewSuperCommand() cmd.Command {
 	storagecmd.Register(envcmd.Wrap(&ListCommand{}))
 	storagecmd.Register(NewPoolSuperCommand())
 	storagecmd.Register(NewVolumeSuperCommand())
+	storagecmd.Register(NewSnapshotSuperCommand())
+	storagecmd.Register(envcmd.Wrap(&ResizeCommand{}))
+	storagecmd.Register(envcmd.Wrap(&DetachCommand{}))
+	storagecmd.Register(envcmd.Wrap(&ImportCommand{}))
 	return &storagecmd
 }
 
@@ -83,9 +87,15 @@ func formatStorageDetails(storages []params.StorageDetails) (map[string]map[stri
 		if err != nil {
 			return nil, errors.Annotate(err, "invalid storage tag")
 		}
-		unitTag, err := names.ParseTag(one.UnitTag)
-		if err != nil {
-			return nil, errors.Annotate(err, "invalid unit tag")
+		// Detached storage is not attached to any unit,
+		// and is listed under an empty unit name.
+		var unit string
+		if one.UnitTag != "" {
+			unitTag, err := names.ParseTag(one.UnitTag)
+			if err != nil {
+				return nil, errors.Annotate(err, "invalid unit tag")
+			}
+			unit = unitTag.Id()
 		}
 
 		storageName, err := names.StorageName(storageTag.Id())
@@ -99,7 +109,6 @@ func formatStorageDetails(storages []params.StorageDetails) (map[string]map[stri
 			Location:    one.Location,
 			Persistent:  one.Persistent,
 		}
-		unit := unitTag.Id()
 		unitColl, ok := output[unit]
 		if !ok {
 			unitColl = map[string]StorageInfo{}
@@ -10,10 +10,14 @@ import (
 )
 
 var expectedSubCommmandNames = []string{
+	"detach",
 	"help",
+	"import",
 	"list",
 	"pool",
+	"resize",
 	"show",
+	"snapshot",
 	"volume",
 }
 
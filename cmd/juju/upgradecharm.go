@@ -26,6 +26,9 @@ type UpgradeCharmCommand struct {
 	RepoPath    string // defaults to JUJU_REPOSITORY
 	SwitchURL   string
 	Revision    int // defaults to -1 (latest)
+	BatchSize   int // 0 upgrades all units at once
+	Rollback    bool
+	Resume      bool
 }
 
 const upgradeCharmDoc = `
@@ -64,6 +67,22 @@ would specify revision number 5 of the wordpress charm.
 Use of the --force flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
+
+The --batch-size flag starts a rolling upgrade: the service's units are upgraded
+that many at a time, and each batch is upgraded only once the units already
+upgraded report an "active" workload status and have finished running hooks.
+Units added while a rolling upgrade is in progress start with the old charm,
+and are upgraded in turn. If an upgraded unit goes into an "error" or "blocked"
+state, no more units are upgraded, and the upgrade is paused until it is resumed
+with the --resume flag. If --rollback is given as well, the service is instead
+set back to the charm it was upgraded from, and units already upgraded are
+forced back to it.
+
+Examples:
+
+$ juju upgrade-charm --batch-size 2 wordpress
+$ juju upgrade-charm --batch-size 1 --rollback mysql
+$ juju upgrade-charm --resume wordpress
 `
 
 func (c *UpgradeCharmCommand) Info() *cmd.Info {
@@ -80,6 +99,9 @@ func (c *UpgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.RepoPath, "repository", os.Getenv("JUJU_REPOSITORY"), "local charm repository path")
 	f.StringVar(&c.SwitchURL, "switch", "", "crossgrade to a different charm")
 	f.IntVar(&c.Revision, "revision", -1, "explicit revision of current charm")
+	f.IntVar(&c.BatchSize, "batch-size", 0, "upgrade this many units at a time, waiting for each batch to be active")
+	f.BoolVar(&c.Rollback, "rollback", false, "roll back a rolling upgrade if a unit fails, rather than pausing it")
+	f.BoolVar(&c.Resume, "resume", false, "resume a paused rolling upgrade")
 }
 
 func (c *UpgradeCharmCommand) Init(args []string) error {
@@ -97,6 +119,15 @@ func (c *UpgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.Revision != -1 {
 		return fmt.Errorf("--switch and --revision are mutually exclusive")
 	}
+	if c.BatchSize < 0 {
+		return fmt.Errorf("--batch-size must not be negative")
+	}
+	if c.Rollback && c.BatchSize == 0 {
+		return fmt.Errorf("--rollback requires --batch-size")
+	}
+	if c.Resume && (c.Force || c.SwitchURL != "" || c.Revision != -1 || c.BatchSize != 0) {
+		return fmt.Errorf("--resume cannot be combined with other upgrade flags")
+	}
 	return nil
 }
 
@@ -108,6 +139,9 @@ func (c *UpgradeCharmCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
+	if c.Resume {
+		return block.ProcessBlockedError(client.ServiceResumeRollingUpgrade(c.ServiceName), block.BlockChange)
+	}
 	oldURL, err := client.ServiceGetCharmURL(c.ServiceName)
 	if err != nil {
 		return err
@@ -158,5 +192,9 @@ func (c *UpgradeCharmCommand) Run(ctx *cmd.Context) error {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
 
+	if c.BatchSize > 0 {
+		err = client.ServiceSetCharmRolling(c.ServiceName, addedURL.String(), c.Force, c.BatchSize, c.Rollback)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
 	return block.ProcessBlockedError(client.ServiceSetCharm(c.ServiceName, addedURL.String(), c.Force), block.BlockChange)
 }
@@ -102,6 +102,18 @@ func (s *UpgradeCharmErrorsSuite) TestSwitchAndRevisionFails(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "--switch and --revision are mutually exclusive")
 }
 
+func (s *UpgradeCharmErrorsSuite) TestInvalidRollingFlags(c *gc.C) {
+	s.deployService(c)
+	err := runUpgradeCharm(c, "riak", "--batch-size=-1")
+	c.Assert(err, gc.ErrorMatches, "--batch-size must not be negative")
+	err = runUpgradeCharm(c, "riak", "--rollback")
+	c.Assert(err, gc.ErrorMatches, "--rollback requires --batch-size")
+	err = runUpgradeCharm(c, "riak", "--resume", "--batch-size=2")
+	c.Assert(err, gc.ErrorMatches, "--resume cannot be combined with other upgrade flags")
+	err = runUpgradeCharm(c, "riak", "--resume", "--revision=2")
+	c.Assert(err, gc.ErrorMatches, "--resume cannot be combined with other upgrade flags")
+}
+
 func (s *UpgradeCharmErrorsSuite) TestInvalidRevision(c *gc.C) {
 	s.deployService(c)
 	err := runUpgradeCharm(c, "riak", "--revision=blah")
@@ -231,6 +243,35 @@ func (s *UpgradeCharmSuccessSuite) TestBlockForcedUpgrade(c *gc.C) {
 	s.assertLocalRevision(c, 7, s.path)
 }
 
+func (s *UpgradeCharmSuccessSuite) TestRollingUpgrade(c *gc.C) {
+	err := runUpgradeCharm(c, "riak", "--batch-size", "2", "--rollback")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUpgraded(c, 8, false)
+	rolling := s.riak.RollingUpgrade()
+	c.Assert(rolling, gc.NotNil)
+	c.Assert(rolling.FromCharmURL.Revision, gc.Equals, 7)
+	c.Assert(rolling.BatchSize, gc.Equals, 2)
+	c.Assert(rolling.Rollback, jc.IsTrue)
+	c.Assert(rolling.Status, gc.Equals, state.RollingUpgradeRunning)
+}
+
+func (s *UpgradeCharmSuccessSuite) TestResumeRollingUpgrade(c *gc.C) {
+	err := runUpgradeCharm(c, "riak", "--resume")
+	c.Assert(err, gc.ErrorMatches, `cannot resume rolling upgrade of service "riak": rolling upgrade is not paused`)
+
+	err = runUpgradeCharm(c, "riak", "--batch-size", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUpgraded(c, 8, false)
+	err = s.riak.PauseRollingUpgrade(`unit "riak/0" is in blocked state`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runUpgradeCharm(c, "riak", "--resume")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.riak.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.riak.RollingUpgrade().Status, gc.Equals, state.RollingUpgradeRunning)
+}
+
 var myriakMeta = []byte(`
 name: myriak
 summary: "K/V storage engine"
@@ -60,6 +60,8 @@ import (
 	coretools "github.com/juju/juju/tools"
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionpruner"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/addresser"
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/authenticationworker"
@@ -73,7 +75,9 @@ import (
 	"github.com/juju/juju/worker/envworkermanager"
 	"github.com/juju/juju/worker/firewaller"
 	"github.com/juju/juju/worker/instancepoller"
+	"github.com/juju/juju/worker/introspection"
 	"github.com/juju/juju/worker/localstorage"
+	"github.com/juju/juju/worker/logforwarder"
 	workerlogger "github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/machiner"
 	"github.com/juju/juju/worker/metricworker"
@@ -84,6 +88,7 @@ import (
 	"github.com/juju/juju/worker/proxyupdater"
 	rebootworker "github.com/juju/juju/worker/reboot"
 	"github.com/juju/juju/worker/resumer"
+	"github.com/juju/juju/worker/rollingupgrade"
 	"github.com/juju/juju/worker/rsyslog"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
@@ -410,6 +415,9 @@ func (a *MachineAgent) Run(*cmd.Context) error {
 	a.runner.StartWorker("termination", func() (worker.Worker, error) {
 		return terminationworker.NewWorker(), nil
 	})
+	a.runner.StartWorker("introspection", func() (worker.Worker, error) {
+		return introspection.NewWorker(a.runner, introspection.SocketPath(agentConfig.DataDir(), a.Tag()))
+	})
 	// At this point, all workers will have been configured to start
 	close(a.workersStarted)
 	err := a.runner.Wait()
@@ -683,6 +691,13 @@ func (a *MachineAgent) postUpgradeAPIWorker(
 	runner.StartWorker("machiner", func() (worker.Worker, error) {
 		return machiner.NewMachiner(st.Machiner(), agentConfig), nil
 	})
+	runner.StartWorker("workerreports", func() (worker.Worker, error) {
+		machine, err := st.Machiner().Machine(agentConfig.Tag().(names.MachineTag))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return introspection.NewPublisher(machine, a.runner, introspection.PublishPeriod), nil
+	})
 	runner.StartWorker("reboot", func() (worker.Worker, error) {
 		reboot, err := st.Reboot()
 		if err != nil {
@@ -979,9 +994,6 @@ func (a *MachineAgent) StateWorker() (worker.Worker, error) {
 					return dblogpruner.New(st, dblogpruner.NewLogPruneParams()), nil
 				})
 			}
-			a.startWorkerAfterUpgrade(singularRunner, "statushistorypruner", func() (worker.Worker, error) {
-				return statushistorypruner.New(st, statushistorypruner.NewHistoryPrunerParams()), nil
-			})
 
 			a.startWorkerAfterUpgrade(singularRunner, "resumer", func() (worker.Worker, error) {
 				// The action of resumer is so subtle that it is not tested,
@@ -1068,6 +1080,23 @@ func (a *MachineAgent) startEnvWorkers(
 	singularRunner.StartWorker("addresserworker", func() (worker.Worker, error) {
 		return addresser.NewWorker(st)
 	})
+	singularRunner.StartWorker("statushistorypruner", func() (worker.Worker, error) {
+		return statushistorypruner.New(st, statushistorypruner.NewHistoryPrunerParams()), nil
+	})
+	singularRunner.StartWorker("actionpruner", func() (worker.Worker, error) {
+		return actionpruner.New(st, actionpruner.NewActionPrunerParams()), nil
+	})
+	singularRunner.StartWorker("actionscheduler", func() (worker.Worker, error) {
+		return actionscheduler.New(st, actionscheduler.NewActionSchedulerParams()), nil
+	})
+	singularRunner.StartWorker("rollingupgrader", func() (worker.Worker, error) {
+		return rollingupgrade.New(st, rollingupgrade.NewRollingUpgradeParams()), nil
+	})
+	if featureflag.Enabled(feature.DbLog) {
+		singularRunner.StartWorker("logforwarder", func() (worker.Worker, error) {
+			return logforwarder.New(st), nil
+		})
+	}
 
 	// Start workers that use an API connection.
 	singularRunner.StartWorker("environ-provisioner", func() (worker.Worker, error) {
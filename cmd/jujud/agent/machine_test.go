@@ -67,6 +67,7 @@ import (
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
 	"github.com/juju/juju/worker/instancepoller"
+	"github.com/juju/juju/worker/introspection"
 	"github.com/juju/juju/worker/networker"
 	"github.com/juju/juju/worker/peergrouper"
 	"github.com/juju/juju/worker/proxyupdater"
@@ -242,6 +243,10 @@ var perEnvSingularWorkers = []string{
 	"cleaner",
 	"minunitsworker",
 	"addresserworker",
+	"statushistorypruner",
+	"actionpruner",
+	"actionscheduler",
+	"rollingupgrader",
 	"environ-provisioner",
 	"charm-revision-updater",
 	"firewaller",
@@ -685,6 +690,21 @@ func (s *MachineSuite) TestManageEnvironRunsDbLogPrunerIfFeatureFlagEnabled(c *g
 	runner.waitForWorker(c, "dblogpruner")
 }
 
+func (s *MachineSuite) TestManageEnvironRunsLogForwarderIfFeatureFlagEnabled(c *gc.C) {
+	s.SetFeatureFlags(feature.DbLog)
+
+	m, _, _ := s.primeAgent(c, version.Current, state.JobManageEnviron)
+	a := s.newAgent(c, m)
+	defer func() { c.Check(a.Stop(), jc.ErrorIsNil) }()
+	go func() { c.Check(a.Run(nil), jc.ErrorIsNil) }()
+
+	// The log forwarder runs in the singular runner for the
+	// environment, which follows that of the state server.
+	s.singularRecord.nextRunner(c)
+	runner := s.singularRecord.nextRunner(c)
+	runner.waitForWorker(c, "logforwarder")
+}
+
 func (s *MachineSuite) TestManageEnvironDoesntRunDbLogPrunerByDefault(c *gc.C) {
 	m, _, _ := s.primeAgent(c, version.Current, state.JobManageEnviron)
 	a := s.newAgent(c, m)
@@ -704,10 +724,52 @@ func (s *MachineSuite) TestManageEnvironRunsStatusHistoryPruner(c *gc.C) {
 	defer func() { c.Check(a.Stop(), jc.ErrorIsNil) }()
 	go func() { c.Check(a.Run(nil), jc.ErrorIsNil) }()
 
+	// The status history pruner runs in the singular runner for the
+	// environment, which follows that of the state server.
+	s.singularRecord.nextRunner(c)
 	runner := s.singularRecord.nextRunner(c)
 	runner.waitForWorker(c, "statushistorypruner")
 }
 
+func (s *MachineSuite) TestManageEnvironRunsActionPruner(c *gc.C) {
+	m, _, _ := s.primeAgent(c, version.Current, state.JobManageEnviron)
+	a := s.newAgent(c, m)
+	defer func() { c.Check(a.Stop(), jc.ErrorIsNil) }()
+	go func() { c.Check(a.Run(nil), jc.ErrorIsNil) }()
+
+	// The action pruner runs in the singular runner for the
+	// environment, which follows that of the state server.
+	s.singularRecord.nextRunner(c)
+	runner := s.singularRecord.nextRunner(c)
+	runner.waitForWorker(c, "actionpruner")
+}
+
+func (s *MachineSuite) TestManageEnvironRunsActionScheduler(c *gc.C) {
+	m, _, _ := s.primeAgent(c, version.Current, state.JobManageEnviron)
+	a := s.newAgent(c, m)
+	defer func() { c.Check(a.Stop(), jc.ErrorIsNil) }()
+	go func() { c.Check(a.Run(nil), jc.ErrorIsNil) }()
+
+	// The action scheduler runs in the singular runner for the
+	// environment, which follows that of the state server.
+	s.singularRecord.nextRunner(c)
+	runner := s.singularRecord.nextRunner(c)
+	runner.waitForWorker(c, "actionscheduler")
+}
+
+func (s *MachineSuite) TestManageEnvironRunsRollingUpgrader(c *gc.C) {
+	m, _, _ := s.primeAgent(c, version.Current, state.JobManageEnviron)
+	a := s.newAgent(c, m)
+	defer func() { c.Check(a.Stop(), jc.ErrorIsNil) }()
+	go func() { c.Check(a.Run(nil), jc.ErrorIsNil) }()
+
+	// The rolling upgrader runs in the singular runner for the
+	// environment, which follows that of the state server.
+	s.singularRecord.nextRunner(c)
+	runner := s.singularRecord.nextRunner(c)
+	runner.waitForWorker(c, "rollingupgrader")
+}
+
 func (s *MachineSuite) TestManageEnvironCallsUseMultipleCPUs(c *gc.C) {
 	// If it has been enabled, the JobManageEnviron agent should call utils.UseMultipleCPUs
 	usefulVersion := version.Current
@@ -1231,6 +1293,39 @@ func (s *MachineSuite) TestMachineAgentRunsAPIAddressUpdaterWorker(c *gc.C) {
 	c.Fatalf("timeout while waiting for agent config to change")
 }
 
+func (s *MachineSuite) TestMachineAgentReportsWorkers(c *gc.C) {
+	// Start the machine agent.
+	m, conf, _ := s.primeAgent(c, version.Current, state.JobHostUnits)
+	a := s.newAgent(c, m)
+	go func() { c.Check(a.Run(nil), jc.ErrorIsNil) }()
+	defer func() { c.Check(a.Stop(), jc.ErrorIsNil) }()
+
+	// The agent serves the reports of its workers locally...
+	socketPath := introspection.SocketPath(conf.DataDir(), m.Tag())
+	served := false
+	for attempt := coretesting.LongAttempt.Start(); attempt.Next(); {
+		reports, err := introspection.Report(socketPath, "api")
+		if err == nil && len(reports) == 1 && reports[0].State == worker.StateStarted {
+			served = true
+			break
+		}
+	}
+	c.Assert(served, jc.IsTrue)
+
+	// ...and publishes them.
+	for attempt := coretesting.LongAttempt.Start(); attempt.Next(); {
+		reports, _, err := m.WorkerReports()
+		if errors.IsNotFound(err) {
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(reports, gc.Not(gc.HasLen), 0)
+		c.Assert(reports[0].Name, gc.Equals, "api")
+		return
+	}
+	c.Fatalf("timeout while waiting for worker reports to be published")
+}
+
 func (s *MachineSuite) TestMachineAgentRunsDiskManagerWorker(c *gc.C) {
 	// Start the machine agent.
 	m, _, _ := s.primeAgent(c, version.Current, state.JobHostUnits)
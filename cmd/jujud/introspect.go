@@ -0,0 +1,105 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	cmdutil "github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/introspection"
+)
+
+// IntrospectCommand shows the state of the workers of an agent
+// running on this machine.
+type IntrospectCommand struct {
+	cmd.CommandBase
+	out        cmd.Output
+	agent      names.Tag
+	workerName string
+}
+
+const introspectCommandDoc = `
+Show the state of the workers run by an agent on this machine: whether
+each worker is running, how many times it has been started, and the
+error it last stopped with.
+
+agent-name can be either the agent's tag:
+ i.e.  machine-0, unit-ubuntu-0
+or a machine id or unit name:
+ i.e.  0, ubuntu/0
+
+If worker-name is given, only that worker, and the workers it runs,
+are shown.
+`
+
+// Info returns usage information for the command.
+func (c *IntrospectCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "introspect",
+		Args:    "<agent-name> [<worker-name>]",
+		Purpose: "show the state of an agent's workers",
+		Doc:     introspectCommandDoc,
+	}
+}
+
+func (c *IntrospectCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatReportsTabular,
+	})
+}
+
+func (c *IntrospectCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("missing agent-name")
+	}
+	agentName := args[0]
+	switch {
+	case names.IsValidMachine(agentName):
+		c.agent = names.NewMachineTag(agentName)
+	case names.IsValidUnit(agentName):
+		c.agent = names.NewUnitTag(agentName)
+	default:
+		tag, err := names.ParseTag(agentName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		switch tag.(type) {
+		case names.MachineTag, names.UnitTag:
+		default:
+			return errors.Errorf("%q is not a machine or unit agent", agentName)
+		}
+		c.agent = tag
+	}
+	args = args[1:]
+	if len(args) > 0 {
+		c.workerName, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *IntrospectCommand) Run(ctx *cmd.Context) error {
+	socketPath := introspection.SocketPath(cmdutil.DataDir, c.agent)
+	reports, err := introspection.Report(socketPath, c.workerName)
+	if err != nil {
+		return errors.Annotatef(err, "cannot introspect %s", c.agent)
+	}
+	return c.out.Write(ctx, reports)
+}
+
+func formatReportsTabular(value interface{}) ([]byte, error) {
+	reports, ok := value.([]worker.WorkerReport)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", reports, value)
+	}
+	if len(reports) == 0 {
+		return []byte("no workers\n"), nil
+	}
+	return introspection.FormatReports(reports), nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	cmdutil "github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/introspection"
+)
+
+type IntrospectSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IntrospectSuite{})
+
+func (s *IntrospectSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.PatchValue(&cmdutil.DataDir, c.MkDir())
+}
+
+func (*IntrospectSuite) TestArgParsing(c *gc.C) {
+	for i, test := range []struct {
+		args       []string
+		errMatch   string
+		agent      names.Tag
+		workerName string
+	}{{
+		errMatch: "missing agent-name",
+	}, {
+		args:     []string{"foo"},
+		errMatch: `"foo" is not a valid tag`,
+	}, {
+		args:     []string{"service-foo"},
+		errMatch: `"service-foo" is not a machine or unit agent`,
+	}, {
+		args:  []string{"0"},
+		agent: names.NewMachineTag("0"),
+	}, {
+		args:  []string{"foo/1"},
+		agent: names.NewUnitTag("foo/1"),
+	}, {
+		args:       []string{"machine-0", "api"},
+		agent:      names.NewMachineTag("0"),
+		workerName: "api",
+	}, {
+		args:     []string{"0", "api", "machiner"},
+		errMatch: `unrecognized args: \["machiner"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		introspectCmd := &IntrospectCommand{}
+		err := testing.InitCommand(introspectCmd, test.args)
+		if test.errMatch != "" {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(introspectCmd.agent, gc.Equals, test.agent)
+		c.Check(introspectCmd.workerName, gc.Equals, test.workerName)
+	}
+}
+
+type fakeReporter []worker.WorkerReport
+
+func (r fakeReporter) Report() []worker.WorkerReport {
+	return r
+}
+
+func (s *IntrospectSuite) startIntrospection(c *gc.C, tag names.Tag) {
+	err := os.MkdirAll(filepath.Join(cmdutil.DataDir, "agents", tag.String()), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := introspection.NewWorker(fakeReporter{{
+		Name:       "api",
+		State:      worker.StateStarted,
+		StartCount: 2,
+		LastError:  "connection is shut down",
+	}}, introspection.SocketPath(cmdutil.DataDir, tag))
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		c.Check(worker.Stop(w), jc.ErrorIsNil)
+	})
+}
+
+func (s *IntrospectSuite) TestRun(c *gc.C) {
+	s.startIntrospection(c, names.NewMachineTag("0"))
+	ctx, err := testing.RunCommand(c, &IntrospectCommand{}, "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"WORKER  STATE    STARTS  INPUTS  LAST ERROR\n"+
+		"api     started  2               connection is shut down\n",
+	)
+}
+
+func (s *IntrospectSuite) TestRunYAML(c *gc.C) {
+	s.startIntrospection(c, names.NewMachineTag("0"))
+	ctx, err := testing.RunCommand(c, &IntrospectCommand{}, "--format", "yaml", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"- name: api\n"+
+		"  state: started\n"+
+		"  start-count: 2\n"+
+		"  last-error: connection is shut down\n",
+	)
+}
+
+func (s *IntrospectSuite) TestRunAgentNotRunning(c *gc.C) {
+	_, err := testing.RunCommand(c, &IntrospectCommand{}, "0")
+	c.Assert(err, gc.ErrorMatches, "cannot introspect machine-0: cannot connect to agent: .*")
+}
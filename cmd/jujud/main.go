@@ -128,6 +128,7 @@ func jujuDMain(args []string, ctx *cmd.Context) (code int, err error) {
 	a := NewUnitAgent()
 	a.ctx = ctx
 	jujud.Register(a)
+	jujud.Register(&IntrospectCommand{})
 
 	code = cmd.Main(jujud, ctx, args[1:])
 	return code, nil
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/apiaddressupdater"
+	"github.com/juju/juju/worker/introspection"
 	workerlogger "github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/proxyupdater"
 	"github.com/juju/juju/worker/rsyslog"
@@ -119,6 +120,9 @@ func (a *UnitAgent) Run(ctx *cmd.Context) error {
 
 	network.InitializeFromConfig(agentConfig)
 	a.runner.StartWorker("api", a.APIWorkers)
+	a.runner.StartWorker("introspection", func() (worker.Worker, error) {
+		return introspection.NewWorker(a.runner, introspection.SocketPath(agentConfig.DataDir(), a.Tag()))
+	})
 	err := cmdutil.AgentDone(logger, a.runner.Wait())
 	a.tomb.Kill(err)
 	return err
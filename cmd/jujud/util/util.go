@@ -219,6 +219,12 @@ func (c *CloseWorker) Wait() error {
 	return err
 }
 
+// Report implements worker.Reporter by reporting the workers run by
+// the wrapped worker.
+func (c *CloseWorker) Report() []worker.WorkerReport {
+	return worker.ReportWorker(c.worker)
+}
+
 // HookExecutionLock returns an *fslock.Lock suitable for use as a
 // unit hook execution lock. Other workers may also use this lock if
 // they require isolation from hook execution.
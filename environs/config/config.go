@@ -88,6 +88,38 @@ const (
 	// Only prevent all-changes from running
 	// if user specifically requests it. Otherwise, let them run.
 	DefaultPreventAllChanges = false
+
+	// DefaultLogMaxAge is the default age after which log entries
+	// stored in the database are pruned.
+	DefaultLogMaxAge = "72h"
+
+	// DefaultLogMaxSize is the default size above which the oldest
+	// log entries stored in the database are pruned.
+	DefaultLogMaxSize = "4G"
+
+	// DefaultStatusHistoryMaxAge is the default age after which
+	// status history entries are pruned.
+	DefaultStatusHistoryMaxAge = "336h"
+
+	// DefaultStatusHistoryMaxEntries is the default number of status
+	// history entries kept for each entity.
+	DefaultStatusHistoryMaxEntries = 100
+
+	// DefaultMetricsMaxAge is the default age after which metrics
+	// that have been sent are pruned.
+	DefaultMetricsMaxAge = "24h"
+
+	// DefaultMetricsMaxSize is the default size above which the
+	// oldest metrics that have been sent are pruned.
+	DefaultMetricsMaxSize = "1G"
+
+	// DefaultActionResultsMaxAge is the default age after which the
+	// results of finished actions are pruned.
+	DefaultActionResultsMaxAge = "336h"
+
+	// DefaultActionResultsMaxSize is the default size above which the
+	// results of the oldest finished actions are pruned.
+	DefaultActionResultsMaxSize = "5G"
 )
 
 // TODO(katco-): Please grow this over time.
@@ -155,6 +187,50 @@ const (
 	// allowed by the user.
 	AllowLXCLoopMounts = "allow-lxc-loop-mounts"
 
+	// LogMaxAgeKey stores the age after which log entries stored in
+	// the database are pruned. The logs of all environments are
+	// stored together, so only the state server environment's
+	// setting applies.
+	LogMaxAgeKey = "log-max-age"
+
+	// LogMaxSizeKey stores the size above which the oldest log
+	// entries stored in the database are pruned. As with
+	// LogMaxAgeKey, only the state server environment's setting
+	// applies.
+	LogMaxSizeKey = "log-max-size"
+
+	// StatusHistoryMaxAgeKey stores the age after which status
+	// history entries are pruned.
+	StatusHistoryMaxAgeKey = "status-history-max-age"
+
+	// StatusHistoryMaxEntriesKey stores the number of status history
+	// entries kept for each entity.
+	StatusHistoryMaxEntriesKey = "status-history-max-entries"
+
+	// MetricsMaxAgeKey stores the age after which metrics that have
+	// been sent are pruned.
+	MetricsMaxAgeKey = "metrics-max-age"
+
+	// MetricsMaxSizeKey stores the size above which the oldest
+	// metrics that have been sent are pruned.
+	MetricsMaxSizeKey = "metrics-max-size"
+
+	// ActionResultsMaxAgeKey stores the age after which the results
+	// of finished actions are pruned.
+	ActionResultsMaxAgeKey = "action-results-max-age"
+
+	// ActionResultsMaxSizeKey stores the size above which the results
+	// of the oldest finished actions are pruned.
+	ActionResultsMaxSizeKey = "action-results-max-size"
+
+	// LogForwardTargetsKey stores the collectors to which the log
+	// records stored in the database are forwarded.
+	LogForwardTargetsKey = "log-forward-targets"
+
+	// LogForwardCACertKey stores the CA certificate used to verify
+	// the certificates of log collectors reached over TLS.
+	LogForwardCACertKey = "log-forward-ca-cert"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -589,6 +665,36 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	// Check the retention settings.
+	for _, attr := range []string{LogMaxAgeKey, StatusHistoryMaxAgeKey, MetricsMaxAgeKey, ActionResultsMaxAgeKey} {
+		if v, ok := cfg.defined[attr].(string); ok {
+			if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+				return fmt.Errorf("invalid %s in environment configuration: %q", attr, v)
+			}
+		}
+	}
+	for _, attr := range []string{LogMaxSizeKey, MetricsMaxSizeKey, ActionResultsMaxSizeKey} {
+		if v, ok := cfg.defined[attr].(string); ok {
+			if _, err := utils.ParseSize(v); err != nil {
+				return fmt.Errorf("invalid %s in environment configuration: %q", attr, v)
+			}
+		}
+	}
+	if v, ok := cfg.defined[StatusHistoryMaxEntriesKey].(int); ok && v <= 0 {
+		return fmt.Errorf("invalid %s in environment configuration: %d", StatusHistoryMaxEntriesKey, v)
+	}
+
+	if v, ok := cfg.defined[LogForwardTargetsKey].(string); ok {
+		if _, err := ParseLogForwardTargets(v); err != nil {
+			return err
+		}
+	}
+	if v, ok := cfg.defined[LogForwardCACertKey].(string); ok {
+		if _, err := cert.ParseCert(v); err != nil {
+			return errors.Annotatef(err, "bad %s in environment configuration", LogForwardCACertKey)
+		}
+	}
+
 	// Check the immutable config values.  These can't change
 	if old != nil {
 		for _, attr := range immutableAttributes {
@@ -1121,6 +1227,99 @@ func (c *Config) AllowLXCLoopMounts() (bool, bool) {
 	return v, ok
 }
 
+// LogMaxAge returns the age after which log entries stored in the
+// database are pruned. It applies to the logs of all environments,
+// and is only used from the state server environment's config.
+func (c *Config) LogMaxAge() time.Duration {
+	return c.durationOrDefault(LogMaxAgeKey, DefaultLogMaxAge)
+}
+
+// LogMaxSizeMB returns the size, in megabytes, above which the oldest
+// log entries stored in the database are pruned. Like LogMaxAge, it
+// is only used from the state server environment's config.
+func (c *Config) LogMaxSizeMB() int {
+	return c.sizeMBOrDefault(LogMaxSizeKey, DefaultLogMaxSize)
+}
+
+// StatusHistoryMaxAge returns the age after which status history
+// entries are pruned.
+func (c *Config) StatusHistoryMaxAge() time.Duration {
+	return c.durationOrDefault(StatusHistoryMaxAgeKey, DefaultStatusHistoryMaxAge)
+}
+
+// StatusHistoryMaxEntries returns the number of status history
+// entries kept for each entity.
+func (c *Config) StatusHistoryMaxEntries() int {
+	if v, ok := c.defined[StatusHistoryMaxEntriesKey].(int); ok {
+		return v
+	}
+	return DefaultStatusHistoryMaxEntries
+}
+
+// MetricsMaxAge returns the age after which metrics that have been
+// sent are pruned.
+func (c *Config) MetricsMaxAge() time.Duration {
+	return c.durationOrDefault(MetricsMaxAgeKey, DefaultMetricsMaxAge)
+}
+
+// MetricsMaxSizeMB returns the size, in megabytes, above which the
+// oldest metrics that have been sent are pruned.
+func (c *Config) MetricsMaxSizeMB() int {
+	return c.sizeMBOrDefault(MetricsMaxSizeKey, DefaultMetricsMaxSize)
+}
+
+// LogForwardTargets returns the collectors to which the log records
+// stored in the database are forwarded.
+func (c *Config) LogForwardTargets() []LogForwardTarget {
+	// The value has been validated already.
+	targets, _ := ParseLogForwardTargets(c.asString(LogForwardTargetsKey))
+	return targets
+}
+
+// LogForwardCACert returns the CA certificate used to verify the
+// certificates of log collectors reached over TLS, and whether it
+// has been set.
+func (c *Config) LogForwardCACert() (string, bool) {
+	v := c.asString(LogForwardCACertKey)
+	return v, v != ""
+}
+
+// ActionResultsMaxAge returns the age after which the results of
+// finished actions are pruned.
+func (c *Config) ActionResultsMaxAge() time.Duration {
+	return c.durationOrDefault(ActionResultsMaxAgeKey, DefaultActionResultsMaxAge)
+}
+
+// ActionResultsMaxSizeMB returns the size, in megabytes, above which
+// the results of the oldest finished actions are pruned.
+func (c *Config) ActionResultsMaxSizeMB() int {
+	return c.sizeMBOrDefault(ActionResultsMaxSizeKey, DefaultActionResultsMaxSize)
+}
+
+// durationOrDefault returns the named attribute as a duration,
+// falling back to the given default if it is not set.
+func (c *Config) durationOrDefault(name, defaultValue string) time.Duration {
+	v, ok := c.defined[name].(string)
+	if !ok {
+		v = defaultValue
+	}
+	// The value has been validated already.
+	d, _ := time.ParseDuration(v)
+	return d
+}
+
+// sizeMBOrDefault returns the named attribute as a size in megabytes,
+// falling back to the given default if it is not set.
+func (c *Config) sizeMBOrDefault(name, defaultValue string) int {
+	v, ok := c.defined[name].(string)
+	if !ok {
+		v = defaultValue
+	}
+	// The value has been validated already.
+	size, _ := utils.ParseSize(v)
+	return int(size)
+}
+
 // UnknownAttrs returns a copy of the raw configuration attributes
 // that are supposedly specific to the environment type. They could
 // also be wrong attributes, though. Only the specific environment
@@ -1212,6 +1411,16 @@ var fields = schema.Fields{
 	PreventAllChangesKey:         schema.Bool(),
 	StorageDefaultBlockSourceKey: schema.String(),
 	AllowLXCLoopMounts:           schema.Bool(),
+	LogMaxAgeKey:                 schema.String(),
+	LogMaxSizeKey:                schema.String(),
+	StatusHistoryMaxAgeKey:       schema.String(),
+	StatusHistoryMaxEntriesKey:   schema.ForceInt(),
+	MetricsMaxAgeKey:             schema.String(),
+	MetricsMaxSizeKey:            schema.String(),
+	ActionResultsMaxAgeKey:       schema.String(),
+	ActionResultsMaxSizeKey:      schema.String(),
+	LogForwardTargetsKey:         schema.String(),
+	LogForwardCACertKey:          schema.String(),
 
 	// Deprecated fields, retain for backwards compatibility.
 	ToolsMetadataURLKey:    schema.String(),
@@ -1256,6 +1465,18 @@ var alwaysOptional = schema.Defaults{
 	SetNumaControlPolicyKey:      DefaultNumaControlPolicy,
 	AllowLXCLoopMounts:           false,
 
+	// Retention of logs, status history, metrics and action results.
+	LogMaxAgeKey:               DefaultLogMaxAge,
+	LogMaxSizeKey:              DefaultLogMaxSize,
+	StatusHistoryMaxAgeKey:     DefaultStatusHistoryMaxAge,
+	StatusHistoryMaxEntriesKey: DefaultStatusHistoryMaxEntries,
+	MetricsMaxAgeKey:           DefaultMetricsMaxAge,
+	MetricsMaxSizeKey:          DefaultMetricsMaxSize,
+	ActionResultsMaxAgeKey:     DefaultActionResultsMaxAge,
+	ActionResultsMaxSizeKey:    DefaultActionResultsMaxSize,
+	LogForwardTargetsKey:       schema.Omit,
+	LogForwardCACertKey:        schema.Omit,
+
 	// Storage related config.
 	// Environ providers will specify their own defaults.
 	StorageDefaultBlockSourceKey: schema.Omit,
@@ -1331,6 +1331,14 @@ func (s *ConfigSuite) TestConfigAttrs(c *gc.C) {
 	attrs["prefer-ipv6"] = false
 	attrs["set-numa-control-policy"] = false
 	attrs["allow-lxc-loop-mounts"] = false
+	attrs["log-max-age"] = "72h"
+	attrs["log-max-size"] = "4G"
+	attrs["status-history-max-age"] = "336h"
+	attrs["status-history-max-entries"] = 100
+	attrs["metrics-max-age"] = "24h"
+	attrs["metrics-max-size"] = "1G"
+	attrs["action-results-max-age"] = "336h"
+	attrs["action-results-max-size"] = "5G"
 
 	// Default firewall mode is instance
 	attrs["firewall-mode"] = string(config.FwInstance)
@@ -1600,6 +1608,81 @@ func (s *ConfigSuite) TestLoggingConfigFromEnvironment(c *gc.C) {
 	c.Assert(config.LoggingConfig(), gc.Equals, "<root>=INFO;unit=DEBUG")
 }
 
+func (s *ConfigSuite) TestRetentionDefaults(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.LogMaxAge(), gc.Equals, 72*time.Hour)
+	c.Assert(config.LogMaxSizeMB(), gc.Equals, 4*1024)
+	c.Assert(config.StatusHistoryMaxAge(), gc.Equals, 14*24*time.Hour)
+	c.Assert(config.StatusHistoryMaxEntries(), gc.Equals, 100)
+	c.Assert(config.MetricsMaxAge(), gc.Equals, 24*time.Hour)
+	c.Assert(config.MetricsMaxSizeMB(), gc.Equals, 1024)
+	c.Assert(config.ActionResultsMaxAge(), gc.Equals, 14*24*time.Hour)
+	c.Assert(config.ActionResultsMaxSizeMB(), gc.Equals, 5*1024)
+}
+
+func (s *ConfigSuite) TestRetentionValues(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"log-max-age":                "1h",
+		"log-max-size":               "512M",
+		"status-history-max-age":     "48h",
+		"status-history-max-entries": 20,
+		"metrics-max-age":            "30m",
+		"metrics-max-size":           "64M",
+		"action-results-max-age":     "24h",
+		"action-results-max-size":    "100M",
+	})
+	c.Assert(config.LogMaxAge(), gc.Equals, time.Hour)
+	c.Assert(config.LogMaxSizeMB(), gc.Equals, 512)
+	c.Assert(config.StatusHistoryMaxAge(), gc.Equals, 48*time.Hour)
+	c.Assert(config.StatusHistoryMaxEntries(), gc.Equals, 20)
+	c.Assert(config.MetricsMaxAge(), gc.Equals, 30*time.Minute)
+	c.Assert(config.MetricsMaxSizeMB(), gc.Equals, 64)
+	c.Assert(config.ActionResultsMaxAge(), gc.Equals, 24*time.Hour)
+	c.Assert(config.ActionResultsMaxSizeMB(), gc.Equals, 100)
+}
+
+func (s *ConfigSuite) TestRetentionInvalidValues(c *gc.C) {
+	s.addJujuFiles(c)
+	for i, test := range []struct {
+		attrs testing.Attrs
+		err   string
+	}{{
+		attrs: testing.Attrs{"log-max-age": "3 days"},
+		err:   `invalid log-max-age in environment configuration: "3 days"`,
+	}, {
+		attrs: testing.Attrs{"status-history-max-age": "-1h"},
+		err:   `invalid status-history-max-age in environment configuration: "-1h"`,
+	}, {
+		attrs: testing.Attrs{"metrics-max-age": "0s"},
+		err:   `invalid metrics-max-age in environment configuration: "0s"`,
+	}, {
+		attrs: testing.Attrs{"log-max-size": "lots"},
+		err:   `invalid log-max-size in environment configuration: "lots"`,
+	}, {
+		attrs: testing.Attrs{"metrics-max-size": "big"},
+		err:   `invalid metrics-max-size in environment configuration: "big"`,
+	}, {
+		attrs: testing.Attrs{"status-history-max-entries": -1},
+		err:   `invalid status-history-max-entries in environment configuration: -1`,
+	}, {
+		attrs: testing.Attrs{"status-history-max-entries": 0},
+		err:   `invalid status-history-max-entries in environment configuration: 0`,
+	}, {
+		attrs: testing.Attrs{"action-results-max-age": "1 week"},
+		err:   `invalid action-results-max-age in environment configuration: "1 week"`,
+	}, {
+		attrs: testing.Attrs{"action-results-max-size": "big"},
+		err:   `invalid action-results-max-size in environment configuration: "big"`,
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		attrs := testing.Attrs{"type": "my-type", "name": "my-name"}.Merge(test.attrs)
+		_, err := config.New(config.UseDefaults, attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *ConfigSuite) TestProxyValuesWithFallback(c *gc.C) {
 	s.addJujuFiles(c)
 
@@ -0,0 +1,93 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// LogForwardSyslog requests that log records are forwarded as
+	// RFC5424 syslog messages.
+	LogForwardSyslog = "syslog"
+
+	// LogForwardJSON requests that log records are forwarded as
+	// newline delimited JSON objects.
+	LogForwardJSON = "json"
+)
+
+// LogForwardTarget describes a collector to which the log records
+// stored in the database are forwarded.
+type LogForwardTarget struct {
+	// Format holds the format of the forwarded records, either
+	// LogForwardSyslog or LogForwardJSON.
+	Format string
+
+	// TLS reports whether the connection to the collector is
+	// secured with TLS.
+	TLS bool
+
+	// Address holds the host:port address of the collector.
+	Address string
+}
+
+// String returns the target in the form used in the environment
+// configuration.
+func (t LogForwardTarget) String() string {
+	scheme := t.Format
+	if t.TLS {
+		scheme += "+tls"
+	}
+	return scheme + "://" + t.Address
+}
+
+// ParseLogForwardTargets parses a comma or space separated list of
+// log forwarding targets, each of the form <format>[+tls]://<host>:<port>,
+// for example "syslog+tls://logs.example.com:6514".
+func ParseLogForwardTargets(s string) ([]LogForwardTarget, error) {
+	var targets []LogForwardTarget
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	for _, field := range fields {
+		target, err := parseLogForwardTarget(field)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+func parseLogForwardTarget(s string) (LogForwardTarget, error) {
+	var target LogForwardTarget
+	parts := strings.SplitN(s, "://", 2)
+	if len(parts) != 2 {
+		return target, errors.Errorf("invalid log forwarding target %q", s)
+	}
+	scheme, address := parts[0], parts[1]
+	if strings.HasSuffix(scheme, "+tls") {
+		target.TLS = true
+		scheme = strings.TrimSuffix(scheme, "+tls")
+	}
+	switch scheme {
+	case LogForwardSyslog, LogForwardJSON:
+		target.Format = scheme
+	default:
+		return target, errors.Errorf("invalid log forwarding target %q: unknown format %q", s, scheme)
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || host == "" {
+		return target, errors.Errorf("invalid log forwarding target %q: expected host:port address", s)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n <= 0 || n > 65535 {
+		return target, errors.Errorf("invalid log forwarding target %q: invalid port %q", s, port)
+	}
+	target.Address = address
+	return target, nil
+}
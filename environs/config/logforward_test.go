@@ -0,0 +1,97 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testing"
+)
+
+type LogForwardSuite struct {
+	testing.FakeJujuHomeSuite
+}
+
+var _ = gc.Suite(&LogForwardSuite{})
+
+func (s *LogForwardSuite) TestParseLogForwardTargets(c *gc.C) {
+	targets, err := config.ParseLogForwardTargets("syslog+tls://logs.example.com:6514, json://10.0.0.1:5000")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []config.LogForwardTarget{{
+		Format:  config.LogForwardSyslog,
+		TLS:     true,
+		Address: "logs.example.com:6514",
+	}, {
+		Format:  config.LogForwardJSON,
+		Address: "10.0.0.1:5000",
+	}})
+	c.Assert(targets[0].String(), gc.Equals, "syslog+tls://logs.example.com:6514")
+	c.Assert(targets[1].String(), gc.Equals, "json://10.0.0.1:5000")
+
+	targets, err = config.ParseLogForwardTargets("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, gc.HasLen, 0)
+}
+
+func (s *LogForwardSuite) TestParseLogForwardTargetsErrors(c *gc.C) {
+	for i, test := range []struct {
+		targets string
+		err     string
+	}{{
+		targets: "logs.example.com:6514",
+		err:     `invalid log forwarding target "logs.example.com:6514"`,
+	}, {
+		targets: "gelf://logs.example.com:6514",
+		err:     `invalid log forwarding target "gelf://logs.example.com:6514": unknown format "gelf"`,
+	}, {
+		targets: "syslog://logs.example.com",
+		err:     `invalid log forwarding target "syslog://logs.example.com": expected host:port address`,
+	}, {
+		targets: "json+tls://logs.example.com:http",
+		err:     `invalid log forwarding target "json\+tls://logs.example.com:http": invalid port "http"`,
+	}} {
+		c.Logf("test %d: %s", i, test.targets)
+		_, err := config.ParseLogForwardTargets(test.targets)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *LogForwardSuite) TestConfig(c *gc.C) {
+	cfg, err := config.New(config.UseDefaults, testing.Attrs{
+		"type":                "my-type",
+		"name":                "my-name",
+		"authorized-keys":     testing.FakeAuthKeys,
+		"log-forward-targets": "syslog+tls://logs.example.com:6514",
+		"log-forward-ca-cert": testing.CACert,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LogForwardTargets(), jc.DeepEquals, []config.LogForwardTarget{{
+		Format:  config.LogForwardSyslog,
+		TLS:     true,
+		Address: "logs.example.com:6514",
+	}})
+	caCert, ok := cfg.LogForwardCACert()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(caCert, gc.Equals, testing.CACert)
+}
+
+func (s *LogForwardSuite) TestConfigInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.Attrs{
+		"type":                "my-type",
+		"name":                "my-name",
+		"authorized-keys":     testing.FakeAuthKeys,
+		"log-forward-targets": "syslog://logs.example.com",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid log forwarding target "syslog://logs.example.com": expected host:port address`)
+
+	_, err = config.New(config.UseDefaults, testing.Attrs{
+		"type":                "my-type",
+		"name":                "my-name",
+		"authorized-keys":     testing.FakeAuthKeys,
+		"log-forward-ca-cert": "not a certificate",
+	})
+	c.Assert(err, gc.ErrorMatches, `bad log-forward-ca-cert in environment configuration: .*`)
+}
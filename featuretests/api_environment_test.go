@@ -47,6 +47,23 @@ func (s *apiEnvironmentSuite) TestEnvironmentShare(c *gc.C) {
 	c.Assert(envUser.LastConnection(), gc.IsNil)
 }
 
+func (s *apiEnvironmentSuite) TestEnvironmentShareReadAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "auditor", Password: "secret", NoEnvUser: true})
+	err := s.client.ShareEnvironmentWithAccess(params.EnvironReadAccess, user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.OpenAPIAs(c, user.UserTag(), "secret")
+	defer st.Close()
+	client := st.Client()
+
+	_, err = client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.ServiceExpose("wordpress")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
 func (s *apiEnvironmentSuite) TestEnvironmentUnshare(c *gc.C) {
 	// Firt share an environment with a user.
 	user := names.NewUserTag("foo@ubuntuone")
@@ -82,12 +99,14 @@ func (s *apiEnvironmentSuite) TestEnvironmentUserInfo(c *gc.C) {
 			CreatedBy:      owner.UserName(),
 			DateCreated:    owner.DateCreated(),
 			LastConnection: owner.LastConnection(),
+			Access:         "admin",
 		}, {
 			UserName:       "bobjohns@ubuntuone",
 			DisplayName:    "Bob Johns",
 			CreatedBy:      owner.UserName(),
 			DateCreated:    envUser.DateCreated(),
 			LastConnection: envUser.LastConnection(),
+			Access:         "admin",
 		},
 	})
 }
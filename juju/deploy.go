@@ -110,6 +110,16 @@ func DeployService(st *state.State, args DeployServiceParams) (*state.Service, e
 // AddUnits starts n units of the given service and allocates machines
 // to them as necessary.
 func AddUnits(st *state.State, svc *state.Service, n int, machineIdSpec string) ([]*state.Unit, error) {
+	return AddUnitsWithStorage(st, svc, n, machineIdSpec, nil)
+}
+
+// AddUnitsWithStorage starts n units of the given service, attaching
+// the specified detached storage instances to each of them, and
+// allocates machines to them as necessary.
+func AddUnitsWithStorage(
+	st *state.State, svc *state.Service, n int, machineIdSpec string,
+	attachStorage []names.StorageTag,
+) ([]*state.Unit, error) {
 	units := make([]*state.Unit, n)
 	// Hard code for now till we implement a different approach.
 	policy := state.AssignCleanEmpty
@@ -120,7 +130,7 @@ func AddUnits(st *state.State, svc *state.Service, n int, machineIdSpec string)
 	}
 	// TODO what do we do if we fail half-way through this process?
 	for i := 0; i < n; i++ {
-		unit, err := svc.AddUnit()
+		unit, err := svc.AddUnitWithStorage(attachStorage)
 		if err != nil {
 			return nil, fmt.Errorf("cannot add unit %d/%d to service %q: %v", i+1, n, svc.Name(), err)
 		}
@@ -4,12 +4,14 @@
 package ec2
 
 import (
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"github.com/juju/utils"
 	"github.com/juju/utils/set"
 	"gopkg.in/amz.v3/ec2"
@@ -53,6 +55,7 @@ const (
 	volumeInUse        = "VolumeInUse"
 	attachmentNotFound = "InvalidAttachment.NotFound"
 	incorrectState     = "IncorrectState"
+	snapshotNotFound   = "InvalidSnapshot.NotFound"
 )
 
 const (
@@ -166,6 +169,7 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attr map[string]interface{}) (_ ec2.CreateVolume, persistent bool, _ error) {
@@ -554,6 +558,82 @@ func (v *ebsVolumeSource) DetachVolumes(attachParams []storage.VolumeAttachmentP
 	return nil
 }
 
+// CreateSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateSnapshots(params []storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error) {
+	snapshots := make([]storage.VolumeSnapshot, len(params))
+	for i, p := range params {
+		description := fmt.Sprintf("juju snapshot %s of %s", p.Id, names.ReadableString(p.Volume))
+		resp, err := v.ec2.CreateSnapshot(p.VolumeId, description)
+		if err != nil {
+			return nil, errors.Annotatef(err, "creating snapshot of %v", p.VolumeId)
+		}
+		snapshot, err := ebsToJujuSnapshot(&resp.Snapshot)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshots[i] = snapshot
+	}
+	return snapshots, nil
+}
+
+// ListSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) ListSnapshots(volIds []string) ([]storage.VolumeSnapshot, error) {
+	if len(volIds) == 0 {
+		return nil, nil
+	}
+	filter := ec2.NewFilter()
+	filter.Add("volume-id", volIds...)
+	resp, err := v.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return nil, errors.Annotate(err, "querying snapshots")
+	}
+	snapshots := make([]storage.VolumeSnapshot, len(resp.Snapshots))
+	for i := range resp.Snapshots {
+		snapshot, err := ebsToJujuSnapshot(&resp.Snapshots[i])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshots[i] = snapshot
+	}
+	return snapshots, nil
+}
+
+// DestroySnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) DestroySnapshots(snapshotIds []string) []error {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if _, err := v.ec2.DeleteSnapshots([]string{snapshotId}); err != nil {
+			if ec2Err, ok := err.(*ec2.Error); ok && ec2Err.Code == snapshotNotFound {
+				// The snapshot has already been destroyed.
+				continue
+			}
+			results[i] = errors.Annotatef(err, "destroying %q", snapshotId)
+		}
+	}
+	return results
+}
+
+func ebsToJujuSnapshot(snapshot *ec2.Snapshot) (storage.VolumeSnapshot, error) {
+	size, err := strconv.ParseUint(snapshot.VolumeSize, 10, 64)
+	if err != nil {
+		return storage.VolumeSnapshot{}, errors.Annotatef(
+			err, "parsing size of snapshot %v", snapshot.Id,
+		)
+	}
+	created, err := time.Parse(time.RFC3339, snapshot.StartTime)
+	if err != nil {
+		return storage.VolumeSnapshot{}, errors.Annotatef(
+			err, "parsing start time of snapshot %v", snapshot.Id,
+		)
+	}
+	return storage.VolumeSnapshot{
+		SnapshotId: snapshot.Id,
+		VolumeId:   snapshot.VolumeId,
+		Size:       gibToMib(size),
+		Created:    created,
+	}, nil
+}
+
 var errTooManyVolumes = errors.New("too many EBS volumes to attach")
 
 // blockDeviceNamer returns a function that cycles through block device names.
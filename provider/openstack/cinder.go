@@ -84,6 +84,7 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) (_ []storage.Volume, _ []storage.VolumeAttachment, resultErr error) {
@@ -292,6 +293,84 @@ func (s *cinderVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams
 	return nil
 }
 
+// CreateSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) CreateSnapshots(args []storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error) {
+	snapshots := make([]storage.VolumeSnapshot, len(args))
+	for i, arg := range args {
+		cinderSnapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+			VolumeId: arg.VolumeId,
+			Name:     arg.Volume.String() + "-snapshot-" + arg.Id,
+			// Volumes are snapshotted whether or not they are
+			// attached to an instance.
+			Force: true,
+		})
+		if err != nil {
+			return nil, errors.Annotatef(err, "creating snapshot of %v", arg.VolumeId)
+		}
+		snapshot, err := cinderToJujuSnapshot(cinderSnapshot)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshots[i] = snapshot
+	}
+	return snapshots, nil
+}
+
+// ListSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) ListSnapshots(volumeIds []string) ([]storage.VolumeSnapshot, error) {
+	// As with DescribeVolumes, get all snapshots and filter locally.
+	cinderSnapshots, err := s.storageAdapter.GetSnapshotsDetail()
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool)
+	for _, volumeId := range volumeIds {
+		wanted[volumeId] = true
+	}
+	var snapshots []storage.VolumeSnapshot
+	for i := range cinderSnapshots {
+		if !wanted[cinderSnapshots[i].VolumeID] {
+			continue
+		}
+		snapshot, err := cinderToJujuSnapshot(&cinderSnapshots[i])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// DestroySnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) DestroySnapshots(snapshotIds []string) []error {
+	errors := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := s.storageAdapter.DeleteSnapshot(snapshotId); err != nil {
+			errors[i] = err
+		}
+	}
+	return errors
+}
+
+// cinderTimeFormat is the format of the times reported by Cinder, which
+// are UTC but carry no time zone.
+const cinderTimeFormat = "2006-01-02T15:04:05.999999"
+
+func cinderToJujuSnapshot(snapshot *cinder.Snapshot) (storage.VolumeSnapshot, error) {
+	created, err := time.Parse(cinderTimeFormat, snapshot.CreatedAt)
+	if err != nil {
+		return storage.VolumeSnapshot{}, errors.Annotatef(
+			err, "parsing creation time of snapshot %v", snapshot.ID,
+		)
+	}
+	return storage.VolumeSnapshot{
+		SnapshotId: snapshot.ID,
+		VolumeId:   snapshot.VolumeID,
+		Size:       uint64(snapshot.Size * 1024),
+		Created:    created,
+	}, nil
+}
+
 func cinderToJujuVolume(tag names.VolumeTag, volume *cinder.Volume) storage.Volume {
 	return storage.Volume{
 		VolumeId: volume.ID,
@@ -331,6 +410,9 @@ type openstackStorage interface {
 	AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error)
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	GetSnapshotsDetail() ([]cinder.Snapshot, error)
+	DeleteSnapshot(snapshotId string) error
 }
 
 func newOpenstackStorageAdapter(environConfig *config.Config) (openstackStorage, error) {
@@ -394,3 +476,21 @@ func (ga *openstackStorageAdapter) GetVolume(volumeId string) (*cinder.Volume, e
 	}
 	return &resp.Volume, nil
 }
+
+// CreateSnapshot is part of the openstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// GetSnapshotsDetail is part of the openstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.GetSnapshotsDetail()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
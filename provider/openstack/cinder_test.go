@@ -325,6 +325,83 @@ func (s *cinderVolumeSourceSuite) TestCreateVolumeCleanupDestroys(c *gc.C) {
 	c.Assert(numDestroyCalls, gc.Equals, 4)
 }
 
+func (s *cinderVolumeSourceSuite) TestCreateSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			c.Check(args, jc.DeepEquals, cinder.CreateSnapshotSnapshotParams{
+				VolumeId: mockVolId,
+				Name:     "volume-123-snapshot-7",
+				Force:    true,
+			})
+			return &cinder.Snapshot{
+				ID:        "snap-id",
+				VolumeID:  args.VolumeId,
+				Size:      2,
+				CreatedAt: "2015-07-01T10:00:00.000000",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	snapshots, err := volSource.(storage.VolumeSnapshotter).CreateSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "7",
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []storage.VolumeSnapshot{{
+		SnapshotId: "snap-id",
+		VolumeId:   mockVolId,
+		Size:       2 * 1024,
+		Created:    time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC),
+	}})
+}
+
+func (s *cinderVolumeSourceSuite) TestListSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getSnapshotsDetail: func() ([]cinder.Snapshot, error) {
+			return []cinder.Snapshot{{
+				ID:        "snap-0",
+				VolumeID:  mockVolId,
+				Size:      1,
+				CreatedAt: "2015-07-01T10:00:00.000000",
+			}, {
+				ID:        "snap-1",
+				VolumeID:  "other",
+				Size:      1,
+				CreatedAt: "2015-07-01T11:00:00.000000",
+			}}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	snapshots, err := volSource.(storage.VolumeSnapshotter).ListSnapshots([]string{mockVolId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []storage.VolumeSnapshot{{
+		SnapshotId: "snap-0",
+		VolumeId:   mockVolId,
+		Size:       1024,
+		Created:    time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC),
+	}})
+}
+
+func (s *cinderVolumeSourceSuite) TestDestroySnapshots(c *gc.C) {
+	var deleted []string
+	mockAdapter := &mockAdapter{
+		deleteSnapshot: func(snapshotId string) error {
+			deleted = append(deleted, snapshotId)
+			if snapshotId == "snap-1" {
+				return errors.New("snapshot in use")
+			}
+			return nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	errs := volSource.(storage.VolumeSnapshotter).DestroySnapshots([]string{"snap-0", "snap-1"})
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, "snapshot in use")
+	c.Assert(deleted, jc.DeepEquals, []string{"snap-0", "snap-1"})
+}
+
 type mockAdapter struct {
 	getVolume             func(string) (*cinder.Volume, error)
 	getVolumesSimple      func() ([]cinder.Volume, error)
@@ -334,6 +411,9 @@ type mockAdapter struct {
 	volumeStatusNotifier  func(string, string, int, time.Duration) <-chan error
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	getSnapshotsDetail    func() ([]cinder.Snapshot, error)
+	deleteSnapshot        func(string) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -387,3 +467,24 @@ func (ma *mockAdapter) ListVolumeAttachments(serverId string) ([]nova.VolumeAtta
 	}
 	return nil, nil
 }
+
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	if ma.getSnapshotsDetail != nil {
+		return ma.getSnapshotsDetail()
+	}
+	return nil, nil
+}
+
+func (ma *mockAdapter) DeleteSnapshot(snapshotId string) error {
+	if ma.deleteSnapshot != nil {
+		return ma.deleteSnapshot(snapshotId)
+	}
+	return nil
+}
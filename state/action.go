@@ -31,9 +31,14 @@ const (
 	// ActionCompleted indicates that the action ran to completion as intended.
 	ActionCompleted ActionStatus = "completed"
 
-	// ActionCancelled means that the Action was cancelled before being run.
+	// ActionCancelled means that the Action was cancelled before it
+	// finished running.
 	ActionCancelled ActionStatus = "cancelled"
 
+	// ActionTimedOut means that the Action was killed because it ran
+	// for longer than its timeout.
+	ActionTimedOut ActionStatus = "timed-out"
+
 	// ActionPending is the default status when an Action is first queued.
 	ActionPending ActionStatus = "pending"
 
@@ -99,6 +104,38 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Group holds the id of the group the action was queued in,
+	// if it was queued on several units at once.
+	Group string `bson:"group,omitempty"`
+
+	// Timeout is how long the action may run for before it is
+	// killed; zero means that it may run for as long as it takes.
+	Timeout time.Duration `bson:"timeout,omitempty"`
+
+	// Logs holds the progress messages logged by the action while
+	// it runs, oldest first. Only the most recent maxActionMessages
+	// messages are kept.
+	Logs []actionMessageDoc `bson:"logs,omitempty"`
+
+	// LogCount is the number of messages logged by the action,
+	// including those no longer kept in Logs.
+	LogCount int `bson:"logcount,omitempty"`
+}
+
+// maxActionMessages is the number of progress messages kept for each
+// action; older messages are discarded as new ones are logged.
+var maxActionMessages = 1000
+
+type actionMessageDoc struct {
+	Timestamp time.Time `bson:"timestamp"`
+	Message   string    `bson:"message"`
+}
+
+// ActionMessage is a progress message logged by a running action.
+type ActionMessage struct {
+	Timestamp time.Time
+	Message   string
 }
 
 // Action represents an instruction to do some "action" and is expected
@@ -152,11 +189,79 @@ func (a *Action) Status() ActionStatus {
 	return a.doc.Status
 }
 
+// Group returns the id of the group the action was queued in, or
+// the empty string if it was queued on its own.
+func (a *Action) Group() string {
+	return a.doc.Group
+}
+
+// Timeout returns how long the action may run for before it is
+// killed, or zero if it may run for as long as it takes.
+func (a *Action) Timeout() time.Duration {
+	return a.doc.Timeout
+}
+
 // Results returns the structured output of the action and any error.
 func (a *Action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action, oldest
+// first.
+func (a *Action) Messages() []ActionMessage {
+	messages := make([]ActionMessage, len(a.doc.Logs))
+	for i, doc := range a.doc.Logs {
+		messages[i] = ActionMessage{
+			Timestamp: doc.Timestamp,
+			Message:   doc.Message,
+		}
+	}
+	return messages
+}
+
+// LogCount returns the number of progress messages logged by the
+// action, which may be more than the number returned by Messages if
+// older messages have been discarded.
+func (a *Action) LogCount() int {
+	if a.doc.LogCount < len(a.doc.Logs) {
+		return len(a.doc.Logs)
+	}
+	return a.doc.LogCount
+}
+
+// Log records a progress message for the action, discarding the oldest
+// message if the action has already logged maxActionMessages. It
+// asserts that the action is running.
+func (a *Action) Log(message string) error {
+	doc := actionMessageDoc{
+		Timestamp: nowToTheSecond(),
+		Message:   message,
+	}
+	err := a.st.runTransaction([]txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: bson.D{{"status", ActionRunning}},
+		Update: bson.D{
+			{"$push", bson.D{{"logs", bson.D{
+				{"$each", []actionMessageDoc{doc}},
+				{"$slice", -maxActionMessages},
+			}}}},
+			{"$inc", bson.D{{"logcount", 1}}},
+		},
+	}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot log message to action %s: action is not running", a.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot log message to action %s", a.Id())
+	}
+	a.doc.LogCount = a.LogCount() + 1
+	a.doc.Logs = append(a.doc.Logs, doc)
+	if len(a.doc.Logs) > maxActionMessages {
+		a.doc.Logs = a.doc.Logs[len(a.doc.Logs)-maxActionMessages:]
+	}
+	return nil
+}
+
 // ValidateTag should be called before calls to Tag() or ActionTag(). It verifies
 // that the Action can produce a valid Tag.
 func (a *Action) ValidateTag() bool {
@@ -221,6 +326,7 @@ func (a *Action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 					ActionCompleted,
 					ActionCancelled,
 					ActionFailed,
+					ActionTimedOut,
 				}}}}},
 			Update: bson.D{{"$set", bson.D{
 				{"status", finalStatus},
@@ -328,8 +434,17 @@ func (st *State) FindActionTagsByPrefix(prefix string) []names.ActionTag {
 	return results
 }
 
-// EnqueueAction
+// EnqueueAction queues the named action, with the given payload, on
+// the given receiver.
 func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (*Action, error) {
+	return st.EnqueueActionWithTimeout(receiver, actionName, payload, 0)
+}
+
+// EnqueueActionWithTimeout queues the named action, with the given
+// payload, on the given receiver; the action is killed if it runs
+// for longer than timeout. A zero timeout lets the action run for as
+// long as it takes.
+func (st *State) EnqueueActionWithTimeout(receiver names.Tag, actionName string, payload map[string]interface{}, timeout time.Duration) (*Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
@@ -339,12 +454,100 @@ func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload ma
 		return nil, errors.Trace(err)
 	}
 
+	if timeout < 0 {
+		return nil, errors.NotValidf("negative action timeout")
+	}
+
 	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	doc.Timeout = timeout
+
+	ops := enqueueActionOps(receiverCollectionName, receiverId, doc, ndoc)
 
-	ops := []txn.Op{{
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if notDead, err := isNotDead(st, receiverCollectionName, receiverId); err != nil {
+			return nil, err
+		} else if !notDead {
+			return nil, ErrDead
+		} else if attempt != 0 {
+			return nil, errors.Errorf("unexpected attempt number '%d'", attempt)
+		}
+		return ops, nil
+	}
+	if err = st.run(buildTxn); err == nil {
+		return newAction(st, doc), nil
+	}
+	return nil, err
+}
+
+// EnqueueActionGroup queues the named action, with the given payload,
+// on each of the given units as one group, and returns the id of the
+// group along with the queued actions. The payload is validated
+// against the charm of each unit, and either all of the actions are
+// queued or none are. Each action is killed if it runs for longer
+// than timeout; a zero timeout lets them run for as long as they take.
+func (st *State) EnqueueActionGroup(units []*Unit, actionName string, payload map[string]interface{}, timeout time.Duration) (string, []*Action, error) {
+	if len(actionName) == 0 {
+		return "", nil, errors.New("action name required")
+	}
+	if len(units) == 0 {
+		return "", nil, errors.New("no units specified")
+	}
+	if timeout < 0 {
+		return "", nil, errors.NotValidf("negative action timeout")
+	}
+	groupId, err := NewUUID()
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+
+	var ops []txn.Op
+	var docs []actionDoc
+	for _, u := range units {
+		payloadWithDefaults, err := u.actionPayload(actionName, payload)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		doc, ndoc, err := newActionDoc(st, u.Tag(), actionName, payloadWithDefaults)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		doc.Group = groupId.String()
+		doc.Timeout = timeout
+		ops = append(ops, enqueueActionOps(unitsC, u.doc.DocID, doc, ndoc)...)
+		docs = append(docs, doc)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		for _, u := range units {
+			if notDead, err := isNotDead(st, unitsC, u.doc.DocID); err != nil {
+				return nil, err
+			} else if !notDead {
+				return nil, errors.Annotatef(ErrDead, "unit %q", u.Name())
+			}
+		}
+		if attempt != 0 {
+			return nil, errors.Errorf("unexpected attempt number '%d'", attempt)
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return "", nil, err
+	}
+	actions := make([]*Action, len(docs))
+	for i, doc := range docs {
+		actions[i] = newAction(st, doc)
+	}
+	return groupId.String(), actions, nil
+}
+
+// enqueueActionOps returns the operations needed to queue the action
+// described by doc and ndoc on the receiver with the given id, stored
+// in the named collection.
+func enqueueActionOps(receiverCollectionName, receiverId string, doc actionDoc, ndoc actionNotificationDoc) []txn.Op {
+	return []txn.Op{{
 		C:      receiverCollectionName,
 		Id:     receiverId,
 		Assert: notDeadDoc,
@@ -359,21 +562,27 @@ func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload ma
 		Assert: txn.DocMissing,
 		Insert: ndoc,
 	}}
+}
 
-	buildTxn := func(attempt int) ([]txn.Op, error) {
-		if notDead, err := isNotDead(st, receiverCollectionName, receiverId); err != nil {
-			return nil, err
-		} else if !notDead {
-			return nil, ErrDead
-		} else if attempt != 0 {
-			return nil, errors.Errorf("unexpected attempt number '%d'", attempt)
-		}
-		return ops, nil
+// ActionGroup returns the actions queued in the group with the given
+// id, ordered by receiver.
+func (st *State) ActionGroup(id string) ([]*Action, error) {
+	actionsCollection, closer := st.getCollection(actionsC)
+	defer closer()
+
+	var doc actionDoc
+	var actions []*Action
+	iter := actionsCollection.Find(bson.D{{"group", id}}).Sort("receiver").Iter()
+	for iter.Next(&doc) {
+		actions = append(actions, newAction(st, doc))
 	}
-	if err = st.run(buildTxn); err == nil {
-		return newAction(st, doc), nil
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotatef(err, "cannot get action group %q", id)
 	}
-	return nil, err
+	if len(actions) == 0 {
+		return nil, errors.NotFoundf("action group %q", id)
+	}
+	return actions, nil
 }
 
 // matchingActions finds actions that match ActionReceiver.
@@ -437,6 +646,7 @@ func (st *State) matchingActionsCompleted(ar ActionReceiver) ([]*Action, error)
 		{{"status", ActionCompleted}},
 		{{"status", ActionCancelled}},
 		{{"status", ActionFailed}},
+		{{"status", ActionTimedOut}},
 	}}}
 	return st.matchingActionsByReceiverAndStatus(ar.Tag(), completed)
 }
@@ -458,3 +668,132 @@ func (st *State) matchingActionsByReceiverAndStatus(tag names.Tag, statusConditi
 	}
 	return actions, errors.Trace(iter.Close())
 }
+
+// ActionsFilter selects the actions returned by FindActions. Empty
+// fields do not restrict the selection.
+type ActionsFilter struct {
+	// Services and Units select the actions queued for the units of
+	// the named services, and for the named units.
+	Services []string
+	Units    []string
+
+	// Names selects actions with the given names.
+	Names []string
+
+	// Statuses selects actions with the given statuses.
+	Statuses []ActionStatus
+
+	// EnqueuedAfter and EnqueuedBefore select actions enqueued
+	// within the time range, inclusive.
+	EnqueuedAfter  time.Time
+	EnqueuedBefore time.Time
+}
+
+// FindActions returns the actions in the environment selected by
+// the filter, oldest first.
+func (st *State) FindActions(filter ActionsFilter) ([]*Action, error) {
+	sel := bson.D{}
+	var receivers []bson.D
+	for _, service := range filter.Services {
+		receivers = append(receivers, bson.D{{"receiver", bson.D{{"$regex", "^" + service + "/"}}}})
+	}
+	if len(filter.Units) > 0 {
+		receivers = append(receivers, bson.D{{"receiver", bson.D{{"$in", filter.Units}}}})
+	}
+	if len(receivers) > 0 {
+		sel = append(sel, bson.DocElem{"$or", receivers})
+	}
+	if len(filter.Names) > 0 {
+		sel = append(sel, bson.DocElem{"name", bson.D{{"$in", filter.Names}}})
+	}
+	if len(filter.Statuses) > 0 {
+		sel = append(sel, bson.DocElem{"status", bson.D{{"$in", filter.Statuses}}})
+	}
+	enqueued := bson.D{}
+	if !filter.EnqueuedAfter.IsZero() {
+		enqueued = append(enqueued, bson.DocElem{"$gte", filter.EnqueuedAfter.UTC()})
+	}
+	if !filter.EnqueuedBefore.IsZero() {
+		enqueued = append(enqueued, bson.DocElem{"$lte", filter.EnqueuedBefore.UTC()})
+	}
+	if len(enqueued) > 0 {
+		sel = append(sel, bson.DocElem{"enqueued", enqueued})
+	}
+
+	actionsCollection, closer := st.getCollection(actionsC)
+	defer closer()
+
+	var doc actionDoc
+	var actions []*Action
+	iter := actionsCollection.Find(sel).Sort("enqueued").Iter()
+	for iter.Next(&doc) {
+		actions = append(actions, newAction(st, doc))
+	}
+	return actions, errors.Trace(iter.Close())
+}
+
+// completedActionsSel selects the actions that have finished running.
+var completedActionsSel = bson.D{{"status", bson.D{{"$in", []ActionStatus{
+	ActionCompleted,
+	ActionCancelled,
+	ActionFailed,
+	ActionTimedOut,
+}}}}}
+
+// PruneActions removes the results of actions that finished before
+// minCompletedTime, and then removes the results of the oldest
+// finished actions until the environment's actions take up no more
+// than maxActionsMB. Pending and running actions are never removed.
+func PruneActions(st *State, minCompletedTime time.Time, maxActionsMB int) error {
+	actions, closer := st.getCollection(actionsC)
+	defer closer()
+
+	// Finished actions are never touched by transactions again, so
+	// their documents can be removed directly.
+	sel := append(bson.D{{"completed", bson.D{{"$lt", minCompletedTime.UTC()}}}}, completedActionsSel...)
+	info, err := actions.RemoveAll(sel)
+	if err != nil {
+		return errors.Annotate(err, "cannot prune actions by time")
+	}
+	pruned := info.Removed
+
+	// Do further pruning if the environment's actions are over the
+	// maximum size.
+	for {
+		actionsMB, err := getEnvCollectionMB(actions.Underlying(), st.EnvironUUID())
+		if err != nil {
+			return errors.Annotate(err, "cannot get size of actions")
+		}
+		if actionsMB <= maxActionsMB {
+			break
+		}
+		count, err := actions.Find(completedActionsSel).Count()
+		if err != nil {
+			return errors.Annotate(err, "cannot count finished actions")
+		}
+		if count == 0 {
+			break
+		}
+
+		// Remove the oldest 1% of finished actions.
+		toRemove := count / 100
+		if toRemove < 1 {
+			toRemove = 1
+		}
+		var doc actionDoc
+		err = actions.Find(completedActionsSel).Sort("completed").Skip(toRemove - 1).One(&doc)
+		if err != nil {
+			return errors.Annotate(err, "cannot find oldest finished actions")
+		}
+		sel := append(bson.D{{"completed", bson.D{{"$lte", doc.Completed}}}}, completedActionsSel...)
+		info, err := actions.RemoveAll(sel)
+		if err != nil {
+			return errors.Annotate(err, "cannot prune actions by size")
+		}
+		pruned += info.Removed
+	}
+	if pruned > 0 {
+		actionLogger.Debugf("pruned %d actions", pruned)
+	}
+	return nil
+}
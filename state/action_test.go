@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -301,6 +302,23 @@ func (s *ActionSuite) TestEnqueueActionRequiresName(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "action name required")
 }
 
+func (s *ActionSuite) TestAddActionWithTimeout(c *gc.C) {
+	a, err := s.unit.AddActionWithTimeout("snapshot", nil, 90*time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Timeout(), gc.Equals, 90*time.Second)
+
+	action, err := s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Timeout(), gc.Equals, 90*time.Second)
+
+	a, err = s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Timeout(), gc.Equals, time.Duration(0))
+
+	_, err = s.unit.AddActionWithTimeout("snapshot", nil, -time.Second)
+	c.Assert(err, gc.ErrorMatches, "negative action timeout not valid")
+}
+
 func (s *ActionSuite) TestAddActionAcceptsDuplicateNames(c *gc.C) {
 	name := "snapshot"
 	params1 := map[string]interface{}{"outfile": "outfile.tar.bz2"}
@@ -376,6 +394,72 @@ func (s *ActionSuite) TestAddActionFailsOnDeadUnitInTransaction(c *gc.C) {
 	c.Assert(err, gc.Equals, state.ErrDead)
 }
 
+func (s *ActionSuite) TestEnqueueActionGroup(c *gc.C) {
+	units := []*state.Unit{s.unit2, s.unit}
+	groupId, actions, err := s.State.EnqueueActionGroup(units, "snapshot", map[string]interface{}{}, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groupId, gc.Not(gc.Equals), "")
+	c.Assert(actions, gc.HasLen, 2)
+	for i, action := range actions {
+		c.Check(action.Receiver(), gc.Equals, units[i].Name())
+		c.Check(action.Group(), gc.Equals, groupId)
+		c.Check(action.Status(), gc.Equals, state.ActionPending)
+		c.Check(action.Timeout(), gc.Equals, time.Minute)
+		// The defaults given by the charm are inserted.
+		c.Check(action.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+	}
+
+	// Each unit is notified of its own action.
+	pending, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expectActionIds(pending...), jc.DeepEquals, expectActionIds(actions[1]))
+
+	group, err := s.State.ActionGroup(groupId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expectActionIds(group...), jc.DeepEquals, expectActionIds(actions[1], actions[0]))
+}
+
+func (s *ActionSuite) TestEnqueueActionGroupValidatesEveryUnit(c *gc.C) {
+	units := []*state.Unit{s.unit, s.actionlessUnit}
+	_, _, err := s.State.EnqueueActionGroup(units, "snapshot", nil, 0)
+	c.Assert(err, gc.ErrorMatches, `action "snapshot" not defined on unit "actionless/0"`)
+
+	// No action is queued when any unit rejects it.
+	pending, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestEnqueueActionGroupDeadUnit(c *gc.C) {
+	unit, err := s.State.Unit(s.unit2.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+	c.Assert(unit.Destroy(), jc.ErrorIsNil)
+	c.Assert(unit.EnsureDead(), jc.ErrorIsNil)
+
+	_, _, err = s.State.EnqueueActionGroup([]*state.Unit{s.unit, unit}, "snapshot", nil, 0)
+	c.Assert(err, gc.ErrorMatches, `unit "dummy/1": not found or dead`)
+	c.Assert(errors.Cause(err), gc.Equals, state.ErrDead)
+
+	pending, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestEnqueueActionGroupNoUnits(c *gc.C) {
+	_, _, err := s.State.EnqueueActionGroup(nil, "snapshot", nil, 0)
+	c.Assert(err, gc.ErrorMatches, "no units specified")
+}
+
+func (s *ActionSuite) TestActionGroupNotFound(c *gc.C) {
+	_, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ActionGroup("deadbeef-0000-4000-8000-feedfacebeef")
+	c.Assert(err, gc.ErrorMatches, `action group "deadbeef-0000-4000-8000-feedfacebeef" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *ActionSuite) TestFail(c *gc.C) {
 	// get unit, add an action, retrieve that action
 	unit, err := s.State.Unit(s.unit.Name())
@@ -463,6 +547,94 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestCancelRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cancelled, err := s.unit.CancelAction(action)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cancelled.Status(), gc.Equals, state.ActionCancelled)
+
+	// The unit cannot record a result for a cancelled action.
+	_, err = action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, gc.ErrorMatches, "transaction aborted")
+}
+
+func (s *ActionSuite) TestLog(c *gc.C) {
+	s.PatchValue(state.NowToTheSecondFunc, func() time.Time {
+		return time.Date(2015, 7, 1, 10, 17, 0, 0, time.UTC)
+	})
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Messages can only be logged while the action is running.
+	err = a.Log("too soon")
+	c.Assert(err, gc.ErrorMatches, "cannot log message to action .*: action is not running")
+
+	action, err := a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = action.Log("migrating table 1 of 2")
+	c.Assert(err, jc.ErrorIsNil)
+	err = action.Log("migrating table 2 of 2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message, gc.Equals, "migrating table 1 of 2")
+	c.Assert(messages[1].Message, gc.Equals, "migrating table 2 of 2")
+	c.Assert(messages[0].Timestamp.Equal(time.Date(2015, 7, 1, 10, 17, 0, 0, time.UTC)), jc.IsTrue)
+
+	result, err := action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Messages(), gc.HasLen, 2)
+	err = result.Log("too late")
+	c.Assert(err, gc.ErrorMatches, "cannot log message to action .*: action is not running")
+}
+
+func (s *ActionSuite) TestLogDiscardsOldMessages(c *gc.C) {
+	s.PatchValue(state.MaxActionMessages, 2)
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 1; i <= 3; i++ {
+		err = action.Log(fmt.Sprintf("step %d", i))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(action.Messages(), gc.HasLen, 2)
+	c.Assert(action.LogCount(), gc.Equals, 3)
+
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message, gc.Equals, "step 2")
+	c.Assert(messages[1].Message, gc.Equals, "step 3")
+	c.Assert(action.LogCount(), gc.Equals, 3)
+}
+
+func (s *ActionSuite) TestTimedOut(c *gc.C) {
+	a, err := s.unit.AddActionWithTimeout("snapshot", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := action.Finish(state.ActionResults{Status: state.ActionTimedOut, Message: "action timed out after 1m0s"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionTimedOut)
+
+	// A timed out action is finished, and cannot be cancelled.
+	results, err := s.unit.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expectActionIds(results...), jc.DeepEquals, expectActionIds(result))
+	_, err = s.unit.CancelAction(result)
+	c.Assert(err, gc.ErrorMatches, "transaction aborted")
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -493,6 +665,112 @@ func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	}
 }
 
+func (s *ActionSuite) TestFindActions(c *gc.C) {
+	t0 := time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC)
+	now := t0
+	s.PatchValue(state.NowToTheSecondFunc, func() time.Time { return now })
+
+	a1, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a1, err = a1.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	now = t0.Add(time.Hour)
+	a2, err := s.unit2.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	now = t0.Add(2 * time.Hour)
+	a3, err := s.State.EnqueueAction(s.actionlessUnit.Tag(), "fetch", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		about    string
+		filter   state.ActionsFilter
+		expected []*state.Action
+	}{{
+		about:    "no filter",
+		expected: []*state.Action{a1, a2, a3},
+	}, {
+		about:    "by service",
+		filter:   state.ActionsFilter{Services: []string{"dummy"}},
+		expected: []*state.Action{a1, a2},
+	}, {
+		about:    "by unit",
+		filter:   state.ActionsFilter{Units: []string{"actionless/0"}},
+		expected: []*state.Action{a3},
+	}, {
+		about: "by service or unit",
+		filter: state.ActionsFilter{
+			Services: []string{"dummy"},
+			Units:    []string{"actionless/0"},
+		},
+		expected: []*state.Action{a1, a2, a3},
+	}, {
+		about:    "by name",
+		filter:   state.ActionsFilter{Names: []string{"fetch"}},
+		expected: []*state.Action{a3},
+	}, {
+		about:    "by status",
+		filter:   state.ActionsFilter{Statuses: []state.ActionStatus{state.ActionCompleted}},
+		expected: []*state.Action{a1},
+	}, {
+		about:    "enqueued after",
+		filter:   state.ActionsFilter{EnqueuedAfter: t0.Add(time.Hour)},
+		expected: []*state.Action{a2, a3},
+	}, {
+		about:    "enqueued before",
+		filter:   state.ActionsFilter{EnqueuedBefore: t0.Add(time.Hour)},
+		expected: []*state.Action{a1, a2},
+	}, {
+		about: "by service and status",
+		filter: state.ActionsFilter{
+			Services: []string{"dummy"},
+			Statuses: []state.ActionStatus{state.ActionPending},
+		},
+		expected: []*state.Action{a2},
+	}, {
+		about:  "no match",
+		filter: state.ActionsFilter{Names: []string{"backup"}},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		actions, err := s.State.FindActions(test.filter)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(expectActionIds(actions...), jc.DeepEquals, expectActionIds(test.expected...))
+	}
+}
+
+func (s *ActionSuite) TestPruneActions(c *gc.C) {
+	t0 := time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC)
+	now := t0
+	s.PatchValue(state.NowToTheSecondFunc, func() time.Time { return now })
+
+	old, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = old.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	recent, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	now = t0.Add(2 * time.Hour)
+	_, err = recent.Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only finished actions older than the minimum time are pruned
+	// while the collection is within the maximum size.
+	err = state.PruneActions(s.State, t0.Add(time.Hour), 1000)
+	c.Assert(err, jc.ErrorIsNil)
+	actions, err := s.State.FindActions(state.ActionsFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expectActionIds(actions...), jc.DeepEquals, expectActionIds(recent, pending))
+
+	// All finished actions are pruned while the collection is over
+	// the maximum size, but pending actions are kept.
+	err = state.PruneActions(s.State, t0, -1)
+	c.Assert(err, jc.ErrorIsNil)
+	actions, err = s.State.FindActions(state.ActionsFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expectActionIds(actions...), jc.DeepEquals, expectActionIds(pending))
+}
+
 func (s *ActionSuite) TestActionsWatcherEmitsInitialChanges(c *gc.C) {
 	// LP-1391914 :: idPrefixWatcher fails watcher contract to send
 	// initial Change event
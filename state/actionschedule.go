@@ -0,0 +1,359 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/utils/cron"
+)
+
+// ActionSchedule describes a cron schedule on which an action is
+// enqueued by the state server, either on a single unit or on all the
+// units of a service.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+type actionScheduleDoc struct {
+	DocID   string `bson:"_id"`
+	Id      string `bson:"id"`
+	EnvUUID string `bson:"env-uuid"`
+
+	// Spec is the cron expression describing when the action is
+	// enqueued. Schedules are evaluated in UTC.
+	Spec string `bson:"spec"`
+
+	// Receiver is the tag of the unit, or the service whose units,
+	// the action is enqueued on.
+	Receiver string `bson:"receiver"`
+
+	Name       string                 `bson:"name"`
+	Parameters map[string]interface{} `bson:"parameters,omitempty"`
+	Timeout    time.Duration          `bson:"timeout,omitempty"`
+	Created    time.Time              `bson:"created"`
+
+	// LastRun records when the action was last enqueued, and
+	// LastError why doing so failed, if it did.
+	LastRun   time.Time `bson:"last-run,omitempty"`
+	LastError string    `bson:"last-error,omitempty"`
+}
+
+// Id returns the schedule's id.
+func (s *ActionSchedule) Id() string {
+	return s.doc.Id
+}
+
+// Spec returns the cron expression describing when the action is
+// enqueued.
+func (s *ActionSchedule) Spec() string {
+	return s.doc.Spec
+}
+
+// Receiver returns the tag of the unit, or service, the action is
+// enqueued on.
+func (s *ActionSchedule) Receiver() (names.Tag, error) {
+	tag, err := names.ParseTag(s.doc.Receiver)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid receiver for action schedule %s", s.doc.Id)
+	}
+	return tag, nil
+}
+
+// Name returns the name of the action enqueued.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Parameters returns the parameters the action is enqueued with.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Timeout returns the timeout the action is enqueued with.
+func (s *ActionSchedule) Timeout() time.Duration {
+	return s.doc.Timeout
+}
+
+// Created returns when the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// LastRun returns when the action was last enqueued by the schedule,
+// or the zero time if it has never been.
+func (s *ActionSchedule) LastRun() time.Time {
+	return s.doc.LastRun
+}
+
+// LastError returns why the action could not be enqueued the last
+// time the schedule ran, or "" if it was enqueued.
+func (s *ActionSchedule) LastError() string {
+	return s.doc.LastError
+}
+
+// Next returns the time at which the schedule is next due to run. It
+// returns the zero time if the schedule will never be due.
+func (s *ActionSchedule) Next() (time.Time, error) {
+	schedule, err := cron.Parse(s.doc.Spec)
+	if err != nil {
+		return time.Time{}, errors.Annotatef(err, "invalid spec for action schedule %s", s.doc.Id)
+	}
+	from := s.doc.Created
+	if s.doc.LastRun.After(from) {
+		from = s.doc.LastRun
+	}
+	return schedule.Next(from.UTC()), nil
+}
+
+// Run enqueues the scheduled action, recording that the schedule ran
+// at the given time, and returns the actions enqueued. The run is
+// recorded before the action is enqueued, so that an action is never
+// enqueued twice for the same scheduled time.
+func (s *ActionSchedule) Run(now time.Time) (actions []*Action, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot run action schedule %s", s.doc.Id)
+	now = now.UTC().Truncate(time.Second)
+	lastRun := bson.D{{"last-run", s.doc.LastRun}}
+	if s.doc.LastRun.IsZero() {
+		lastRun = bson.D{{"last-run", bson.D{{"$exists", false}}}}
+	}
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocID,
+		Assert: lastRun,
+		Update: bson.D{{"$set", bson.D{{"last-run", now}}}},
+	}}
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.New("schedule has been removed or run concurrently")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.doc.LastRun = now
+
+	actions, enqueueErr := s.enqueue()
+	var lastError string
+	if enqueueErr != nil {
+		lastError = enqueueErr.Error()
+	}
+	if lastError != s.doc.LastError {
+		ops := []txn.Op{{
+			C:      actionSchedulesC,
+			Id:     s.doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"last-error", lastError}}}},
+		}}
+		if err := s.st.runTransaction(ops); err != nil && err != txn.ErrAborted {
+			return nil, errors.Trace(err)
+		}
+		s.doc.LastError = lastError
+	}
+	return actions, errors.Trace(enqueueErr)
+}
+
+// enqueue queues the scheduled action on its receiver.
+func (s *ActionSchedule) enqueue() ([]*Action, error) {
+	receiver, err := s.Receiver()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch tag := receiver.(type) {
+	case names.UnitTag:
+		unit, err := s.st.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		action, err := unit.AddActionWithTimeout(s.doc.Name, s.doc.Parameters, s.doc.Timeout)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []*Action{action}, nil
+	case names.ServiceTag:
+		service, err := s.st.Service(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		allUnits, err := service.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var units []*Unit
+		for _, unit := range allUnits {
+			if unit.Life() == Alive {
+				units = append(units, unit)
+			}
+		}
+		if len(units) == 0 {
+			return nil, errors.Errorf("service %q has no units", service.Name())
+		}
+		_, actions, err := s.st.EnqueueActionGroup(units, s.doc.Name, s.doc.Parameters, s.doc.Timeout)
+		return actions, errors.Trace(err)
+	}
+	return nil, errors.Errorf("unexpected receiver %q", s.doc.Receiver)
+}
+
+// AddActionSchedule adds a schedule on which the named action, with
+// the given parameters and timeout, is enqueued on the receiver. The
+// receiver must be a unit, or a service, in which case the action is
+// enqueued on all of the service's units as a group. Schedules are
+// given as cron expressions, which are evaluated in UTC.
+func (st *State) AddActionSchedule(spec string, receiver names.Tag, name string, parameters map[string]interface{}, timeout time.Duration) (_ *ActionSchedule, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add action schedule")
+	if _, err := cron.Parse(spec); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(name) == 0 {
+		return nil, errors.New("action name required")
+	}
+	if timeout < 0 {
+		return nil, errors.NotValidf("negative action timeout")
+	}
+	var coll, actionsOwner string
+	var specs ActionSpecsByName
+	switch receiver := receiver.(type) {
+	case names.UnitTag:
+		unit, err := st.Unit(receiver.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if specs, err = unit.ActionSpecs(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		coll, actionsOwner = unitsC, fmt.Sprintf("unit %q", unit.Name())
+	case names.ServiceTag:
+		service, err := st.Service(receiver.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := service.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if chActions := ch.Actions(); chActions != nil {
+			specs = chActions.ActionSpecs
+		}
+		coll, actionsOwner = servicesC, fmt.Sprintf("service %q", service.Name())
+	default:
+		return nil, errors.NotValidf("action schedule receiver %q", receiver)
+	}
+	actionSpec, ok := specs[name]
+	if !ok {
+		return nil, errors.Errorf("action %q not defined on %s", name, actionsOwner)
+	}
+	if err := actionSpec.ValidateParams(parameters); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	seq, err := st.sequence("actionschedule")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := fmt.Sprint(seq)
+	doc := actionScheduleDoc{
+		DocID:      st.docID(id),
+		Id:         id,
+		EnvUUID:    st.EnvironUUID(),
+		Spec:       spec,
+		Receiver:   receiver.String(),
+		Name:       name,
+		Parameters: parameters,
+		Timeout:    timeout,
+		Created:    nowToTheSecond(),
+	}
+	ops := []txn.Op{{
+		C:      coll,
+		Id:     st.docID(receiver.Id()),
+		Assert: isAliveDoc,
+	}, {
+		C:      actionSchedulesC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.Errorf("%s is no longer alive", actionsOwner)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionSchedule{st: st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the given id.
+func (st *State) ActionSchedule(id string) (*ActionSchedule, error) {
+	schedules, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	return &ActionSchedule{st: st, doc: doc}, nil
+}
+
+// AllActionSchedules returns all the action schedules in the
+// environment.
+func (st *State) AllActionSchedules() ([]*ActionSchedule, error) {
+	schedules, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(nil).Sort("created").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	results := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		results[i] = &ActionSchedule{st: st, doc: doc}
+	}
+	return results, nil
+}
+
+// removeActionSchedulesOps returns the operations necessary to remove
+// the action schedules of the given receiver. Schedules cannot be added
+// to a receiver that is not alive.
+func removeActionSchedulesOps(st *State, receiver names.Tag) ([]txn.Op, error) {
+	schedules, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	err := schedules.Find(bson.D{{"receiver", receiver.String()}}).Select(bson.D{{"_id", true}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedules for %s", receiver)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      actionSchedulesC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given id.
+// Actions already enqueued by the schedule are not affected.
+func (st *State) RemoveActionSchedule(id string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     st.docID(id),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove action schedule %q", id)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	service *state.Service
+	unit    *state.Unit
+	unit2   *state.Unit
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.service = s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	var err error
+	s.unit, err = s.service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit2, err = s.service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(state.NowToTheSecondFunc, func() time.Time {
+		return time.Date(2015, 7, 1, 10, 17, 0, 0, time.UTC)
+	})
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	params := map[string]interface{}{"outfile": "backup.tar.bz2"}
+	schedule, err := s.State.AddActionSchedule("30 2 * * *", s.unit.Tag(), "snapshot", params, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Id(), gc.Equals, "0")
+
+	schedule, err = s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Spec(), gc.Equals, "30 2 * * *")
+	receiver, err := schedule.Receiver()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(receiver, gc.Equals, s.unit.Tag())
+	c.Assert(schedule.Name(), gc.Equals, "snapshot")
+	c.Assert(schedule.Parameters(), jc.DeepEquals, params)
+	c.Assert(schedule.Timeout(), gc.Equals, time.Hour)
+	c.Assert(schedule.LastRun().IsZero(), jc.IsTrue)
+	next, err := schedule.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, gc.Equals, time.Date(2015, 7, 2, 2, 30, 0, 0, time.UTC))
+
+	schedule, err = s.State.AddActionSchedule("@hourly", s.service.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Id(), gc.Equals, "1")
+
+	schedules, err := s.State.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 2)
+	c.Assert(schedules[0].Id(), gc.Equals, "0")
+	c.Assert(schedules[1].Id(), gc.Equals, "1")
+	receiver, err = schedules[1].Receiver()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(receiver, gc.Equals, s.service.Tag())
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec     string
+		receiver names.Tag
+		name     string
+		params   map[string]interface{}
+		timeout  time.Duration
+		err      string
+	}{{
+		spec:     "* * *",
+		receiver: s.unit.Tag(),
+		name:     "snapshot",
+		err:      `cannot add action schedule: invalid cron expression "\* \* \*": expected 5 fields, got 3`,
+	}, {
+		spec:     "@daily",
+		receiver: s.unit.Tag(),
+		err:      "cannot add action schedule: action name required",
+	}, {
+		spec:     "@daily",
+		receiver: s.unit.Tag(),
+		name:     "snapshot",
+		timeout:  -time.Second,
+		err:      "cannot add action schedule: negative action timeout not valid",
+	}, {
+		spec:     "@daily",
+		receiver: names.NewMachineTag("0"),
+		name:     "snapshot",
+		err:      `cannot add action schedule: action schedule receiver "machine-0" not valid`,
+	}, {
+		spec:     "@daily",
+		receiver: names.NewUnitTag("dummy/9"),
+		name:     "snapshot",
+		err:      `cannot add action schedule: unit "dummy/9" not found`,
+	}, {
+		spec:     "@daily",
+		receiver: s.service.Tag(),
+		name:     "compact",
+		err:      `cannot add action schedule: action "compact" not defined on service "dummy"`,
+	}, {
+		spec:     "@daily",
+		receiver: s.service.Tag(),
+		name:     "snapshot",
+		params:   map[string]interface{}{"outfile": 5.0},
+		err:      `cannot add action schedule: validation failed: .*`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddActionSchedule(test.spec, test.receiver, test.name, test.params, test.timeout)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	schedules, err := s.State.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule("@daily", s.unit.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, gc.ErrorMatches, `action schedule "0" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestRunUnit(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule("@hourly", s.unit.Tag(), "snapshot", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ran := time.Date(2015, 7, 1, 11, 0, 3, 0, time.UTC)
+	actions, err := schedule.Run(ran)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Assert(actions[0].Receiver(), gc.Equals, s.unit.Name())
+	c.Assert(actions[0].Name(), gc.Equals, "snapshot")
+	c.Assert(actions[0].Timeout(), gc.Equals, time.Minute)
+	// Defaults from the charm are filled in.
+	c.Assert(actions[0].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+
+	schedule, err = s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastRun().Equal(ran), jc.IsTrue)
+	c.Assert(schedule.LastError(), gc.Equals, "")
+	next, err := schedule.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, gc.Equals, time.Date(2015, 7, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *ActionScheduleSuite) TestRunService(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule("@hourly", s.service.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := schedule.Run(time.Date(2015, 7, 1, 11, 0, 0, 0, time.UTC))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 2)
+	c.Assert(actions[0].Receiver(), gc.Equals, s.unit.Name())
+	c.Assert(actions[1].Receiver(), gc.Equals, s.unit2.Name())
+	c.Assert(actions[0].Group(), gc.Not(gc.Equals), "")
+	c.Assert(actions[0].Group(), gc.Equals, actions[1].Group())
+}
+
+func (s *ActionScheduleSuite) TestRunConcurrently(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule("@hourly", s.unit.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	ran := time.Date(2015, 7, 1, 11, 0, 0, 0, time.UTC)
+	_, err = schedule.Run(ran)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = other.Run(ran)
+	c.Assert(err, gc.ErrorMatches, "cannot run action schedule 0: schedule has been removed or run concurrently")
+
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+}
+
+func (s *ActionScheduleSuite) TestRunRecordsError(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule("@hourly", s.unit.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = schedule.Run(time.Date(2015, 7, 1, 11, 0, 0, 0, time.UTC))
+	c.Assert(err, gc.ErrorMatches, "cannot run action schedule 0: .*")
+
+	schedule, err = s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastError(), gc.Not(gc.Equals), "")
+	next, err := schedule.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, gc.Equals, time.Date(2015, 7, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *ActionScheduleSuite) TestCorruptSchedule(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule("@hourly", s.unit.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedules, closer := state.GetRawCollection(s.State, "actionschedules")
+	defer closer()
+	err = schedules.UpdateId(state.DocID(s.State, schedule.Id()), bson.D{{"$set", bson.D{
+		{"spec", "* * *"},
+		{"receiver", "bad"},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedule, err = s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = schedule.Receiver()
+	c.Assert(err, gc.ErrorMatches, `invalid receiver for action schedule 0: "bad" is not a valid tag`)
+	_, err = schedule.Next()
+	c.Assert(err, gc.ErrorMatches, `invalid spec for action schedule 0: invalid cron expression .*`)
+	_, err = schedule.Run(time.Date(2015, 7, 1, 11, 0, 0, 0, time.UTC))
+	c.Assert(err, gc.ErrorMatches, `cannot run action schedule 0: invalid receiver for action schedule 0: .*`)
+}
+
+func (s *ActionScheduleSuite) TestRemoveUnitRemovesSchedules(c *gc.C) {
+	_, err := s.State.AddActionSchedule("@hourly", s.unit.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.State.AddActionSchedule("@hourly", s.unit2.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedules, err := s.State.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 1)
+	c.Assert(schedules[0].Id(), gc.Equals, other.Id())
+}
+
+func (s *ActionScheduleSuite) TestRemoveServiceRemovesSchedules(c *gc.C) {
+	_, err := s.State.AddActionSchedule("@hourly", s.service.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddActionSchedule("@hourly", s.unit.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.service.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, unit := range []*state.Unit{s.unit, s.unit2} {
+		err = unit.EnsureDead()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.Remove()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = s.service.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	schedules, err := s.State.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 0)
+}
@@ -532,8 +532,26 @@ func (st *State) machineStorageOps(mdoc *machineDoc, args *machineStorageParams)
 		})
 	}
 
-	// TODO(axw) handle args.filesystemAttachments, args.volumeAttachments
-	// when we handle attaching to existing (e.g. shared) storage.
+	// Create attachments to existing filesystems and volumes,
+	// e.g. shared storage, or storage detached from another unit.
+	for tag, params := range args.filesystemAttachments {
+		_, err := st.FilesystemAttachment(names.NewMachineTag(mdoc.Id), tag)
+		if err == nil {
+			// The filesystem is shared, and is already
+			// attached to the machine for another unit.
+			continue
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
+			tag, params,
+		})
+	}
+	for tag, params := range args.volumeAttachments {
+		volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+			tag, params,
+		})
+	}
 
 	ops := make([]txn.Op, 0, len(filesystemOps)+len(volumeOps)+len(fsAttachments)+len(volumeAttachments))
 	if len(fsAttachments) > 0 {
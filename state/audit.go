@@ -0,0 +1,134 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/audit"
+)
+
+// auditEntryDoc records a single audited API call made against
+// the environment.
+type auditEntryDoc struct {
+	Id        bson.ObjectId `bson:"_id"`
+	EnvUUID   string        `bson:"env-uuid"`
+	Timestamp time.Time     `bson:"timestamp"`
+	User      string        `bson:"user"`
+	Facade    string        `bson:"facade"`
+	Version   int           `bson:"version"`
+	Method    string        `bson:"method"`
+	Args      string        `bson:"args,omitempty"`
+	Entities  []string      `bson:"entities,omitempty"`
+	Outcome   string        `bson:"outcome"`
+	Error     string        `bson:"error,omitempty"`
+}
+
+func (doc *auditEntryDoc) entry() audit.AuditEntry {
+	return audit.AuditEntry{
+		Timestamp: doc.Timestamp.UTC(),
+		EnvUUID:   doc.EnvUUID,
+		User:      doc.User,
+		Facade:    doc.Facade,
+		Version:   doc.Version,
+		Method:    doc.Method,
+		Args:      doc.Args,
+		Entities:  doc.Entities,
+		Outcome:   audit.Outcome(doc.Outcome),
+		Error:     doc.Error,
+	}
+}
+
+// AuditFilter holds the criteria used to select audit entries.
+// Zero valued fields do not restrict the selection.
+type AuditFilter struct {
+	// User selects entries made by the user with this canonical
+	// name (e.g. "bob@local").
+	User string
+
+	// Entity selects entries that name the entity with this tag.
+	Entity string
+
+	// From and To select entries recorded within the time range.
+	From time.Time
+	To   time.Time
+
+	// Limit restricts the number of entries returned.
+	Limit int
+}
+
+// AddAuditEntry stores an audit entry for the environment. It
+// implements audit.EntryWriter.
+func (st *State) AddAuditEntry(entry audit.AuditEntry) error {
+	if err := entry.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if entry.EnvUUID != st.EnvironUUID() {
+		return errors.Errorf("audit entry for environment %q cannot be added to environment %q", entry.EnvUUID, st.EnvironUUID())
+	}
+	coll, closer := st.getCollection(auditC)
+	defer closer()
+
+	doc := &auditEntryDoc{
+		Id:        bson.NewObjectId(),
+		EnvUUID:   entry.EnvUUID,
+		Timestamp: entry.Timestamp.UTC(),
+		User:      entry.User,
+		Facade:    entry.Facade,
+		Version:   entry.Version,
+		Method:    entry.Method,
+		Args:      entry.Args,
+		Entities:  entry.Entities,
+		Outcome:   string(entry.Outcome),
+		Error:     entry.Error,
+	}
+	// Audit entries are written directly rather than in a transaction
+	// so they can still be recorded once the environment is dying.
+	if err := coll.Insert(doc); err != nil {
+		return errors.Annotate(err, "cannot add audit entry")
+	}
+	return nil
+}
+
+// AuditEntries returns the audit entries for the environment that
+// match the given filter, most recent first.
+func (st *State) AuditEntries(filter AuditFilter) ([]audit.AuditEntry, error) {
+	coll, closer := st.getCollection(auditC)
+	defer closer()
+
+	query := bson.D{}
+	if filter.User != "" {
+		query = append(query, bson.DocElem{"user", filter.User})
+	}
+	if filter.Entity != "" {
+		query = append(query, bson.DocElem{"entities", filter.Entity})
+	}
+	timeRange := bson.D{}
+	if !filter.From.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$gte", filter.From.UTC()})
+	}
+	if !filter.To.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$lte", filter.To.UTC()})
+	}
+	if len(timeRange) > 0 {
+		query = append(query, bson.DocElem{"timestamp", timeRange})
+	}
+
+	q := coll.Find(query).Sort("-timestamp", "-_id")
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	var docs []auditEntryDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get audit entries")
+	}
+	entries := make([]audit.AuditEntry, len(docs))
+	for i, doc := range docs {
+		entries[i] = doc.entry()
+	}
+	return entries, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+type auditSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) entry(user, method string, ts time.Time, entities ...string) audit.AuditEntry {
+	return audit.AuditEntry{
+		Timestamp: ts,
+		EnvUUID:   s.State.EnvironUUID(),
+		User:      user,
+		Facade:    "Client",
+		Method:    method,
+		Args:      "{}",
+		Entities:  entities,
+		Outcome:   audit.Success,
+	}
+}
+
+func (s *auditSuite) addEntries(c *gc.C) []audit.AuditEntry {
+	base := time.Date(2015, 5, 1, 10, 0, 0, 0, time.UTC)
+	entries := []audit.AuditEntry{
+		s.entry("admin@local", "ServiceDeploy", base, "service-mysql"),
+		s.entry("bob@local", "ServiceSet", base.Add(time.Hour), "service-mysql"),
+		s.entry("bob@local", "DestroyMachines", base.Add(2*time.Hour), "machine-1"),
+		s.entry("admin@local", "ServiceDestroy", base.Add(3*time.Hour), "service-mysql"),
+	}
+	for _, entry := range entries {
+		err := s.State.AddAuditEntry(entry)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return entries
+}
+
+func (s *auditSuite) TestAddAuditEntryInvalid(c *gc.C) {
+	entry := s.entry("", "ServiceDeploy", time.Now())
+	err := s.State.AddAuditEntry(entry)
+	c.Assert(err, gc.ErrorMatches, "audit entry with blank user not valid")
+}
+
+func (s *auditSuite) TestAddAuditEntryOtherEnvironment(c *gc.C) {
+	entry := s.entry("admin@local", "ServiceDeploy", time.Now())
+	entry.EnvUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	err := s.State.AddAuditEntry(entry)
+	c.Assert(err, gc.ErrorMatches, `audit entry for environment "deadbeef-0bad-400d-8000-4b1d0d06f00d" cannot be added to environment ".*"`)
+}
+
+func (s *auditSuite) TestAuditEntriesAll(c *gc.C) {
+	entries := s.addEntries(c)
+	found, err := s.State.AuditEntries(state.AuditFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []audit.AuditEntry{
+		entries[3], entries[2], entries[1], entries[0],
+	})
+}
+
+func (s *auditSuite) TestAuditEntriesByUser(c *gc.C) {
+	entries := s.addEntries(c)
+	found, err := s.State.AuditEntries(state.AuditFilter{User: "bob@local"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []audit.AuditEntry{entries[2], entries[1]})
+}
+
+func (s *auditSuite) TestAuditEntriesByEntity(c *gc.C) {
+	entries := s.addEntries(c)
+	found, err := s.State.AuditEntries(state.AuditFilter{Entity: "service-mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []audit.AuditEntry{entries[3], entries[1], entries[0]})
+}
+
+func (s *auditSuite) TestAuditEntriesByTimeRange(c *gc.C) {
+	entries := s.addEntries(c)
+	found, err := s.State.AuditEntries(state.AuditFilter{
+		From: entries[1].Timestamp,
+		To:   entries[2].Timestamp,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []audit.AuditEntry{entries[2], entries[1]})
+}
+
+func (s *auditSuite) TestAuditEntriesLimit(c *gc.C) {
+	entries := s.addEntries(c)
+	found, err := s.State.AuditEntries(state.AuditFilter{Limit: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []audit.AuditEntry{entries[3]})
+}
+
+func (s *auditSuite) TestAuditEntriesOtherEnvironment(c *gc.C) {
+	s.addEntries(c)
+	st := s.Factory.MakeEnvironment(c, nil)
+	defer st.Close()
+
+	found, err := st.AuditEntries(state.AuditFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, gc.HasLen, 0)
+}
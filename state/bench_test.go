@@ -103,7 +103,7 @@ func (*BenchmarkSuite) BenchmarkCleanupMetrics(c *gc.C) {
 	defer s.TearDownSuite(c)
 	s.SetUpTest(c)
 	defer s.TearDownTest(c)
-	oldTime := time.Now().Add(-(state.CleanupAge))
+	oldTime := time.Now().Add(-24 * time.Hour)
 	charm := s.AddTestingCharm(c, "wordpress")
 	svc := s.AddTestingService(c, "wordpress", charm)
 	unit, err := svc.AddUnit()
@@ -144,7 +144,10 @@ func (st *State) GetBlockForType(t BlockType) (Block, bool, error) {
 	defer closer()
 
 	doc := blockDoc{}
-	err := all.Find(bson.D{{"type", t}}).One(&doc)
+	err := all.Find(bson.D{
+		{"type", t},
+		{"tag", st.EnvironTag().String()},
+	}).One(&doc)
 
 	switch err {
 	case nil:
@@ -156,6 +159,152 @@ func (st *State) GetBlockForType(t BlockType) (Block, bool, error) {
 	}
 }
 
+// SwitchEntityBlockOn enables block of specified type for the given
+// service, unit or machine in the current environment. A block on a
+// service also applies to the units of that service.
+func (st *State) SwitchEntityBlockOn(t BlockType, tag names.Tag, msg string) error {
+	if err := validateEntityBlock(t, tag); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, exists, err := st.GetBlockForEntity(t, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if exists {
+			return nil, errors.Errorf("block %v on %s is already ON", t.String(), names.ReadableString(tag))
+		}
+		assertOp, err := assertBlockEntityAliveOp(st, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc := blockDoc{
+			DocID:   entityBlockDocID(st, t, tag),
+			EnvUUID: st.EnvironUUID(),
+			Tag:     tag.String(),
+			Type:    t,
+			Message: msg,
+		}
+		return []txn.Op{assertOp, {
+			C:      blocksC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// SwitchEntityBlockOff disables block of specified type for the given
+// service, unit or machine in the current environment.
+func (st *State) SwitchEntityBlockOff(t BlockType, tag names.Tag) error {
+	if err := validateEntityBlock(t, tag); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, exists, err := st.GetBlockForEntity(t, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !exists {
+			return nil, errors.Errorf("block %v on %s is already OFF", t.String(), names.ReadableString(tag))
+		}
+		return []txn.Op{{
+			C:      blocksC,
+			Id:     entityBlockDocID(st, t, tag),
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// GetBlockForEntity returns the Block of the specified type for the given
+// service, unit or machine, with the same results as GetBlockForType.
+// Blocks on the environment, or on the service of a unit, are not
+// considered.
+func (st *State) GetBlockForEntity(t BlockType, tag names.Tag) (Block, bool, error) {
+	all, closer := st.getCollection(blocksC)
+	defer closer()
+
+	doc := blockDoc{}
+	err := all.FindId(entityBlockDocID(st, t, tag)).One(&doc)
+
+	switch err {
+	case nil:
+		return &block{doc}, true, nil
+	case mgo.ErrNotFound:
+		return nil, false, nil
+	default:
+		return nil, false, errors.Annotatef(err, "cannot get block of type %v for %s", t.String(), names.ReadableString(tag))
+	}
+}
+
+// validateEntityBlock returns an error if blocks of the given type
+// cannot be placed on the tagged entity.
+func validateEntityBlock(t BlockType, tag names.Tag) error {
+	switch tag.(type) {
+	case names.ServiceTag, names.UnitTag, names.MachineTag:
+	default:
+		return errors.NotValidf("block on %s", names.ReadableString(tag))
+	}
+	if t == DestroyBlock {
+		return errors.NotValidf("block %v on %s", t.String(), names.ReadableString(tag))
+	}
+	return nil
+}
+
+// entityBlockDocID returns the id of the document recording a block of
+// the given type on the tagged entity. Unlike environment blocks, these
+// ids are derived from the entity, so that they can be removed along
+// with the entity.
+func entityBlockDocID(st *State, t BlockType, tag names.Tag) string {
+	return st.docID(fmt.Sprintf("%s#%d", tag.String(), t))
+}
+
+// assertBlockEntityAliveOp returns an operation asserting that the
+// tagged entity exists and is alive.
+func assertBlockEntityAliveOp(st *State, tag names.Tag) (txn.Op, error) {
+	entity, err := st.FindEntity(tag)
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	if entity.(Lifer).Life() != Alive {
+		return txn.Op{}, errors.Errorf("%s is not alive", names.ReadableString(tag))
+	}
+	var coll string
+	switch tag.(type) {
+	case names.ServiceTag:
+		coll = servicesC
+	case names.UnitTag:
+		coll = unitsC
+	case names.MachineTag:
+		coll = machinesC
+	}
+	return txn.Op{
+		C:      coll,
+		Id:     st.docID(tag.Id()),
+		Assert: isAliveDoc,
+	}, nil
+}
+
+// removeEntityBlocksOps returns the operations required to remove all
+// blocks on the tagged entity.
+func removeEntityBlocksOps(st *State, tag names.Tag) []txn.Op {
+	var ops []txn.Op
+	for _, t := range AllTypes() {
+		if validateEntityBlock(t, tag) != nil {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      blocksC,
+			Id:     entityBlockDocID(st, t, tag),
+			Remove: true,
+		})
+	}
+	return ops
+}
+
 // AllBlocks returns all blocks in the environment.
 func (st *State) AllBlocks() ([]Block, error) {
 	blocksCollection, closer := st.getCollection(blocksC)
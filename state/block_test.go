@@ -159,3 +159,58 @@ func (s *blockSuite) TestConcurrentBlocked(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	assertEnvHasBlock(c, s.State, t, msg)
 }
+
+func (s *blockSuite) TestEntityBlock(c *gc.C) {
+	svc := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := s.State.SwitchEntityBlockOn(state.RemoveBlock, svc.Tag(), "protect wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+
+	b, found, err := s.State.GetBlockForEntity(state.RemoveBlock, svc.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+	c.Assert(b.Type(), gc.Equals, state.RemoveBlock)
+	c.Assert(b.Message(), gc.Equals, "protect wordpress")
+	tag, err := b.Tag()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, svc.Tag())
+
+	// Entity blocks are not environment blocks.
+	s.assertNoTypedBlock(c, state.RemoveBlock)
+	all, err := s.State.AllBlocks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+
+	err = s.State.SwitchEntityBlockOn(state.RemoveBlock, svc.Tag(), "again")
+	c.Assert(err, gc.ErrorMatches, `.*block BlockRemove on service wordpress is already ON`)
+
+	err = s.State.SwitchEntityBlockOff(state.RemoveBlock, svc.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, found, err = s.State.GetBlockForEntity(state.RemoveBlock, svc.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+
+	err = s.State.SwitchEntityBlockOff(state.RemoveBlock, svc.Tag())
+	c.Assert(err, gc.ErrorMatches, `.*block BlockRemove on service wordpress is already OFF`)
+}
+
+func (s *blockSuite) TestEntityBlockInvalid(c *gc.C) {
+	svc := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := s.State.SwitchEntityBlockOn(state.DestroyBlock, svc.Tag(), "")
+	c.Assert(err, gc.ErrorMatches, `block BlockDestroy on service wordpress not valid`)
+
+	err = s.State.SwitchEntityBlockOn(state.ChangeBlock, s.State.EnvironTag(), "")
+	c.Assert(err, gc.ErrorMatches, `block on environment .* not valid`)
+
+	err = s.State.SwitchEntityBlockOn(state.ChangeBlock, names.NewServiceTag("mysql"), "")
+	c.Assert(err, gc.ErrorMatches, `service "mysql" not found`)
+}
+
+func (s *blockSuite) TestEntityBlockRemovedWithEntity(c *gc.C) {
+	svc := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := s.State.SwitchEntityBlockOn(state.ChangeBlock, svc.Tag(), "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = svc.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertNoEnvBlock(c, s.State)
+}
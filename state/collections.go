@@ -60,8 +60,10 @@ type stateCollection interface {
 // these collections.
 var multiEnvCollections = set.NewStrings(
 	actionNotificationsC,
+	actionSchedulesC,
 	actionsC,
 	annotationsC,
+	auditC,
 	blockDevicesC,
 	blocksC,
 	charmsC,
@@ -96,6 +98,8 @@ var multiEnvCollections = set.NewStrings(
 	unitsC,
 	volumesC,
 	volumeAttachmentsC,
+	volumeSnapshotsC,
+	workerReportsC,
 )
 
 func newStateCollection(coll *mgo.Collection, envUUID string) stateCollection {
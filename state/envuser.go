@@ -32,6 +32,33 @@ type envUserDoc struct {
 	CreatedBy      string     `bson:"createdby"`
 	DateCreated    time.Time  `bson:"datecreated"`
 	LastConnection *time.Time `bson:"lastconnection"`
+	Access         string     `bson:"access,omitempty"`
+}
+
+// EnvironmentAccess is the level of access a user has to an environment.
+type EnvironmentAccess string
+
+const (
+	// EnvironmentReadAccess allows a user to view an environment, but
+	// not to change it.
+	EnvironmentReadAccess EnvironmentAccess = "read"
+
+	// EnvironmentWriteAccess allows a user to make changes to an
+	// environment, such as deploying and destroying services.
+	EnvironmentWriteAccess EnvironmentAccess = "write"
+
+	// EnvironmentAdminAccess allows a user to make any change to an
+	// environment, including sharing it with other users.
+	EnvironmentAdminAccess EnvironmentAccess = "admin"
+)
+
+// Validate returns an error if the access level is not known.
+func (a EnvironmentAccess) Validate() error {
+	switch a {
+	case EnvironmentReadAccess, EnvironmentWriteAccess, EnvironmentAdminAccess:
+		return nil
+	}
+	return errors.NotValidf("environment access %q", string(a))
 }
 
 // ID returns the ID of the environment user.
@@ -69,6 +96,35 @@ func (e *EnvironmentUser) DateCreated() time.Time {
 	return e.doc.DateCreated.UTC()
 }
 
+// Access returns the level of access the environment user has to the
+// environment. Environment users added before access levels were
+// introduced had unrestricted access, and so have admin access.
+func (e *EnvironmentUser) Access() EnvironmentAccess {
+	if e.doc.Access == "" {
+		return EnvironmentAdminAccess
+	}
+	return EnvironmentAccess(e.doc.Access)
+}
+
+// SetAccess changes the level of access the environment user has to the
+// environment.
+func (e *EnvironmentUser) SetAccess(access EnvironmentAccess) error {
+	if err := access.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      envUsersC,
+		Id:     e.ID(),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"access", string(access)}}}},
+	}}
+	if err := e.st.runTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot set access for envuser %q", e.ID())
+	}
+	e.doc.Access = string(access)
+	return nil
+}
+
 // LastLogin returns when this EnvironmentUser last connected through the API
 // in UTC. The resulting time will be nil if the user has never logged in.
 func (e *EnvironmentUser) LastConnection() *time.Time {
@@ -114,8 +170,19 @@ func (st *State) EnvironmentUser(user names.UserTag) (*EnvironmentUser, error) {
 	return envUser, nil
 }
 
-// AddEnvironmentUser adds a new user to the database.
+// AddEnvironmentUser adds a new user to the database with admin access
+// to the environment.
 func (st *State) AddEnvironmentUser(user, createdBy names.UserTag, displayName string) (*EnvironmentUser, error) {
+	return st.AddEnvironmentUserWithAccess(user, createdBy, displayName, EnvironmentAdminAccess)
+}
+
+// AddEnvironmentUserWithAccess adds a new user to the database with the
+// given level of access to the environment.
+func (st *State) AddEnvironmentUserWithAccess(user, createdBy names.UserTag, displayName string, access EnvironmentAccess) (*EnvironmentUser, error) {
+	if err := access.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	// Ensure local user exists in state before adding them as an environment user.
 	if user.IsLocal() {
 		localUser, err := st.User(user)
@@ -135,7 +202,7 @@ func (st *State) AddEnvironmentUser(user, createdBy names.UserTag, displayName s
 	}
 
 	envuuid := st.EnvironUUID()
-	op, doc := createEnvUserOpAndDoc(envuuid, user, createdBy, displayName)
+	op, doc := createEnvUserOpAndDoc(envuuid, user, createdBy, displayName, access)
 	err := st.runTransaction([]txn.Op{op})
 	if err == txn.ErrAborted {
 		err = errors.AlreadyExistsf("environment user %q", user.Username())
@@ -146,7 +213,7 @@ func (st *State) AddEnvironmentUser(user, createdBy names.UserTag, displayName s
 	return &EnvironmentUser{st: st, doc: *doc}, nil
 }
 
-func createEnvUserOpAndDoc(envuuid string, user, createdBy names.UserTag, displayName string) (txn.Op, *envUserDoc) {
+func createEnvUserOpAndDoc(envuuid string, user, createdBy names.UserTag, displayName string, access EnvironmentAccess) (txn.Op, *envUserDoc) {
 	username := user.Username()
 	usernameLowerCase := strings.ToLower(username)
 	creatorname := createdBy.Username()
@@ -157,6 +224,7 @@ func createEnvUserOpAndDoc(envuuid string, user, createdBy names.UserTag, displa
 		DisplayName: displayName,
 		CreatedBy:   creatorname,
 		DateCreated: nowToTheSecond(),
+		Access:      string(access),
 	}
 	op := txn.Op{
 		C:      envUsersC,
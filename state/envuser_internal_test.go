@@ -17,7 +17,7 @@ var _ = gc.Suite(&internalEnvUserSuite{})
 
 func (s *internalEnvUserSuite) TestCreateEnvUserOpAndDoc(c *gc.C) {
 	tag := names.NewUserTag("UserName")
-	op, doc := createEnvUserOpAndDoc("ignored", tag, names.NewUserTag("ignored"), "ignored")
+	op, doc := createEnvUserOpAndDoc("ignored", tag, names.NewUserTag("ignored"), "ignored", EnvironmentAdminAccess)
 
 	c.Assert(op.Id, gc.Equals, "username@local")
 	c.Assert(doc.ID, gc.Equals, "username@local")
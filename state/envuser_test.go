@@ -111,6 +111,45 @@ func (s *EnvUserSuite) TestAddEnvironmentNoCreatedByUserFails(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `createdBy user "createdby" does not exist locally: user "createdby" not found`)
 }
 
+func (s *EnvUserSuite) TestAddEnvironmentUserDefaultAccess(c *gc.C) {
+	user := s.factory.MakeUser(c, &factory.UserParams{Name: "validusername", NoEnvUser: true})
+	envUser, err := s.State.AddEnvironmentUser(user.UserTag(), s.Owner, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.EnvironmentAdminAccess)
+}
+
+func (s *EnvUserSuite) TestAddEnvironmentUserWithAccess(c *gc.C) {
+	user := s.factory.MakeUser(c, &factory.UserParams{Name: "validusername", NoEnvUser: true})
+	envUser, err := s.State.AddEnvironmentUserWithAccess(user.UserTag(), s.Owner, "", state.EnvironmentReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.EnvironmentReadAccess)
+
+	envUser, err = s.State.EnvironmentUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.EnvironmentReadAccess)
+}
+
+func (s *EnvUserSuite) TestAddEnvironmentUserInvalidAccess(c *gc.C) {
+	user := s.factory.MakeUser(c, &factory.UserParams{Name: "validusername", NoEnvUser: true})
+	_, err := s.State.AddEnvironmentUserWithAccess(user.UserTag(), s.Owner, "", "superuser")
+	c.Assert(err, gc.ErrorMatches, `environment access "superuser" not valid`)
+	c.Assert(errors.IsNotValid(err), jc.IsTrue)
+}
+
+func (s *EnvUserSuite) TestSetAccess(c *gc.C) {
+	envUser := s.factory.MakeEnvUser(c, nil)
+	err := envUser.SetAccess(state.EnvironmentWriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.EnvironmentWriteAccess)
+
+	envUser, err = s.State.EnvironmentUser(envUser.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.EnvironmentWriteAccess)
+
+	err = envUser.SetAccess("superuser")
+	c.Assert(err, gc.ErrorMatches, `environment access "superuser" not valid`)
+}
+
 func (s *EnvUserSuite) TestRemoveEnvironmentUser(c *gc.C) {
 	user := s.factory.MakeUser(c, &factory.UserParams{Name: "validusername"})
 	_, err := s.State.EnvironmentUser(user.UserTag())
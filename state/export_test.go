@@ -45,11 +45,14 @@ var (
 	PortsGlobalKey         = portsGlobalKey
 	CurrentUpgradeId       = currentUpgradeId
 	NowToTheSecond         = nowToTheSecond
+	NowToTheSecondFunc     = &nowToTheSecond
 	MultiEnvCollections    = multiEnvCollections
 	PickAddress            = &pickAddress
 	AddVolumeOp            = (*State).addVolumeOp
 	CombineMeterStatus     = combineMeterStatus
 	NewStatusNotFound      = newStatusNotFound
+	LogTailerPollInterval  = &logTailerPollInterval
+	MaxActionMessages      = &maxActionMessages
 )
 
 type (
@@ -198,6 +201,7 @@ func GetPasswordHash(e Authenticator) string {
 
 func init() {
 	txnLogSize = txnLogSizeTests
+	hookRunsSize = hookRunsSizeTests
 }
 
 // TxnRevno returns the txn-revno field of the document
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/juju/storage"
 	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -398,6 +399,43 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 	return ops, names.NewFilesystemTag(id), volumeTag, nil
 }
 
+// addSharedFilesystemOps returns txn.Ops to create a new shared filesystem
+// with the specified parameters. Shared filesystems exist independently of
+// Juju, so the filesystem is recorded as provisioned immediately; they are
+// attached to machines by each machine's storage provisioner.
+func (st *State) addSharedFilesystemOps(params FilesystemParams) ([]txn.Op, names.FilesystemTag, error) {
+	params, err := st.filesystemParamsWithDefaults(params)
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Trace(err)
+	}
+	if _, err := st.validateFilesystemParams(params, ""); err != nil {
+		return nil, names.FilesystemTag{}, errors.Annotate(err, "validating filesystem params")
+	}
+	filesystemId, err := sharedFilesystemId(st, params.Pool)
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Trace(err)
+	}
+	id, err := newFilesystemId(st, "")
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Annotate(err, "cannot generate filesystem name")
+	}
+	ops := []txn.Op{{
+		C:      filesystemsC,
+		Id:     id,
+		Assert: txn.DocMissing,
+		Insert: &filesystemDoc{
+			FilesystemId: id,
+			StorageId:    params.storage.Id(),
+			Info: &FilesystemInfo{
+				Size:         params.Size,
+				Pool:         params.Pool,
+				FilesystemId: filesystemId,
+			},
+		},
+	}}
+	return ops, names.NewFilesystemTag(id), nil
+}
+
 func (st *State) filesystemParamsWithDefaults(params FilesystemParams) (FilesystemParams, error) {
 	if params.Pool != "" {
 		return params, nil
@@ -572,3 +610,55 @@ func setFilesystemAttachmentInfoOps(
 		Update: update,
 	}}
 }
+
+// DetachFilesystem marks the filesystem attachment identified by the
+// specified machine and filesystem tags as Dying, if it is Alive. The
+// storage provisioner will detach the filesystem from the machine, and
+// then remove the attachment from state.
+func (st *State) DetachFilesystem(machine names.MachineTag, filesystem names.FilesystemTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach filesystem %s from machine %s", filesystem.Id(), machine.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		fsa, err := st.FilesystemAttachment(machine, filesystem)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if fsa.Life() != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return detachFilesystemOps(machine.Id(), filesystem.Id()), nil
+	}
+	return st.run(buildTxn)
+}
+
+func detachFilesystemOps(machineId, filesystemId string) []txn.Op {
+	return []txn.Op{{
+		C:      filesystemAttachmentsC,
+		Id:     filesystemAttachmentId(machineId, filesystemId),
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+	}}
+}
+
+// RemoveFilesystemAttachment removes the filesystem attachment from state.
+// RemoveFilesystemAttachment will fail if the attachment is Alive.
+func (st *State) RemoveFilesystemAttachment(machine names.MachineTag, filesystem names.FilesystemTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove attachment of filesystem %s from machine %s", filesystem.Id(), machine.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		fsa, err := st.FilesystemAttachment(machine, filesystem)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if fsa.Life() == Alive {
+			return nil, errors.New("filesystem attachment is not dying")
+		}
+		return []txn.Op{{
+			C:      filesystemAttachmentsC,
+			Id:     filesystemAttachmentId(machine.Id(), filesystem.Id()),
+			Assert: bson.D{{"life", bson.D{{"$ne", Alive}}}},
+			Remove: true,
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
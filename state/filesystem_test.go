@@ -426,3 +426,201 @@ func (s *FilesystemStateSuite) assertFilesystemAttachmentInfo(c *gc.C, m names.M
 	_, ok := filesystemAttachment.Params()
 	c.Assert(ok, jc.IsFalse)
 }
+
+func (s *FilesystemStateSuite) setupSharedStorage(c *gc.C) (*state.Service, names.StorageTag, names.FilesystemTag) {
+	ch := s.AddTestingCharm(c, "storage-filesystem-shared")
+	service := s.AddTestingServiceWithStorage(c, "storage-filesystem-shared", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("shared", 1024, 1),
+	})
+	storageTag := names.NewStorageTag("data/0")
+	filesystem, err := s.State.StorageInstanceFilesystem(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return service, storageTag, filesystem.FilesystemTag()
+}
+
+func (s *FilesystemStateSuite) TestAddServiceSharedStorage(c *gc.C) {
+	service, storageTag, filesystemTag := s.setupSharedStorage(c)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, service.Tag())
+	c.Assert(si.Kind(), gc.Equals, state.StorageKindFilesystem)
+
+	// Shared filesystems are provisioned as soon as they are added.
+	s.assertFilesystemInfo(c, filesystemTag, state.FilesystemInfo{
+		Size:         1024,
+		Pool:         "shared",
+		FilesystemId: "shared",
+	})
+}
+
+func (s *FilesystemStateSuite) TestAddServiceSharedStorageUnsupportedPool(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-filesystem-shared")
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop-pool", 1024, 1),
+	}
+	_, err := s.State.AddService("storage-filesystem-shared", s.Owner.String(), ch, nil, storage)
+	c.Assert(err, gc.ErrorMatches, `.*charm "storage-filesystem-shared" store "data": shared filesystems with "loop" provider not supported`)
+}
+
+func (s *FilesystemStateSuite) TestSharedFilesystemAttachments(c *gc.C) {
+	service, storageTag, filesystemTag := s.setupSharedStorage(c)
+	u0, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	u1, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, u := range []*state.Unit{u0, u1} {
+		attachments, err := s.State.UnitStorageAttachments(u.UnitTag())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(attachments, gc.HasLen, 1)
+		c.Assert(attachments[0].StorageInstance(), gc.Equals, storageTag)
+		err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// The shared filesystem is attached to each unit's machine.
+	var machineTags []names.MachineTag
+	for _, u := range []*state.Unit{u0, u1} {
+		machineId, err := u.AssignedMachineId()
+		c.Assert(err, jc.ErrorIsNil)
+		machineTag := names.NewMachineTag(machineId)
+		s.assertFilesystemAttachmentUnprovisioned(c, machineTag, filesystemTag)
+		attachment, err := s.State.FilesystemAttachment(machineTag, filesystemTag)
+		c.Assert(err, jc.ErrorIsNil)
+		params, _ := attachment.Params()
+		c.Assert(params.Location, gc.Equals, "/srv/data")
+		machineTags = append(machineTags, machineTag)
+	}
+	c.Assert(machineTags[0], gc.Not(gc.Equals), machineTags[1])
+
+	// Removing a unit's storage attachment detaches the filesystem
+	// from the unit's machine only.
+	err = s.State.DestroyStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	attachment, err := s.State.FilesystemAttachment(machineTags[0], filesystemTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+	attachment, err = s.State.FilesystemAttachment(machineTags[1], filesystemTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+
+	err = s.State.RemoveFilesystemAttachment(machineTags[0], filesystemTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.FilesystemAttachment(machineTags[0], filesystemTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// The shared storage outlives the unit's attachment to it.
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+}
+
+func (s *FilesystemStateSuite) TestSharedFilesystemAttachmentsSameMachine(c *gc.C) {
+	service, storageTag, filesystemTag := s.setupSharedStorage(c)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	u0, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	u1, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u0.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u1.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	attachments, err := s.State.MachineFilesystemAttachments(machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+
+	// The filesystem remains attached to the machine
+	// while another unit on it is attached to the storage.
+	err = s.State.DestroyStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	attachment, err := s.State.FilesystemAttachment(machine.MachineTag(), filesystemTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+
+	err = s.State.DestroyStorageAttachment(storageTag, u1.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u1.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	attachment, err = s.State.FilesystemAttachment(machine.MachineTag(), filesystemTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+}
+
+func (s *FilesystemStateSuite) TestSharedFilesystemAttachmentsSameMachineConcurrentAttach(c *gc.C) {
+	service, storageTag, filesystemTag := s.setupSharedStorage(c)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	u0, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u0.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DestroyStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		u1, err := service.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		err = u1.AssignToMachine(machine)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	// The filesystem remains attached to the machine for
+	// the unit that was assigned to it concurrently.
+	err = s.State.RemoveStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	attachment, err := s.State.FilesystemAttachment(machine.MachineTag(), filesystemTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+}
+
+func (s *FilesystemStateSuite) TestSharedFilesystemAttachmentsSameMachineConcurrentDetach(c *gc.C) {
+	service, storageTag, filesystemTag := s.setupSharedStorage(c)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	u0, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	u1, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u0.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u1.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DestroyStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DestroyStorageAttachment(storageTag, u1.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.RemoveStorageAttachment(storageTag, u1.UnitTag())
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	// The filesystem is detached from the machine once neither
+	// unit is attached to the storage.
+	err = s.State.RemoveStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	attachment, err := s.State.FilesystemAttachment(machine.MachineTag(), filesystemTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+}
+
+func (s *FilesystemStateSuite) TestRemoveAliveFilesystemAttachmentError(c *gc.C) {
+	filesystemAttachment := s.addUnitWithFilesystem(c, "rootfs", false)
+	err := s.State.RemoveFilesystemAttachment(filesystemAttachment.Machine(), filesystemAttachment.Filesystem())
+	c.Assert(err, gc.ErrorMatches, `cannot remove attachment of filesystem .* from machine 0: filesystem attachment is not dying`)
+}
+
+func (s *FilesystemStateSuite) TestDestroyServiceDestroysSharedStorage(c *gc.C) {
+	service, storageTag, _ := s.setupSharedStorage(c)
+	err := service.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
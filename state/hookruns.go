@@ -0,0 +1,233 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// HookRunKind describes what a unit ran.
+type HookRunKind string
+
+const (
+	HookRunHook     HookRunKind = "hook"
+	HookRunAction   HookRunKind = "action"
+	HookRunCommands HookRunKind = "commands"
+)
+
+// HookRunStatus describes how a run ended.
+type HookRunStatus string
+
+const (
+	HookRunCompleted HookRunStatus = "completed"
+	HookRunFailed    HookRunStatus = "failed"
+)
+
+// HookRun records a single run of a hook, action or juju-run commands
+// by a unit.
+type HookRun struct {
+	Unit     string
+	Kind     HookRunKind
+	Name     string
+	Started  time.Time
+	Finished time.Time
+	Status   HookRunStatus
+}
+
+// Duration returns how long the run took.
+func (r HookRun) Duration() time.Duration {
+	return r.Finished.Sub(r.Started)
+}
+
+// validate returns an error if the run is not valid.
+func (r HookRun) validate() error {
+	switch r.Kind {
+	case HookRunHook, HookRunAction, HookRunCommands:
+	default:
+		return errors.NotValidf("hook run kind %q", r.Kind)
+	}
+	switch r.Status {
+	case HookRunCompleted, HookRunFailed:
+	default:
+		return errors.NotValidf("hook run status %q", r.Status)
+	}
+	if r.Name == "" {
+		return errors.NotValidf("hook run without a name")
+	}
+	if r.Finished.Before(r.Started) {
+		return errors.NotValidf("hook run finishing before it started")
+	}
+	return nil
+}
+
+// hookRunDoc records a hook run in the hook runs collection, which is
+// capped, so the oldest runs are discarded as new ones are added.
+type hookRunDoc struct {
+	Id       bson.ObjectId `bson:"_id"`
+	EnvUUID  string        `bson:"env-uuid"`
+	Service  string        `bson:"service"`
+	Unit     string        `bson:"unit"`
+	Kind     string        `bson:"kind"`
+	Name     string        `bson:"name"`
+	Started  time.Time     `bson:"started"`
+	Finished time.Time     `bson:"finished"`
+	Status   string        `bson:"status"`
+}
+
+func (doc *hookRunDoc) run() HookRun {
+	return HookRun{
+		Unit:     doc.Unit,
+		Kind:     HookRunKind(doc.Kind),
+		Name:     doc.Name,
+		Started:  doc.Started.UTC(),
+		Finished: doc.Finished.UTC(),
+		Status:   HookRunStatus(doc.Status),
+	}
+}
+
+// AddHookRuns records runs of hooks, actions and commands by the unit.
+func (u *Unit) AddHookRuns(runs []HookRun) error {
+	docs := make([]interface{}, len(runs))
+	for i, run := range runs {
+		if err := run.validate(); err != nil {
+			return errors.Annotatef(err, "cannot add hook runs for unit %q", u)
+		}
+		docs[i] = &hookRunDoc{
+			Id:       bson.NewObjectId(),
+			EnvUUID:  u.st.EnvironUUID(),
+			Service:  u.ServiceName(),
+			Unit:     u.Name(),
+			Kind:     string(run.Kind),
+			Name:     run.Name,
+			Started:  run.Started.UTC(),
+			Finished: run.Finished.UTC(),
+			Status:   string(run.Status),
+		}
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	coll, closer := u.st.getRawCollection(hookRunsC)
+	defer closer()
+	// Runs are written directly rather than in a transaction, as
+	// documents in a capped collection cannot grow to hold the
+	// transaction fields.
+	if err := coll.Insert(docs...); err != nil {
+		return errors.Annotatef(err, "cannot add hook runs for unit %q", u)
+	}
+	return nil
+}
+
+// HookRuns returns the recorded runs of hooks, actions and commands by
+// the service's units, oldest first.
+func (s *Service) HookRuns() ([]HookRun, error) {
+	coll, closer := s.st.getRawCollection(hookRunsC)
+	defer closer()
+
+	var docs []hookRunDoc
+	sel := bson.D{{"env-uuid", s.st.EnvironUUID()}, {"service", s.doc.Name}}
+	err := coll.Find(sel).Sort("_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook runs for service %q", s)
+	}
+	runs := make([]HookRun, len(docs))
+	for i, doc := range docs {
+		runs[i] = doc.run()
+	}
+	return runs, nil
+}
+
+// HookStats summarizes the runs of a single hook, action or commands
+// by the units of a service.
+type HookStats struct {
+	Kind   HookRunKind
+	Name   string
+	Runs   int
+	Failed int
+
+	// Min, Max and the percentiles are of the durations of the runs.
+	Min time.Duration
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// HookStats returns statistics on the recorded runs of hooks, actions
+// and commands by the service's units, sorted by kind and name.
+func (s *Service) HookStats() ([]HookStats, error) {
+	runs, err := s.HookRuns()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return hookRunStats(runs), nil
+}
+
+type hookRunKey struct {
+	kind HookRunKind
+	name string
+}
+
+// hookRunStats summarizes the given runs for each hook, action and
+// commands run.
+func hookRunStats(runs []HookRun) []HookStats {
+	durations := make(map[hookRunKey][]time.Duration)
+	failed := make(map[hookRunKey]int)
+	for _, run := range runs {
+		key := hookRunKey{run.Kind, run.Name}
+		durations[key] = append(durations[key], run.Duration())
+		if run.Status == HookRunFailed {
+			failed[key]++
+		}
+	}
+	stats := make([]HookStats, 0, len(durations))
+	for key, ds := range durations {
+		sort.Sort(durationSlice(ds))
+		stats = append(stats, HookStats{
+			Kind:   key.kind,
+			Name:   key.name,
+			Runs:   len(ds),
+			Failed: failed[key],
+			Min:    ds[0],
+			P50:    percentile(ds, 50),
+			P90:    percentile(ds, 90),
+			P99:    percentile(ds, 99),
+			Max:    ds[len(ds)-1],
+		})
+	}
+	sort.Sort(hookStatsSlice(stats))
+	return stats
+}
+
+// percentile returns the p'th percentile of the sorted durations, using
+// the nearest-rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+type durationSlice []time.Duration
+
+func (s durationSlice) Len() int           { return len(s) }
+func (s durationSlice) Less(i, j int) bool { return s[i] < s[j] }
+func (s durationSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+type hookStatsSlice []HookStats
+
+func (s hookStatsSlice) Len() int { return len(s) }
+func (s hookStatsSlice) Less(i, j int) bool {
+	if s[i].Kind != s[j].Kind {
+		return s[i].Kind < s[j].Kind
+	}
+	return s[i].Name < s[j].Name
+}
+func (s hookStatsSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
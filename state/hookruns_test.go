@@ -0,0 +1,165 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type HookRunsSuite struct {
+	ConnSuite
+	service *state.Service
+	unit    *state.Unit
+	unit2   *state.Unit
+}
+
+var _ = gc.Suite(&HookRunsSuite{})
+
+func (s *HookRunsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.service = s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	var err error
+	s.unit, err = s.service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit2, err = s.service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+var hookRunsStart = time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC)
+
+func hookRun(kind state.HookRunKind, name string, seconds int, status state.HookRunStatus) state.HookRun {
+	return state.HookRun{
+		Kind:     kind,
+		Name:     name,
+		Started:  hookRunsStart,
+		Finished: hookRunsStart.Add(time.Duration(seconds) * time.Second),
+		Status:   status,
+	}
+}
+
+func (s *HookRunsSuite) TestAddHookRuns(c *gc.C) {
+	err := s.unit.AddHookRuns([]state.HookRun{
+		hookRun(state.HookRunHook, "install", 30, state.HookRunCompleted),
+		hookRun(state.HookRunAction, "snapshot", 5, state.HookRunFailed),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit2.AddHookRuns([]state.HookRun{
+		hookRun(state.HookRunCommands, "juju-run", 1, state.HookRunCompleted),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	runs, err := s.service.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, gc.HasLen, 3)
+	expect := hookRun(state.HookRunHook, "install", 30, state.HookRunCompleted)
+	expect.Unit = "dummy/0"
+	c.Assert(runs[0], jc.DeepEquals, expect)
+	c.Assert(runs[0].Duration(), gc.Equals, 30*time.Second)
+	c.Assert(runs[1].Unit, gc.Equals, "dummy/0")
+	c.Assert(runs[1].Status, gc.Equals, state.HookRunFailed)
+	c.Assert(runs[2].Unit, gc.Equals, "dummy/1")
+	c.Assert(runs[2].Kind, gc.Equals, state.HookRunCommands)
+
+	other := s.AddTestingService(c, "other", s.AddTestingCharm(c, "dummy"))
+	runs, err = other.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, gc.HasLen, 0)
+}
+
+func (s *HookRunsSuite) TestHookRunsOtherEnvironment(c *gc.C) {
+	err := s.unit.AddHookRuns([]state.HookRun{
+		hookRun(state.HookRunHook, "install", 30, state.HookRunCompleted),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.factory.MakeEnvironment(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st)
+	service := f.MakeService(c, &factory.ServiceParams{Name: "dummy"})
+	runs, err := service.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, gc.HasLen, 0)
+
+	unit := f.MakeUnit(c, &factory.UnitParams{Service: service})
+	err = unit.AddHookRuns([]state.HookRun{
+		hookRun(state.HookRunHook, "install", 30, state.HookRunCompleted),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Recorded hook runs do not prevent the environment's removal.
+	err = st.RemoveAllEnvironDocs()
+	c.Assert(err, jc.ErrorIsNil)
+	runs, err = s.service.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, gc.HasLen, 1)
+}
+
+func (s *HookRunsSuite) TestAddHookRunsInvalid(c *gc.C) {
+	for i, test := range []struct {
+		run state.HookRun
+		err string
+	}{{
+		run: hookRun("party", "install", 1, state.HookRunCompleted),
+		err: `cannot add hook runs for unit "dummy/0": hook run kind "party" not valid`,
+	}, {
+		run: hookRun(state.HookRunHook, "install", 1, "meh"),
+		err: `cannot add hook runs for unit "dummy/0": hook run status "meh" not valid`,
+	}, {
+		run: hookRun(state.HookRunHook, "", 1, state.HookRunCompleted),
+		err: `cannot add hook runs for unit "dummy/0": hook run without a name not valid`,
+	}, {
+		run: hookRun(state.HookRunHook, "install", -1, state.HookRunCompleted),
+		err: `cannot add hook runs for unit "dummy/0": hook run finishing before it started not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.unit.AddHookRuns([]state.HookRun{test.run})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	runs, err := s.service.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, gc.HasLen, 0)
+}
+
+func (s *HookRunsSuite) TestHookStats(c *gc.C) {
+	var runs []state.HookRun
+	for i := 1; i <= 10; i++ {
+		status := state.HookRunCompleted
+		if i == 10 {
+			status = state.HookRunFailed
+		}
+		runs = append(runs, hookRun(state.HookRunHook, "config-changed", i, status))
+	}
+	runs = append(runs, hookRun(state.HookRunAction, "snapshot", 42, state.HookRunCompleted))
+	err := s.unit.AddHookRuns(runs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stats, err := s.service.HookStats()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stats, jc.DeepEquals, []state.HookStats{{
+		Kind: state.HookRunAction,
+		Name: "snapshot",
+		Runs: 1,
+		Min:  42 * time.Second,
+		P50:  42 * time.Second,
+		P90:  42 * time.Second,
+		P99:  42 * time.Second,
+		Max:  42 * time.Second,
+	}, {
+		Kind:   state.HookRunHook,
+		Name:   "config-changed",
+		Runs:   10,
+		Failed: 1,
+		Min:    1 * time.Second,
+		P50:    5 * time.Second,
+		P90:    9 * time.Second,
+		P99:    10 * time.Second,
+		Max:    10 * time.Second,
+	}})
+}
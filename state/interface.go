@@ -4,6 +4,8 @@
 package state
 
 import (
+	"time"
+
 	"github.com/juju/names"
 
 	"github.com/juju/juju/environs/config"
@@ -179,8 +181,12 @@ type ActionReceiver interface {
 	// ActionReceiver.
 	AddAction(name string, payload map[string]interface{}) (*Action, error)
 
-	// CancelAction removes a pending Action from the queue for this
-	// ActionReceiver and marks it as cancelled.
+	// AddActionWithTimeout queues an action like AddAction, which is
+	// killed if it runs for longer than timeout.
+	AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (*Action, error)
+
+	// CancelAction removes a pending or running Action from the queue
+	// for this ActionReceiver and marks it as cancelled.
 	CancelAction(action *Action) (*Action, error)
 
 	// WatchActionNotifications returns a StringsWatcher that will notify
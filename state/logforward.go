@@ -0,0 +1,95 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// logForwardBookmarksC holds, for each log forwarding target of each
+// environment, how far forwarding to it has got. It lives in the logs
+// database, alongside the records themselves.
+const logForwardBookmarksC = "logforwardbookmarks"
+
+// logForwardBookmarkDoc records how far log forwarding to a target
+// has got.
+type logForwardBookmarkDoc struct {
+	DocID   string          `bson:"_id"`
+	EnvUUID string          `bson:"env-uuid"`
+	Target  string          `bson:"target"`
+	Record  bson.ObjectId   `bson:"record"`
+	Recent  []bson.ObjectId `bson:"recent,omitempty"`
+}
+
+func logForwardBookmarkID(envUUID, target string) string {
+	return envUUID + ":" + target
+}
+
+// LogForwardBookmark records how far log forwarding to a target has
+// got. Log records may be stored out of order by different API
+// servers, so the ids of the records forwarded shortly before the
+// last one are remembered too; a LogTailer started from the bookmark
+// returns the records stored late without returning those already
+// forwarded.
+type LogForwardBookmark struct {
+	// Last is the id of the most recently stored record forwarded.
+	Last bson.ObjectId
+
+	// Recent holds the ids of the records forwarded that were
+	// stored shortly before Last.
+	Recent []bson.ObjectId
+}
+
+// Add records that the log record with the given id has been
+// forwarded.
+func (b *LogForwardBookmark) Add(id bson.ObjectId) {
+	if id > b.Last {
+		b.Last = id
+	}
+	b.Recent = append(b.Recent, id)
+	// Records are mostly forwarded in the order they were stored,
+	// so the oldest ids are found at the start.
+	cutoff := b.Last.Time().Add(-logTailerWindow)
+	for len(b.Recent) > 0 && b.Recent[0].Time().Before(cutoff) {
+		b.Recent = b.Recent[1:]
+	}
+}
+
+// LogForwardBookmark returns how far log forwarding to the given
+// target has got. Last is the empty id if no records have been
+// forwarded to it.
+func (st *State) LogForwardBookmark(target string) (LogForwardBookmark, error) {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+	bookmarks := session.DB(logsDB).C(logForwardBookmarksC)
+
+	var doc logForwardBookmarkDoc
+	err := bookmarks.FindId(logForwardBookmarkID(st.EnvironUUID(), target)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return LogForwardBookmark{}, nil
+	} else if err != nil {
+		return LogForwardBookmark{}, errors.Annotatef(err, "cannot read log forwarding bookmark for %q", target)
+	}
+	return LogForwardBookmark{Last: doc.Record, Recent: doc.Recent}, nil
+}
+
+// SetLogForwardBookmark records how far log forwarding to the given
+// target has got.
+func (st *State) SetLogForwardBookmark(target string, bookmark LogForwardBookmark) error {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+	bookmarks := session.DB(logsDB).C(logForwardBookmarksC)
+
+	envUUID := st.EnvironUUID()
+	_, err := bookmarks.UpsertId(logForwardBookmarkID(envUUID, target), &logForwardBookmarkDoc{
+		DocID:   logForwardBookmarkID(envUUID, target),
+		EnvUUID: envUUID,
+		Target:  target,
+		Record:  bookmark.Last,
+		Recent:  bookmark.Recent,
+	})
+	return errors.Annotatef(err, "cannot save log forwarding bookmark for %q", target)
+}
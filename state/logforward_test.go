@@ -0,0 +1,70 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+)
+
+type LogForwardSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&LogForwardSuite{})
+
+func (s *LogForwardSuite) TestBookmarks(c *gc.C) {
+	bookmark, err := s.State.LogForwardBookmark("syslog://10.0.0.1:514")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bookmark, jc.DeepEquals, state.LogForwardBookmark{})
+
+	first := state.LogForwardBookmark{Last: bson.NewObjectId()}
+	err = s.State.SetLogForwardBookmark("syslog://10.0.0.1:514", first)
+	c.Assert(err, jc.ErrorIsNil)
+	second := state.LogForwardBookmark{
+		Last:   bson.NewObjectId(),
+		Recent: []bson.ObjectId{bson.NewObjectId()},
+	}
+	err = s.State.SetLogForwardBookmark("syslog://10.0.0.1:514", second)
+	c.Assert(err, jc.ErrorIsNil)
+
+	bookmark, err = s.State.LogForwardBookmark("syslog://10.0.0.1:514")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bookmark, jc.DeepEquals, second)
+	bookmark, err = s.State.LogForwardBookmark("json://10.0.0.1:514")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bookmark, jc.DeepEquals, state.LogForwardBookmark{})
+}
+
+func (s *LogForwardSuite) TestBookmarkAdd(c *gc.C) {
+	now := time.Now()
+	old := bson.NewObjectIdWithTime(now.Add(-time.Minute))
+	recent := bson.NewObjectIdWithTime(now.Add(-time.Second))
+	last := bson.NewObjectIdWithTime(now)
+
+	var bookmark state.LogForwardBookmark
+	bookmark.Add(old)
+	bookmark.Add(last)
+	// Records forwarded out of order do not move the bookmark back.
+	bookmark.Add(recent)
+	c.Assert(bookmark.Last, gc.Equals, last)
+	// Only records stored shortly before the last are remembered.
+	c.Assert(bookmark.Recent, jc.DeepEquals, []bson.ObjectId{last, recent})
+}
+
+func (s *LogForwardSuite) TestBookmarksPerEnvironment(c *gc.C) {
+	st := s.factory.MakeEnvironment(c, nil)
+	defer st.Close()
+	err := st.SetLogForwardBookmark("json://10.0.0.1:514", state.LogForwardBookmark{Last: bson.NewObjectId()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	bookmark, err := s.State.LogForwardBookmark("json://10.0.0.1:514")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bookmark.Last, gc.Equals, bson.ObjectId(""))
+}
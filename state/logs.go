@@ -6,6 +6,8 @@
 package state
 
 import (
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -14,6 +16,7 @@ import (
 	"github.com/juju/names"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
+	"launchpad.net/tomb"
 )
 
 const logsDB = "logs"
@@ -86,6 +89,363 @@ func (logger *DbLogger) Close() {
 	}
 }
 
+// LogRecord defines a single Juju log message as returned by
+// LogTailer.
+type LogRecord struct {
+	// Id uniquely identifies the record. Ids increase with the
+	// time at which records were stored.
+	Id bson.ObjectId
+
+	Time     time.Time
+	Entity   string
+	Module   string
+	Location string
+	Level    loggo.Level
+	Message  string
+}
+
+// LogTailerParams specifies the filtering a LogTailer should apply to
+// log records in order to decide which to return.
+type LogTailerParams struct {
+	// StartTime, if set, excludes records logged before this time.
+	StartTime time.Time
+
+	// EndTime, if set, excludes records logged after this time. A
+	// LogTailer with an EndTime does not wait for new records.
+	EndTime time.Time
+
+	// MinLevel excludes records below this level.
+	MinLevel loggo.Level
+
+	// InitialLines, if non-zero, limits the existing records
+	// returned to the most recent InitialLines matching records.
+	InitialLines int
+
+	// Replay returns all existing matching records, regardless of
+	// InitialLines.
+	Replay bool
+
+	// NoTail stops the LogTailer once the existing records
+	// have been returned.
+	NoTail bool
+
+	// StartID, if set, returns the existing records stored after
+	// the record with this id, in the order they were stored, so
+	// that a reader may resume where it left off. Records may be
+	// stored out of order, so records stored shortly before it are
+	// also returned, unless they are listed in SeenIDs.
+	StartID bson.ObjectId
+
+	// SeenIDs holds the ids of the records stored shortly before
+	// StartID that the reader has already read.
+	SeenIDs []bson.ObjectId
+
+	// IncludeEntity and ExcludeEntity hold entity tags, or entity
+	// names such as "mysql/0", which may end with a '*' to match
+	// a prefix.
+	IncludeEntity []string
+	ExcludeEntity []string
+
+	// IncludeModule and ExcludeModule hold logging modules. A
+	// module also matches all of its submodules.
+	IncludeModule []string
+	ExcludeModule []string
+}
+
+// LogTailer allows for retrieval of Juju's logs from MongoDB. It
+// first returns any matching existing log records and then waits for
+// further matching records to be written.
+type LogTailer interface {
+	// Logs returns the channel through which the LogTailer
+	// returns log records. It is closed when the LogTailer stops.
+	Logs() <-chan *LogRecord
+
+	// Dying returns a channel which is closed as the LogTailer
+	// stops.
+	Dying() <-chan struct{}
+
+	// Stop is used to request that the LogTailer stops. It blocks
+	// until the LogTailer has stopped.
+	Stop() error
+
+	// Err returns the error that caused the LogTailer to stop. If
+	// it hasn't stopped or stopped without error nil will be
+	// returned.
+	Err() error
+}
+
+// logTailerPollInterval is how often the logs collection is checked
+// for new records once the existing records have been returned.
+var logTailerPollInterval = time.Second
+
+// logTailerWindow is how far back each check for new records
+// looks. Records may be written out of order by different API
+// servers, so records seen within the window are remembered in order
+// to report each record exactly once.
+const logTailerWindow = 5 * time.Second
+
+// NewLogTailer returns a LogTailer which returns the log records
+// for the environment that match the given parameters.
+func NewLogTailer(st *State, params *LogTailerParams) LogTailer {
+	session := st.MongoSession().Copy()
+	t := &logTailer{
+		envUUID:  st.EnvironUUID(),
+		session:  session,
+		logsColl: session.DB(logsDB).C(logsC).With(session),
+		params:   params,
+		logCh:    make(chan *LogRecord),
+		seen:     make(map[bson.ObjectId]bool),
+	}
+	go func() {
+		err := t.loop()
+		t.tomb.Kill(errors.Cause(err))
+		close(t.logCh)
+		session.Close()
+		t.tomb.Done()
+	}()
+	return t
+}
+
+type logTailer struct {
+	tomb     tomb.Tomb
+	envUUID  string
+	session  *mgo.Session
+	logsColl *mgo.Collection
+	params   *LogTailerParams
+	logCh    chan *LogRecord
+
+	// seen holds the ids of the records already returned that are
+	// recent enough to be found again by the next check.
+	seen map[bson.ObjectId]bool
+	// lastTime is the time of the most recent record id seen.
+	lastTime time.Time
+}
+
+// Logs implements the LogTailer interface.
+func (t *logTailer) Logs() <-chan *LogRecord {
+	return t.logCh
+}
+
+// Dying implements the LogTailer interface.
+func (t *logTailer) Dying() <-chan struct{} {
+	return t.tomb.Dying()
+}
+
+// Stop implements the LogTailer interface.
+func (t *logTailer) Stop() error {
+	t.tomb.Kill(nil)
+	return t.tomb.Wait()
+}
+
+// Err implements the LogTailer interface.
+func (t *logTailer) Err() error {
+	return t.tomb.Err()
+}
+
+func (t *logTailer) loop() error {
+	// Note the time before looking at the existing records, so
+	// that records written meanwhile are found when tailing.
+	t.lastTime = time.Now()
+	if err := t.processCollection(); err != nil {
+		return errors.Trace(err)
+	}
+	if t.params.NoTail || !t.params.EndTime.IsZero() {
+		return nil
+	}
+	return t.tailCollection()
+}
+
+// processCollection returns the existing records which match the
+// parameters.
+func (t *logTailer) processCollection() error {
+	if t.params.StartID != "" {
+		t.seen[t.params.StartID] = true
+		for _, id := range t.params.SeenIDs {
+			t.seen[id] = true
+		}
+		selector := append(t.selector(), bson.DocElem{
+			"_id", bson.M{"$gte": t.startWindowID()},
+		})
+		return t.sendAll(t.logsColl.Find(selector).Sort("_id"))
+	}
+	if !t.params.Replay && t.params.InitialLines <= 0 && t.params.StartTime.IsZero() {
+		return nil
+	}
+	query := t.logsColl.Find(t.selector())
+	if !t.params.Replay && t.params.InitialLines > 0 {
+		// Fetch the most recent records, then return them oldest
+		// first.
+		var docs []logDoc
+		err := query.Sort("-t", "-_id").Limit(t.params.InitialLines).All(&docs)
+		if err != nil {
+			return errors.Annotate(err, "cannot read log records")
+		}
+		for i := len(docs) - 1; i >= 0; i-- {
+			if err := t.send(&docs[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return t.sendAll(query.Sort("t", "_id"))
+}
+
+// startWindowID returns the lowest id of the records that may have
+// been stored out of order with the record with id StartID.
+func (t *logTailer) startWindowID() bson.ObjectId {
+	return bson.NewObjectIdWithTime(t.params.StartID.Time().Add(-logTailerWindow))
+}
+
+// sendAll returns all the records found by the given query that
+// have not already been seen.
+func (t *logTailer) sendAll(query *mgo.Query) error {
+	iter := query.Iter()
+	var doc logDoc
+	for iter.Next(&doc) {
+		if t.seen[doc.Id] {
+			continue
+		}
+		if err := t.send(&doc); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	return errors.Annotate(iter.Close(), "cannot read log records")
+}
+
+// tailCollection polls for records written since the last check
+// until the LogTailer is stopped.
+func (t *logTailer) tailCollection() error {
+	for {
+		select {
+		case <-t.tomb.Dying():
+			return tomb.ErrDying
+		case <-time.After(logTailerPollInterval):
+		}
+		since := t.lastTime.Add(-logTailerWindow)
+		sinceID := bson.NewObjectIdWithTime(since)
+		if t.params.StartID != "" {
+			// Never return records from before the starting point.
+			if startID := t.startWindowID(); startID > sinceID {
+				sinceID = startID
+			}
+		}
+		selector := append(t.selector(), bson.DocElem{"_id", bson.M{"$gte": sinceID}})
+		iter := t.logsColl.Find(selector).Sort("_id").Iter()
+		var doc logDoc
+		for iter.Next(&doc) {
+			if t.seen[doc.Id] {
+				continue
+			}
+			if err := t.send(&doc); err != nil {
+				iter.Close()
+				return err
+			}
+		}
+		if err := iter.Close(); err != nil {
+			return errors.Annotate(err, "cannot read log records")
+		}
+		// Forget records too old to be found again.
+		for id := range t.seen {
+			if id.Time().Before(since) {
+				delete(t.seen, id)
+			}
+		}
+	}
+}
+
+// send returns the record for the given document through the logs
+// channel, and remembers that it has been returned.
+func (t *logTailer) send(doc *logDoc) error {
+	rec := &LogRecord{
+		Id:       doc.Id,
+		Time:     doc.Time,
+		Entity:   doc.Entity,
+		Module:   doc.Module,
+		Location: doc.Location,
+		Level:    doc.Level,
+		Message:  doc.Message,
+	}
+	select {
+	case <-t.tomb.Dying():
+		return tomb.ErrDying
+	case t.logCh <- rec:
+	}
+	t.seen[doc.Id] = true
+	if idTime := doc.Id.Time(); idTime.After(t.lastTime) {
+		t.lastTime = idTime
+	}
+	return nil
+}
+
+// selector returns the query selector for records matching the
+// LogTailer's parameters.
+func (t *logTailer) selector() bson.D {
+	sel := bson.D{{"e", t.envUUID}}
+	timeSel := bson.M{}
+	if !t.params.StartTime.IsZero() {
+		timeSel["$gte"] = t.params.StartTime
+	}
+	if !t.params.EndTime.IsZero() {
+		timeSel["$lte"] = t.params.EndTime
+	}
+	if len(timeSel) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeSel})
+	}
+	if t.params.MinLevel > loggo.UNSPECIFIED {
+		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": t.params.MinLevel}})
+	}
+	if entitySel := inclusionSelector(
+		t.params.IncludeEntity, t.params.ExcludeEntity, entityFilterPattern,
+	); len(entitySel) > 0 {
+		sel = append(sel, bson.DocElem{"n", entitySel})
+	}
+	if moduleSel := inclusionSelector(
+		t.params.IncludeModule, t.params.ExcludeModule, moduleFilterPattern,
+	); len(moduleSel) > 0 {
+		sel = append(sel, bson.DocElem{"m", moduleSel})
+	}
+	return sel
+}
+
+// inclusionSelector returns a selector which matches values that
+// match any of the include filters, if there are any, and none of
+// the exclude filters.
+func inclusionSelector(include, exclude []string, pattern func(string) string) bson.M {
+	toRegexes := func(filters []string) []bson.RegEx {
+		regexes := make([]bson.RegEx, len(filters))
+		for i, filter := range filters {
+			regexes[i] = bson.RegEx{Pattern: pattern(filter)}
+		}
+		return regexes
+	}
+	sel := bson.M{}
+	if len(include) > 0 {
+		sel["$in"] = toRegexes(include)
+	}
+	if len(exclude) > 0 {
+		sel["$nin"] = toRegexes(exclude)
+	}
+	return sel
+}
+
+// entityFilterPattern returns a regular expression matching the tags
+// of the entities selected by the given filter. The filter may be a
+// tag or an entity name, and may end with a '*' to match a prefix.
+func entityFilterPattern(filter string) string {
+	quote := func(s string) string {
+		return strings.Replace(regexp.QuoteMeta(s), `\*`, ".*", -1)
+	}
+	asTag := quote(strings.Replace(filter, "/", "-", -1))
+	return "^(" + quote(filter) + "|(unit|machine)-" + asTag + ")$"
+}
+
+// moduleFilterPattern returns a regular expression matching the
+// given logging module and its submodules.
+func moduleFilterPattern(module string) string {
+	return "^" + regexp.QuoteMeta(module) + `(\.|$)`
+}
+
 // PruneLogs removes old log documents in order to control the size of
 // logs collection. All logs older than minLogTime are
 // removed. Further removal is also performed if the logs collection
@@ -197,6 +557,32 @@ func getCollectionMB(coll *mgo.Collection) (int, error) {
 	return result["size"].(int), nil
 }
 
+// getEnvCollectionMB returns an estimate of the size (in megabytes)
+// of the documents belonging to an environment in a multi-environment
+// collection: the collection's average document size multiplied by
+// the number of documents in the environment.
+func getEnvCollectionMB(coll *mgo.Collection, envUUID string) (int, error) {
+	var result bson.M
+	err := coll.Database.Run(bson.D{{"collStats", coll.Name}}, &result)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var avgObjSize float64
+	switch v := result["avgObjSize"].(type) {
+	case int:
+		avgObjSize = float64(v)
+	case int64:
+		avgObjSize = float64(v)
+	case float64:
+		avgObjSize = v
+	}
+	count, err := coll.Find(bson.D{{"env-uuid", envUUID}}).Count()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return int(avgObjSize * float64(count) / humanize.MiByte), nil
+}
+
 // getEnvsInLogs returns the unique envrionment UUIDs that exist in
 // the logs collection. This uses the one of the indexes on the
 // collection and should be fast.
@@ -4,6 +4,7 @@
 package state_test
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type LogsSuite struct {
@@ -167,3 +169,207 @@ func (s *LogsSuite) countLogs(c *gc.C, st *state.State) int {
 	c.Assert(err, jc.ErrorIsNil)
 	return count
 }
+
+type LogTailerSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&LogTailerSuite{})
+
+func (s *LogTailerSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.PatchValue(state.LogTailerPollInterval, 10*time.Millisecond)
+}
+
+func (s *LogTailerSuite) log(c *gc.C, t time.Time, entity, module string, level loggo.Level, msg string) {
+	tag, err := names.ParseTag(entity)
+	c.Assert(err, jc.ErrorIsNil)
+	logger := state.NewDbLogger(s.State, tag)
+	defer logger.Close()
+	err = logger.Log(t, module, "loc.go:1", level, msg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LogTailerSuite) assertMessages(c *gc.C, tailer state.LogTailer, expected ...string) {
+	for _, msg := range expected {
+		select {
+		case rec, ok := <-tailer.Logs():
+			c.Assert(ok, jc.IsTrue)
+			c.Assert(rec.Message, gc.Equals, msg)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %q", msg)
+		}
+	}
+}
+
+func (s *LogTailerSuite) assertNoMore(c *gc.C, tailer state.LogTailer) {
+	select {
+	case rec, ok := <-tailer.Logs():
+		if ok {
+			c.Fatalf("unexpected log record %q", rec.Message)
+		}
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *LogTailerSuite) TestInitialLines(c *gc.C) {
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		s.log(c, now.Add(time.Duration(i)*time.Second), "machine-0", "juju", loggo.INFO, fmt.Sprint(i))
+	}
+	tailer := state.NewLogTailer(s.State, &state.LogTailerParams{
+		InitialLines: 2,
+		NoTail:       true,
+	})
+	defer tailer.Stop()
+	s.assertMessages(c, tailer, "3", "4")
+	s.assertNoMore(c, tailer)
+	c.Assert(tailer.Err(), jc.ErrorIsNil)
+}
+
+func (s *LogTailerSuite) TestTimeRange(c *gc.C) {
+	now := time.Now().Truncate(time.Millisecond)
+	for i := 0; i < 5; i++ {
+		s.log(c, now.Add(time.Duration(i)*time.Minute), "machine-0", "juju", loggo.INFO, fmt.Sprint(i))
+	}
+	tailer := state.NewLogTailer(s.State, &state.LogTailerParams{
+		StartTime: now.Add(time.Minute),
+		EndTime:   now.Add(3 * time.Minute),
+	})
+	defer tailer.Stop()
+	s.assertMessages(c, tailer, "1", "2", "3")
+	s.assertNoMore(c, tailer)
+}
+
+func (s *LogTailerSuite) TestFilters(c *gc.C) {
+	now := time.Now()
+	s.log(c, now, "machine-0", "juju.worker", loggo.INFO, "machine worker")
+	s.log(c, now, "unit-mysql-0", "juju.worker.uniter", loggo.INFO, "mysql uniter")
+	s.log(c, now, "unit-mysql-1", "juju.worker.uniter", loggo.DEBUG, "mysql debug")
+	s.log(c, now, "unit-mysql-1", "juju.workers", loggo.ERROR, "other module")
+	s.log(c, now, "unit-wordpress-0", "juju.worker.uniter", loggo.ERROR, "wordpress uniter")
+
+	tailer := state.NewLogTailer(s.State, &state.LogTailerParams{
+		StartTime:     now.Add(-time.Minute),
+		NoTail:        true,
+		MinLevel:      loggo.INFO,
+		IncludeEntity: []string{"mysql/*", "unit-wordpress-0"},
+		ExcludeEntity: []string{"wordpress/0"},
+		IncludeModule: []string{"juju.worker"},
+	})
+	defer tailer.Stop()
+	s.assertMessages(c, tailer, "mysql uniter")
+	s.assertNoMore(c, tailer)
+}
+
+func (s *LogTailerSuite) TestTailing(c *gc.C) {
+	now := time.Now()
+	s.log(c, now, "machine-0", "juju", loggo.INFO, "before")
+	tailer := state.NewLogTailer(s.State, &state.LogTailerParams{
+		InitialLines: 1,
+	})
+	defer tailer.Stop()
+	s.assertMessages(c, tailer, "before")
+	s.log(c, now, "machine-0", "juju", loggo.INFO, "after 1")
+	s.log(c, now, "machine-1", "juju", loggo.INFO, "after 2")
+	s.assertMessages(c, tailer, "after 1", "after 2")
+	s.assertNoMore(c, tailer)
+
+	c.Assert(tailer.Stop(), jc.ErrorIsNil)
+	_, ok := <-tailer.Logs()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *LogTailerSuite) TestStartID(c *gc.C) {
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		s.log(c, now, "machine-0", "juju", loggo.INFO, fmt.Sprint(i))
+	}
+	tailer := state.NewLogTailer(s.State, &state.LogTailerParams{
+		Replay: true,
+		NoTail: true,
+	})
+	defer tailer.Stop()
+	var ids []bson.ObjectId
+	for i := 0; i < 3; i++ {
+		select {
+		case rec := <-tailer.Logs():
+			c.Assert(rec.Message, gc.Equals, fmt.Sprint(i))
+			ids = append(ids, rec.Id)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for log record")
+		}
+	}
+
+	s.log(c, now.Add(-time.Hour), "machine-0", "juju", loggo.INFO, "3")
+	tailer = state.NewLogTailer(s.State, &state.LogTailerParams{
+		StartID: ids[2],
+		SeenIDs: ids[:2],
+	})
+	defer tailer.Stop()
+	// Records are returned in the order they were stored.
+	s.assertMessages(c, tailer, "3")
+	s.log(c, now, "machine-0", "juju", loggo.INFO, "4")
+	s.assertMessages(c, tailer, "4")
+	s.assertNoMore(c, tailer)
+}
+
+func (s *LogTailerSuite) TestStartIDRecordsStoredLate(c *gc.C) {
+	s.log(c, time.Now(), "machine-0", "juju", loggo.INFO, "seen")
+	tailer := state.NewLogTailer(s.State, &state.LogTailerParams{
+		InitialLines: 1,
+		NoTail:       true,
+	})
+	defer tailer.Stop()
+	var last *state.LogRecord
+	select {
+	case last = <-tailer.Logs():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for log record")
+	}
+
+	// Records stored out of order, shortly before the starting
+	// point, are returned; records stored long before it are not.
+	logs := s.State.MongoSession().DB("logs").C("logs")
+	for _, rec := range []struct {
+		id  bson.ObjectId
+		msg string
+	}{
+		{bson.NewObjectIdWithTime(last.Id.Time().Add(-time.Second)), "late"},
+		{bson.NewObjectIdWithTime(last.Id.Time().Add(-time.Hour)), "too late"},
+	} {
+		err := logs.Insert(bson.D{
+			{"_id", rec.id},
+			{"t", time.Now()},
+			{"e", s.State.EnvironUUID()},
+			{"n", "machine-0"},
+			{"m", "juju"},
+			{"l", "loc.go:1"},
+			{"v", loggo.INFO},
+			{"x", rec.msg},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	tailer = state.NewLogTailer(s.State, &state.LogTailerParams{
+		StartID: last.Id,
+	})
+	defer tailer.Stop()
+	s.assertMessages(c, tailer, "late")
+	s.assertNoMore(c, tailer)
+}
+
+func (s *LogTailerSuite) TestOtherEnvironmentIgnored(c *gc.C) {
+	st := s.factory.MakeEnvironment(c, nil)
+	defer st.Close()
+	logger := state.NewDbLogger(st, names.NewMachineTag("0"))
+	defer logger.Close()
+	err := logger.Log(time.Now(), "juju", "loc.go:1", loggo.INFO, "elsewhere")
+	c.Assert(err, jc.ErrorIsNil)
+
+	tailer := state.NewLogTailer(s.State, &state.LogTailerParams{
+		InitialLines: 10,
+		NoTail:       true,
+	})
+	defer tailer.Stop()
+	s.assertNoMore(c, tailer)
+}
@@ -665,6 +665,7 @@ func (m *Machine) Remove() (err error) {
 		removeRequestedNetworksOp(m.st, m.globalKey()),
 		annotationRemoveOp(m.st, m.globalKey()),
 		removeRebootDocOp(m.st, m.globalKey()),
+		removeWorkerReportsOp(m.st, m.globalKey()),
 		removeMachineBlockDevicesOp(m.Id()),
 	}
 	ifacesOps, err := m.removeNetworkInterfacesOps()
@@ -678,6 +679,7 @@ func (m *Machine) Remove() (err error) {
 	ops = append(ops, ifacesOps...)
 	ops = append(ops, portsOps...)
 	ops = append(ops, removeContainerRefOps(m.st, m.Id())...)
+	ops = append(ops, removeEntityBlocksOps(m.st, m.Tag())...)
 	// The only abort conditions in play indicate that the machine has already
 	// been removed.
 	return onAbort(m.st.runTransaction(ops), nil)
@@ -1299,6 +1301,10 @@ func (m *Machine) SetStatus(status Status, info string, data map[string]interfac
 	if err != nil {
 		return err
 	}
+	oldDoc, err := getStatus(m.st, m.globalKey())
+	if err != nil && !IsStatusNotFound(err) {
+		logger.Debugf("cannot get state for %q yet", m.globalKey())
+	}
 	ops := []txn.Op{{
 		C:      machinesC,
 		Id:     m.doc.DocID,
@@ -1309,9 +1315,21 @@ func (m *Machine) SetStatus(status Status, info string, data map[string]interfac
 	if err = m.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set status of machine %q: %v", m, onAbort(err, errNotAlive))
 	}
+
+	if oldDoc.Status != "" {
+		if err := updateStatusHistory(oldDoc, m.globalKey(), m.st); err != nil {
+			logger.Errorf("could not record status history before change to %q: %v", status, err)
+		}
+	}
 	return nil
 }
 
+// StatusHistory returns a slice of at most <size> StatusInfo items
+// representing past statuses for this machine.
+func (m *Machine) StatusHistory(size int) ([]StatusInfo, error) {
+	return statusHistory(size, m.globalKey(), m.st)
+}
+
 // Clean returns true if the machine does not have any deployed units or containers.
 func (m *Machine) Clean() bool {
 	return m.doc.Clean
@@ -1600,6 +1600,19 @@ func (s *MachineSuite) TestSetStatusPending(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot set status "pending"`)
 }
 
+func (s *MachineSuite) TestSetStatusHistory(c *gc.C) {
+	err := s.machine.SetStatus(state.StatusStarted, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetStatus(state.StatusError, "boom", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	h, err := s.machine.StatusHistory(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(h, gc.HasLen, 2)
+	c.Assert(h[0].Status, gc.Equals, state.StatusStarted)
+	c.Assert(h[1].Status, gc.Equals, state.StatusPending)
+}
+
 func (s *MachineSuite) TestGetSetStatusWhileNotAlive(c *gc.C) {
 	// When Dying set/get should work.
 	err := s.machine.Destroy()
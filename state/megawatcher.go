@@ -452,6 +452,11 @@ func (a *backingAnnotation) mongoId() interface{} {
 type backingBlock blockDoc
 
 func (a *backingBlock) updated(st *State, store *multiwatcherStore, id interface{}) error {
+	// Blocks on individual entities are only exposed through version 2
+	// of the Block facade; watchers only report environment blocks.
+	if a.Tag != st.EnvironTag().String() {
+		return nil
+	}
 	info := &multiwatcher.BlockInfo{
 		Id:      st.localID(a.DocID),
 		Tag:     a.Tag,
@@ -592,6 +592,22 @@ func (s *storeManagerStateSuite) TestChangeBlocks(c *gc.C) {
 				},
 			}
 		},
+		func(c *gc.C, st *State) changeTestCase {
+			svc := AddTestingService(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"), s.owner)
+			err := st.SwitchEntityBlockOn(RemoveBlock, svc.Tag(), "multiwatcher testing")
+			c.Assert(err, jc.ErrorIsNil)
+			blocks, err := st.AllBlocks()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(blocks, gc.HasLen, 1)
+
+			return changeTestCase{
+				about: "blocks on individual entities are not reported",
+				change: watcher.Change{
+					C:  blocksC,
+					Id: blocks[0].Id(),
+				},
+			}
+		},
 	}
 	s.performChangeTestCases(c, changeTestFuncs)
 }
@@ -18,10 +18,6 @@ import (
 
 var metricsLogger = loggo.GetLogger("juju.state.metrics")
 
-const (
-	CleanupAge = time.Hour * 24
-)
-
 // MetricBatch represents a batch of metrics reported from a unit.
 // These will be received from the unit in batches.
 // The main contents of the metric (key, value) is defined
@@ -171,22 +167,73 @@ func (st *State) MetricBatch(id string) (*MetricBatch, error) {
 	return &MetricBatch{st: st, doc: doc}, nil
 }
 
-// CleanupOldMetrics looks for metrics that are 24 hours old (or older)
-// and have been sent. Any metrics it finds are deleted.
+// CleanupOldMetrics looks for metrics that are older than the
+// environment's metrics-max-age setting (24 hours by default) and have
+// been sent. Any metrics it finds are deleted. The oldest sent metrics
+// are also deleted while the environment's metrics are larger than its
+// metrics-max-size setting.
 func (st *State) CleanupOldMetrics() error {
-	age := time.Now().Add(-(CleanupAge))
-	metricsLogger.Tracef("cleaning up metrics created before %v", age)
+	cfg, err := st.EnvironConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	minCreated := time.Now().Add(-cfg.MetricsMaxAge())
+	return PruneMetrics(st, minCreated, cfg.MetricsMaxSizeMB())
+}
+
+// PruneMetrics removes sent metrics created before minCreated. Further
+// removal of the oldest sent metrics is performed while the
+// environment's metrics are larger than maxMetricsMB.
+func PruneMetrics(st *State, minCreated time.Time, maxMetricsMB int) error {
+	metricsLogger.Tracef("cleaning up metrics created before %v", minCreated)
 	c, closer := st.getCollection(metricsC)
 	defer closer()
 	// Nothing else in the system will interact with sent metrics, and nothing needs
 	// to watch them either; so in this instance it's safe to do an end run around the
 	// mgo/txn package. See State.cleanupRelationSettings for a similar situation.
-	info, err := c.RemoveAll(bson.M{
-		"sent":    true,
-		"created": bson.M{"$lte": age},
-	})
-	metricsLogger.Tracef("cleanup removed %d metrics", info.Removed)
-	return errors.Trace(err)
+	// The metrics collection is not filtered by environment, so the
+	// environment is selected explicitly.
+	sentSel := bson.D{{"env-uuid", st.EnvironUUID()}, {"sent", true}}
+	info, err := c.RemoveAll(append(sentSel, bson.DocElem{"created", bson.D{{"$lte", minCreated}}}))
+	if err != nil {
+		return errors.Annotate(err, "cannot prune metrics by time")
+	}
+	removed := info.Removed
+
+	for {
+		metricsMB, err := getEnvCollectionMB(c.Underlying(), st.EnvironUUID())
+		if err != nil {
+			return errors.Annotate(err, "cannot get size of metrics")
+		}
+		if metricsMB <= maxMetricsMB {
+			break
+		}
+		count, err := c.Find(sentSel).Count()
+		if err != nil {
+			return errors.Annotate(err, "cannot count sent metrics")
+		}
+		if count == 0 {
+			break
+		}
+
+		// Remove the oldest 1% of sent metrics.
+		toRemove := count / 100
+		if toRemove < 1 {
+			toRemove = 1
+		}
+		var doc metricBatchDoc
+		err = c.Find(sentSel).Sort("created").Skip(toRemove - 1).One(&doc)
+		if err != nil {
+			return errors.Annotate(err, "cannot find oldest sent metrics")
+		}
+		info, err := c.RemoveAll(append(sentSel, bson.DocElem{"created", bson.D{{"$lte", doc.Created}}}))
+		if err != nil {
+			return errors.Annotate(err, "cannot prune metrics by size")
+		}
+		removed += info.Removed
+	}
+	metricsLogger.Tracef("cleanup removed %d metrics", removed)
+	return nil
 }
 
 // MetricsToSend returns batchSize metrics that need to be sent
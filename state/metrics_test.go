@@ -142,6 +142,90 @@ func (s *MetricSuite) TestCleanupMetrics(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *MetricSuite) TestCleanupMetricsConfiguredAge(c *gc.C) {
+	err := s.State.UpdateEnvironConfig(map[string]interface{}{"metrics-max-age": "1h"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	oldTime := time.Now().Add(-2 * time.Hour)
+	m := state.Metric{"pings", "5", oldTime}
+	oldMetric, err := s.unit.AddMetrics(utils.MustNewUUID().String(), oldTime, "", []state.Metric{m})
+	c.Assert(err, jc.ErrorIsNil)
+	oldMetric.SetSent()
+
+	now := time.Now()
+	m = state.Metric{"pings", "5", now}
+	newMetric, err := s.unit.AddMetrics(utils.MustNewUUID().String(), now, "", []state.Metric{m})
+	c.Assert(err, jc.ErrorIsNil)
+	newMetric.SetSent()
+	err = s.State.CleanupOldMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.MetricBatch(newMetric.UUID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.MetricBatch(oldMetric.UUID())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MetricSuite) TestPruneMetricsBySize(c *gc.C) {
+	now := time.Now()
+	m := state.Metric{"pings", "5", now}
+	sent1, err := s.unit.AddMetrics(utils.MustNewUUID().String(), now.Add(-2*time.Minute), "", []state.Metric{m})
+	c.Assert(err, jc.ErrorIsNil)
+	sent1.SetSent()
+	sent2, err := s.unit.AddMetrics(utils.MustNewUUID().String(), now.Add(-time.Minute), "", []state.Metric{m})
+	c.Assert(err, jc.ErrorIsNil)
+	sent2.SetSent()
+	unsent, err := s.unit.AddMetrics(utils.MustNewUUID().String(), now, "", []state.Metric{m})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing is pruned while the metrics are within the maximum size.
+	err = state.PruneMetrics(s.State, now.Add(-time.Hour), 1000)
+	c.Assert(err, jc.ErrorIsNil)
+	batches, err := s.State.MetricBatches()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(batches, gc.HasLen, 3)
+
+	// All sent metrics are pruned while the metrics are over the
+	// maximum size, but unsent metrics are kept.
+	err = state.PruneMetrics(s.State, now.Add(-time.Hour), -1)
+	c.Assert(err, jc.ErrorIsNil)
+	batches, err = s.State.MetricBatches()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(batches, gc.HasLen, 1)
+	c.Assert(batches[0].UUID(), gc.Equals, unsent.UUID())
+}
+
+func (s *MetricSuite) TestPruneMetricsOtherEnvironment(c *gc.C) {
+	st := s.factory.MakeEnvironment(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st)
+	meteredCharm := f.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "cs:quantal/metered"})
+	service := f.MakeService(c, &factory.ServiceParams{Charm: meteredCharm})
+	unit := f.MakeUnit(c, &factory.UnitParams{Service: service, SetCharmURL: true})
+
+	old := time.Now().Add(-2 * time.Hour)
+	m := state.Metric{"pings", "5", old}
+	other, err := unit.AddMetrics(utils.MustNewUUID().String(), old, "", []state.Metric{m})
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.SetSent()
+	c.Assert(err, jc.ErrorIsNil)
+	sent, err := s.unit.AddMetrics(utils.MustNewUUID().String(), old, "", []state.Metric{m})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sent.SetSent()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Neither pruning by age nor by size affects the metrics of
+	// other environments.
+	err = state.PruneMetrics(s.State, time.Now().Add(-time.Hour), -1)
+	c.Assert(err, jc.ErrorIsNil)
+	batches, err := s.State.MetricBatches()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(batches, gc.HasLen, 1)
+	c.Assert(batches[0].UUID(), gc.Equals, other.UUID())
+	c.Assert(batches[0].EnvUUID(), gc.Equals, st.EnvironUUID())
+}
+
 func (s *MetricSuite) TestCleanupNoMetrics(c *gc.C) {
 	err := s.State.CleanupOldMetrics()
 	c.Assert(err, jc.ErrorIsNil)
@@ -140,7 +140,7 @@ func (st *State) envSetupOps(cfg *config.Config, envUUID, serverUUID string, own
 	if serverUUID == "" {
 		serverUUID = envUUID
 	}
-	envUserOp, _ := createEnvUserOpAndDoc(envUUID, owner, owner, owner.Name())
+	envUserOp, _ := createEnvUserOpAndDoc(envUUID, owner, owner, owner.Name(), EnvironmentAdminAccess)
 	ops := []txn.Op{
 		createConstraintsOp(st, environGlobalKey, constraints.Value{}),
 		createSettingsOp(st, environGlobalKey, cfg.AllAttrs()),
@@ -180,8 +180,13 @@ var indexes = []struct {
 	{storageAttachmentsC, []string{"env-uuid", "storageid"}, false, false},
 	{storageAttachmentsC, []string{"env-uuid", "unitid"}, false, false},
 	{volumesC, []string{"env-uuid", "storageid"}, false, false},
+	{volumeSnapshotsC, []string{"env-uuid", "volumeid"}, false, false},
 	{filesystemsC, []string{"env-uuid", "storageid"}, false, false},
 	{statusesHistoryC, []string{"env-uuid", "entityid"}, false, false},
+	{auditC, []string{"env-uuid", "timestamp"}, false, false},
+	{auditC, []string{"env-uuid", "user"}, false, false},
+	{auditC, []string{"env-uuid", "entities"}, false, false},
+	{hookRunsC, []string{"env-uuid", "service"}, false, false},
 }
 
 // The capped collection used for transaction logs defaults to 10MB.
@@ -192,6 +197,13 @@ var (
 	txnLogSizeTests = 1000000
 )
 
+// The capped collection used to record hook runs defaults to 50MB,
+// and is tweaked in export_test.go as the transaction log is.
+var (
+	hookRunsSize      = 50000000
+	hookRunsSizeTests = 1000000
+)
+
 func maybeUnauthorized(err error, msg string) error {
 	if err == nil {
 		return nil
@@ -247,6 +259,16 @@ func newState(session *mgo.Session, mongoInfo *mongo.MongoInfo, policy Policy) (
 		return nil, maybeUnauthorized(err, "cannot create transaction collection")
 	}
 
+	// Hook runs are recorded in a capped collection, so the oldest are
+	// discarded as new ones are added. It must be created before its
+	// indexes are.
+	hookRuns := db.C(hookRunsC)
+	hookRunsInfo := mgo.CollectionInfo{Capped: true, MaxBytes: hookRunsSize}
+	err = hookRuns.Create(&hookRunsInfo)
+	if isCollectionExistsError(err) {
+		return nil, maybeUnauthorized(err, "cannot create hook runs collection")
+	}
+
 	// Create and set up State.
 	st := &State{
 		mongoInfo: mongoInfo,
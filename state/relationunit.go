@@ -226,7 +226,7 @@ func (ru *RelationUnit) subordinateOps() ([]txn.Op, string, error) {
 		if err != nil {
 			return nil, "", err
 		}
-		_, ops, err := service.addUnitOps(unitName, nil)
+		_, ops, err := service.addUnitOps(unitName, nil, nil)
 		return ops, "", err
 	} else if err != nil {
 		return nil, "", err
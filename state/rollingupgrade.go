@@ -0,0 +1,206 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v5"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// RollingUpgradeStatus describes the progress of a rolling charm
+// upgrade.
+type RollingUpgradeStatus string
+
+const (
+	// RollingUpgradeRunning indicates that units are being released
+	// to upgrade in batches.
+	RollingUpgradeRunning RollingUpgradeStatus = "running"
+
+	// RollingUpgradePaused indicates that a unit failed after it was
+	// upgraded, and that no more units will be released until the
+	// upgrade is resumed.
+	RollingUpgradePaused RollingUpgradeStatus = "paused"
+
+	// RollingUpgradeCompleted indicates that every unit has been
+	// upgraded.
+	RollingUpgradeCompleted RollingUpgradeStatus = "completed"
+
+	// RollingUpgradeRolledBack indicates that a unit failed after it
+	// was upgraded, and that the service was set back to the charm it
+	// was upgraded from.
+	RollingUpgradeRolledBack RollingUpgradeStatus = "rolled-back"
+)
+
+// RollingUpgrade records the progress of a rolling charm upgrade of a
+// service. While the upgrade is running or paused, the service's units
+// are held at the charm the service was upgraded from until they are
+// released to upgrade.
+type RollingUpgrade struct {
+	// FromCharmURL is the charm the service was upgraded from.
+	FromCharmURL *charm.URL `bson:"fromcharmurl"`
+
+	// BatchSize is how many units are released to upgrade at once.
+	BatchSize int `bson:"batchsize"`
+
+	// Rollback is whether the service is set back to the charm it was
+	// upgraded from if a unit fails, rather than the upgrade pausing.
+	Rollback bool `bson:"rollback"`
+
+	Status RollingUpgradeStatus `bson:"status"`
+
+	// Released holds the names of the units released to upgrade.
+	Released []string `bson:"released"`
+
+	// Message records why the upgrade was paused or rolled back.
+	Message string `bson:"message,omitempty"`
+}
+
+// InProgress reports whether the upgrade is running or paused, so that
+// units not yet released are held at the charm they are upgraded from.
+func (r *RollingUpgrade) InProgress() bool {
+	return r.Status == RollingUpgradeRunning || r.Status == RollingUpgradePaused
+}
+
+// IsReleased reports whether the named unit has been released to
+// upgrade.
+func (r *RollingUpgrade) IsReleased(unitName string) bool {
+	for _, name := range r.Released {
+		if name == unitName {
+			return true
+		}
+	}
+	return false
+}
+
+// RollingUpgrade returns the service's latest rolling charm upgrade, or
+// nil if it has never had one, or the charm has since been changed
+// without one.
+func (s *Service) RollingUpgrade() *RollingUpgrade {
+	if s.doc.RollingUpgrade == nil {
+		return nil
+	}
+	rolling := *s.doc.RollingUpgrade
+	rolling.Released = append([]string{}, rolling.Released...)
+	return &rolling
+}
+
+// UnitCharmURL returns the charm URL the named unit of the service
+// should be using, and whether the unit should upgrade to it even if it
+// is in an error state. While a rolling upgrade is in progress, units
+// not yet released are held at the charm the service was upgraded from.
+func (s *Service) UnitCharmURL(unitName string) (*charm.URL, bool) {
+	if rolling := s.doc.RollingUpgrade; rolling != nil && rolling.InProgress() && !rolling.IsReleased(unitName) {
+		return rolling.FromCharmURL, false
+	}
+	return s.doc.CharmURL, s.doc.ForceCharm
+}
+
+// SetCharmRolling changes the charm for the service as SetCharm does,
+// except that existing units are held at their current charm until
+// they are released to upgrade, batchSize at a time, by
+// ReleaseRollingUpgradeUnits. If rollback is true, a failed upgrade
+// sets the service back to its current charm; otherwise it pauses.
+func (s *Service) SetCharmRolling(ch *Charm, force bool, batchSize int, rollback bool) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot start rolling upgrade of service %q", s)
+	if batchSize < 1 {
+		return errors.NotValidf("batch size %d", batchSize)
+	}
+	return s.setCharm(ch, force, nil, &RollingUpgrade{
+		FromCharmURL: s.doc.CharmURL,
+		BatchSize:    batchSize,
+		Rollback:     rollback,
+		Status:       RollingUpgradeRunning,
+		Released:     []string{},
+	})
+}
+
+// setRollingUpgradeOp returns the operation that records the given
+// rolling upgrade on the service, or abandons the service's rolling
+// upgrade if it is nil.
+func (s *Service) setRollingUpgradeOp(rolling *RollingUpgrade) txn.Op {
+	update := bson.D{{"$unset", bson.D{{"rollingupgrade", nil}}}}
+	if rolling != nil {
+		update = bson.D{{"$set", bson.D{{"rollingupgrade", rolling}}}}
+	}
+	return txn.Op{
+		C:      servicesC,
+		Id:     s.doc.DocID,
+		Assert: txn.DocExists,
+		Update: update,
+	}
+}
+
+// updateRollingUpgrade applies the given update to the service's rolling
+// upgrade, asserting that its status is one of those given.
+func (s *Service) updateRollingUpgrade(update bson.D, statuses ...RollingUpgradeStatus) error {
+	ops := []txn.Op{{
+		C:      servicesC,
+		Id:     s.doc.DocID,
+		Assert: bson.D{{"rollingupgrade.status", bson.D{{"$in", statuses}}}},
+		Update: update,
+	}}
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf("rolling upgrade is not %s", statuses[0])
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return s.Refresh()
+}
+
+// ReleaseRollingUpgradeUnits releases the named units to upgrade to the
+// service's charm.
+func (s *Service) ReleaseRollingUpgradeUnits(unitNames ...string) error {
+	err := s.updateRollingUpgrade(bson.D{{"$addToSet", bson.D{{
+		"rollingupgrade.released", bson.D{{"$each", unitNames}},
+	}}}}, RollingUpgradeRunning)
+	return errors.Annotatef(err, "cannot release units of service %q to upgrade", s)
+}
+
+// PauseRollingUpgrade stops any more units being released to upgrade,
+// recording why.
+func (s *Service) PauseRollingUpgrade(message string) error {
+	err := s.updateRollingUpgrade(bson.D{{"$set", bson.D{
+		{"rollingupgrade.status", RollingUpgradePaused},
+		{"rollingupgrade.message", message},
+	}}}, RollingUpgradeRunning)
+	return errors.Annotatef(err, "cannot pause rolling upgrade of service %q", s)
+}
+
+// ResumeRollingUpgrade resumes a paused rolling upgrade.
+func (s *Service) ResumeRollingUpgrade() error {
+	err := s.updateRollingUpgrade(bson.D{
+		{"$set", bson.D{{"rollingupgrade.status", RollingUpgradeRunning}}},
+		{"$unset", bson.D{{"rollingupgrade.message", nil}}},
+	}, RollingUpgradePaused)
+	return errors.Annotatef(err, "cannot resume rolling upgrade of service %q", s)
+}
+
+// CompleteRollingUpgrade records that every unit of the service has
+// been upgraded.
+func (s *Service) CompleteRollingUpgrade() error {
+	err := s.updateRollingUpgrade(bson.D{{"$set", bson.D{
+		{"rollingupgrade.status", RollingUpgradeCompleted},
+	}}}, RollingUpgradeRunning)
+	return errors.Annotatef(err, "cannot complete rolling upgrade of service %q", s)
+}
+
+// RollBackRollingUpgrade sets the service back to the charm it was
+// upgraded from, forcing units that have already upgraded to return to
+// it even if they are in an error state, and records why.
+func (s *Service) RollBackRollingUpgrade(message string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot roll back rolling upgrade of service %q", s)
+	rolling := s.RollingUpgrade()
+	if rolling == nil || !rolling.InProgress() {
+		return errors.New("rolling upgrade is not in progress")
+	}
+	ch, err := s.st.Charm(rolling.FromCharmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rolling.Status = RollingUpgradeRolledBack
+	rolling.Message = message
+	return s.setCharm(ch, true, nil, rolling)
+}
@@ -0,0 +1,134 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type RollingUpgradeSuite struct {
+	ConnSuite
+	charm    *state.Charm
+	newCharm *state.Charm
+	mysql    *state.Service
+}
+
+var _ = gc.Suite(&RollingUpgradeSuite{})
+
+func (s *RollingUpgradeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "mysql")
+	s.newCharm = s.AddMetaCharm(c, "mysql", metaBase, 2)
+	s.mysql = s.AddTestingService(c, "mysql", s.charm)
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmRolling(c *gc.C) {
+	c.Assert(s.mysql.RollingUpgrade(), gc.IsNil)
+
+	err := s.mysql.SetCharmRolling(s.newCharm, false, 2, true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	url, _ := s.mysql.CharmURL()
+	c.Assert(url, gc.DeepEquals, s.newCharm.URL())
+	c.Assert(s.mysql.RollingUpgrade(), jc.DeepEquals, &state.RollingUpgrade{
+		FromCharmURL: s.charm.URL(),
+		BatchSize:    2,
+		Rollback:     true,
+		Status:       state.RollingUpgradeRunning,
+		Released:     []string{},
+	})
+
+	// Units are held at the old charm until released.
+	url, force := s.mysql.UnitCharmURL("mysql/0")
+	c.Assert(url, gc.DeepEquals, s.charm.URL())
+	c.Assert(force, jc.IsFalse)
+	err = s.mysql.ReleaseRollingUpgradeUnits("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	url, _ = s.mysql.UnitCharmURL("mysql/0")
+	c.Assert(url, gc.DeepEquals, s.newCharm.URL())
+	url, _ = s.mysql.UnitCharmURL("mysql/1")
+	c.Assert(url, gc.DeepEquals, s.charm.URL())
+
+	// Once completed, no units are held.
+	err = s.mysql.CompleteRollingUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RollingUpgrade().Status, gc.Equals, state.RollingUpgradeCompleted)
+	url, _ = s.mysql.UnitCharmURL("mysql/1")
+	c.Assert(url, gc.DeepEquals, s.newCharm.URL())
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmRollingErrors(c *gc.C) {
+	err := s.mysql.SetCharmRolling(s.newCharm, false, 0, false)
+	c.Assert(err, gc.ErrorMatches, `cannot start rolling upgrade of service "mysql": batch size 0 not valid`)
+	err = s.mysql.SetCharmRolling(s.charm, false, 1, false)
+	c.Assert(err, gc.ErrorMatches, `cannot start rolling upgrade of service "mysql": service "mysql" already uses charm ".*"`)
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmAbandonsRollingUpgrade(c *gc.C) {
+	err := s.mysql.SetCharmRolling(s.newCharm, false, 1, false)
+	c.Assert(err, jc.ErrorIsNil)
+	newerCharm := s.AddMetaCharm(c, "mysql", metaBase, 3)
+	err = s.mysql.SetCharm(newerCharm, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RollingUpgrade(), gc.IsNil)
+	url, _ := s.mysql.UnitCharmURL("mysql/0")
+	c.Assert(url, gc.DeepEquals, newerCharm.URL())
+}
+
+func (s *RollingUpgradeSuite) TestPauseAndResume(c *gc.C) {
+	err := s.mysql.SetCharmRolling(s.newCharm, false, 1, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.PauseRollingUpgrade(`unit "mysql/0" is in error`)
+	c.Assert(err, jc.ErrorIsNil)
+	rolling := s.mysql.RollingUpgrade()
+	c.Assert(rolling.Status, gc.Equals, state.RollingUpgradePaused)
+	c.Assert(rolling.Message, gc.Equals, `unit "mysql/0" is in error`)
+
+	// Units are still held, and none can be released.
+	url, _ := s.mysql.UnitCharmURL("mysql/0")
+	c.Assert(url, gc.DeepEquals, s.charm.URL())
+	err = s.mysql.ReleaseRollingUpgradeUnits("mysql/0")
+	c.Assert(err, gc.ErrorMatches, `cannot release units of service "mysql" to upgrade: rolling upgrade is not running`)
+
+	err = s.mysql.ResumeRollingUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	rolling = s.mysql.RollingUpgrade()
+	c.Assert(rolling.Status, gc.Equals, state.RollingUpgradeRunning)
+	c.Assert(rolling.Message, gc.Equals, "")
+	err = s.mysql.ResumeRollingUpgrade()
+	c.Assert(err, gc.ErrorMatches, `cannot resume rolling upgrade of service "mysql": rolling upgrade is not paused`)
+}
+
+func (s *RollingUpgradeSuite) TestRollBack(c *gc.C) {
+	err := s.mysql.SetCharmRolling(s.newCharm, false, 1, true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ReleaseRollingUpgradeUnits("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.RollBackRollingUpgrade(`unit "mysql/0" is in error`)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	url, force := s.mysql.CharmURL()
+	c.Assert(url, gc.DeepEquals, s.charm.URL())
+	c.Assert(force, jc.IsTrue)
+	rolling := s.mysql.RollingUpgrade()
+	c.Assert(rolling.Status, gc.Equals, state.RollingUpgradeRolledBack)
+	c.Assert(rolling.Message, gc.Equals, `unit "mysql/0" is in error`)
+
+	// The upgraded unit is forced back to the old charm.
+	url, force = s.mysql.UnitCharmURL("mysql/0")
+	c.Assert(url, gc.DeepEquals, s.charm.URL())
+	c.Assert(force, jc.IsTrue)
+
+	err = s.mysql.RollBackRollingUpgrade("again")
+	c.Assert(err, gc.ErrorMatches, `cannot roll back rolling upgrade of service "mysql": rolling upgrade is not in progress`)
+}
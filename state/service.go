@@ -46,6 +46,20 @@ type serviceDoc struct {
 	OwnerTag          string     `bson:"ownertag"`
 	TxnRevno          int64      `bson:"txn-revno"`
 	MetricCredentials []byte     `bson:"metric-credentials"`
+
+	// RollingUpgrade records the progress of the service's latest
+	// rolling charm upgrade, if any.
+	RollingUpgrade *RollingUpgrade `bson:"rollingupgrade,omitempty"`
+
+	// PreviousCharmURL and PreviousSettings record the charm and
+	// config settings the service used before its charm was last
+	// changed, so that the change can be rolled back.
+	PreviousCharmURL *charm.URL             `bson:"previouscharmurl,omitempty"`
+	PreviousSettings map[string]interface{} `bson:"previoussettings,omitempty"`
+
+	// HookTimeout is how long the service's units may run a hook
+	// before it is killed. Zero means hooks are never killed.
+	HookTimeout time.Duration `bson:"hooktimeout,omitempty"`
 }
 
 func newService(st *State, doc *serviceDoc) *Service {
@@ -148,6 +162,11 @@ func (s *Service) destroyOps() ([]txn.Op, error) {
 		return nil, errRefresh
 	}
 	ops := []txn.Op{minUnitsRemoveOp(s.st, s.doc.Name)}
+	storageOps, err := s.destroySharedStorageOps()
+	if err != nil {
+		return nil, err
+	}
+	ops = append(ops, storageOps...)
 	removeCount := 0
 	for _, rel := range rels {
 		relOps, isRemove, err := rel.destroyOps(s.doc.Name)
@@ -169,7 +188,11 @@ func (s *Service) destroyOps() ([]txn.Op, error) {
 	// removed, the service can also be removed.
 	if s.doc.UnitCount == 0 && s.doc.RelationCount == removeCount {
 		hasLastRefs := bson.D{{"life", Alive}, {"unitcount", 0}, {"relationcount", removeCount}}
-		return append(ops, s.removeOps(hasLastRefs)...), nil
+		removeOps, err := s.removeOps(hasLastRefs)
+		if err != nil {
+			return nil, err
+		}
+		return append(ops, removeOps...), nil
 	}
 	// In all other cases, service removal will be handled as a consequence
 	// of the removal of the last unit or relation referencing it. If any
@@ -205,9 +228,49 @@ func (s *Service) destroyOps() ([]txn.Op, error) {
 	}), nil
 }
 
+// destroySharedStorageOps returns the operations required to destroy the
+// shared storage instances owned by the service.
+func (s *Service) destroySharedStorageOps() ([]txn.Op, error) {
+	instances, err := s.sharedStorageInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, si := range instances {
+		siOps, err := s.st.destroyStorageInstanceOps(si)
+		if err == errAlreadyDying {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, siOps...)
+	}
+	return ops, nil
+}
+
+// sharedStorageInstances returns the shared storage instances
+// owned by the service.
+func (s *Service) sharedStorageInstances() ([]*storageInstance, error) {
+	coll, closer := s.st.getCollection(storageInstancesC)
+	defer closer()
+	var docs []storageInstanceDoc
+	if err := coll.Find(bson.D{{"owner", s.Tag().String()}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get shared storage instances for service %q", s.doc.Name)
+	}
+	instances := make([]*storageInstance, len(docs))
+	for i, doc := range docs {
+		instances[i] = &storageInstance{s.st, doc}
+	}
+	return instances, nil
+}
+
 // removeOps returns the operations required to remove the service. Supplied
 // asserts will be included in the operation on the service document.
-func (s *Service) removeOps(asserts bson.D) []txn.Op {
+func (s *Service) removeOps(asserts bson.D) ([]txn.Op, error) {
+	schedulesOps, err := removeActionSchedulesOps(s.st, s.Tag())
+	if err != nil {
+		return nil, err
+	}
 	settingsDocID := s.st.docID(s.settingsKey())
 	ops := []txn.Op{
 		{
@@ -230,7 +293,9 @@ func (s *Service) removeOps(asserts bson.D) []txn.Op {
 		annotationRemoveOp(s.st, s.globalKey()),
 		removeLeadershipSettingsOp(s.Tag().Id()),
 	}
-	return ops
+	ops = append(ops, removeEntityBlocksOps(s.st, s.Tag())...)
+	ops = append(ops, schedulesOps...)
+	return ops, nil
 }
 
 // IsExposed returns whether this service is exposed. The explicitly open
@@ -266,6 +331,32 @@ func (s *Service) setExposed(exposed bool) (err error) {
 	return nil
 }
 
+// HookTimeout returns how long the service's units may run a hook before
+// it is killed and the unit is put into an error state. Zero means hooks
+// are never killed.
+func (s *Service) HookTimeout() time.Duration {
+	return s.doc.HookTimeout
+}
+
+// SetHookTimeout sets how long the service's units may run a hook before
+// it is killed. Zero means hooks are never killed.
+func (s *Service) SetHookTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return errors.NotValidf("negative hook timeout")
+	}
+	ops := []txn.Op{{
+		C:      servicesC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"hooktimeout", timeout}}}},
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return fmt.Errorf("cannot set hook timeout for service %q to %v: %v", s, timeout, onAbort(err, errNotAlive))
+	}
+	s.doc.HookTimeout = timeout
+	return nil
+}
+
 // Charm returns the service's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (s *Service) Charm() (ch *Charm, force bool, err error) {
@@ -373,19 +464,27 @@ func (s *Service) checkRelationsOps(ch *Charm, relations []*Relation) ([]txn.Op,
 }
 
 // changeCharmOps returns the operations necessary to set a service's
-// charm URL to a new value.
-func (s *Service) changeCharmOps(ch *Charm, force bool) ([]txn.Op, error) {
-	// Build the new service config from what can be used of the old one.
+// charm URL to a new value, and the escaped settings that will be
+// recorded as the service's previous settings. If settings is not nil,
+// it replaces the service's config settings.
+func (s *Service) changeCharmOps(ch *Charm, force bool, settings charm.Settings) ([]txn.Op, map[string]interface{}, error) {
+	// Build the new service config from what can be used of the old one,
+	// or of the settings given.
 	var newSettings charm.Settings
+	previousSettings := make(map[string]interface{})
 	oldSettings, err := readSettings(s.st, s.settingsKey())
 	if err == nil {
 		// Filter the old settings through to get the new settings.
 		newSettings = ch.Config().FilterSettings(oldSettings.Map())
+		previousSettings = copyMap(oldSettings.Map(), escapeReplacer.Replace)
 	} else if errors.IsNotFound(err) {
 		// No old settings, start with empty new settings.
 		newSettings = make(charm.Settings)
 	} else {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
+	}
+	if settings != nil {
+		newSettings = ch.Config().FilterSettings(settings)
 	}
 
 	// Create or replace service settings.
@@ -395,26 +494,26 @@ func (s *Service) changeCharmOps(ch *Charm, force bool) ([]txn.Op, error) {
 		// No settings for this key yet, create it.
 		settingsOp = createSettingsOp(s.st, newKey, newSettings)
 	} else if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	} else {
 		// Settings exist, just replace them with the new ones.
 		settingsOp, _, err = replaceSettingsOp(s.st, newKey, newSettings)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 	}
 
 	// Add or create a reference to the new settings doc.
 	incOp, err := settingsIncRefOp(s.st, s.doc.Name, ch.URL(), true)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	var decOps []txn.Op
 	// Drop the reference to the old settings doc (if they exist).
 	if oldSettings != nil {
 		decOps, err = settingsDecRefOps(s.st, s.doc.Name, s.doc.CharmURL) // current charm
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 	}
 
@@ -430,25 +529,31 @@ func (s *Service) changeCharmOps(ch *Charm, force bool) ([]txn.Op, error) {
 		settingsOp,
 		// Increment the ref count.
 		incOp,
-		// Update the charm URL and force flag (if relevant).
+		// Update the charm URL and force flag (if relevant), and
+		// remember the old ones.
 		{
 			C:      servicesC,
 			Id:     s.doc.DocID,
 			Assert: append(notDeadDoc, differentCharm...),
-			Update: bson.D{{"$set", bson.D{{"charmurl", ch.URL()}, {"forcecharm", force}}}},
+			Update: bson.D{{"$set", bson.D{
+				{"charmurl", ch.URL()},
+				{"forcecharm", force},
+				{"previouscharmurl", s.doc.CharmURL},
+				{"previoussettings", previousSettings},
+			}}},
 		},
 	}...)
 	// Add any extra peer relations that need creation.
 	newPeers := s.extraPeerRelations(ch.Meta())
 	peerOps, err := s.st.addPeerRelationsOps(s.doc.Name, newPeers)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 
 	// Get all relations - we need to check them later.
 	relations, err := s.Relations()
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	// Make sure the relation count does not change.
 	sameRelCount := bson.D{{"relationcount", len(relations)}}
@@ -464,18 +569,27 @@ func (s *Service) changeCharmOps(ch *Charm, force bool) ([]txn.Op, error) {
 	// Check relations to ensure no active relations are removed.
 	relOps, err := s.checkRelationsOps(ch, relations)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	ops = append(ops, relOps...)
 
 	// And finally, decrement the old settings.
-	return append(ops, decOps...), nil
+	return append(ops, decOps...), previousSettings, nil
 }
 
 // SetCharm changes the charm for the service. New units will be started with
 // this charm, and existing units will be upgraded to use it. If force is true,
 // units will be upgraded even if they are in an error state.
 func (s *Service) SetCharm(ch *Charm, force bool) error {
+	return s.setCharm(ch, force, nil, nil)
+}
+
+// setCharm changes the charm for the service, recording the given
+// rolling upgrade. If rolling is nil, any rolling upgrade in progress is
+// abandoned, and all units are upgraded at once. If settings is not nil,
+// it replaces the service's config settings; otherwise the settings are
+// carried over from the current charm.
+func (s *Service) setCharm(ch *Charm, force bool, settings charm.Settings, rolling *RollingUpgrade) error {
 	if ch.Meta().Subordinate != s.doc.Subordinate {
 		return errors.Errorf("cannot change a service's subordinacy")
 	}
@@ -486,7 +600,10 @@ func (s *Service) SetCharm(ch *Charm, force bool) error {
 	services, closer := s.st.getCollection(servicesC)
 	defer closer()
 
+	var changedCharm bool
+	var previousSettings map[string]interface{}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
+		changedCharm = false
 		if attempt > 0 {
 			// NOTE: We're explicitly allowing SetCharm to succeed
 			// when the service is Dying, because service/charm
@@ -505,6 +622,9 @@ func (s *Service) SetCharm(ch *Charm, force bool) error {
 		if count, err := services.Find(sel).Count(); err != nil {
 			return nil, errors.Trace(err)
 		} else if count == 1 {
+			if rolling != nil {
+				return nil, errors.Errorf("service %q already uses charm %q", s.doc.Name, ch.URL())
+			}
 			// Charm URL already set; just update the force flag.
 			sameCharm := bson.D{{"charmurl", ch.URL()}}
 			ops = []txn.Op{{
@@ -515,21 +635,53 @@ func (s *Service) SetCharm(ch *Charm, force bool) error {
 			}}
 		} else {
 			// Change the charm URL.
-			ops, err = s.changeCharmOps(ch, force)
+			ops, previousSettings, err = s.changeCharmOps(ch, force, settings)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
+			ops = append(ops, s.setRollingUpgradeOp(rolling))
+			changedCharm = true
 		}
 		return ops, nil
 	}
 	err := s.st.run(buildTxn)
 	if err == nil {
+		if changedCharm {
+			s.doc.RollingUpgrade = rolling
+			s.doc.PreviousCharmURL = s.doc.CharmURL
+			s.doc.PreviousSettings = previousSettings
+		}
 		s.doc.CharmURL = ch.URL()
 		s.doc.ForceCharm = force
 	}
 	return err
 }
 
+// PreviousCharmURL returns the charm the service used before its charm
+// was last changed, or nil if it has never been changed.
+func (s *Service) PreviousCharmURL() *charm.URL {
+	return s.doc.PreviousCharmURL
+}
+
+// RollbackCharm changes the charm for the service back to the one it
+// used before its charm was last changed, and restores the config
+// settings it had then, in a single transaction. Units are upgraded to
+// the previous charm as they would be by SetCharm, running their
+// upgrade-charm hooks. If force is true, units will be rolled back even
+// if they are in an error state.
+func (s *Service) RollbackCharm(force bool) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot roll back charm of service %q", s)
+	if s.doc.PreviousCharmURL == nil {
+		return errors.New("service has no previous charm")
+	}
+	ch, err := s.st.Charm(s.doc.PreviousCharmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	settings := charm.Settings(copyMap(s.doc.PreviousSettings, unescapeReplacer.Replace))
+	return s.setCharm(ch, force, settings, nil)
+}
+
 // String returns the service name.
 func (s *Service) String() string {
 	return s.doc.Name
@@ -575,7 +727,11 @@ func (s *Service) newUnitName() (string, error) {
 // and only if s is a subordinate service. Only one subordinate of a given
 // service will be assigned to a given principal. The asserts param can be used
 // to include additional assertions for the service document.
-func (s *Service) addUnitOps(principalName string, asserts bson.D) (string, []txn.Op, error) {
+// addUnitOps returns the operations necessary to add a unit to the service,
+// and the name of the new unit. The storage instances specified by
+// attachStorage are attached to the new unit in place of creating new
+// ones.
+func (s *Service) addUnitOps(principalName string, asserts bson.D, attachStorage []names.StorageTag) (string, []txn.Op, error) {
 	if s.doc.Subordinate && principalName == "" {
 		return "", nil, fmt.Errorf("service is a subordinate")
 	} else if !s.doc.Subordinate && principalName != "" {
@@ -587,7 +743,7 @@ func (s *Service) addUnitOps(principalName string, asserts bson.D) (string, []tx
 	}
 
 	// Create instances of the charm's declared stores.
-	storageOps, numStorageAttachments, err := s.unitStorageOps(name)
+	storageOps, numStorageAttachments, err := s.unitStorageOps(name, attachStorage)
 	if err != nil {
 		return "", nil, errors.Trace(err)
 	}
@@ -661,10 +817,11 @@ func (s *Service) addUnitOps(principalName string, asserts bson.D) (string, []tx
 }
 
 // unitStorageOps returns operations for creating storage
-// instances and attachments for a new unit. unitStorageOps
+// instances and attachments for a new unit, and for attaching
+// the specified existing storage instances to it. unitStorageOps
 // returns the number of initial storage attachments, to
 // initialise the unit's storage attachment refcount.
-func (s *Service) unitStorageOps(unitName string) (ops []txn.Op, numStorageAttachments int, err error) {
+func (s *Service) unitStorageOps(unitName string, attachStorage []names.StorageTag) (ops []txn.Op, numStorageAttachments int, err error) {
 	cons, err := s.StorageConstraints()
 	if err != nil {
 		return nil, -1, err
@@ -676,14 +833,136 @@ func (s *Service) unitStorageOps(unitName string) (ops []txn.Op, numStorageAttac
 	meta := charm.Meta()
 	url := charm.URL()
 	tag := names.NewUnitTag(unitName)
+	if len(attachStorage) > 0 {
+		attachOps, attached, err := s.attachStorageOps(tag, meta, attachStorage)
+		if err != nil {
+			return nil, -1, errors.Trace(err)
+		}
+		// Create only as many new storage instances as are
+		// needed in addition to the attached ones.
+		remaining := make(map[string]StorageConstraints)
+		for name, c := range cons {
+			if attached[name] >= c.Count {
+				c.Count = 0
+			} else {
+				c.Count -= attached[name]
+			}
+			remaining[name] = c
+		}
+		cons = remaining
+		ops = append(ops, attachOps...)
+		numStorageAttachments += len(attachStorage)
+	}
 	// TODO(wallyworld) - record constraints info in data model - size and pool name
-	ops, numStorageAttachments, err = createStorageOps(s.st, tag, meta, url, cons)
+	createOps, numCreated, err := createStorageOps(s.st, tag, meta, url, cons)
 	if err != nil {
 		return nil, -1, errors.Trace(err)
 	}
+	ops = append(ops, createOps...)
+	numStorageAttachments += numCreated
+	sharedOps, numShared, err := s.sharedStorageAttachmentOps(tag)
+	if err != nil {
+		return nil, -1, errors.Trace(err)
+	}
+	ops = append(ops, sharedOps...)
+	numStorageAttachments += numShared
+	return ops, numStorageAttachments, nil
+}
+
+// sharedStorageAttachmentOps returns operations for attaching the
+// shared storage instances owned by the service to a new unit, and
+// the number of storage attachments created.
+func (s *Service) sharedStorageAttachmentOps(unit names.UnitTag) ([]txn.Op, int, error) {
+	instances, err := s.sharedStorageInstances()
+	if err != nil {
+		return nil, -1, errors.Trace(err)
+	}
+	ops := make([]txn.Op, 0, len(instances)*2)
+	numStorageAttachments := 0
+	for _, si := range instances {
+		if si.doc.Life != Alive {
+			continue
+		}
+		ops = append(ops, createStorageAttachmentOp(si.StorageTag(), unit), txn.Op{
+			C:      storageInstancesC,
+			Id:     si.doc.Id,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+		})
+		numStorageAttachments++
+	}
 	return ops, numStorageAttachments, nil
 }
 
+// attachStorageOps returns operations for attaching the specified
+// detached storage instances to a new unit of the service, and the
+// number of storage instances attached for each charm storage name.
+func (s *Service) attachStorageOps(
+	unit names.UnitTag,
+	charmMeta *charm.Meta,
+	attachStorage []names.StorageTag,
+) ([]txn.Op, map[string]uint64, error) {
+	attached := make(map[string]uint64)
+	ops := make([]txn.Op, 0, len(attachStorage)*2)
+	for _, tag := range attachStorage {
+		si, err := s.st.storageInstance(tag)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if si.doc.Life != Alive {
+			return nil, nil, errors.Errorf("storage %q is not alive", tag.Id())
+		}
+		if _, ok := si.Owner(); ok || si.doc.AttachmentCount != 0 {
+			return nil, nil, errors.Errorf("storage %q is attached", tag.Id())
+		}
+		charmStorage, ok := charmMeta.Storage[si.doc.StorageName]
+		if !ok || charmStorage.Shared {
+			return nil, nil, errors.Errorf(
+				"charm %q has no non-shared storage %q",
+				charmMeta.Name, si.doc.StorageName,
+			)
+		}
+		if charmStorage.Type != charm.StorageBlock || si.doc.Kind != StorageKindBlock {
+			return nil, nil, errors.NotSupportedf("attaching filesystem storage")
+		}
+		attached[si.doc.StorageName]++
+		if charmStorage.CountMax >= 0 && attached[si.doc.StorageName] > uint64(charmStorage.CountMax) {
+			return nil, nil, errors.Errorf(
+				"charm %q store %q: at most %d instances may be attached",
+				charmMeta.Name, si.doc.StorageName, charmStorage.CountMax,
+			)
+		}
+		volume, err := s.st.StorageInstanceVolume(tag)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		volumeAttachments, err := s.st.VolumeAttachments(volume.VolumeTag())
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if len(volumeAttachments) > 0 {
+			return nil, nil, errors.Errorf(
+				"storage %q is still attached to machine %q",
+				tag.Id(), volumeAttachments[0].Machine().Id(),
+			)
+		}
+		ops = append(ops, txn.Op{
+			C:  storageInstancesC,
+			Id: si.doc.Id,
+			Assert: bson.D{
+				{"life", Alive},
+				{"owner", ""},
+				{"attachmentcount", 0},
+			},
+			Update: bson.D{
+				{"$set", bson.D{{"owner", unit.String()}}},
+				{"$inc", bson.D{{"attachmentcount", 1}}},
+			},
+		}, createStorageAttachmentOp(tag, unit))
+	}
+	return ops, attached, nil
+}
+
 // SCHEMACHANGE
 // TODO(mattyw) remove when schema upgrades are possible
 func (s *Service) GetOwnerTag() string {
@@ -698,8 +977,16 @@ func (s *Service) GetOwnerTag() string {
 
 // AddUnit adds a new principal unit to the service.
 func (s *Service) AddUnit() (unit *Unit, err error) {
+	return s.AddUnitWithStorage(nil)
+}
+
+// AddUnitWithStorage adds a new principal unit to the service, attaching
+// the specified detached storage instances to it. Only as many new
+// storage instances are created for the unit as are required in addition
+// to the attached ones.
+func (s *Service) AddUnitWithStorage(attachStorage []names.StorageTag) (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to service %q", s)
-	name, ops, err := s.addUnitOps("", nil)
+	name, ops, err := s.addUnitOps("", nil, attachStorage)
 	if err != nil {
 		return nil, err
 	}
@@ -731,6 +1018,10 @@ func (s *Service) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 	if err != nil {
 		return nil, err
 	}
+	schedulesOps, err := removeActionSchedulesOps(s.st, u.Tag())
+	if err != nil {
+		return nil, err
+	}
 
 	observedFieldsMatch := bson.D{
 		{"charmurl", u.doc.CharmURL},
@@ -752,6 +1043,8 @@ func (s *Service) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 	)
 	ops = append(ops, portsOps...)
 	ops = append(ops, storageInstanceOps...)
+	ops = append(ops, schedulesOps...)
+	ops = append(ops, removeEntityBlocksOps(s.st, u.Tag())...)
 	if u.doc.CharmURL != nil {
 		decOps, err := settingsDecRefOps(s.st, s.doc.Name, u.doc.CharmURL)
 		if errors.IsNotFound(err) {
@@ -763,7 +1056,11 @@ func (s *Service) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 	}
 	if s.doc.Life == Dying && s.doc.RelationCount == 0 && s.doc.UnitCount == 1 {
 		hasLastRef := bson.D{{"life", Dying}, {"relationcount", 0}, {"unitcount", 1}}
-		return append(ops, s.removeOps(hasLastRef)...), nil
+		removeOps, err := s.removeOps(hasLastRef)
+		if err != nil {
+			return nil, err
+		}
+		return append(ops, removeOps...), nil
 	}
 	svcOp := txn.Op{
 		C:      servicesC,
@@ -6,6 +6,7 @@ package state_test
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -314,6 +315,68 @@ func (s *ServiceSuite) TestSetCharmConfig(c *gc.C) {
 	}
 }
 
+func (s *ServiceSuite) TestRollbackCharm(c *gc.C) {
+	err := s.mysql.RollbackCharm(false)
+	c.Assert(err, gc.ErrorMatches, `cannot roll back charm of service "mysql": service has no previous charm`)
+
+	oldCh := s.AddConfigCharm(c, "wordpress", stringConfig, 1)
+	newCh := s.AddConfigCharm(c, "wordpress", newStringConfig, 2)
+	svc := s.AddTestingService(c, "wordpress", oldCh)
+	c.Assert(svc.PreviousCharmURL(), gc.IsNil)
+	err = svc.UpdateConfigSettings(charm.Settings{"key": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = svc.SetCharm(newCh, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.PreviousCharmURL(), gc.DeepEquals, oldCh.URL())
+	err = svc.UpdateConfigSettings(charm.Settings{"key": "changed", "other": "new"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = svc.RollbackCharm(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	url, force := svc.CharmURL()
+	c.Assert(url, gc.DeepEquals, oldCh.URL())
+	c.Assert(force, jc.IsFalse)
+	settings, err := svc.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, charm.Settings{"key": "value"})
+
+	// Rolling back again returns to the charm and settings that were
+	// rolled back from.
+	c.Assert(svc.PreviousCharmURL(), gc.DeepEquals, newCh.URL())
+	err = svc.RollbackCharm(true)
+	c.Assert(err, jc.ErrorIsNil)
+	url, force = svc.CharmURL()
+	c.Assert(url, gc.DeepEquals, newCh.URL())
+	c.Assert(force, jc.IsTrue)
+	settings, err = svc.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, charm.Settings{"key": "changed", "other": "new"})
+}
+
+func (s *ServiceSuite) TestSetHookTimeout(c *gc.C) {
+	c.Assert(s.mysql.HookTimeout(), gc.Equals, time.Duration(0))
+
+	err := s.mysql.SetHookTimeout(10 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.HookTimeout(), gc.Equals, 10*time.Minute)
+	svc, err := s.State.Service("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.HookTimeout(), gc.Equals, 10*time.Minute)
+
+	err = s.mysql.SetHookTimeout(-time.Second)
+	c.Assert(err, gc.ErrorMatches, "negative hook timeout not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = s.mysql.SetHookTimeout(0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.HookTimeout(), gc.Equals, time.Duration(0))
+}
+
 func (s *ServiceSuite) TestSetCharmWithDyingService(c *gc.C) {
 	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
 
@@ -66,6 +66,9 @@ const (
 	// actionResultsC is deprecated and will soon be folded into
 	// actionsC.
 	actionresultsC = "actionresults"
+	// actionSchedulesC stores the schedules on which Actions are
+	// enqueued by the state server.
+	actionSchedulesC = "actionschedules"
 
 	usersC                 = "users"
 	envUsersC              = "envusers"
@@ -86,8 +89,10 @@ const (
 	storageInstancesC      = "storageinstances"
 	volumesC               = "volumes"
 	volumeAttachmentsC     = "volumeattachments"
+	volumeSnapshotsC       = "volumesnapshots"
 	filesystemsC           = "filesystems"
 	filesystemAttachmentsC = "filesystemAttachments"
+	workerReportsC         = "workerreports"
 
 	// leaseC is used to store lease tokens
 	leaseC = "lease"
@@ -114,6 +119,17 @@ const (
 	// blocksC is used to identify collection of environment blocks.
 	blocksC = "blocks"
 
+	// auditC is used to record audited API calls.
+	auditC = "audit"
+
+	// hookRunsC is a capped collection recording the hooks, actions
+	// and commands run by units. Its documents hold the environment
+	// UUID, but it is not a multi-environment collection: documents
+	// in a capped collection cannot be removed by transactions, so
+	// the runs of a removed environment are left to be discarded as
+	// new runs are added.
+	hookRunsC = "hookruns"
+
 	// The following mongo collections are used as unique key restraints. The
 	// _id field of each collection is a concatenation of multiple fields
 	// that form a compound index.
@@ -1318,6 +1334,13 @@ func (st *State) AddService(
 	}
 	ops = append(ops, peerOps...)
 
+	// Create the service's shared storage instances.
+	storageOps, _, err := createStorageOps(st, svc.Tag(), ch.Meta(), ch.URL(), storage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, storageOps...)
+
 	if err := st.runTransaction(ops); err == txn.ErrAborted {
 		err := env.Refresh()
 		if (err == nil && env.Life() != Alive) || errors.IsNotFound(err) {
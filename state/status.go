@@ -4,9 +4,12 @@
 package state
 
 import (
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -322,6 +325,182 @@ func statusHistory(size int, globalKey string, st *State) ([]StatusInfo, error)
 	return sInfo, nil
 }
 
+// StatusHistoryKind identifies the status whose changes are recorded
+// by a status history entry.
+type StatusHistoryKind string
+
+const (
+	StatusHistoryMachine  StatusHistoryKind = "machine"
+	StatusHistoryAgent    StatusHistoryKind = "agent"
+	StatusHistoryWorkload StatusHistoryKind = "workload"
+)
+
+// StatusHistoryEntry holds a past status of an entity.
+type StatusHistoryEntry struct {
+	StatusInfo
+
+	// Entity holds the tag of the machine or unit whose status
+	// this was.
+	Entity names.Tag
+
+	// Kind identifies which of the entity's statuses this was.
+	Kind StatusHistoryKind
+}
+
+// StatusHistoryFilter selects the status history entries returned
+// by State.StatusHistoryEntries.
+type StatusHistoryFilter struct {
+	// Entities selects the entries of the machines and units with
+	// these tags. A service tag selects the entries of all units
+	// of the service. When empty, entries for all entities are
+	// selected.
+	Entities []names.Tag
+
+	// Kind, if set, selects only entries of the given kind.
+	Kind StatusHistoryKind
+
+	// From and To select entries for statuses set within the
+	// time range.
+	From time.Time
+	To   time.Time
+
+	// Offset skips this many of the matching entries, and Limit
+	// restricts the number of entries returned.
+	Offset int
+	Limit  int
+}
+
+// StatusHistoryEntries returns the status history entries for the
+// environment that match the given filter, most recent first.
+func (st *State) StatusHistoryEntries(filter StatusHistoryFilter) ([]StatusHistoryEntry, error) {
+	coll, closer := st.getCollection(statusesHistoryC)
+	defer closer()
+
+	keys, err := statusHistoryKeyPatterns(filter.Entities, filter.Kind)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	query := bson.D{{"entityid", bson.D{{"$in", keys}}}}
+	timeRange := bson.D{}
+	if !filter.From.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$gte", filter.From.UTC()})
+	}
+	if !filter.To.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$lte", filter.To.UTC()})
+	}
+	if len(timeRange) > 0 {
+		query = append(query, bson.DocElem{"updated", timeRange})
+	}
+
+	q := coll.Find(query).Sort("-_id")
+	if filter.Offset > 0 {
+		q = q.Skip(filter.Offset)
+	}
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	var docs []historicalStatusDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get status history")
+	}
+	entries := make([]StatusHistoryEntry, 0, len(docs))
+	for _, doc := range docs {
+		tag, kind, ok := statusHistoryEntity(doc.EntityId)
+		if !ok {
+			continue
+		}
+		entries = append(entries, StatusHistoryEntry{
+			StatusInfo: StatusInfo{
+				Status:  doc.Status,
+				Message: doc.StatusInfo,
+				Data:    doc.StatusData,
+				Since:   doc.Updated,
+			},
+			Entity: tag,
+			Kind:   kind,
+		})
+	}
+	return entries, nil
+}
+
+// statusHistoryKeyPatterns returns the patterns matching the global
+// keys under which the given kind of status history of the given
+// entities is recorded.
+func statusHistoryKeyPatterns(entities []names.Tag, kind StatusHistoryKind) ([]interface{}, error) {
+	includeMachines := kind == "" || kind == StatusHistoryMachine
+	includeUnits := kind != StatusHistoryMachine
+	if len(entities) == 0 {
+		var keys []interface{}
+		if includeMachines {
+			keys = append(keys, bson.RegEx{Pattern: "^m#"})
+		}
+		if includeUnits {
+			keys = append(keys, unitStatusHistoryPattern("[^#]+", kind))
+		}
+		return keys, nil
+	}
+	keys := []interface{}{}
+	for _, tag := range entities {
+		switch tag := tag.(type) {
+		case names.MachineTag:
+			if includeMachines {
+				keys = append(keys, machineGlobalKey(tag.Id()))
+			}
+		case names.UnitTag:
+			if includeUnits {
+				keys = append(keys, unitStatusHistoryPattern(regexp.QuoteMeta(tag.Id()), kind))
+			}
+		case names.ServiceTag:
+			if includeUnits {
+				unitNames := regexp.QuoteMeta(tag.Id()) + "/[0-9]+"
+				keys = append(keys, unitStatusHistoryPattern(unitNames, kind))
+			}
+		default:
+			return nil, errors.NotValidf("status history of %q", tag)
+		}
+	}
+	return keys, nil
+}
+
+// unitStatusHistoryPattern returns a pattern matching the global keys
+// of the agent and workload statuses of units with matching names.
+func unitStatusHistoryPattern(unitNames string, kind StatusHistoryKind) bson.RegEx {
+	suffix := "(#charm)?"
+	switch kind {
+	case StatusHistoryAgent:
+		suffix = ""
+	case StatusHistoryWorkload:
+		suffix = "#charm"
+	}
+	return bson.RegEx{Pattern: "^u#" + unitNames + suffix + "$"}
+}
+
+// statusHistoryEntity returns the tag of the entity and the kind of
+// status recorded under the given status history global key.
+func statusHistoryEntity(globalKey string) (names.Tag, StatusHistoryKind, bool) {
+	switch {
+	case strings.HasPrefix(globalKey, "m#"):
+		id := strings.TrimPrefix(globalKey, "m#")
+		if names.IsValidMachine(id) {
+			return names.NewMachineTag(id), StatusHistoryMachine, true
+		}
+	case strings.HasPrefix(globalKey, "u#"):
+		name := strings.TrimPrefix(globalKey, "u#")
+		kind := StatusHistoryAgent
+		if strings.HasSuffix(name, "#charm") {
+			name = strings.TrimSuffix(name, "#charm")
+			kind = StatusHistoryWorkload
+		}
+		if names.IsValidUnit(name) {
+			return names.NewUnitTag(name), kind, true
+		}
+	}
+	return nil, "", false
+}
+
 type machineStatusDoc struct {
 	statusDoc
 }
@@ -571,11 +750,18 @@ func removeStatusOp(st *State, globalKey string) txn.Op {
 	}
 }
 
-// PruneStatusHistory removes status history entries until
+// PruneStatusHistory removes status history entries for statuses
+// set before minHistoryTime, and then removes further entries until
 // only the maxLogsPerEntity newest records per unit remain.
-func PruneStatusHistory(st *State, maxLogsPerEntity int) error {
+func PruneStatusHistory(st *State, minHistoryTime time.Time, maxLogsPerEntity int) error {
 	historyColl, closer := st.getCollection(statusesHistoryC)
 	defer closer()
+	_, err := historyColl.RemoveAll(bson.D{
+		{"updated", bson.M{"$lt": minHistoryTime.UTC()}},
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot prune status history by time")
+	}
 	globalKeys, err := getEntitiesWithStatuses(historyColl)
 	if err != nil {
 		return errors.Trace(err)
@@ -5,8 +5,10 @@ package state_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/mgo.v2/txn"
@@ -52,7 +54,7 @@ func (s *statusSuite) TestPruneStatusHistory(c *gc.C) {
 	c.Assert(history[0].Message, gc.Equals, "Status change 200")
 	c.Assert(history[199].Message, gc.Equals, "Status change 1")
 
-	err = state.PruneStatusHistory(st, 100)
+	err = state.PruneStatusHistory(st, time.Time{}, 100)
 	c.Assert(err, jc.ErrorIsNil)
 	history, err = state.StatusHistory(500, globalKey, st)
 	c.Assert(history, gc.HasLen, 100)
@@ -60,6 +62,134 @@ func (s *statusSuite) TestPruneStatusHistory(c *gc.C) {
 	c.Assert(history[99].Message, gc.Equals, "Status change 101")
 }
 
+func (s *statusSuite) addStatusHistory(c *gc.C, globalKey, message string, updated time.Time) {
+	doc := state.StatusDoc{
+		Status:     state.StatusActive,
+		StatusInfo: message,
+		Updated:    &updated,
+	}
+	err := state.UpdateStatusHistory(doc, globalKey, s.State)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *statusSuite) TestStatusHistoryEntries(c *gc.C) {
+	t0 := time.Date(2015, 5, 1, 10, 0, 0, 0, time.UTC)
+	s.addStatusHistory(c, "m#0", "machine 0", t0)
+	s.addStatusHistory(c, "u#mysql/0", "mysql/0 agent", t0.Add(time.Minute))
+	s.addStatusHistory(c, "u#mysql/0#charm", "mysql/0 workload", t0.Add(2*time.Minute))
+	s.addStatusHistory(c, "u#mysql/1#charm", "mysql/1 workload", t0.Add(3*time.Minute))
+	s.addStatusHistory(c, "u#mysql-slave/0", "mysql-slave/0 agent", t0.Add(4*time.Minute))
+	s.addStatusHistory(c, "BogusKey", "bogus", t0.Add(5*time.Minute))
+
+	messages := func(filter state.StatusHistoryFilter) []string {
+		entries, err := s.State.StatusHistoryEntries(filter)
+		c.Assert(err, jc.ErrorIsNil)
+		var result []string
+		for _, entry := range entries {
+			result = append(result, entry.Message)
+		}
+		return result
+	}
+	for i, test := range []struct {
+		about    string
+		filter   state.StatusHistoryFilter
+		expected []string
+	}{{
+		about: "everything",
+		expected: []string{
+			"mysql-slave/0 agent",
+			"mysql/1 workload",
+			"mysql/0 workload",
+			"mysql/0 agent",
+			"machine 0",
+		},
+	}, {
+		about: "service",
+		filter: state.StatusHistoryFilter{
+			Entities: []names.Tag{names.NewServiceTag("mysql")},
+		},
+		expected: []string{"mysql/1 workload", "mysql/0 workload", "mysql/0 agent"},
+	}, {
+		about: "service workload",
+		filter: state.StatusHistoryFilter{
+			Entities: []names.Tag{names.NewServiceTag("mysql")},
+			Kind:     state.StatusHistoryWorkload,
+		},
+		expected: []string{"mysql/1 workload", "mysql/0 workload"},
+	}, {
+		about: "unit agent",
+		filter: state.StatusHistoryFilter{
+			Entities: []names.Tag{names.NewUnitTag("mysql/0")},
+			Kind:     state.StatusHistoryAgent,
+		},
+		expected: []string{"mysql/0 agent"},
+	}, {
+		about: "machine and unit",
+		filter: state.StatusHistoryFilter{
+			Entities: []names.Tag{names.NewMachineTag("0"), names.NewUnitTag("mysql/1")},
+		},
+		expected: []string{"mysql/1 workload", "machine 0"},
+	}, {
+		about: "machine with unit kind",
+		filter: state.StatusHistoryFilter{
+			Entities: []names.Tag{names.NewMachineTag("0")},
+			Kind:     state.StatusHistoryAgent,
+		},
+	}, {
+		about: "time range",
+		filter: state.StatusHistoryFilter{
+			From: t0.Add(time.Minute),
+			To:   t0.Add(3 * time.Minute),
+		},
+		expected: []string{"mysql/1 workload", "mysql/0 workload", "mysql/0 agent"},
+	}, {
+		about: "paging",
+		filter: state.StatusHistoryFilter{
+			Offset: 1,
+			Limit:  2,
+		},
+		expected: []string{"mysql/1 workload", "mysql/0 workload"},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		c.Check(messages(test.filter), jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *statusSuite) TestStatusHistoryEntriesEntityAndKind(c *gc.C) {
+	t0 := time.Date(2015, 5, 1, 10, 0, 0, 0, time.UTC)
+	s.addStatusHistory(c, "m#0/lxc/1", "container", t0)
+	s.addStatusHistory(c, "u#mysql/0#charm", "workload", t0.Add(time.Minute))
+
+	entries, err := s.State.StatusHistoryEntries(state.StatusHistoryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 2)
+	c.Assert(entries[0].Entity, gc.Equals, names.NewUnitTag("mysql/0"))
+	c.Assert(entries[0].Kind, gc.Equals, state.StatusHistoryWorkload)
+	c.Assert(entries[0].Since.Equal(t0.Add(time.Minute)), jc.IsTrue)
+	c.Assert(entries[1].Entity, gc.Equals, names.NewMachineTag("0/lxc/1"))
+	c.Assert(entries[1].Kind, gc.Equals, state.StatusHistoryMachine)
+}
+
+func (s *statusSuite) TestStatusHistoryEntriesInvalidEntity(c *gc.C) {
+	_, err := s.State.StatusHistoryEntries(state.StatusHistoryFilter{
+		Entities: []names.Tag{names.NewUserTag("bob")},
+	})
+	c.Assert(err, gc.ErrorMatches, `status history of "user-bob" not valid`)
+}
+
+func (s *statusSuite) TestPruneStatusHistoryByAge(c *gc.C) {
+	now := time.Now()
+	s.addStatusHistory(c, "u#mysql/0", "old", now.Add(-2*time.Hour))
+	s.addStatusHistory(c, "u#mysql/0", "new", now)
+
+	err := state.PruneStatusHistory(s.State, now.Add(-time.Hour), 100)
+	c.Assert(err, jc.ErrorIsNil)
+	history, err := state.StatusHistory(10, "u#mysql/0", s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Message, gc.Equals, "new")
+}
+
 func (s *statusSuite) TestTranslateLegacyAgentState(c *gc.C) {
 	for i, test := range []struct {
 		agentStatus     state.Status
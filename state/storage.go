@@ -40,8 +40,10 @@ type StorageInstance interface {
 	Kind() StorageKind
 
 	// Owner returns the tag of the service or unit that owns this storage
-	// instance.
-	Owner() names.Tag
+	// instance, and a boolean indicating whether or not there is an owner.
+	// Storage that has been detached from its unit has no owner until it
+	// is attached to another unit.
+	Owner() (names.Tag, bool)
 
 	// StorageName returns the name of the storage, as defined in the charm
 	// storage metadata. This does not uniquely identify storage instances,
@@ -98,14 +100,17 @@ func (s *storageInstance) Kind() StorageKind {
 	return s.doc.Kind
 }
 
-func (s *storageInstance) Owner() names.Tag {
+func (s *storageInstance) Owner() (names.Tag, bool) {
+	if s.doc.Owner == "" {
+		return nil, false
+	}
 	tag, err := names.ParseTag(s.doc.Owner)
 	if err != nil {
-		// This should be impossible; we do not expose
-		// a means of modifying the owner tag.
+		// This should be impossible; the owner tag is only
+		// ever set to a valid tag, or cleared.
 		panic(err)
 	}
-	return tag
+	return tag, true
 }
 
 func (s *storageInstance) StorageName() string {
@@ -369,11 +374,24 @@ func createStorageOps(
 				StorageName: t.storageName,
 				CharmURL:    curl,
 			}
+			storage := names.NewStorageTag(id)
 			if unit, ok := entity.(names.UnitTag); ok {
 				doc.AttachmentCount = 1
-				storage := names.NewStorageTag(id)
 				ops = append(ops, createStorageAttachmentOp(storage, unit))
 				numStorageAttachments++
+			} else if kind == StorageKindFilesystem {
+				// Shared filesystems are created with the
+				// service, and attached to the machine of
+				// each unit as the units are assigned.
+				filesystemOps, _, err := st.addSharedFilesystemOps(FilesystemParams{
+					storage: storage,
+					Pool:    t.cons.Pool,
+					Size:    t.cons.Size,
+				})
+				if err != nil {
+					return nil, -1, errors.Annotatef(err, "cannot create filesystem for storage %q", id)
+				}
+				ops = append(ops, filesystemOps...)
 			}
 			ops = append(ops, txn.Op{
 				C:      storageInstancesC,
@@ -384,9 +402,6 @@ func createStorageOps(
 		}
 	}
 
-	// TODO(axw) create storage attachments for each shared storage
-	// instance owned by the service.
-	//
 	// TODO(axw) prevent creation of shared storage after service
 	// creation, because the only sane time to add storage attachments
 	// is when units are added to said service.
@@ -546,6 +561,25 @@ func (st *State) RemoveStorageAttachment(storage names.StorageTag, unit names.Un
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		owner, ok := inst.Owner()
+		if !ok {
+			// The storage has been detached from the unit, so
+			// its volume must be detached from the unit's machine.
+			detachOps, err := st.detachStorageVolumeOps(storage, unit)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, detachOps...)
+		} else if _, ok := owner.(names.ServiceTag); ok && inst.Kind() == StorageKindFilesystem {
+			// The storage is shared, so its filesystem must be
+			// detached from the unit's machine, unless another
+			// unit on the machine is still attached to it.
+			detachOps, err := st.detachSharedFilesystemOps(inst, unit)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, detachOps...)
+		}
 		return ops, nil
 	}
 	return st.run(buildTxn)
@@ -598,6 +632,244 @@ func removeStorageAttachmentOps(s *storageAttachment, si *storageInstance) ([]tx
 	return ops, nil
 }
 
+// DetachStorage detaches the storage instance with the specified tag
+// from the unit that owns it, so that it may later be attached to a
+// new unit of the same service. The storage attachment is made Dying,
+// and the storage instance is left without an owner; once the unit
+// has removed the attachment, the storage's volume is detached from
+// the unit's machine.
+//
+// Only storage backed by a provisioned, persistent volume may be
+// detached, as any other storage would not outlive the unit.
+func (st *State) DetachStorage(tag names.StorageTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		owner, ok := s.Owner()
+		if !ok {
+			// The storage has already been detached.
+			return nil, jujutxn.ErrNoOperations
+		}
+		unit, ok := owner.(names.UnitTag)
+		if !ok {
+			return nil, errors.NotSupportedf("detaching shared storage")
+		}
+		if err := st.validateDetachableStorage(s); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      storageInstancesC,
+			Id:     s.doc.Id,
+			Assert: append(bson.D{{"owner", s.doc.Owner}}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"owner", ""}}}},
+		}}
+		attachment, err := st.storageAttachment(tag, unit)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if err == nil && attachment.doc.Life == Alive {
+			ops = append(ops, destroyStorageAttachmentOps(tag, unit)...)
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// validateDetachableStorage returns an error if the storage instance
+// is not backed by a provisioned, persistent volume.
+func (st *State) validateDetachableStorage(s *storageInstance) error {
+	if s.doc.Kind != StorageKindBlock {
+		return errors.NotSupportedf("detaching filesystem storage")
+	}
+	volume, err := st.StorageInstanceVolume(s.StorageTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !info.Persistent {
+		return errors.NotSupportedf("detaching non-persistent storage")
+	}
+	return nil
+}
+
+// detachStorageVolumeOps returns the operations to detach the volume
+// of a detached storage instance from the machine that the specified
+// unit is assigned to.
+func (st *State) detachStorageVolumeOps(storage names.StorageTag, unit names.UnitTag) ([]txn.Op, error) {
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volume, err := st.StorageInstanceVolume(storage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachment, err := st.VolumeAttachment(names.NewMachineTag(machineId), volume.VolumeTag())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if attachment.Life() != Alive {
+		return nil, nil
+	}
+	return detachVolumeOps(machineId, volume.VolumeTag().Id()), nil
+}
+
+// detachSharedFilesystemOps returns the operations to detach the filesystem
+// of a shared storage instance from the machine that the specified unit is
+// assigned to, if no other unit assigned to the machine is attached to the
+// storage. The operations assert that the other units attached to the
+// storage are unchanged, so the transaction is retried if another unit on
+// the machine attaches to or detaches from the storage concurrently.
+func (st *State) detachSharedFilesystemOps(si *storageInstance, unit names.UnitTag) ([]txn.Op, error) {
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storage := si.StorageTag()
+	attachments, err := st.StorageAttachments(storage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     si.doc.Id,
+		Assert: bson.D{{"attachmentcount", si.doc.AttachmentCount}},
+	}}
+	for _, attachment := range attachments {
+		if attachment.Unit() == unit {
+			continue
+		}
+		other, err := st.Unit(attachment.Unit().Id())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if other.doc.MachineId == machineId {
+			// The other unit keeps the filesystem attached,
+			// for as long as it remains attached to the storage.
+			return []txn.Op{{
+				C:      storageAttachmentsC,
+				Id:     storageAttachmentId(other.Name(), storage.Id()),
+				Assert: txn.DocExists,
+			}, {
+				C:      unitsC,
+				Id:     other.Name(),
+				Assert: bson.D{{"machineid", machineId}},
+			}}, nil
+		}
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     other.Name(),
+			Assert: bson.D{{"machineid", bson.D{{"$ne", machineId}}}},
+		})
+	}
+	filesystem, err := st.StorageInstanceFilesystem(storage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachment, err := st.FilesystemAttachment(names.NewMachineTag(machineId), filesystem.FilesystemTag())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if attachment.Life() != Alive {
+		return nil, nil
+	}
+	return append(ops, detachFilesystemOps(machineId, filesystem.FilesystemTag().Id())...), nil
+}
+
+// ImportStorage records a pre-existing volume, described by the supplied
+// VolumeInfo, as a detached block storage instance with the specified
+// storage name. The volume is recorded as persistent and provisioned, so
+// it will not be created by the storage provisioner. The storage may
+// subsequently be attached to a unit of a service whose charm declares
+// non-shared block storage with the same name.
+func (st *State) ImportStorage(storageName string, info VolumeInfo) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot import volume %q", info.VolumeId)
+	if !names.IsValidStorage(storageName + "/0") {
+		return names.StorageTag{}, errors.NotValidf("storage name %q", storageName)
+	}
+	if info.VolumeId == "" {
+		return names.StorageTag{}, errors.New("volume ID is required")
+	}
+	if err := validateStoragePool(st, info.Pool, storage.StorageKindBlock, nil); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if _, provider, err := poolStorageProvider(st, info.Pool); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	} else if provider.Scope() != storage.ScopeEnviron {
+		return names.StorageTag{}, errors.NotSupportedf("importing machine-scoped volumes")
+	}
+
+	volumes, cleanup := st.getCollection(volumesC)
+	defer cleanup()
+	n, err := volumes.Find(bson.D{{"info.volumeid", info.VolumeId}}).Count()
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if n > 0 {
+		return names.StorageTag{}, errors.AlreadyExistsf("volume %q", info.VolumeId)
+	}
+
+	id, err := newStorageInstanceId(st, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate storage instance name")
+	}
+	volumeName, err := newVolumeName(st, "")
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate volume name")
+	}
+	info.Persistent = true
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     id,
+		Assert: txn.DocMissing,
+		Insert: &storageInstanceDoc{
+			Id:          id,
+			Kind:        StorageKindBlock,
+			StorageName: storageName,
+		},
+	}, {
+		C:      volumesC,
+		Id:     volumeName,
+		Assert: txn.DocMissing,
+		Insert: &volumeDoc{
+			Name:      volumeName,
+			StorageId: id,
+			Info:      &info,
+		},
+	}}
+	if err := st.runTransaction(ops); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return names.NewStorageTag(id), nil
+}
+
 // removeStorageInstancesOps returns the transaction operations to remove all
 // storage instances owned by the specified entity.
 func removeStorageInstancesOps(st *State, owner names.Tag) ([]txn.Op, error) {
@@ -712,11 +984,9 @@ func validateStorageConstraintsAgainstCharm(
 			return errors.Errorf("charm %q has no store called %q", charmMeta.Name, name)
 		}
 		if charmStorage.Shared {
-			// TODO(axw) implement shared storage support.
-			return errors.Errorf(
-				"charm %q store %q: shared storage support not implemented",
-				charmMeta.Name, name,
-			)
+			if err := validateSharedStorageConstraints(st, charmStorage, cons); err != nil {
+				return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+			}
 		}
 		if cons.Count < uint64(charmStorage.CountMin) {
 			return errors.Errorf(
@@ -836,6 +1106,46 @@ func poolStorageProvider(st *State, poolName string) (storage.ProviderType, stor
 	return providerType, provider, nil
 }
 
+// validateSharedStorageConstraints returns an error if the storage pool
+// specified in the constraints cannot provide shared storage of the
+// charm storage's type.
+func validateSharedStorageConstraints(st *State, charmStorage charm.Storage, cons StorageConstraints) error {
+	if charmStorage.Type != charm.StorageFilesystem {
+		return errors.NotSupportedf("shared %s storage", charmStorage.Type)
+	}
+	_, err := sharedFilesystemId(st, cons.Pool)
+	return errors.Trace(err)
+}
+
+// sharedFilesystemId returns the provider-specific ID of the shared
+// filesystem described by the storage pool with the specified name,
+// or an error satisfying errors.IsNotSupported if the pool's provider
+// does not support shared filesystems.
+func sharedFilesystemId(st *State, poolName string) (string, error) {
+	providerType, provider, err := poolStorageProvider(st, poolName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	sharedProvider, ok := provider.(storage.SharedFilesystemProvider)
+	if !ok {
+		return "", errors.NotSupportedf("shared filesystems with %q provider", providerType)
+	}
+	poolManager := poolmanager.New(NewStateSettings(st))
+	cfg, err := poolManager.Get(poolName)
+	if errors.IsNotFound(err) {
+		// The pool name is a storage provider type.
+		cfg, err = storage.NewConfig(poolName, providerType, map[string]interface{}{})
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	filesystemId, err := sharedProvider.SharedFilesystemId(cfg)
+	if err != nil {
+		return "", errors.Annotatef(err, "getting shared filesystem ID for pool %q", poolName)
+	}
+	return filesystemId, nil
+}
+
 // ErrNoDefaultStoragePool is returned when a storage pool is required but none
 // is specified nor available as a default.
 var ErrNoDefaultStoragePool = fmt.Errorf("no storage pool specifed and no default available")
@@ -4,6 +4,7 @@
 package state_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/set"
@@ -44,14 +45,24 @@ func (s *StorageStateSuiteBase) SetUpSuite(c *gc.C) {
 			return k == storage.StorageKindBlock
 		},
 	})
+	registry.RegisterProvider("shared", &dummy.SharedStorageProvider{
+		StorageProvider: dummy.StorageProvider{
+			StorageScope: storage.ScopeEnviron,
+			IsDynamic:    true,
+			SupportsFunc: func(k storage.StorageKind) bool {
+				return k == storage.StorageKindFilesystem
+			},
+		},
+	})
 	registry.RegisterEnvironStorageProviders(
 		"someprovider", "environscoped", "machinescoped",
-		"environscoped-block",
+		"environscoped-block", "shared",
 	)
 	s.AddSuiteCleanup(func(c *gc.C) {
 		registry.RegisterProvider("environscoped", nil)
 		registry.RegisterProvider("machinescoped", nil)
 		registry.RegisterProvider("environscoped-block", nil)
+		registry.RegisterProvider("shared", nil)
 	})
 }
 
@@ -318,7 +329,9 @@ func (s *StorageStateSuite) TestAllStorageInstances(c *gc.C) {
 	for _, one := range all {
 		c.Assert(one.Kind(), gc.DeepEquals, state.StorageKindBlock)
 		c.Assert(nameSet.Contains(one.StorageName()), jc.IsTrue)
-		c.Assert(ownerSet.Contains(one.Owner().String()), jc.IsTrue)
+		owner, ok := one.Owner()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(ownerSet.Contains(owner.String()), jc.IsTrue)
 	}
 }
 
@@ -549,6 +562,208 @@ func (s *StorageStateSuite) TestDestroyUnitStorageAttachments(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+// setupPersistentStorage creates a unit with a single block storage
+// instance, assigns the unit to a machine, and records the storage's
+// volume as provisioned and persistent.
+func (s *StorageStateSuite) setupPersistentStorage(c *gc.C) (*state.Service, *state.Unit, names.StorageTag, names.VolumeTag) {
+	service, u, storageTag := s.setupSingleStorage(c, "block", "environscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{
+		VolumeId:   "vol-123",
+		Size:       1024,
+		Persistent: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return service, u, storageTag, volume.VolumeTag()
+}
+
+func (s *StorageStateSuite) TestDetachStorage(c *gc.C) {
+	_, u, storageTag, volumeTag := s.setupPersistentStorage(c)
+	machineTag := names.NewMachineTag("0")
+
+	err := s.State.DetachStorage(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	attachment, err := s.State.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+
+	// Detaching again is a no-op.
+	err = s.State.DetachStorage(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The volume stays attached to the machine until the
+	// unit has removed its attachment to the storage.
+	volumeAttachment, err := s.State.VolumeAttachment(machineTag, volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeAttachment.Life(), gc.Equals, state.Alive)
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	volumeAttachment, err = s.State.VolumeAttachment(machineTag, volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeAttachment.Life(), gc.Equals, state.Dying)
+
+	// The storage instance outlives the unit.
+	err = u.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	exists := s.storageInstanceExists(c, storageTag)
+	c.Assert(exists, jc.IsTrue)
+}
+
+func (s *StorageStateSuite) TestDetachStorageNotPersistent(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "environscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "vol-123",
+		Size:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.DetachStorage(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage "data/0": detaching non-persistent storage not supported`)
+}
+
+func (s *StorageStateSuite) TestDetachStorageNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "environscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.DetachStorage(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage "data/0": volume "0" not provisioned`)
+}
+
+func (s *StorageStateSuite) TestDetachStorageFilesystem(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	err := s.State.DetachStorage(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage "data/0": detaching filesystem storage not supported`)
+}
+
+func (s *StorageStateSuite) TestAddUnitWithStorage(c *gc.C) {
+	service, u, storageTag, volumeTag := s.setupPersistentStorage(c)
+	err := s.State.DetachStorage(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The storage cannot be attached to a new unit until
+	// its volume has been detached from the old unit's machine.
+	_, err = service.AddUnitWithStorage([]names.StorageTag{storageTag})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to service "storage-block": storage "data/0" is still attached to machine "0"`)
+	err = s.State.RemoveVolumeAttachment(names.NewMachineTag("0"), volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	u2, err := service.AddUnitWithStorage([]names.StorageTag{storageTag})
+	c.Assert(err, jc.ErrorIsNil)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u2.Tag())
+	attachments, err := s.State.UnitStorageAttachments(u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Assert(attachments[0].StorageInstance(), gc.Equals, storageTag)
+
+	// No new storage instance is created for the unit in place
+	// of the attached one.
+	all, err := s.State.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+
+	// Assigning the unit to a machine attaches the
+	// existing volume, rather than creating a new one.
+	err = s.State.AssignUnit(u2, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u2.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeAttachment(names.NewMachineTag(machineId), volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volumes, err := s.State.AllVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes, gc.HasLen, 1)
+}
+
+func (s *StorageStateSuite) TestAddUnitWithStorageAttached(c *gc.C) {
+	service, _, storageTag, _ := s.setupPersistentStorage(c)
+	_, err := service.AddUnitWithStorage([]names.StorageTag{storageTag})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to service "storage-block": storage "data/0" is attached`)
+}
+
+func (s *StorageStateSuite) TestImportStorage(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	service := s.AddTestingServiceWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("environscoped", 1024, 1),
+	})
+
+	storageTag, err := s.State.ImportStorage("data", state.VolumeInfo{
+		Pool:     "environscoped",
+		VolumeId: "vol-123",
+		Size:     2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("data/0"))
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Kind(), gc.Equals, state.StorageKindBlock)
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeInfo{
+		Pool:       "environscoped",
+		VolumeId:   "vol-123",
+		Size:       2048,
+		Persistent: true,
+	})
+
+	// The imported storage can be attached to a new unit.
+	u, err := service.AddUnitWithStorage([]names.StorageTag{storageTag})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeAttachment(names.NewMachineTag(machineId), volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageStateSuite) TestImportStorageAlreadyImported(c *gc.C) {
+	info := state.VolumeInfo{Pool: "environscoped", VolumeId: "vol-123", Size: 2048}
+	_, err := s.State.ImportStorage("data", info)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ImportStorage("data", info)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "vol-123": volume "vol-123" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *StorageStateSuite) TestImportStorageMachineScoped(c *gc.C) {
+	_, err := s.State.ImportStorage("data", state.VolumeInfo{
+		Pool: "machinescoped", VolumeId: "vol-123", Size: 2048,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "vol-123": importing machine-scoped volumes not supported`)
+}
+
+func (s *StorageStateSuite) TestImportStorageInvalidName(c *gc.C) {
+	_, err := s.State.ImportStorage("0data", state.VolumeInfo{
+		Pool: "environscoped", VolumeId: "vol-123", Size: 2048,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "vol-123": storage name "0data" not valid`)
+}
+
 // TODO(axw) StorageAttachments can't be added to Dying StorageInstance
 // TODO(axw) StorageInstance without attachments is removed by Destroy
 // TODO(axw) StorageInstance becomes Dying when Unit becomes Dying
@@ -12,8 +12,19 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/utils/prometheus"
+)
+
+var txnRetries = prometheus.NewCounterVec(
+	"juju_state_txn_retries_total",
+	"Number of times transactions were retried after their assertions failed.",
 )
 
+func init() {
+	prometheus.MustRegister(txnRetries)
+}
+
 const (
 	txnAssertEnvIsAlive    = true
 	txnAssertEnvIsNotAlive = false
@@ -103,6 +114,9 @@ func (r *multiEnvRunner) RunTransaction(ops []txn.Op) error {
 // with these collections.
 func (r *multiEnvRunner) Run(transactions jujutxn.TransactionSource) error {
 	return r.rawRunner.Run(func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			txnRetries.Inc()
+		}
 		ops, err := transactions(attempt)
 		if err != nil {
 			// Don't use Trace here as jujutxn doens't use juju/errors
@@ -1586,13 +1586,15 @@ func (u *Unit) machineStorageParams() (*machineStorageParams, error) {
 		}
 
 		charmStorage := ch.Meta().Storage[storage.StorageName()]
+		owner, _ := storage.Owner()
 
 		switch storage.Kind() {
 		case StorageKindBlock:
 			volumeAttachmentParams := VolumeAttachmentParams{
 				charmStorage.ReadOnly,
 			}
-			if storage.Owner() == u.Tag() {
+			volume, err := u.st.StorageInstanceVolume(storage.StorageTag())
+			if errors.IsNotFound(err) && owner == u.Tag() {
 				// The storage instance is owned by the unit, so we'll need
 				// to create a volume.
 				cons := allCons[storage.StorageName()]
@@ -1605,10 +1607,10 @@ func (u *Unit) machineStorageParams() (*machineStorageParams, error) {
 					volumeParams, volumeAttachmentParams,
 				})
 			} else {
-				// The storage instance is owned by the service, so there
-				// should be a (shared) volume already, for which we will
+				// The storage instance is owned by the service, or was
+				// detached from another unit and attached to this one,
+				// so there should be a volume already, for which we will
 				// just add an attachment.
-				volume, err := u.st.StorageInstanceVolume(storage.StorageTag())
 				if err != nil {
 					return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
 				}
@@ -1619,7 +1621,7 @@ func (u *Unit) machineStorageParams() (*machineStorageParams, error) {
 				charmStorage.Location,
 				charmStorage.ReadOnly,
 			}
-			if storage.Owner() == u.Tag() {
+			if owner == u.Tag() {
 				// The storage instance is owned by the unit, so we'll need
 				// to create a filesystem.
 				cons := allCons[storage.StorageName()]
@@ -1917,6 +1919,24 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (*Action, error) {
+	return u.AddActionWithTimeout(name, payload, 0)
+}
+
+// AddActionWithTimeout adds a new Action like AddAction, which is
+// killed if it runs for longer than timeout. A zero timeout lets the
+// action run for as long as it takes.
+func (u *Unit) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (*Action, error) {
+	payloadWithDefaults, err := u.actionPayload(name, payload)
+	if err != nil {
+		return nil, err
+	}
+	return u.st.EnqueueActionWithTimeout(u.Tag(), name, payloadWithDefaults, timeout)
+}
+
+// actionPayload validates the payload of the named action against the
+// unit's charm, and returns it with the defaults of any missing params
+// inserted.
+func (u *Unit) actionPayload(name string, payload map[string]interface{}) (map[string]interface{}, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -1933,11 +1953,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (*Action,
 	if err != nil {
 		return nil, err
 	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
-	if err != nil {
-		return nil, err
-	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return spec.InsertDefaults(payload)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -1966,8 +1982,8 @@ func (u *Unit) ActionSpecs() (ActionSpecsByName, error) {
 	return chActions.ActionSpecs, nil
 }
 
-// CancelAction removes a pending Action from the queue for this
-// ActionReceiver and marks it as cancelled.
+// CancelAction removes a pending or running Action from the queue for
+// this ActionReceiver and marks it as cancelled.
 func (u *Unit) CancelAction(action *Action) (*Action, error) {
 	return action.Finish(ActionResults{Status: ActionCancelled})
 }
@@ -78,6 +78,13 @@ func (u *UnitAgent) SetStatus(status Status, info string, data map[string]interf
 		return errors.Errorf("cannot set status of unit agent %q: %v", u, onAbort(err, ErrDead))
 	}
 
+	if oldDoc.Status == StatusExecuting && oldDoc.Updated != nil && doc.Updated != nil {
+		// Record how long the hook, action or commands ran for, so
+		// that slow and hung hooks can be found in the history.
+		oldDoc.StatusData = map[string]interface{}{
+			"elapsed": doc.Updated.Sub(*oldDoc.Updated).String(),
+		}
+	}
 	if oldDoc.Status != "" {
 		if err := updateStatusHistory(oldDoc, u.globalKey(), u.st); err != nil {
 			logger.Errorf("could not record status history before change to %q: %v", status, err)
@@ -190,6 +190,27 @@ func (s *UnitAgentSuite) TestSetUnitAgentStatusHistory(c *gc.C) {
 	}
 }
 
+func (s *UnitAgentSuite) TestStatusHistoryRecordsElapsedExecution(c *gc.C) {
+	err := state.EraseUnitHistory(s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	agent := s.unit.Agent().(*state.UnitAgent)
+	now := time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC)
+	s.PatchValue(state.NowToTheSecondFunc, func() time.Time { return now })
+
+	err = agent.SetStatus(state.StatusExecuting, "running config-changed hook", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	now = now.Add(95 * time.Second)
+	err = agent.SetStatus(state.StatusIdle, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	h, err := agent.StatusHistory(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(h, gc.HasLen, 1)
+	c.Assert(h[0].Status, gc.Equals, state.StatusExecuting)
+	c.Assert(h[0].Message, gc.Equals, "running config-changed hook")
+	c.Assert(h[0].Data, jc.DeepEquals, map[string]interface{}{"elapsed": "1m35s"})
+}
+
 func (s *UnitAgentSuite) TestGetUnitAgentStatusHistory(c *gc.C) {
 	err := state.EraseUnitHistory(s.unit)
 	c.Assert(err, jc.ErrorIsNil)
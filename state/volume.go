@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -43,6 +44,11 @@ type Volume interface {
 	// if it has not already been provisioned. Params returns true if the
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
+
+	// DesiredSize returns the size, in MiB, that the volume has been
+	// asked to grow to. DesiredSize returns true if the volume is
+	// waiting to be resized, otherwise false.
+	DesiredSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -86,6 +92,10 @@ type volumeDoc struct {
 	StorageId string        `bson:"storageid,omitempty"`
 	Info      *VolumeInfo   `bson:"info,omitempty"`
 	Params    *VolumeParams `bson:"params,omitempty"`
+
+	// DesiredSize, if non-zero, is the size in MiB that the
+	// volume is to be grown to by the storage provisioner.
+	DesiredSize uint64 `bson:"desiredsize,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -171,6 +181,11 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// DesiredSize is required to implement Volume.
+func (v *volume) DesiredSize() (uint64, bool) {
+	return v.doc.DesiredSize, v.doc.DesiredSize != 0
+}
+
 // Volume is required to implement VolumeAttachment.
 func (v *volumeAttachment) Volume() names.VolumeTag {
 	return names.NewVolumeTag(v.doc.Volume)
@@ -483,6 +498,58 @@ func setVolumeAttachmentInfoOps(machine names.MachineTag, volume names.VolumeTag
 	}}
 }
 
+// DetachVolume marks the volume attachment identified by the specified
+// machine and volume tags as Dying, if it is Alive. The storage
+// provisioner will detach the volume from the machine, and then remove
+// the attachment from state.
+func (st *State) DetachVolume(machine names.MachineTag, volume names.VolumeTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach volume %s from machine %s", volume.Id(), machine.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		va, err := st.VolumeAttachment(machine, volume)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if va.Life() != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return detachVolumeOps(machine.Id(), volume.Id()), nil
+	}
+	return st.run(buildTxn)
+}
+
+func detachVolumeOps(machineId, volumeName string) []txn.Op {
+	return []txn.Op{{
+		C:      volumeAttachmentsC,
+		Id:     volumeAttachmentId(machineId, volumeName),
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+	}}
+}
+
+// RemoveVolumeAttachment removes the volume attachment from state.
+// RemoveVolumeAttachment will fail if the attachment is Alive.
+func (st *State) RemoveVolumeAttachment(machine names.MachineTag, volume names.VolumeTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove attachment of volume %s from machine %s", volume.Id(), machine.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		va, err := st.VolumeAttachment(machine, volume)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if va.Life() == Alive {
+			return nil, errors.New("volume attachment is not dying")
+		}
+		return []txn.Op{{
+			C:      volumeAttachmentsC,
+			Id:     volumeAttachmentId(machine.Id(), volume.Id()),
+			Assert: bson.D{{"life", bson.D{{"$ne", Alive}}}},
+			Remove: true,
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
 // setProvisionedVolumeInfo sets the initial info for newly
 // provisioned volumes. If non-empty, machineId must be the
 // machine ID associated with the volumes.
@@ -522,7 +589,49 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 				return nil, err
 			}
 		}
-		return setVolumeInfoOps(tag, info, unsetParams), nil
+		// If the volume was waiting to be grown, and has
+		// now reached the desired size, clear the request.
+		desiredSize, resized := v.DesiredSize()
+		resized = resized && info.Size >= desiredSize
+		return setVolumeInfoOps(tag, info, unsetParams, resized, desiredSize), nil
+	}
+	return st.run(buildTxn)
+}
+
+// ResizeVolume requests that the specified volume be grown to the
+// given size, in MiB. The volume must be provisioned, and the size
+// must be larger than the volume's current size. The storage
+// provisioner responsible for the volume will grow it, after which
+// the request is cleared.
+func (st *State) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.Volume(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.Errorf(
+				"new size %dMiB is not larger than current size %dMiB",
+				size, info.Size,
+			)
+		}
+		if desiredSize, ok := v.DesiredSize(); ok && desiredSize == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: append(isAliveDoc, bson.DocElem{"info.size", info.Size}),
+			Update: bson.D{{"$set", bson.D{{"desiredsize", size}}}},
+		}}, nil
 	}
 	return st.run(buildTxn)
 }
@@ -543,15 +652,23 @@ func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	return nil
 }
 
-func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool) []txn.Op {
+func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams, resized bool, desiredSize uint64) []txn.Op {
 	asserts := isAliveDoc
 	update := bson.D{
 		{"$set", bson.D{{"info", &info}}},
 	}
+	var unset bson.D
 	if unsetParams {
 		asserts = append(asserts, bson.DocElem{"info", bson.D{{"$exists", false}}})
 		asserts = append(asserts, bson.DocElem{"params", bson.D{{"$exists", true}}})
-		update = append(update, bson.DocElem{"$unset", bson.D{{"params", nil}}})
+		unset = append(unset, bson.DocElem{"params", nil})
+	}
+	if resized {
+		asserts = append(asserts, bson.DocElem{"desiredsize", desiredSize})
+		unset = append(unset, bson.DocElem{"desiredsize", nil})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
 	}
 	return []txn.Op{{
 		C:      volumesC,
@@ -226,6 +226,150 @@ func (s *VolumeStateSuite) TestSetVolumeInfoImmutable(c *gc.C) {
 	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
 }
 
+func (s *VolumeStateSuite) setupProvisionedVolume(c *gc.C) names.VolumeTag {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "vol-123",
+		Size:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return volume.VolumeTag()
+}
+
+func (s *VolumeStateSuite) assertVolumeDesiredSize(c *gc.C, tag names.VolumeTag, expect uint64) {
+	volume, err := s.State.Volume(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := volume.DesiredSize()
+	c.Assert(ok, gc.Equals, expect != 0)
+	c.Assert(size, gc.Equals, expect)
+}
+
+func (s *VolumeStateSuite) TestResizeVolume(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	s.assertVolumeDesiredSize(c, volumeTag, 0)
+
+	err := s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeDesiredSize(c, volumeTag, 2048)
+
+	// Recording a size smaller than the desired size
+	// leaves the request in place.
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "loop-pool",
+		Size:     1536,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeDesiredSize(c, volumeTag, 2048)
+
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "loop-pool",
+		Size:     2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeDesiredSize(c, volumeTag, 0)
+}
+
+func (s *VolumeStateSuite) TestResizeVolumeNotLarger(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	err := s.State.ResizeVolume(volumeTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0/0": new size 1024MiB is not larger than current size 1024MiB`)
+	s.assertVolumeDesiredSize(c, volumeTag, 0)
+}
+
+func (s *VolumeStateSuite) TestResizeVolumeNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeVolume(volume.VolumeTag(), 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0/0": volume "0/0" not provisioned`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeStateSuite) TestDetachVolume(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	machineTag := names.NewMachineTag("0")
+
+	err := s.State.RemoveVolumeAttachment(machineTag, volumeTag)
+	c.Assert(err, gc.ErrorMatches, `cannot remove attachment of volume 0/0 from machine 0: volume attachment is not dying`)
+
+	err = s.State.DetachVolume(machineTag, volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	attachment, err := s.State.VolumeAttachment(machineTag, volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+
+	// Detaching a Dying attachment is a no-op.
+	err = s.State.DetachVolume(machineTag, volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveVolumeAttachment(machineTag, volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeAttachment(machineTag, volumeTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a removed attachment is a no-op.
+	err = s.State.RemoveVolumeAttachment(machineTag, volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeStateSuite) TestWatchMachineVolumeResizes(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+
+	w := s.State.WatchMachineVolumeResizes(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	err := s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+
+	// Changes to the volume that do not alter
+	// the desired size are not reported.
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "loop-pool",
+		Size:     2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.State.ResizeVolume(volumeTag, 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+
+	w2 := s.State.WatchEnvironVolumeResizes()
+	defer testing.AssertStop(c, w2)
+	wc2 := testing.NewStringsWatcherC(c, s.State, w2)
+	wc2.AssertChangeInSingleEvent() // initial
+	wc2.AssertNoChange()
+}
+
+func (s *VolumeStateSuite) TestWatchVolume(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+
+	w := s.State.WatchVolume(volumeTag)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *VolumeStateSuite) TestWatchVolumeAttachment(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
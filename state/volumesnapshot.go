@@ -0,0 +1,264 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// VolumeSnapshot describes a point-in-time copy of a volume.
+type VolumeSnapshot interface {
+	// Id returns the ID of the snapshot, which is the name of the
+	// volume followed by "@" and a sequence number (e.g. "0/1@2").
+	Id() string
+
+	// Volume returns the tag of the volume that the snapshot is of.
+	Volume() names.VolumeTag
+
+	// Life returns the life of the snapshot.
+	Life() Life
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a
+	// NotProvisioned error if the snapshot has not yet been taken.
+	Info() (VolumeSnapshotInfo, error)
+}
+
+// VolumeSnapshotInfo describes information about a snapshot that has
+// been taken.
+type VolumeSnapshotInfo struct {
+	SnapshotId string    `bson:"snapshotid"`
+	Size       uint64    `bson:"size"`
+	Created    time.Time `bson:"created"`
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot.
+type volumeSnapshotDoc struct {
+	DocID string `bson:"_id"`
+
+	// Name is the volume name followed by "@" and a sequence number.
+	Name    string              `bson:"name"`
+	EnvUUID string              `bson:"env-uuid"`
+	Volume  string              `bson:"volumeid"`
+	Life    Life                `bson:"life"`
+	Info    *VolumeSnapshotInfo `bson:"info,omitempty"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Name
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.Volume)
+}
+
+// Life is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Life() Life {
+	return s.doc.Life
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Name)
+	}
+	return *s.doc.Info, nil
+}
+
+var validVolumeSnapshotSequence = regexp.MustCompile("^" + names.NumberSnippet + "$")
+
+// ParseVolumeSnapshotId parses a volume snapshot ID, returning the tag
+// of the volume that the snapshot is of.
+func ParseVolumeSnapshotId(id string) (names.VolumeTag, error) {
+	at := strings.LastIndex(id, "@")
+	if at == -1 || !names.IsValidVolume(id[:at]) || !validVolumeSnapshotSequence.MatchString(id[at+1:]) {
+		return names.VolumeTag{}, errors.NotValidf("volume snapshot ID %q", id)
+	}
+	return names.NewVolumeTag(id[:at]), nil
+}
+
+// VolumeSnapshot returns the VolumeSnapshot with the specified ID.
+func (st *State) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	coll, cleanup := st.getCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var s volumeSnapshot
+	err := coll.FindId(id).One(&s.doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshot")
+	}
+	return &s, nil
+}
+
+// VolumeSnapshots returns all of the snapshots of the specified volume.
+func (st *State) VolumeSnapshots(volume names.VolumeTag) ([]VolumeSnapshot, error) {
+	snapshots, err := st.volumeSnapshots(bson.D{{"volumeid", volume.Id()}})
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get snapshots for volume %q", volume.Id())
+	}
+	return snapshots, nil
+}
+
+// AllVolumeSnapshots returns all of the volume snapshots in the
+// environment.
+func (st *State) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	snapshots, err := st.volumeSnapshots(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	return snapshots, nil
+}
+
+func (st *State) volumeSnapshots(query bson.D) ([]VolumeSnapshot, error) {
+	coll, cleanup := st.getCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(query).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshots := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{doc}
+	}
+	return snapshots, nil
+}
+
+// AddVolumeSnapshot requests a snapshot of the specified volume, which
+// must be alive and provisioned. The snapshot is taken by the storage
+// provisioner responsible for the volume.
+func (st *State) AddVolumeSnapshot(volumeTag names.VolumeTag) (_ VolumeSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add snapshot of volume %q", volumeTag.Id())
+	seq, err := st.sequence("volumesnapshot")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := fmt.Sprintf("%s@%d", volumeTag.Id(), seq)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.Volume(volumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		if _, err := v.Info(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     volumeTag.Id(),
+			Assert: append(isAliveDoc, bson.DocElem{"info", bson.D{{"$exists", true}}}),
+		}, {
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: &volumeSnapshotDoc{
+				Name:   id,
+				Volume: volumeTag.Id(),
+				Life:   Alive,
+			},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, err
+	}
+	return st.VolumeSnapshot(id)
+}
+
+// SetVolumeSnapshotInfo records information about a snapshot that has
+// been taken.
+func (st *State) SetVolumeSnapshotInfo(id string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", id)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.VolumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if oldInfo, err := s.Info(); err == nil {
+			if oldInfo.SnapshotId != info.SnapshotId {
+				return nil, errors.Errorf(
+					"cannot change snapshot ID from %q to %q",
+					oldInfo.SnapshotId, info.SnapshotId,
+				)
+			}
+		} else if !errors.IsNotProvisioned(err) {
+			return nil, errors.Trace(err)
+		}
+		info.Created = info.Created.UTC()
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// DestroyVolumeSnapshot ensures that the snapshot is Dying, so that the
+// storage provisioner will destroy it and then remove it from state.
+func (st *State) DestroyVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.VolumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// RemoveVolumeSnapshot removes a Dying snapshot from state. It is
+// called once the snapshot has been destroyed by the storage provider.
+func (st *State) RemoveVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.VolumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() == Alive {
+			return nil, errors.New("volume snapshot is not dying")
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"life", bson.D{{"$ne", Alive}}}},
+			Remove: true,
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
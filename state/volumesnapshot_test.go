@@ -0,0 +1,215 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type VolumeSnapshotSuite struct {
+	StorageStateSuiteBase
+	service *state.Service
+}
+
+var _ = gc.Suite(&VolumeSnapshotSuite{})
+
+func (s *VolumeSnapshotSuite) SetUpTest(c *gc.C) {
+	s.StorageStateSuiteBase.SetUpTest(c)
+	s.service = s.setupMixedScopeStorageService(c, "block")
+	s.addUnit(c)
+}
+
+// addUnit adds a unit of the mixed-scope storage service, with one
+// environment-scoped and two machine-scoped volumes, and provisions
+// its volumes.
+func (s *VolumeSnapshotSuite) addUnit(c *gc.C) {
+	u, err := s.service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumes, err := s.State.AllVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, v := range volumes {
+		if _, err := v.Info(); err == nil {
+			continue
+		}
+		err := s.State.SetVolumeInfo(v.VolumeTag(), state.VolumeInfo{
+			VolumeId: "vol-" + v.Tag().Id(),
+			Size:     1024,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshot(c *gc.C) {
+	volumeTag := names.NewVolumeTag("0/1")
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+	c.Assert(snapshot.Life(), gc.Equals, state.Alive)
+	c.Assert(snapshot.Id(), gc.Matches, `0/1@\d+`)
+	parsed, err := state.ParseVolumeSnapshotId(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parsed, gc.Equals, volumeTag)
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	other, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(other.Id(), gc.Not(gc.Equals), snapshot.Id())
+
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshots, err := s.State.VolumeSnapshots(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 2)
+	snapshots, err = s.State.AllVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 3)
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshotUnprovisioned(c *gc.C) {
+	u, err := s.service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("1/4"))
+	c.Assert(err, gc.ErrorMatches, `cannot add snapshot of volume "1/4": volume "1/4" not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshotNotFound(c *gc.C) {
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("42"))
+	c.Assert(err, gc.ErrorMatches, `cannot add snapshot of volume "42": volume "42" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeSnapshotSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	snapshot, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	created := time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC)
+	info := state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024, Created: created}
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshot, err = s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	infoGot, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infoGot.SnapshotId, gc.Equals, "snap-0")
+	c.Assert(infoGot.Size, gc.Equals, uint64(1024))
+	c.Assert(infoGot.Created.Equal(created), jc.IsTrue)
+
+	info.SnapshotId = "snap-1"
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), info)
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot ".*": cannot change snapshot ID from "snap-0" to "snap-1"`)
+
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot ".*": snapshot ID not set`)
+}
+
+func (s *VolumeSnapshotSuite) TestDestroyAndRemoveVolumeSnapshot(c *gc.C) {
+	snapshot, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	id := snapshot.Id()
+
+	err = s.State.RemoveVolumeSnapshot(id)
+	c.Assert(err, gc.ErrorMatches, `cannot remove volume snapshot ".*": volume snapshot is not dying`)
+
+	err = s.State.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.State.VolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Life(), gc.Equals, state.Dying)
+
+	// Destroying a Dying snapshot is a no-op.
+	err = s.State.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Info cannot be set once the snapshot is Dying.
+	err = s.State.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{SnapshotId: "snap-0"})
+	c.Assert(err, gc.NotNil)
+
+	err = s.State.RemoveVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeSnapshot(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a removed snapshot is a no-op.
+	err = s.State.RemoveVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotSuite) TestWatchEnvironVolumeSnapshots(c *gc.C) {
+	first, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("0/1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchEnvironVolumeSnapshots()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent(first.Id()) // initial
+	wc.AssertNoChange()
+
+	err = s.State.DestroyVolumeSnapshot(first.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(first.Id())
+	wc.AssertNoChange()
+
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("0/2"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *VolumeSnapshotSuite) TestWatchMachineVolumeSnapshots(c *gc.C) {
+	first, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0/1"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchMachineVolumeSnapshots(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent(first.Id()) // initial
+	wc.AssertNoChange()
+
+	second, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0/2"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(second.Id())
+	wc.AssertNoChange()
+
+	// Snapshots of other machines' volumes are not reported.
+	s.addUnit(c)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("1/4"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *VolumeSnapshotSuite) TestParseVolumeSnapshotId(c *gc.C) {
+	for id, volume := range map[string]string{
+		"0@1":         "0",
+		"0/1@2":       "0/1",
+		"0/lxc/0/1@2": "0/lxc/0/1",
+	} {
+		tag, err := state.ParseVolumeSnapshotId(id)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(tag, gc.Equals, names.NewVolumeTag(volume))
+	}
+	for _, id := range []string{"0", "0@", "@1", "0@x", "0/1@2@3"} {
+		_, err := state.ParseVolumeSnapshotId(id)
+		c.Check(err, gc.ErrorMatches, `volume snapshot ID ".*" not valid`)
+	}
+}
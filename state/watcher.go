@@ -226,6 +226,78 @@ func (st *State) watchMachineStorage(m names.MachineTag, collection string) Stri
 	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
 
+// WatchEnvironVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of environment-scoped
+// volumes.
+func (st *State) WatchEnvironVolumeSnapshots() StringsWatcher {
+	pattern := fmt.Sprintf("^%s@%s$", st.docID(names.NumberSnippet), names.NumberSnippet)
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(k, "/")
+	}
+	return newLifecycleWatcher(st, volumeSnapshotsC, members, filter, nil)
+}
+
+// WatchMachineVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of volumes scoped to the
+// specified machine.
+func (st *State) WatchMachineVolumeSnapshots(m names.MachineTag) StringsWatcher {
+	pattern := fmt.Sprintf(
+		"^%s/%s@%s$", st.docID(m.Id()), names.NumberSnippet, names.NumberSnippet,
+	)
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	prefix := m.Id() + "/"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix) && !strings.Contains(k[len(prefix):], "/")
+	}
+	return newLifecycleWatcher(st, volumeSnapshotsC, members, filter, nil)
+}
+
+// WatchEnvironVolumeResizes returns a StringsWatcher that notifies of
+// requests to grow environment-scoped volumes. The first event contains
+// the IDs of all environment-scoped volumes waiting to be resized, and
+// subsequent events contain the IDs of volumes whose desired size has
+// changed.
+func (st *State) WatchEnvironVolumeResizes() StringsWatcher {
+	pattern := fmt.Sprintf("^%s$", st.docID(names.NumberSnippet))
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(k, "/")
+	}
+	return newVolumeResizesWatcher(st, members, filter)
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// requests to grow volumes scoped to the specified machine. The first
+// event contains the IDs of all such volumes waiting to be resized, and
+// subsequent events contain the IDs of volumes whose desired size has
+// changed.
+func (st *State) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	pattern := fmt.Sprintf("^%s/%s$", st.docID(m.Id()), names.NumberSnippet)
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	prefix := m.Id() + "/"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix)
+	}
+	return newVolumeResizesWatcher(st, members, filter)
+}
+
 // WatchEnvironVolumeAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all volume attachments related to environ-
 // scoped volumes.
@@ -650,6 +722,113 @@ func (w *minUnitsWatcher) Changes() <-chan []string {
 	return w.out
 }
 
+// volumeResizesWatcher notifies of volumes waiting to be grown to a
+// desired size. The first event returned by the watcher is the set of
+// matching volumes with a desired size; subsequent events are generated
+// when a matching volume's desired size is set or changed.
+type volumeResizesWatcher struct {
+	commonWatcher
+	members bson.D
+	filter  func(interface{}) bool
+	known   map[string]uint64
+	out     chan []string
+}
+
+var _ Watcher = (*volumeResizesWatcher)(nil)
+
+func newVolumeResizesWatcher(st *State, members bson.D, filter func(interface{}) bool) StringsWatcher {
+	w := &volumeResizesWatcher{
+		commonWatcher: commonWatcher{st: st},
+		members:       members,
+		filter:        filter,
+		known:         make(map[string]uint64),
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+func (w *volumeResizesWatcher) initial() (set.Strings, error) {
+	volumeNames := make(set.Strings)
+	var doc volumeDoc
+	volumes, closer := w.st.getCollection(volumesC)
+	defer closer()
+
+	query := append(bson.D{{"desiredsize", bson.D{{"$exists", true}}}}, w.members...)
+	iter := volumes.Find(query).Select(bson.D{{"name", 1}, {"desiredsize", 1}}).Iter()
+	for iter.Next(&doc) {
+		w.known[doc.Name] = doc.DesiredSize
+		volumeNames.Add(doc.Name)
+	}
+	return volumeNames, iter.Close()
+}
+
+func (w *volumeResizesWatcher) merge(volumeNames set.Strings, change watcher.Change) error {
+	volumeName := w.st.localID(change.Id.(string))
+	if change.Revno == -1 {
+		delete(w.known, volumeName)
+		volumeNames.Remove(volumeName)
+		return nil
+	}
+	var doc volumeDoc
+	volumes, closer := w.st.getCollection(volumesC)
+	defer closer()
+	err := volumes.FindId(change.Id).Select(bson.D{{"desiredsize", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		delete(w.known, volumeName)
+		volumeNames.Remove(volumeName)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if doc.DesiredSize == 0 {
+		delete(w.known, volumeName)
+		return nil
+	}
+	if w.known[volumeName] != doc.DesiredSize {
+		w.known[volumeName] = doc.DesiredSize
+		volumeNames.Add(volumeName)
+	}
+	return nil
+}
+
+func (w *volumeResizesWatcher) loop() (err error) {
+	ch := make(chan watcher.Change)
+	w.st.watcher.WatchCollectionWithFilter(volumesC, ch, w.filter)
+	defer w.st.watcher.UnwatchCollection(volumesC, ch)
+	volumeNames, err := w.initial()
+	if err != nil {
+		return err
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.st.watcher.Dead():
+			return stateWatcherDeadError(w.st.watcher.Err())
+		case change := <-ch:
+			if err = w.merge(volumeNames, change); err != nil {
+				return err
+			}
+			if !volumeNames.IsEmpty() {
+				out = w.out
+			}
+		case out <- volumeNames.Values():
+			out = nil
+			volumeNames = set.NewStrings()
+		}
+	}
+}
+
+func (w *volumeResizesWatcher) Changes() <-chan []string {
+	return w.out
+}
+
 func (st *State) isForStateEnv(id interface{}) bool {
 	_, err := st.strictLocalID(id.(string))
 	return err == nil
@@ -1431,6 +1610,17 @@ func (st *State) WatchFilesystemAttachment(m names.MachineTag, f names.Filesyste
 	return newEntityWatcher(st, filesystemAttachmentsC, st.docID(id))
 }
 
+// WatchVolume returns a watcher for observing changes to a volume.
+func (st *State) WatchVolume(v names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(st, volumesC, st.docID(v.Id()))
+}
+
+// WatchFilesystem returns a watcher for observing changes to a
+// filesystem.
+func (st *State) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(st, filesystemsC, st.docID(f.Id()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's service configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -2233,7 +2423,7 @@ func (st *State) WatchActionResults() StringsWatcher {
 // that notifies on new ActionResults being added for the ActionRecevers
 // being watched.
 func (st *State) WatchActionResultsFilteredBy(receivers ...ActionReceiver) StringsWatcher {
-	return newActionStatusWatcher(st, receivers, []ActionStatus{ActionCompleted, ActionCancelled, ActionFailed}...)
+	return newActionStatusWatcher(st, receivers, []ActionStatus{ActionCompleted, ActionCancelled, ActionFailed, ActionTimedOut}...)
 }
 
 // machineInterfacesWatcher notifies about changes to all network interfaces
@@ -0,0 +1,150 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// WorkerReport describes a worker run by an agent, and the workers it
+// runs in turn, as published by the agent.
+type WorkerReport struct {
+	Name       string
+	Inputs     []string
+	State      string
+	StartCount int
+	LastError  string
+	Workers    []WorkerReport
+}
+
+// workerReportsDoc holds the reports last published by an agent.
+type workerReportsDoc struct {
+	DocID   string            `bson:"_id"`
+	EnvUUID string            `bson:"env-uuid"`
+	Reports []workerReportDoc `bson:"reports"`
+	Updated time.Time         `bson:"updated"`
+}
+
+type workerReportDoc struct {
+	Name       string            `bson:"name"`
+	Inputs     []string          `bson:"inputs,omitempty"`
+	State      string            `bson:"state"`
+	StartCount int               `bson:"start-count"`
+	LastError  string            `bson:"last-error,omitempty"`
+	Workers    []workerReportDoc `bson:"workers,omitempty"`
+}
+
+func newWorkerReportDocs(reports []WorkerReport) []workerReportDoc {
+	var docs []workerReportDoc
+	for _, r := range reports {
+		docs = append(docs, workerReportDoc{
+			Name:       r.Name,
+			Inputs:     r.Inputs,
+			State:      r.State,
+			StartCount: r.StartCount,
+			LastError:  r.LastError,
+			Workers:    newWorkerReportDocs(r.Workers),
+		})
+	}
+	return docs
+}
+
+func workerReportsFromDocs(docs []workerReportDoc) []WorkerReport {
+	var reports []WorkerReport
+	for _, doc := range docs {
+		reports = append(reports, WorkerReport{
+			Name:       doc.Name,
+			Inputs:     doc.Inputs,
+			State:      doc.State,
+			StartCount: doc.StartCount,
+			LastError:  doc.LastError,
+			Workers:    workerReportsFromDocs(doc.Workers),
+		})
+	}
+	return reports
+}
+
+// SetWorkerReports records the reports of the workers run by the
+// machine's agent, replacing those previously recorded.
+func (m *Machine) SetWorkerReports(reports []WorkerReport) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set worker reports of machine %v", m)
+	workerReports, closer := m.st.getCollection(workerReportsC)
+	defer closer()
+
+	docs := newWorkerReportDocs(reports)
+	updated := time.Now()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life == Dead {
+			return nil, ErrDead
+		}
+		ops := []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		count, err := workerReports.FindId(m.globalKey()).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count == 0 {
+			ops = append(ops, txn.Op{
+				C:      workerReportsC,
+				Id:     m.globalKey(),
+				Assert: txn.DocMissing,
+				Insert: &workerReportsDoc{
+					Reports: docs,
+					Updated: updated,
+				},
+			})
+		} else {
+			ops = append(ops, txn.Op{
+				C:      workerReportsC,
+				Id:     m.globalKey(),
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"reports", docs},
+					{"updated", updated},
+				}}},
+			})
+		}
+		return ops, nil
+	}
+	return m.st.run(buildTxn)
+}
+
+// WorkerReports returns the reports of the workers run by the
+// machine's agent, as last recorded, and the time they were recorded.
+// It returns an error satisfying errors.IsNotFound if the agent has
+// not recorded any reports.
+func (m *Machine) WorkerReports() ([]WorkerReport, time.Time, error) {
+	workerReports, closer := m.st.getCollection(workerReportsC)
+	defer closer()
+
+	var doc workerReportsDoc
+	err := workerReports.FindId(m.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, time.Time{}, errors.NotFoundf("worker reports for machine %v", m)
+	} else if err != nil {
+		return nil, time.Time{}, errors.Annotatef(err, "cannot get worker reports for machine %v", m)
+	}
+	return workerReportsFromDocs(doc.Reports), doc.Updated, nil
+}
+
+func removeWorkerReportsOp(st *State, globalKey string) txn.Op {
+	return txn.Op{
+		C:      workerReportsC,
+		Id:     st.docID(globalKey),
+		Remove: true,
+	}
+}
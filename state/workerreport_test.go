@@ -0,0 +1,84 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type WorkerReportSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&WorkerReportSuite{})
+
+func (s *WorkerReportSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+var testWorkerReports = []state.WorkerReport{{
+	Name:       "api",
+	State:      "started",
+	StartCount: 2,
+	LastError:  "connection is shut down",
+	Workers: []state.WorkerReport{{
+		Name:       "machiner",
+		Inputs:     []string{"api"},
+		State:      "starting",
+		StartCount: 1,
+	}},
+}}
+
+func (s *WorkerReportSuite) TestWorkerReportsNotFound(c *gc.C) {
+	_, _, err := s.machine.WorkerReports()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "worker reports for machine 0 not found")
+}
+
+func (s *WorkerReportSuite) TestSetWorkerReports(c *gc.C) {
+	before := time.Now().Add(-time.Second)
+	err := s.machine.SetWorkerReports(testWorkerReports)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, updated, err := s.machine.WorkerReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, testWorkerReports)
+	c.Assert(updated.After(before), jc.IsTrue)
+
+	// Setting the reports again replaces them.
+	err = s.machine.SetWorkerReports(testWorkerReports[0].Workers)
+	c.Assert(err, jc.ErrorIsNil)
+	reports, _, err = s.machine.WorkerReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, testWorkerReports[0].Workers)
+}
+
+func (s *WorkerReportSuite) TestSetWorkerReportsDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetWorkerReports(testWorkerReports)
+	c.Assert(err, gc.ErrorMatches, "cannot set worker reports of machine 0: not found or dead")
+}
+
+func (s *WorkerReportSuite) TestRemoveMachineRemovesWorkerReports(c *gc.C) {
+	err := s.machine.SetWorkerReports(testWorkerReports)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = s.machine.WorkerReports()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
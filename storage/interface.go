@@ -67,6 +67,23 @@ type Provider interface {
 	ValidateConfig(*Config) error
 }
 
+// SharedFilesystemProvider is an optional interface that may be
+// implemented by a Provider whose filesystems exist independently of
+// Juju, and may be attached to several machines concurrently, such as
+// network filesystems.
+//
+// Shared filesystems are recorded as provisioned when they are added
+// to state, and are attached by the storage provisioner of each machine
+// that they are attached to.
+type SharedFilesystemProvider interface {
+	Provider
+
+	// SharedFilesystemId returns the provider-specific ID of the
+	// shared filesystem described by the specified storage pool
+	// configuration.
+	SharedFilesystemId(*Config) (string, error)
+}
+
 // VolumeSource provides an interface for creating, destroying, describing,
 // attaching and detaching volumes in the environment. A VolumeSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -115,6 +132,39 @@ type VolumeSource interface {
 	DetachVolumes(params []VolumeAttachmentParams) error
 }
 
+// VolumeResizer is an optional interface that may be implemented by a
+// VolumeSource that can grow its volumes while they are in use.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters,
+	// returning an error for each volume that could not be resized.
+	ResizeVolumes(params []VolumeResizeParams) []error
+}
+
+// FilesystemResizer is an optional interface that may be implemented by
+// a FilesystemSource that can grow its filesystems while they are in use.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters, returning the filesystems as resized.
+	ResizeFilesystems(params []FilesystemResizeParams) ([]Filesystem, error)
+}
+
+// VolumeSnapshotter is an optional interface that may be implemented by
+// a VolumeSource that can take point-in-time copies, or snapshots, of
+// its volumes.
+type VolumeSnapshotter interface {
+	// CreateSnapshots takes snapshots of the volumes with the specified
+	// parameters.
+	CreateSnapshots(params []VolumeSnapshotParams) ([]VolumeSnapshot, error)
+
+	// ListSnapshots returns the snapshots taken of the volumes with the
+	// specified provider volume IDs.
+	ListSnapshots(volIds []string) ([]VolumeSnapshot, error)
+
+	// DestroySnapshots destroys the snapshots with the specified
+	// provider snapshot IDs.
+	DestroySnapshots(snapshotIds []string) []error
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -17,6 +17,7 @@ func CommonProviders() map[storage.ProviderType]storage.Provider {
 		LoopProviderType:   &loopProvider{logAndExec},
 		RootfsProviderType: &rootfsProvider{logAndExec},
 		TmpfsProviderType:  &tmpfsProvider{logAndExec},
+		NFSProviderType:    &nfsProvider{logAndExec},
 	}
 }
 
@@ -26,5 +26,6 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 		provider.LoopProviderType,
 		provider.RootfsProviderType,
 		provider.TmpfsProviderType,
+		provider.NFSProviderType,
 	})
 }
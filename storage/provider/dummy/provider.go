@@ -80,3 +80,24 @@ func (p *StorageProvider) Scope() storage.Scope {
 func (p *StorageProvider) Dynamic() bool {
 	return p.IsDynamic
 }
+
+var _ storage.SharedFilesystemProvider = (*SharedStorageProvider)(nil)
+
+// SharedStorageProvider is an implementation of storage.SharedFilesystemProvider,
+// suitable for testing.
+type SharedStorageProvider struct {
+	StorageProvider
+
+	// SharedFilesystemIdFunc will be called by SharedFilesystemId, if
+	// non-nil; otherwise SharedFilesystemId returns the name of the
+	// storage pool.
+	SharedFilesystemIdFunc func(*storage.Config) (string, error)
+}
+
+// SharedFilesystemId is defined on storage.SharedFilesystemProvider.
+func (p *SharedStorageProvider) SharedFilesystemId(providerConfig *storage.Config) (string, error) {
+	if p.SharedFilesystemIdFunc != nil {
+		return p.SharedFilesystemIdFunc(providerConfig)
+	}
+	return providerConfig.Name(), nil
+}
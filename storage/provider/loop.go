@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
@@ -98,6 +99,8 @@ type loopVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*loopVolumeSource)(nil)
+var _ storage.VolumeResizer = (*loopVolumeSource)(nil)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.Volume, []storage.VolumeAttachment, error) {
@@ -210,6 +213,140 @@ func (lvs *loopVolumeSource) DetachVolumes([]storage.VolumeAttachmentParams) err
 	return errors.NotSupportedf("detaching loop devices")
 }
 
+// ResizeVolumes is defined on the VolumeResizer interface.
+//
+// Loop volumes are grown by extending their backing files, and then
+// having the kernel re-read the size of the associated loop devices.
+func (lvs *loopVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) []error {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := lvs.resizeVolume(arg); err != nil {
+			results[i] = errors.Annotatef(err, "resizing %q", arg.VolumeId)
+		}
+	}
+	return results
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.VolumeResizeParams) error {
+	if _, err := names.ParseVolumeTag(arg.VolumeId); err != nil {
+		return errors.Errorf("invalid loop volume ID %q", arg.VolumeId)
+	}
+	loopFilePath := lvs.volumeFilePath(arg.VolumeId)
+	info, err := os.Stat(loopFilePath)
+	if err != nil {
+		return errors.Annotate(err, "reading loop backing file")
+	}
+	if uint64(info.Size()) > arg.Size*1024*1024 {
+		return errors.Errorf("cannot shrink loop volume to %dMiB", arg.Size)
+	}
+	if _, err := lvs.run("truncate", "-s", fmt.Sprintf("%dM", arg.Size), loopFilePath); err != nil {
+		return errors.Annotate(err, "extending loop backing file")
+	}
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		if _, err := lvs.run("losetup", "-c", path.Join("/dev", deviceName)); err != nil {
+			return errors.Annotatef(err, "updating size of loop device %q", deviceName)
+		}
+	}
+	return nil
+}
+
+// loopSnapshotSeparator separates the ID of a loop volume from the
+// Juju-assigned snapshot ID in the name of a snapshot file.
+const loopSnapshotSeparator = ".snapshot-"
+
+func (lvs *loopVolumeSource) snapshotDir() string {
+	return filepath.Join(lvs.storageDir, "snapshots")
+}
+
+// CreateSnapshots is defined on the VolumeSnapshotter interface.
+//
+// Loop volume snapshots are sparse copies of the volumes' backing files,
+// and are intended only for testing.
+func (lvs *loopVolumeSource) CreateSnapshots(args []storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error) {
+	if err := os.MkdirAll(lvs.snapshotDir(), 0755); err != nil {
+		return nil, errors.Annotate(err, "creating snapshot directory")
+	}
+	snapshots := make([]storage.VolumeSnapshot, len(args))
+	for i, arg := range args {
+		snapshot, err := lvs.createSnapshot(arg)
+		if err != nil {
+			return nil, errors.Annotatef(err, "creating snapshot of %q", arg.VolumeId)
+		}
+		snapshots[i] = snapshot
+	}
+	return snapshots, nil
+}
+
+func (lvs *loopVolumeSource) createSnapshot(arg storage.VolumeSnapshotParams) (storage.VolumeSnapshot, error) {
+	if _, err := names.ParseVolumeTag(arg.VolumeId); err != nil {
+		return storage.VolumeSnapshot{}, errors.Errorf("invalid loop volume ID %q", arg.VolumeId)
+	}
+	snapshotId := arg.VolumeId + loopSnapshotSeparator + strings.Replace(arg.Id, "/", "-", -1)
+	snapshotPath := filepath.Join(lvs.snapshotDir(), snapshotId)
+	if _, err := lvs.run("cp", "--sparse=always", lvs.volumeFilePath(arg.VolumeId), snapshotPath); err != nil {
+		return storage.VolumeSnapshot{}, errors.Annotate(err, "copying loop backing file")
+	}
+	info, err := os.Stat(snapshotPath)
+	if err != nil {
+		return storage.VolumeSnapshot{}, errors.Trace(err)
+	}
+	return loopSnapshot(arg.VolumeId, info), nil
+}
+
+// ListSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) ListSnapshots(volumeIds []string) ([]storage.VolumeSnapshot, error) {
+	infos, err := ioutil.ReadDir(lvs.snapshotDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "reading snapshot directory")
+	}
+	var snapshots []storage.VolumeSnapshot
+	for _, info := range infos {
+		pos := strings.Index(info.Name(), loopSnapshotSeparator)
+		if pos == -1 {
+			continue
+		}
+		volumeId := info.Name()[:pos]
+		for _, id := range volumeIds {
+			if id == volumeId {
+				snapshots = append(snapshots, loopSnapshot(volumeId, info))
+				break
+			}
+		}
+	}
+	return snapshots, nil
+}
+
+// DestroySnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) DestroySnapshots(snapshotIds []string) []error {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if strings.ContainsRune(snapshotId, os.PathSeparator) || !strings.Contains(snapshotId, loopSnapshotSeparator) {
+			results[i] = errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+			continue
+		}
+		err := os.Remove(filepath.Join(lvs.snapshotDir(), snapshotId))
+		if err != nil && !os.IsNotExist(err) {
+			results[i] = errors.Annotatef(err, "destroying %q", snapshotId)
+		}
+	}
+	return results
+}
+
+func loopSnapshot(volumeId string, info os.FileInfo) storage.VolumeSnapshot {
+	return storage.VolumeSnapshot{
+		SnapshotId: info.Name(),
+		VolumeId:   volumeId,
+		Size:       uint64(info.Size()) / (1024 * 1024),
+		Created:    info.ModTime(),
+	}
+}
+
 // createBlockFile creates a file at the specified path, with the
 // given size in mebibytes.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
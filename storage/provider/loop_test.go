@@ -5,6 +5,7 @@ package provider_test
 
 import (
 	"io/ioutil"
+	"os"
 	"path/filepath"
 
 	"github.com/juju/errors"
@@ -189,3 +190,167 @@ func (s *loopSuite) TestDetachVolumes(c *gc.C) {
 	err := source.DetachVolumes(nil)
 	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
 }
+
+func (s *loopSuite) loopVolumeResizer(c *gc.C) storage.VolumeResizer {
+	source := s.loopVolumeSource(c)
+	resizer, ok := source.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+	return resizer
+}
+
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	resizer := s.loopVolumeResizer(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, make([]byte, 1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.commands.expect("truncate", "-s", "4M", fileName)
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+
+	errs := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}})
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], jc.ErrorIsNil)
+}
+
+func (s *loopSuite) TestResizeVolumesShrink(c *gc.C) {
+	resizer := s.loopVolumeResizer(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, make([]byte, 2*1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     1,
+	}})
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], gc.ErrorMatches, `resizing "volume-0": cannot shrink loop volume to 1MiB`)
+}
+
+func (s *loopSuite) TestResizeVolumesInvalidVolumeId(c *gc.C) {
+	resizer := s.loopVolumeResizer(c)
+	errs := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "../super/important/stuff",
+		Size:     4,
+	}})
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], gc.ErrorMatches, `resizing "../super/important/stuff": invalid loop volume ID "../super/important/stuff"`)
+}
+
+func (s *loopSuite) loopVolumeSnapshotter(c *gc.C) storage.VolumeSnapshotter {
+	source := s.loopVolumeSource(c)
+	snapshotter, ok := source.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	return snapshotter
+}
+
+func (s *loopSuite) TestCreateSnapshots(c *gc.C) {
+	snapshotter := s.loopVolumeSnapshotter(c)
+	snapshotPath := filepath.Join(s.storageDir, "snapshots", "volume-0-1.snapshot-0-1@2")
+	s.commands.expect("cp", "--sparse=always", filepath.Join(s.storageDir, "volume-0-1"), snapshotPath)
+
+	// The mock command runner does not copy, so create the snapshot
+	// file as cp would have.
+	err := os.MkdirAll(filepath.Dir(snapshotPath), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(snapshotPath, make([]byte, 2*1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshots, err := snapshotter.CreateSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0/1@2",
+		Volume:   names.NewVolumeTag("0/1"),
+		VolumeId: "volume-0-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	c.Assert(snapshots[0].SnapshotId, gc.Equals, "volume-0-1.snapshot-0-1@2")
+	c.Assert(snapshots[0].VolumeId, gc.Equals, "volume-0-1")
+	c.Assert(snapshots[0].Size, gc.Equals, uint64(2))
+}
+
+func (s *loopSuite) TestCreateSnapshotsCopyFails(c *gc.C) {
+	snapshotter := s.loopVolumeSnapshotter(c)
+	cmd := s.commands.expect("cp", "--sparse=always",
+		filepath.Join(s.storageDir, "volume-0"),
+		filepath.Join(s.storageDir, "snapshots", "volume-0.snapshot-0@0"),
+	)
+	cmd.respond("", errors.New("no space left on device"))
+
+	_, err := snapshotter.CreateSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0@0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}})
+	c.Assert(err, gc.ErrorMatches, `creating snapshot of "volume-0": copying loop backing file: no space left on device`)
+}
+
+func (s *loopSuite) TestCreateSnapshotsInvalidVolumeId(c *gc.C) {
+	snapshotter := s.loopVolumeSnapshotter(c)
+	_, err := snapshotter.CreateSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0@0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "../super/important/stuff",
+	}})
+	c.Assert(err, gc.ErrorMatches, `.* invalid loop volume ID "\.\./super/important/stuff"`)
+}
+
+func (s *loopSuite) TestListSnapshots(c *gc.C) {
+	snapshotter := s.loopVolumeSnapshotter(c)
+	snapshots, err := snapshotter.ListSnapshots([]string{"volume-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 0)
+
+	snapshotDir := filepath.Join(s.storageDir, "snapshots")
+	err = os.MkdirAll(snapshotDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, name := range []string{
+		"volume-0.snapshot-0@0",
+		"volume-0.snapshot-0@1",
+		"volume-1.snapshot-1@0",
+		"volume-10.snapshot-10@0",
+	} {
+		err := ioutil.WriteFile(filepath.Join(snapshotDir, name), nil, 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	snapshots, err = snapshotter.ListSnapshots([]string{"volume-0", "volume-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, snapshot := range snapshots {
+		ids = append(ids, snapshot.VolumeId+" "+snapshot.SnapshotId)
+	}
+	c.Assert(ids, jc.SameContents, []string{
+		"volume-0 volume-0.snapshot-0@0",
+		"volume-0 volume-0.snapshot-0@1",
+		"volume-1 volume-1.snapshot-1@0",
+	})
+}
+
+func (s *loopSuite) TestDestroySnapshots(c *gc.C) {
+	snapshotter := s.loopVolumeSnapshotter(c)
+	snapshotDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.MkdirAll(snapshotDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotPath := filepath.Join(snapshotDir, "volume-0.snapshot-0@0")
+	err = ioutil.WriteFile(snapshotPath, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs := snapshotter.DestroySnapshots([]string{
+		"volume-0.snapshot-0@0",
+		"volume-0.snapshot-0@1", // already gone
+		"../super/important/stuff",
+	})
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `invalid loop snapshot ID "\.\./super/important/stuff"`)
+	_, err = os.Stat(snapshotPath)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
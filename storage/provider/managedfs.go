@@ -29,6 +29,8 @@ type managedFilesystemSource struct {
 	filesystems        map[names.FilesystemTag]storage.Filesystem
 }
 
+var _ storage.FilesystemResizer = (*managedFilesystemSource)(nil)
+
 // NewManagedFilesystemSource returns a storage.FilesystemSource that manages
 // filesystems on block devices on the host machine.
 //
@@ -140,6 +142,45 @@ func (s *managedFilesystemSource) DetachFilesystems(args []storage.FilesystemAtt
 	return errors.NotImplementedf("DetachFilesystems")
 }
 
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+//
+// The filesystems are grown to fill their backing block devices,
+// which must already have been grown to at least the requested size.
+func (s *managedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]storage.Filesystem, error) {
+	filesystems := make([]storage.Filesystem, len(args))
+	for i, arg := range args {
+		filesystem, err := s.resizeFilesystem(arg)
+		if err != nil {
+			return nil, errors.Annotatef(err, "resizing filesystem %s", arg.Tag.Id())
+		}
+		filesystems[i] = filesystem
+	}
+	return filesystems, nil
+}
+
+func (s *managedFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) (storage.Filesystem, error) {
+	blockDevice, err := s.backingVolumeBlockDevice(arg.Volume)
+	if err != nil {
+		return storage.Filesystem{}, errors.Trace(err)
+	}
+	if blockDevice.Size < arg.Size {
+		return storage.Filesystem{}, errors.Errorf(
+			"backing-volume %s is %dMiB, smaller than %dMiB",
+			arg.Volume.Id(), blockDevice.Size, arg.Size,
+		)
+	}
+	devicePath := s.devicePath(blockDevice)
+	if err := resizeFilesystem(s.run, devicePath); err != nil {
+		return storage.Filesystem{}, errors.Trace(err)
+	}
+	return storage.Filesystem{
+		arg.Tag,
+		arg.Volume,
+		arg.FilesystemId,
+		blockDevice.Size,
+	}, nil
+}
+
 func createFilesystem(run runCommandFunc, devicePath string) error {
 	logger.Debugf("attempting to create filesystem on %q", devicePath)
 	mkfscmd := "mkfs." + defaultFilesystemType
@@ -151,6 +192,15 @@ func createFilesystem(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
+func resizeFilesystem(run runCommandFunc, devicePath string) error {
+	logger.Debugf("attempting to resize filesystem on %q", devicePath)
+	if _, err := run("resize2fs", devicePath); err != nil {
+		return errors.Annotate(err, "resize2fs failed")
+	}
+	logger.Infof("resized filesystem on %q", devicePath)
+	return nil
+}
+
 func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoint string) error {
 	logger.Debugf("attempting to mount filesystem on %q at %q", devicePath, mountPoint)
 	if err := dirFuncs.mkDirAll(mountPoint, 0755); err != nil {
@@ -95,6 +95,47 @@ func (s *managedfsSuite) TestCreateFilesystemsNoBlockDevice(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "creating filesystem 0/0: backing-volume 0 is not yet attached")
 }
 
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	s.commands.expect("resize2fs", "/dev/sda")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		HardwareId: "capncrunch",
+		Size:       4,
+	}
+	resizer, ok := source.(storage.FilesystemResizer)
+	c.Assert(ok, jc.IsTrue)
+	filesystems, err := resizer.ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		Volume:       names.NewVolumeTag("0"),
+		FilesystemId: "filesystem-0-0",
+		Size:         3,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystems, jc.DeepEquals, []storage.Filesystem{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		Volume:       names.NewVolumeTag("0"),
+		FilesystemId: "filesystem-0-0",
+		Size:         4,
+	}})
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsBlockDeviceTooSmall(c *gc.C) {
+	source := s.initSource(c)
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       2,
+	}
+	resizer := source.(storage.FilesystemResizer)
+	_, err := resizer.ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		Size:   3,
+	}})
+	c.Assert(err, gc.ErrorMatches, "resizing filesystem 0/0: backing-volume 0 is 2MiB, smaller than 3MiB")
+}
+
 func (s *managedfsSuite) TestAttachFilesystems(c *gc.C) {
 	source := s.initSource(c)
 	s.commands.expect("mount", "/dev/sda", "/in/the/place")
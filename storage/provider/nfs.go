@@ -0,0 +1,226 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	NFSProviderType = storage.ProviderType("nfs")
+
+	// NFSServer is the name of the pool config attribute that
+	// specifies the address of the NFS server. If the server is
+	// not specified, the export is taken to be a directory on
+	// the local machine, which is bind-mounted in place of an
+	// NFS mount.
+	NFSServer = "server"
+
+	// NFSExport is the name of the pool config attribute that
+	// specifies the absolute path of the exported directory.
+	NFSExport = "export"
+)
+
+// nfsProviders create storage sources which provide access to
+// network filesystems exported by an NFS server. Each storage
+// pool describes a single export, which may be attached to the
+// machines of several units concurrently.
+type nfsProvider struct {
+	// run is a function type used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider                 = (*nfsProvider)(nil)
+	_ storage.SharedFilesystemProvider = (*nfsProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (p *nfsProvider) ValidateConfig(cfg *storage.Config) error {
+	export, _ := cfg.ValueString(NFSExport)
+	if export == "" {
+		return errors.New("NFS export not specified")
+	}
+	if !filepath.IsAbs(export) {
+		return errors.Errorf("NFS export %q must be an absolute path", export)
+	}
+	if server, ok := cfg.Attrs()[NFSServer]; ok {
+		if _, ok := server.(string); !ok {
+			return errors.Errorf("NFS server must be a string, got %T", server)
+		}
+	}
+	return nil
+}
+
+// SharedFilesystemId is defined on the SharedFilesystemProvider interface.
+//
+// The filesystem ID is the source passed to "mount": "server:/export",
+// or just "/export" if there is no NFS server.
+func (p *nfsProvider) SharedFilesystemId(cfg *storage.Config) (string, error) {
+	if err := p.ValidateConfig(cfg); err != nil {
+		return "", errors.Trace(err)
+	}
+	export, _ := cfg.ValueString(NFSExport)
+	server, _ := cfg.ValueString(NFSServer)
+	if server == "" {
+		return export, nil
+	}
+	return server + ":" + export, nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *nfsProvider) VolumeSource(environConfig *config.Config, providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *nfsProvider) FilesystemSource(environConfig *config.Config, sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	// The source requires no configuration: everything required
+	// to mount an export is encoded in the filesystem ID.
+	return &nfsFilesystemSource{&osDirFuncs{p.run}, p.run}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*nfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*nfsProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on the Provider interface.
+func (*nfsProvider) Dynamic() bool {
+	return true
+}
+
+type nfsFilesystemSource struct {
+	dirFuncs dirFuncs
+	run      runCommandFunc
+}
+
+var _ storage.FilesystemSource = (*nfsFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	// The size of an NFS export is not under our control.
+	return nil
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.Filesystem, error) {
+	// NFS exports exist independently of Juju; there is nothing
+	// to create. Shared filesystems are recorded in state as
+	// provisioned, so this should not ordinarily be called.
+	filesystems := make([]storage.Filesystem, len(args))
+	for i, arg := range args {
+		filesystems[i] = storage.Filesystem{
+			Tag:    arg.Tag,
+			Volume: arg.Volume,
+			Size:   arg.Size,
+		}
+	}
+	return filesystems, nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.FilesystemAttachment, error) {
+	attachments := make([]storage.FilesystemAttachment, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			return nil, errors.Annotatef(err, "attaching %s", names.ReadableString(arg.Filesystem))
+		}
+		attachments[i] = attachment
+	}
+	return attachments, nil
+}
+
+func (s *nfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (storage.FilesystemAttachment, error) {
+	path := arg.Path
+	if path == "" {
+		return storage.FilesystemAttachment{}, errNoMountPoint
+	}
+	if arg.FilesystemId == "" {
+		return storage.FilesystemAttachment{}, errors.New("filesystem ID not specified")
+	}
+	if err := ensureDir(s.dirFuncs, path); err != nil {
+		return storage.FilesystemAttachment{}, errors.Trace(err)
+	}
+
+	// Check if the mount already exists.
+	mountPoint, err := s.dirFuncs.mountPoint(path)
+	if err != nil {
+		return storage.FilesystemAttachment{}, errors.Annotate(err, "getting mount point")
+	}
+	if mountPoint != path {
+		if err := ensureEmptyDir(s.dirFuncs, path); err != nil {
+			return storage.FilesystemAttachment{}, errors.Trace(err)
+		}
+		if err := s.mount(arg.FilesystemId, path); err != nil {
+			return storage.FilesystemAttachment{}, errors.Annotatef(err, "cannot mount %q", arg.FilesystemId)
+		}
+	}
+
+	return storage.FilesystemAttachment{
+		Filesystem: arg.Filesystem,
+		Machine:    arg.Machine,
+		Path:       path,
+	}, nil
+}
+
+// mount mounts the NFS export with the specified filesystem ID at
+// the target path. If the filesystem ID is an absolute path, then
+// there is no NFS server, and the local directory is bind-mounted
+// in its place.
+func (s *nfsFilesystemSource) mount(filesystemId, target string) error {
+	if filepath.IsAbs(filesystemId) {
+		return s.dirFuncs.bindMount(filesystemId, target)
+	}
+	_, err := s.run("mount", "-t", "nfs", filesystemId, target)
+	return err
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) error {
+	for _, arg := range args {
+		if err := s.detachFilesystem(arg); err != nil {
+			return errors.Annotatef(err, "detaching %s", names.ReadableString(arg.Filesystem))
+		}
+	}
+	return nil
+}
+
+func (s *nfsFilesystemSource) detachFilesystem(arg storage.FilesystemAttachmentParams) error {
+	path := arg.Path
+	if path == "" {
+		return errNoMountPoint
+	}
+	if _, err := s.dirFuncs.lstat(path); os.IsNotExist(err) {
+		// The filesystem was never mounted.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	mountPoint, err := s.dirFuncs.mountPoint(path)
+	if err != nil {
+		return errors.Annotate(err, "getting mount point")
+	}
+	if mountPoint != path {
+		// The filesystem is not mounted.
+		return nil
+	}
+	if _, err := s.run("umount", path); err != nil {
+		return errors.Annotatef(err, "cannot unmount %q", path)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&nfsSuite{})
+
+type nfsSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+}
+
+func (s *nfsSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Tests relevant only on *nix systems")
+	}
+	s.BaseSuite.SetUpTest(c)
+}
+
+func (s *nfsSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *nfsSuite) nfsProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.NFSProvider(s.commands.run)
+}
+
+func (s *nfsSuite) nfsFilesystemSource(c *gc.C) (storage.FilesystemSource, *provider.MockDirFuncs) {
+	s.commands = &mockRunCommand{c: c}
+	return provider.NFSFilesystemSource(s.commands.run)
+}
+
+func (s *nfsSuite) TestValidateConfig(c *gc.C) {
+	p := s.nfsProvider(c)
+	for _, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{},
+		err:   "NFS export not specified",
+	}, {
+		attrs: map[string]interface{}{"export": "srv/nfs"},
+		err:   `NFS export "srv/nfs" must be an absolute path`,
+	}, {
+		attrs: map[string]interface{}{"export": "/srv/nfs", "server": 123},
+		err:   "NFS server must be a string, got int",
+	}, {
+		attrs: map[string]interface{}{"export": "/srv/nfs"},
+	}, {
+		attrs: map[string]interface{}{"export": "/srv/nfs", "server": "10.0.0.1"},
+	}} {
+		cfg, err := storage.NewConfig("name", provider.NFSProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *nfsSuite) TestSharedFilesystemId(c *gc.C) {
+	p := s.nfsProvider(c).(storage.SharedFilesystemProvider)
+	cfg, err := storage.NewConfig("name", provider.NFSProviderType, map[string]interface{}{
+		"server": "10.0.0.1",
+		"export": "/srv/nfs",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := p.SharedFilesystemId(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "10.0.0.1:/srv/nfs")
+
+	cfg, err = storage.NewConfig("name", provider.NFSProviderType, map[string]interface{}{
+		"export": "/srv/nfs",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	id, err = p.SharedFilesystemId(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "/srv/nfs")
+
+	cfg, err = storage.NewConfig("name", provider.NFSProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.SharedFilesystemId(cfg)
+	c.Assert(err, gc.ErrorMatches, "NFS export not specified")
+}
+
+func (s *nfsSuite) TestSupports(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestScope(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+}
+
+func (s *nfsSuite) TestVolumeSource(c *gc.C) {
+	p := s.nfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.NFSProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(nil, cfg)
+	c.Assert(err, gc.ErrorMatches, "volumes not supported")
+}
+
+func (s *nfsSuite) TestCreateFilesystems(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	filesystems, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystems, jc.DeepEquals, []storage.Filesystem{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 1024,
+	}})
+}
+
+func (s *nfsSuite) TestAttachFilesystems(c *gc.C) {
+	source, dirFuncs := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=target", "/srv/data")
+	cmd.respond("header\n/", nil)
+	s.commands.expect("mount", "-t", "nfs", "10.0.0.1:/srv/nfs", "/srv/data")
+
+	attachments, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "10.0.0.1:/srv/nfs",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, jc.DeepEquals, []storage.FilesystemAttachment{{
+		Filesystem: names.NewFilesystemTag("6"),
+		Machine:    names.NewMachineTag("0"),
+		Path:       "/srv/data",
+	}})
+	c.Assert(dirFuncs.Dirs.Contains("/srv/data"), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsBindMount(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=target", "/srv/data")
+	cmd.respond("header\n/", nil)
+	s.commands.expect("mount", "--bind", "/srv/nfs", "/srv/data")
+
+	_, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "/srv/nfs",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsAlreadyMounted(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=target", "/srv/data")
+	cmd.respond("header\n/srv/data", nil)
+
+	_, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "10.0.0.1:/srv/nfs",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsMountFails(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=target", "/srv/data")
+	cmd.respond("header\n/", nil)
+	cmd = s.commands.expect("mount", "-t", "nfs", "10.0.0.1:/srv/nfs", "/srv/data")
+	cmd.respond("", errors.New("mount failed"))
+
+	_, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "10.0.0.1:/srv/nfs",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, gc.ErrorMatches, `attaching filesystem 6: cannot mount "10.0.0.1:/srv/nfs": mount failed`)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsNoPathSpecified(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	_, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "10.0.0.1:/srv/nfs",
+	}})
+	c.Assert(err, gc.ErrorMatches, "attaching filesystem 6: filesystem mount point not specified")
+}
+
+func (s *nfsSuite) TestAttachFilesystemsNoFilesystemId(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	_, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("6"),
+		Path:       "/srv/data",
+	}})
+	c.Assert(err, gc.ErrorMatches, "attaching filesystem 6: filesystem ID not specified")
+}
+
+func (s *nfsSuite) TestDetachFilesystems(c *gc.C) {
+	source, dirFuncs := s.nfsFilesystemSource(c)
+	dirFuncs.Dirs.Add("/srv/data")
+	cmd := s.commands.expect("df", "--output=target", "/srv/data")
+	cmd.respond("header\n/srv/data", nil)
+	s.commands.expect("umount", "/srv/data")
+
+	err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "10.0.0.1:/srv/nfs",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *nfsSuite) TestDetachFilesystemsNotMounted(c *gc.C) {
+	source, dirFuncs := s.nfsFilesystemSource(c)
+	dirFuncs.Dirs.Add("/srv/data")
+	cmd := s.commands.expect("df", "--output=target", "/srv/data")
+	cmd.respond("header\n/", nil)
+
+	err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "10.0.0.1:/srv/nfs",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *nfsSuite) TestDetachFilesystemsNoDirectory(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "10.0.0.1:/srv/nfs",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *nfsSuite) TestDetachFilesystemsUnmountFails(c *gc.C) {
+	source, dirFuncs := s.nfsFilesystemSource(c)
+	dirFuncs.Dirs.Add("/srv/data")
+	cmd := s.commands.expect("df", "--output=target", "/srv/data")
+	cmd.respond("header\n/srv/data", nil)
+	cmd = s.commands.expect("umount", "/srv/data")
+	cmd.respond("", errors.New("device is busy"))
+
+	err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "10.0.0.1:/srv/nfs",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, gc.ErrorMatches, `detaching filesystem 6: cannot unmount "/srv/data": device is busy`)
+}
@@ -0,0 +1,38 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import "github.com/juju/names"
+
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the volume.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Size is the minimum size that the volume is to be grown to,
+	// in MiB.
+	Size uint64
+}
+
+// FilesystemResizeParams is a set of parameters for growing a
+// filesystem.
+type FilesystemResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the filesystem.
+	Tag names.FilesystemTag
+
+	// Volume is the tag of the volume that backs the filesystem,
+	// if any.
+	Volume names.VolumeTag
+
+	// FilesystemId is the unique provider-supplied ID for the
+	// filesystem.
+	FilesystemId string
+
+	// Size is the minimum size that the filesystem is to be grown
+	// to, in MiB.
+	Size uint64
+}
@@ -0,0 +1,42 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"time"
+
+	"github.com/juju/names"
+)
+
+// VolumeSnapshotParams is a set of parameters for taking a snapshot of
+// a volume.
+type VolumeSnapshotParams struct {
+	// Id is a unique ID assigned by Juju for the requested snapshot.
+	Id string
+
+	// Volume is the unique tag assigned by Juju for the volume that is
+	// to be snapshotted.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume that
+	// is to be snapshotted.
+	VolumeId string
+}
+
+// VolumeSnapshot describes a point-in-time copy of a volume.
+type VolumeSnapshot struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the provider-supplied ID of the volume that the
+	// snapshot was taken of.
+	VolumeId string
+
+	// Size is the size of the volume when the snapshot was taken,
+	// in MiB.
+	Size uint64
+
+	// Created is the time at which the snapshot was taken.
+	Created time.Time
+}
@@ -52,6 +52,7 @@ type EnvUserParams struct {
 	User        string
 	DisplayName string
 	CreatedBy   names.Tag
+	Access      state.EnvironmentAccess
 }
 
 // CharmParams defines the parameters for creating a charm.
@@ -187,8 +188,11 @@ func (factory *Factory) MakeEnvUser(c *gc.C, params *EnvUserParams) *state.Envir
 		c.Assert(err, jc.ErrorIsNil)
 		params.CreatedBy = env.Owner()
 	}
+	if params.Access == "" {
+		params.Access = state.EnvironmentAdminAccess
+	}
 	createdByUserTag := params.CreatedBy.(names.UserTag)
-	envUser, err := factory.st.AddEnvironmentUser(names.NewUserTag(params.User), createdByUserTag, params.DisplayName)
+	envUser, err := factory.st.AddEnvironmentUserWithAccess(names.NewUserTag(params.User), createdByUserTag, params.DisplayName, params.Access)
 	c.Assert(err, jc.ErrorIsNil)
 	return envUser
 }
@@ -0,0 +1,240 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses schedules written as cron expressions, and
+// computes the times at which they are due.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule holds a parsed cron expression.
+type Schedule struct {
+	spec   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domAll and dowAll record whether the day of month and day of
+	// week fields match every day, whether given as "*", "*/1" or a
+	// full range such as "1-31" or "0-6". As in cron, when both
+	// fields are restricted a day matches if either of them matches.
+	domAll bool
+	dowAll bool
+}
+
+// field describes the range of values allowed in one field of a cron
+// expression.
+type field struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{
+		name: "month", min: 1, max: 12,
+		names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"},
+	}
+	dowField = field{
+		name: "day of week", min: 0, max: 6,
+		names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"},
+	}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a cron expression made up of five fields: minute,
+// hour, day of month, month and day of week. Each field may be "*",
+// a number, a range such as "1-5", a list such as "1,3,5", and may
+// have a step such as "*/15" or "0-30/10". Months and days of the
+// week may also be given by their three letter names, and the
+// expressions "@hourly", "@daily", "@weekly", "@monthly" and
+// "@yearly" are accepted as shorthands. A field that matches every
+// value, such as "*/1" or "0-6", places no restriction on the
+// schedule; when neither the day of month nor the day of week is
+// unrestricted, a day matches if either of them does.
+func Parse(spec string) (*Schedule, error) {
+	expr := strings.TrimSpace(spec)
+	if strings.HasPrefix(expr, "@") {
+		expanded, ok := macros[strings.ToLower(expr)]
+		if !ok {
+			return nil, errors.NotValidf("cron expression %q", spec)
+		}
+		expr = expanded
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("invalid cron expression %q: expected 5 fields, got %d", spec, len(fields))
+	}
+	s := &Schedule{spec: spec}
+	for i, f := range []struct {
+		bits  *uint64
+		field field
+	}{
+		{&s.minute, minuteField},
+		{&s.hour, hourField},
+		{&s.dom, domField},
+		{&s.month, monthField},
+		{&s.dow, dowField},
+	} {
+		bits, err := f.field.parse(fields[i])
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid cron expression %q", spec)
+		}
+		*f.bits = bits
+	}
+	s.domAll = s.dom == domField.all()
+	s.dowAll = s.dow == dowField.all()
+	return s, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// parse returns the set of values described by the text of a field,
+// as a bit mask.
+func (f field) parse(text string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(text, ",") {
+		rangeText, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangeText = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.Errorf("invalid step %q in %s field", part[i+1:], f.name)
+			}
+		}
+		var lo, hi int
+		switch {
+		case rangeText == "*":
+			lo, hi = f.min, f.max
+		case strings.Contains(rangeText, "-"):
+			bounds := strings.SplitN(rangeText, "-", 2)
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, errors.Errorf("invalid range %q in %s field", rangeText, f.name)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rangeText); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if step > 1 {
+				// "n/step" means from n to the end of the range.
+				hi = f.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	if f.name == dowField.name && bits&(1<<7) != 0 {
+		bits = bits&^(1<<7) | 1
+	}
+	return bits, nil
+}
+
+// all returns the bit mask holding every value of the field.
+func (f field) all() uint64 {
+	var bits uint64
+	for v := f.min; v <= f.max; v++ {
+		bits |= 1 << uint(v)
+	}
+	return bits
+}
+
+// value parses a single value of the field, given either as a number
+// or by name.
+func (f field) value(text string) (int, error) {
+	for i, name := range f.names {
+		if strings.ToLower(text) == name {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, errors.Errorf("invalid value %q in %s field", text, f.name)
+	}
+	max := f.max
+	if f.name == dowField.name {
+		// Sunday may be given as either 0 or 7; parse folds 7
+		// into 0 once any range ending in it has been expanded.
+		max = 7
+	}
+	if v < f.min || v > max {
+		return 0, errors.Errorf("%s %d out of range %d-%d", f.name, v, f.min, max)
+	}
+	return v, nil
+}
+
+// maxSearchYears bounds the search for the next time a schedule is
+// due, so that a schedule that can never be due (such as "0 0 30 2 *")
+// does not search forever.
+const maxSearchYears = 5
+
+// Next returns the first time after t at which the schedule is due,
+// in t's location. Schedules are due at the start of a minute. If the
+// schedule will never be due, Next returns the zero time.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAll || s.dowAll {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,158 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	stdtesting "testing"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/utils/cron"
+)
+
+func Test(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type cronSuite struct{}
+
+var _ = gc.Suite(&cronSuite{})
+
+// from is a Wednesday.
+var from = time.Date(2015, 7, 1, 10, 17, 30, 0, time.UTC)
+
+func (*cronSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		next []string
+	}{{
+		spec: "* * * * *",
+		next: []string{"2015-07-01 10:18", "2015-07-01 10:19"},
+	}, {
+		spec: "*/15 * * * *",
+		next: []string{"2015-07-01 10:30", "2015-07-01 10:45", "2015-07-01 11:00"},
+	}, {
+		spec: "30 2 * * *",
+		next: []string{"2015-07-02 02:30", "2015-07-03 02:30"},
+	}, {
+		spec: "0 9-17/4 * * mon-fri",
+		next: []string{"2015-07-01 13:00", "2015-07-01 17:00", "2015-07-02 09:00"},
+	}, {
+		spec: "0 0 * * 0",
+		next: []string{"2015-07-05 00:00", "2015-07-12 00:00"},
+	}, {
+		spec: "0 0 * * 7",
+		next: []string{"2015-07-05 00:00"},
+	}, {
+		spec: "0 0 * * 5-7",
+		next: []string{"2015-07-03 00:00", "2015-07-04 00:00", "2015-07-05 00:00", "2015-07-10 00:00"},
+	}, {
+		spec: "0 0 * * 1-7",
+		next: []string{"2015-07-02 00:00", "2015-07-03 00:00", "2015-07-04 00:00", "2015-07-05 00:00", "2015-07-06 00:00"},
+	}, {
+		spec: "0 0 * * 6-7/1",
+		next: []string{"2015-07-04 00:00", "2015-07-05 00:00", "2015-07-11 00:00"},
+	}, {
+		spec: "0 0 * * fri-sat,7",
+		next: []string{"2015-07-03 00:00", "2015-07-04 00:00", "2015-07-05 00:00", "2015-07-10 00:00"},
+	}, {
+		spec: "0 0 31 * *",
+		next: []string{"2015-07-31 00:00", "2015-08-31 00:00", "2015-10-31 00:00"},
+	}, {
+		spec: "0 0 13 * fri",
+		next: []string{"2015-07-03 00:00", "2015-07-10 00:00", "2015-07-13 00:00"},
+	}, {
+		spec: "0 0 13 * 0-6",
+		next: []string{"2015-07-13 00:00", "2015-08-13 00:00"},
+	}, {
+		spec: "0 0 13 * */1",
+		next: []string{"2015-07-13 00:00", "2015-08-13 00:00"},
+	}, {
+		spec: "0 0 1-31 * fri",
+		next: []string{"2015-07-03 00:00", "2015-07-10 00:00"},
+	}, {
+		spec: "0 0 */1 * sun-sat",
+		next: []string{"2015-07-02 00:00", "2015-07-03 00:00"},
+	}, {
+		spec: "0 0 13 * */2",
+		next: []string{"2015-07-02 00:00", "2015-07-04 00:00", "2015-07-05 00:00", "2015-07-07 00:00", "2015-07-09 00:00", "2015-07-11 00:00", "2015-07-12 00:00", "2015-07-13 00:00"},
+	}, {
+		spec: "0 0 29 feb *",
+		next: []string{"2016-02-29 00:00", "2020-02-29 00:00"},
+	}, {
+		spec: "15,45 1 1 jan,jul *",
+		next: []string{"2016-01-01 01:15", "2016-01-01 01:45", "2016-07-01 01:15"},
+	}, {
+		spec: "@daily",
+		next: []string{"2015-07-02 00:00"},
+	}, {
+		spec: "@hourly",
+		next: []string{"2015-07-01 11:00", "2015-07-01 12:00"},
+	}, {
+		spec: "@monthly",
+		next: []string{"2015-08-01 00:00"},
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		schedule, err := cron.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.String(), gc.Equals, test.spec)
+		t := from
+		for _, expect := range test.next {
+			t = schedule.Next(t)
+			c.Check(t.Format("2006-01-02 15:04"), gc.Equals, expect)
+		}
+	}
+}
+
+func (*cronSuite) TestNextNever(c *gc.C) {
+	schedule, err := cron.Parse("0 0 30 feb *")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Next(from).IsZero(), jc.IsTrue)
+}
+
+func (*cronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  `invalid cron expression "": expected 5 fields, got 0`,
+	}, {
+		spec: "* * * *",
+		err:  `invalid cron expression "\* \* \* \*": expected 5 fields, got 4`,
+	}, {
+		spec: "60 * * * *",
+		err:  `invalid cron expression "60 \* \* \* \*": minute 60 out of range 0-59`,
+	}, {
+		spec: "* 24 * * *",
+		err:  `invalid cron expression "\* 24 \* \* \*": hour 24 out of range 0-23`,
+	}, {
+		spec: "* * 0 * *",
+		err:  `invalid cron expression "\* \* 0 \* \*": day of month 0 out of range 1-31`,
+	}, {
+		spec: "* * * foo *",
+		err:  `invalid cron expression "\* \* \* foo \*": invalid value "foo" in month field`,
+	}, {
+		spec: "*/0 * * * *",
+		err:  `invalid cron expression "\*/0 \* \* \* \*": invalid step "0" in minute field`,
+	}, {
+		spec: "* * * * 8",
+		err:  `invalid cron expression "\* \* \* \* 8": day of week 8 out of range 0-7`,
+	}, {
+		spec: "* * * * 7-1",
+		err:  `invalid cron expression "\* \* \* \* 7-1": invalid range "7-1" in day of week field`,
+	}, {
+		spec: "* 5-1 * * *",
+		err:  `invalid cron expression "\* 5-1 \* \* \*": invalid range "5-1" in hour field`,
+	}, {
+		spec: "@fortnightly",
+		err:  `cron expression "@fortnightly" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := cron.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,360 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package prometheus implements counters, gauges and histograms that
+// can be exposed in the Prometheus text exposition format.
+//
+// Metrics are created at package level by the code they instrument,
+// registered with the default registry, and written out by the API
+// server's metrics endpoint.
+package prometheus
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// ContentType is the content type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4"
+
+// Collector is implemented by metrics that may be registered with a
+// Registry.
+type Collector interface {
+	// Name returns the name of the metric.
+	Name() string
+
+	// write writes the current values of the metric in the text
+	// exposition format.
+	write(w *bytes.Buffer)
+}
+
+// Registry holds a set of metrics.
+type Registry struct {
+	mu         sync.Mutex
+	collectors map[string]Collector
+}
+
+// NewRegistry returns a new empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		collectors: make(map[string]Collector),
+	}
+}
+
+// DefaultRegistry holds the metrics of the running process.
+var DefaultRegistry = NewRegistry()
+
+// Register adds the given metric to the registry. It is an error to
+// register two metrics with the same name.
+func (r *Registry) Register(c Collector) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.collectors[c.Name()]; ok {
+		return errors.AlreadyExistsf("metric %q", c.Name())
+	}
+	r.collectors[c.Name()] = c
+	return nil
+}
+
+// MustRegister adds the given metrics to the default registry,
+// panicking if any cannot be registered.
+func MustRegister(cs ...Collector) {
+	for _, c := range cs {
+		if err := DefaultRegistry.Register(c); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// WriteText writes the current values of all the registered metrics,
+// ordered by name, in the text exposition format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	collectors := make(map[string]Collector, len(r.collectors))
+	for name, c := range r.collectors {
+		collectors[name] = c
+	}
+	r.mu.Unlock()
+
+	sort.Strings(names)
+	var buf bytes.Buffer
+	for _, name := range names {
+		collectors[name].write(&buf)
+	}
+	_, err := w.Write(buf.Bytes())
+	return errors.Trace(err)
+}
+
+// desc holds the description common to all metrics.
+type desc struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+}
+
+// Name implements Collector.Name.
+func (d *desc) Name() string {
+	return d.name
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// writeHeader writes the HELP and TYPE lines of the metric.
+func (d *desc) writeHeader(w *bytes.Buffer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, helpEscaper.Replace(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, d.kind)
+}
+
+// writeSample writes a single sample of the metric. The extra label,
+// if not empty, follows the metric's own labels.
+func (d *desc) writeSample(w *bytes.Buffer, suffix string, labelValues []string, extraName, extraValue string, value float64) {
+	w.WriteString(d.name)
+	w.WriteString(suffix)
+	var labels []string
+	for i, name := range d.labelNames {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, name, labelValueEscaper.Replace(labelValues[i])))
+	}
+	if extraName != "" {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, extraName, extraValue))
+	}
+	if len(labels) > 0 {
+		w.WriteString("{" + strings.Join(labels, ",") + "}")
+	}
+	w.WriteString(" " + formatFloat(value) + "\n")
+}
+
+// checkLabels panics if the wrong number of label values is given;
+// this is always a programming error.
+func (d *desc) checkLabels(labelValues []string) {
+	if len(labelValues) != len(d.labelNames) {
+		panic(fmt.Sprintf("metric %q: expected %d label values, got %d", d.name, len(d.labelNames), len(labelValues)))
+	}
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// labelKey returns a key identifying a set of label values.
+func labelKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+// values holds a value for each set of label values.
+type values struct {
+	desc
+	mu     sync.Mutex
+	values map[string]*value
+}
+
+type value struct {
+	labelValues []string
+	v           float64
+}
+
+func newValues(name, help, kind string, labelNames []string) *values {
+	return &values{
+		desc: desc{
+			name:       name,
+			help:       help,
+			kind:       kind,
+			labelNames: labelNames,
+		},
+		values: make(map[string]*value),
+	}
+}
+
+// update applies f to the value with the given label values.
+func (vs *values) update(labelValues []string, f func(float64) float64) {
+	vs.checkLabels(labelValues)
+	key := labelKey(labelValues)
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	v, ok := vs.values[key]
+	if !ok {
+		v = &value{labelValues: append([]string(nil), labelValues...)}
+		vs.values[key] = v
+	}
+	v.v = f(v.v)
+}
+
+func (vs *values) write(w *bytes.Buffer) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	vs.writeHeader(w)
+	if len(vs.labelNames) == 0 && len(vs.values) == 0 {
+		// A metric without labels always has a value.
+		vs.writeSample(w, "", nil, "", "", 0)
+		return
+	}
+	keys := make([]string, 0, len(vs.values))
+	for key := range vs.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		v := vs.values[key]
+		vs.writeSample(w, "", v.labelValues, "", "", v.v)
+	}
+}
+
+// CounterVec is a counter, partitioned by the values of its labels,
+// whose values only ever increase.
+type CounterVec struct {
+	*values
+}
+
+// NewCounterVec returns a new counter with the given labels.
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{newValues(name, help, "counter", labelNames)}
+}
+
+// Inc increments the counter with the given label values.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds the given non-negative amount to the counter with the
+// given label values.
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("metric %q: counter cannot decrease", c.name))
+	}
+	c.update(labelValues, func(v float64) float64 { return v + delta })
+}
+
+// GaugeVec is a gauge, partitioned by the values of its labels,
+// whose values may go up and down.
+type GaugeVec struct {
+	*values
+}
+
+// NewGaugeVec returns a new gauge with the given labels.
+func NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{newValues(name, help, "gauge", labelNames)}
+}
+
+// Set sets the gauge with the given label values.
+func (g *GaugeVec) Set(v float64, labelValues ...string) {
+	g.update(labelValues, func(float64) float64 { return v })
+}
+
+// Add adds the given amount to the gauge with the given label
+// values.
+func (g *GaugeVec) Add(delta float64, labelValues ...string) {
+	g.update(labelValues, func(v float64) float64 { return v + delta })
+}
+
+// Inc increments the gauge with the given label values.
+func (g *GaugeVec) Inc(labelValues ...string) {
+	g.Add(1, labelValues...)
+}
+
+// Dec decrements the gauge with the given label values.
+func (g *GaugeVec) Dec(labelValues ...string) {
+	g.Add(-1, labelValues...)
+}
+
+// DefaultBuckets holds the default upper bounds of histogram buckets,
+// suitable for measuring request durations in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// HistogramVec counts observations in buckets, partitioned by the
+// values of its labels.
+type HistogramVec struct {
+	desc
+	buckets []float64
+
+	mu         sync.Mutex
+	histograms map[string]*histogram
+}
+
+type histogram struct {
+	labelValues []string
+	counts      []uint64 // per bucket, not cumulative
+	count       uint64
+	sum         float64
+}
+
+// NewHistogramVec returns a new histogram with the given bucket upper
+// bounds, which must be in increasing order, and labels.
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	if !sort.Float64sAreSorted(buckets) {
+		panic(fmt.Sprintf("metric %q: buckets not in increasing order", name))
+	}
+	return &HistogramVec{
+		desc: desc{
+			name:       name,
+			help:       help,
+			kind:       "histogram",
+			labelNames: labelNames,
+		},
+		buckets:    buckets,
+		histograms: make(map[string]*histogram),
+	}
+}
+
+// Observe records a value in the histogram with the given label
+// values.
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	h.checkLabels(labelValues)
+	key := labelKey(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hist, ok := h.histograms[key]
+	if !ok {
+		hist = &histogram{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.histograms[key] = hist
+	}
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		hist.counts[i]++
+	}
+	hist.count++
+	hist.sum += v
+}
+
+func (h *HistogramVec) write(w *bytes.Buffer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.writeHeader(w)
+	keys := make([]string, 0, len(h.histograms))
+	for key := range h.histograms {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		hist := h.histograms[key]
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += hist.counts[i]
+			h.writeSample(w, "_bucket", hist.labelValues, "le", formatFloat(bound), float64(cumulative))
+		}
+		h.writeSample(w, "_bucket", hist.labelValues, "le", "+Inf", float64(hist.count))
+		h.writeSample(w, "_sum", hist.labelValues, "", "", hist.sum)
+		h.writeSample(w, "_count", hist.labelValues, "", "", float64(hist.count))
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package prometheus_test
+
+import (
+	"bytes"
+	stdtesting "testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/utils/prometheus"
+)
+
+func Test(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type prometheusSuite struct{}
+
+var _ = gc.Suite(&prometheusSuite{})
+
+func writeText(c *gc.C, r *prometheus.Registry) string {
+	var buf bytes.Buffer
+	err := r.WriteText(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.String()
+}
+
+func (*prometheusSuite) TestCounter(c *gc.C) {
+	r := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec("requests_total", "Number of requests.", "method", "code")
+	err := r.Register(counter)
+	c.Assert(err, jc.ErrorIsNil)
+	counter.Inc("GET", "200")
+	counter.Inc("GET", "200")
+	counter.Add(3, "POST", `a"b\c`)
+	c.Assert(writeText(c, r), gc.Equals, ""+
+		"# HELP requests_total Number of requests.\n"+
+		"# TYPE requests_total counter\n"+
+		`requests_total{method="GET",code="200"} 2`+"\n"+
+		`requests_total{method="POST",code="a\"b\\c"} 3`+"\n",
+	)
+}
+
+func (*prometheusSuite) TestCounterCannotDecrease(c *gc.C) {
+	counter := prometheus.NewCounterVec("requests_total", "Number of requests.")
+	c.Assert(func() { counter.Add(-1) }, gc.PanicMatches, `metric "requests_total": counter cannot decrease`)
+}
+
+func (*prometheusSuite) TestWrongLabels(c *gc.C) {
+	counter := prometheus.NewCounterVec("requests_total", "Number of requests.", "method")
+	c.Assert(func() { counter.Inc() }, gc.PanicMatches, `metric "requests_total": expected 1 label values, got 0`)
+}
+
+func (*prometheusSuite) TestGauge(c *gc.C) {
+	r := prometheus.NewRegistry()
+	gauge := prometheus.NewGaugeVec("connections", "Number of connections.\nAll of them.")
+	err := r.Register(gauge)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(writeText(c, r), gc.Equals, ""+
+		"# HELP connections Number of connections.\\nAll of them.\n"+
+		"# TYPE connections gauge\n"+
+		"connections 0\n",
+	)
+	gauge.Inc()
+	gauge.Inc()
+	gauge.Dec()
+	gauge.Add(0.5)
+	c.Assert(writeText(c, r), jc.Contains, "\nconnections 1.5\n")
+	gauge.Set(7)
+	c.Assert(writeText(c, r), jc.Contains, "\nconnections 7\n")
+}
+
+func (*prometheusSuite) TestHistogram(c *gc.C) {
+	r := prometheus.NewRegistry()
+	hist := prometheus.NewHistogramVec("duration_seconds", "Request duration.", []float64{0.1, 1}, "method")
+	err := r.Register(hist)
+	c.Assert(err, jc.ErrorIsNil)
+	hist.Observe(0.05, "GET")
+	hist.Observe(0.1, "GET")
+	hist.Observe(0.5, "GET")
+	hist.Observe(2, "GET")
+	c.Assert(writeText(c, r), gc.Equals, ""+
+		"# HELP duration_seconds Request duration.\n"+
+		"# TYPE duration_seconds histogram\n"+
+		`duration_seconds_bucket{method="GET",le="0.1"} 2`+"\n"+
+		`duration_seconds_bucket{method="GET",le="1"} 3`+"\n"+
+		`duration_seconds_bucket{method="GET",le="+Inf"} 4`+"\n"+
+		`duration_seconds_sum{method="GET"} 2.65`+"\n"+
+		`duration_seconds_count{method="GET"} 4`+"\n",
+	)
+}
+
+func (*prometheusSuite) TestRegistryOrderAndDuplicates(c *gc.C) {
+	r := prometheus.NewRegistry()
+	err := r.Register(prometheus.NewGaugeVec("b", "B."))
+	c.Assert(err, jc.ErrorIsNil)
+	err = r.Register(prometheus.NewCounterVec("a", "A."))
+	c.Assert(err, jc.ErrorIsNil)
+	err = r.Register(prometheus.NewCounterVec("a", "A again."))
+	c.Assert(err, gc.ErrorMatches, `metric "a" already exists`)
+	c.Assert(writeText(c, r), gc.Equals, ""+
+		"# HELP a A.\n"+
+		"# TYPE a counter\n"+
+		"a 0\n"+
+		"# HELP b B.\n"+
+		"# TYPE b gauge\n"+
+		"b 0\n",
+	)
+}
@@ -0,0 +1,86 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"launchpad.net/tomb"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/worker"
+)
+
+// ActionPrunerParams specifies how often the results of finished
+// actions should be pruned. The maximum age and total size of the
+// results kept are read from the environment configuration, and
+// changes to them take effect without restarting the worker.
+type ActionPrunerParams struct {
+	PruneInterval time.Duration
+}
+
+const DefaultPruneInterval = 5 * time.Minute
+
+// NewActionPrunerParams returns an ActionPrunerParams initialized
+// with default parameters.
+func NewActionPrunerParams() *ActionPrunerParams {
+	return &ActionPrunerParams{
+		PruneInterval: DefaultPruneInterval,
+	}
+}
+
+type pruneWorker struct {
+	st     *state.State
+	params *ActionPrunerParams
+}
+
+// New returns a worker.Worker that prunes the results of finished
+// actions.
+func New(st *state.State, params *ActionPrunerParams) worker.Worker {
+	w := &pruneWorker{
+		st:     st,
+		params: params,
+	}
+	return worker.NewSimpleWorker(w.loop)
+}
+
+func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
+	configWatcher := w.st.WatchForEnvironConfigChanges()
+	defer configWatcher.Stop()
+
+	var (
+		maxAge    time.Duration
+		maxSizeMB int
+		pruneCh   <-chan time.Time
+	)
+	for {
+		select {
+		case <-stopCh:
+			return tomb.ErrDying
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return watcher.EnsureErr(configWatcher)
+			}
+			config, err := w.st.EnvironConfig()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			maxAge = config.ActionResultsMaxAge()
+			maxSizeMB = config.ActionResultsMaxSizeMB()
+			if pruneCh == nil {
+				// Start pruning once the retention settings are known.
+				pruneCh = time.After(w.params.PruneInterval)
+			}
+		case <-pruneCh:
+			minCompletedTime := time.Now().Add(-maxAge)
+			err := state.PruneActions(w.st, minCompletedTime, maxSizeMB)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			pruneCh = time.After(w.params.PruneInterval)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner_test
+
+import (
+	stdtesting "testing"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionpruner"
+)
+
+func TestPackage(t *stdtesting.T) {
+	testing.MgoTestPackage(t)
+}
+
+var _ = gc.Suite(&suite{})
+
+type suite struct {
+	statetesting.StateSuite
+}
+
+func (s *suite) setMaxAge(c *gc.C, maxAge string) {
+	err := s.State.UpdateEnvironConfig(map[string]interface{}{
+		"action-results-max-age": maxAge,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *suite) TestPrunesFinishedActions(c *gc.C) {
+	s.setMaxAge(c, "999h")
+	pruner := actionpruner.New(s.State, &actionpruner.ActionPrunerParams{
+		PruneInterval: time.Millisecond, // Speed up pruning interval for testing
+	})
+	defer func() {
+		pruner.Kill()
+		c.Assert(pruner.Wait(), jc.ErrorIsNil)
+	}()
+
+	unit := s.Factory.MakeUnit(c, nil)
+	finished, err := s.State.EnqueueAction(unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = finished.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := s.State.EnqueueAction(unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The results are kept until the maximum age is reduced.
+	s.State.StartSync()
+	time.Sleep(testing.ShortWait)
+	_, err = s.State.Action(finished.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setMaxAge(c, "1ns")
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		s.State.StartSync()
+		_, err := s.State.Action(finished.Id())
+		if err == nil {
+			continue
+		}
+		c.Assert(err, gc.ErrorMatches, `action ".*" not found`)
+		_, err = s.State.Action(pending.Id())
+		c.Assert(err, jc.ErrorIsNil)
+		return
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
@@ -0,0 +1,100 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"launchpad.net/tomb"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// timeNow is patched in tests.
+var timeNow = time.Now
+
+// ActionSchedulerParams specifies how often the action schedules
+// should be checked for actions that are due.
+type ActionSchedulerParams struct {
+	CheckInterval time.Duration
+}
+
+// DefaultCheckInterval is how often schedules are checked by default.
+// Schedules are given to the minute, so there is no point checking
+// them more often than that.
+const DefaultCheckInterval = time.Minute
+
+// NewActionSchedulerParams returns an ActionSchedulerParams
+// initialized with default parameters.
+func NewActionSchedulerParams() *ActionSchedulerParams {
+	return &ActionSchedulerParams{
+		CheckInterval: DefaultCheckInterval,
+	}
+}
+
+type scheduleWorker struct {
+	st     *state.State
+	params *ActionSchedulerParams
+}
+
+// New returns a worker.Worker that enqueues actions on the schedules
+// held in state.
+func New(st *state.State, params *ActionSchedulerParams) worker.Worker {
+	w := &scheduleWorker{
+		st:     st,
+		params: params,
+	}
+	return worker.NewSimpleWorker(w.loop)
+}
+
+func (w *scheduleWorker) loop(stopCh <-chan struct{}) error {
+	for {
+		if err := w.runDue(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-stopCh:
+			return tomb.ErrDying
+		case <-time.After(w.params.CheckInterval):
+		}
+	}
+}
+
+// runDue enqueues the actions of all the schedules that are due. A
+// schedule that was due more than once since it last ran, because the
+// state server was down, runs only once.
+func (w *scheduleWorker) runDue() error {
+	schedules, err := w.st.AllActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := timeNow()
+	for _, schedule := range schedules {
+		next, err := schedule.Next()
+		if err != nil {
+			// A schedule that cannot be evaluated must not stop
+			// the others from running.
+			logger.Errorf("%v", err)
+			continue
+		}
+		if next.IsZero() || next.After(now) {
+			continue
+		}
+		actions, err := schedule.Run(now)
+		if err != nil {
+			// The error is recorded on the schedule; failing to
+			// enqueue one schedule's action must not stop the others.
+			logger.Warningf("%v", err)
+			continue
+		}
+		logger.Infof("action schedule %s enqueued %d %q action(s)",
+			schedule.Id(), len(actions), schedule.Name())
+	}
+	return nil
+}
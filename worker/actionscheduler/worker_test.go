@@ -0,0 +1,123 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	stdtesting "testing"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+func TestPackage(t *stdtesting.T) {
+	testing.MgoTestPackage(t)
+}
+
+var _ = gc.Suite(&suite{})
+
+type suite struct {
+	statetesting.StateSuite
+	service *state.Service
+	unit    *state.Unit
+}
+
+func (s *suite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.service = s.Factory.MakeService(c, &factory.ServiceParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"}),
+	})
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{
+		Service:     s.service,
+		SetCharmURL: true,
+	})
+}
+
+func (s *suite) startScheduler(c *gc.C) {
+	scheduler := actionscheduler.New(s.State, &actionscheduler.ActionSchedulerParams{
+		CheckInterval: time.Millisecond, // Speed up checking for testing
+	})
+	s.AddCleanup(func(c *gc.C) {
+		scheduler.Kill()
+		c.Assert(scheduler.Wait(), jc.ErrorIsNil)
+	})
+}
+
+func (s *suite) pendingActions(c *gc.C) []*state.Action {
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	return actions
+}
+
+func (s *suite) TestEnqueuesDueActions(c *gc.C) {
+	_, err := s.State.AddActionSchedule("@hourly", s.unit.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddActionSchedule("@yearly", s.unit.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the hourly schedule is due two hours from now, and it
+	// runs only once although it was due twice.
+	later := time.Now().Add(2 * time.Hour)
+	if later.Month() == 1 && later.Day() == 1 && later.Hour() < 2 {
+		c.Skip("the yearly schedule is due too")
+	}
+	s.PatchValue(actionscheduler.TimeNow, func() time.Time { return later })
+	s.startScheduler(c)
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		actions := s.pendingActions(c)
+		if len(actions) == 0 {
+			continue
+		}
+		c.Assert(actions, gc.HasLen, 1)
+		c.Assert(actions[0].Name(), gc.Equals, "snapshot")
+		time.Sleep(testing.ShortWait)
+		c.Assert(s.pendingActions(c), gc.HasLen, 1)
+		return
+	}
+	c.Fatal("scheduled action wasn't enqueued")
+}
+
+func (s *suite) TestSkipsSchedulesNotDue(c *gc.C) {
+	_, err := s.State.AddActionSchedule("@hourly", s.unit.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	s.startScheduler(c)
+
+	time.Sleep(testing.ShortWait)
+	c.Assert(s.pendingActions(c), gc.HasLen, 0)
+}
+
+func (s *suite) TestContinuesAfterFailure(c *gc.C) {
+	broken, err := s.State.AddActionSchedule("@hourly", s.unit.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Service:     s.service,
+		SetCharmURL: true,
+	})
+	_, err = s.State.AddActionSchedule("@hourly", other.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	later := time.Now().Add(2 * time.Hour)
+	s.PatchValue(actionscheduler.TimeNow, func() time.Time { return later })
+	s.startScheduler(c)
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		actions, err := other.PendingActions()
+		c.Assert(err, jc.ErrorIsNil)
+		broken, err = s.State.ActionSchedule(broken.Id())
+		c.Assert(err, jc.ErrorIsNil)
+		if len(actions) == 1 && broken.LastError() != "" {
+			return
+		}
+	}
+	c.Fatal("scheduled action wasn't enqueued")
+}
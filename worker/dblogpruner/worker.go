@@ -10,27 +10,25 @@ import (
 	"launchpad.net/tomb"
 
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/worker"
 )
 
-// LogPruneParams specifies how logs should be pruned.
+// LogPruneParams specifies how often logs should be pruned. The
+// maximum age and size of the logs collection are read from the
+// environment configuration, and changes to them take effect
+// without restarting the worker.
 type LogPruneParams struct {
-	MaxLogAge       time.Duration
-	MaxCollectionMB int
-	PruneInterval   time.Duration
+	PruneInterval time.Duration
 }
 
-const DefaultMaxLogAge = 3 * 24 * time.Hour // 3 days
-const DefaultMaxCollectionMB = 4 * 1024     // 4 GB
 const DefaultPruneInterval = 5 * time.Minute
 
 // NewLogPruneParams returns a LogPruneParams initialised with default
 // values.
 func NewLogPruneParams() *LogPruneParams {
 	return &LogPruneParams{
-		MaxLogAge:       DefaultMaxLogAge,
-		MaxCollectionMB: DefaultMaxCollectionMB,
-		PruneInterval:   DefaultPruneInterval,
+		PruneInterval: DefaultPruneInterval,
 	}
 }
 
@@ -51,17 +49,39 @@ type pruneWorker struct {
 }
 
 func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
-	p := w.params
+	configWatcher := w.st.WatchForEnvironConfigChanges()
+	defer configWatcher.Stop()
+
+	var (
+		maxLogAge       time.Duration
+		maxCollectionMB int
+		pruneCh         <-chan time.Time
+	)
 	for {
 		select {
 		case <-stopCh:
 			return tomb.ErrDying
-		case <-time.After(p.PruneInterval):
-			minLogTime := time.Now().Add(-p.MaxLogAge)
-			err := state.PruneLogs(w.st, minLogTime, p.MaxCollectionMB)
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return watcher.EnsureErr(configWatcher)
+			}
+			config, err := w.st.EnvironConfig()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			maxLogAge = config.LogMaxAge()
+			maxCollectionMB = config.LogMaxSizeMB()
+			if pruneCh == nil {
+				// Start pruning once the retention settings are known.
+				pruneCh = time.After(w.params.PruneInterval)
+			}
+		case <-pruneCh:
+			minLogTime := time.Now().Add(-maxLogAge)
+			err := state.PruneLogs(w.st, minLogTime, maxCollectionMB)
 			if err != nil {
 				return errors.Trace(err)
 			}
+			pruneCh = time.After(w.params.PruneInterval)
 		}
 	}
 }
@@ -4,6 +4,7 @@
 package dblogpruner_test
 
 import (
+	"fmt"
 	stdtesting "testing"
 	"time"
 
@@ -38,11 +39,18 @@ func (s *suite) SetUpTest(c *gc.C) {
 	s.logsColl = s.State.MongoSession().DB("logs").C("logs")
 }
 
+func (s *suite) setRetention(c *gc.C, maxLogAge time.Duration, maxCollectionMB int) {
+	err := s.State.UpdateEnvironConfig(map[string]interface{}{
+		"log-max-age":  maxLogAge.String(),
+		"log-max-size": fmt.Sprintf("%dM", maxCollectionMB),
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *suite) StartWorker(c *gc.C, maxLogAge time.Duration, maxCollectionMB int) {
+	s.setRetention(c, maxLogAge, maxCollectionMB)
 	params := &dblogpruner.LogPruneParams{
-		MaxLogAge:       maxLogAge,
-		MaxCollectionMB: maxCollectionMB,
-		PruneInterval:   time.Millisecond, // Speed up pruning interval for testing
+		PruneInterval: time.Millisecond, // Speed up pruning interval for testing
 	}
 	s.pruner = dblogpruner.New(s.State, params)
 	s.AddCleanup(func(*gc.C) {
@@ -106,6 +114,36 @@ func (s *suite) TestPrunesLogsBySize(c *gc.C) {
 	c.Fatal("pruning didn't happen as expected")
 }
 
+func (s *suite) TestRetentionConfigChange(c *gc.C) {
+	noPruneAge := 999 * time.Hour
+	noPruneMB := int(1e9)
+	s.StartWorker(c, noPruneAge, noPruneMB)
+
+	s.addLogs(c, time.Now().Add(-48*time.Hour), "prune", 10)
+	s.addLogs(c, time.Now(), "keep", 10)
+
+	// The logs are kept until the maximum age is reduced.
+	s.State.StartSync()
+	time.Sleep(testing.ShortWait)
+	count, err := s.logsColl.Find(bson.M{"x": "prune"}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 10)
+
+	s.setRetention(c, 24*time.Hour, noPruneMB)
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		s.State.StartSync()
+		pruneRemaining, err := s.logsColl.Find(bson.M{"x": "prune"}).Count()
+		c.Assert(err, jc.ErrorIsNil)
+		if pruneRemaining == 0 {
+			keepCount, err := s.logsColl.Find(bson.M{"x": "keep"}).Count()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(keepCount, gc.Equals, 10)
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen after the retention config changed")
+}
+
 func (s *suite) addLogs(c *gc.C, t0 time.Time, text string, count int) {
 	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("0"))
 	defer dbLogger.Close()
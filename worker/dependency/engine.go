@@ -4,6 +4,7 @@
 package dependency
 
 import (
+	"sort"
 	"time"
 
 	"github.com/juju/errors"
@@ -29,10 +30,12 @@ func NewEngine(isFatal IsFatalFunc, errorDelay, bounceDelay time.Duration) Engin
 		manifolds:  map[string]Manifold{},
 		dependents: map[string][]string{},
 		current:    map[string]workerInfo{},
+		history:    map[string]workerHistory{},
 
 		install: make(chan installTicket),
 		started: make(chan startedTicket),
 		stopped: make(chan stoppedTicket),
+		report:  make(chan reportTicket),
 	}
 	go func() {
 		defer engine.tomb.Done()
@@ -66,11 +69,16 @@ type engine struct {
 	// current holds the active worker information for each installed manifold.
 	current map[string]workerInfo
 
-	// install, started, and stopped each communicate requests and changes into
-	// the loop goroutine.
+	// history holds, for each installed manifold, what has happened to its
+	// workers so far.
+	history map[string]workerHistory
+
+	// install, started, stopped, and report each communicate requests and
+	// changes into the loop goroutine.
 	install chan installTicket
 	started chan startedTicket
 	stopped chan stoppedTicket
+	report  chan reportTicket
 }
 
 // loop serializes manifold install operations and worker start/stop notifications.
@@ -97,6 +105,9 @@ func (engine *engine) loop() error {
 			engine.gotStarted(ticket.name, ticket.worker)
 		case ticket := <-engine.stopped:
 			engine.gotStopped(ticket.name, ticket.error)
+		case ticket := <-engine.report:
+			// This is safe so long as the Report method reads the result.
+			ticket.result <- engine.gotReport()
 		}
 		if engine.isDying() {
 			if engine.allStopped() {
@@ -128,6 +139,50 @@ func (engine *engine) Install(name string, manifold Manifold) error {
 	}
 }
 
+// Report is part of the Engine interface.
+func (engine *engine) Report() []worker.WorkerReport {
+	result := make(chan reportResult, 1)
+	select {
+	case <-engine.tomb.Dead():
+		return nil
+	case engine.report <- reportTicket{result}:
+	}
+	report := <-result
+	// Workers may run workers of their own; ask them for their reports
+	// outside the loop goroutine, which must not be held up.
+	for i, w := range report.workers {
+		report.reports[i].Workers = worker.ReportWorker(w)
+	}
+	return report.reports
+}
+
+// gotReport returns a snapshot of the state of every installed manifold's
+// worker. It must only be called from the loop goroutine.
+func (engine *engine) gotReport() reportResult {
+	names := make([]string, 0, len(engine.manifolds))
+	for name := range engine.manifolds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var result reportResult
+	for _, name := range names {
+		info := engine.current[name]
+		history := engine.history[name]
+		report := worker.WorkerReport{
+			Name:       name,
+			Inputs:     engine.manifolds[name].Inputs,
+			State:      info.state(),
+			StartCount: history.startCount,
+		}
+		if history.lastError != nil {
+			report.LastError = history.lastError.Error()
+		}
+		result.reports = append(result.reports, report)
+		result.workers = append(result.workers, info.worker)
+	}
+	return result
+}
+
 // gotInstall handles the params originally supplied to Install. It must only be
 // called from the loop goroutine.
 func (engine *engine) gotInstall(name string, manifold Manifold) error {
@@ -302,6 +357,9 @@ func (engine *engine) gotStarted(name string, worker worker.Worker) {
 		info.starting = false
 		info.worker = worker
 		engine.current[name] = info
+		history := engine.history[name]
+		history.startCount++
+		engine.history[name] = history
 
 		// Any manifold that declares this one as an input needs to be restarted.
 		engine.bounceDependents(name)
@@ -321,6 +379,13 @@ func (engine *engine) gotStopped(name string, err error) {
 		engine.tomb.Kill(err)
 	}
 
+	// Remember the error, which is otherwise lost when the worker restarts.
+	if err != nil && err != tomb.ErrDying {
+		history := engine.history[name]
+		history.lastError = err
+		engine.history[name] = history
+	}
+
 	// Reset engine info; and bail out if we can be sure there's no need to bounce.
 	engine.current[name] = workerInfo{}
 	if engine.isDying() {
@@ -429,6 +494,26 @@ func (info workerInfo) stopped() bool {
 	return true
 }
 
+// state returns the state of the worker, as reported by the engine.
+func (info workerInfo) state() string {
+	switch {
+	case info.stopping:
+		return worker.StateStopping
+	case info.worker != nil:
+		return worker.StateStarted
+	case info.starting:
+		return worker.StateStarting
+	}
+	return worker.StateStopped
+}
+
+// workerHistory stores what an engine reports about the past workers for a
+// given Manifold.
+type workerHistory struct {
+	startCount int
+	lastError  error
+}
+
 // installTicket is used by engine to induce installation of a named manifold
 // and pass on any errors encountered in the process.
 type installTicket struct {
@@ -450,3 +535,16 @@ type stoppedTicket struct {
 	name  string
 	error error
 }
+
+// reportTicket is used by engine to request a snapshot of the state of its
+// workers from the loop.
+type reportTicket struct {
+	result chan<- reportResult
+}
+
+// reportResult holds a snapshot of the state of an engine's workers, and the
+// workers themselves, in the same order.
+type reportResult struct {
+	reports []worker.WorkerReport
+	workers []worker.Worker
+}
@@ -4,6 +4,7 @@
 package dependency_test
 
 import (
+	"reflect"
 	"time"
 
 	"github.com/juju/errors"
@@ -400,3 +401,53 @@ func (s *EngineSuite) TestErrMissing(c *gc.C) {
 	mh1.AssertNoStart(c)
 	mh2.AssertOneStart(c)
 }
+
+func (s *EngineSuite) TestReport(c *gc.C) {
+
+	// Install a worker and a dependent that can't start yet.
+	mh1 := newManifoldHarness()
+	err := s.engine.Install("some-task", mh1.Manifold())
+	c.Assert(err, jc.ErrorIsNil)
+	mh1.AssertOneStart(c)
+	mh2 := newManifoldHarness("some-task", "later-task")
+	err = s.engine.Install("other-task", mh2.Manifold())
+	c.Assert(err, jc.ErrorIsNil)
+	mh2.AssertNoStart(c)
+
+	// Fail the first worker; check the report shows it restarting.
+	mh1.InjectError(c, errors.New("boom"))
+	mh1.AssertOneStart(c)
+	s.waitForReport(c, []worker.WorkerReport{{
+		Name:      "other-task",
+		Inputs:    []string{"some-task", "later-task"},
+		State:     worker.StateStopped,
+		LastError: "dependency not available",
+	}, {
+		Name:       "some-task",
+		State:      worker.StateStarted,
+		StartCount: 2,
+		LastError:  "boom",
+	}})
+}
+
+func (s *EngineSuite) TestReportStopped(c *gc.C) {
+	err := worker.Stop(s.engine)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.engine.Report(), gc.IsNil)
+}
+
+// waitForReport waits for the engine to report the given workers.
+func (s *EngineSuite) waitForReport(c *gc.C, expect []worker.WorkerReport) {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		report := s.engine.Report()
+		if reflect.DeepEqual(report, expect) {
+			return
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("timed out waiting for report %#v; got %#v", expect, report)
+		case <-time.After(coretesting.ShortWait / 10):
+		}
+	}
+}
@@ -18,6 +18,10 @@ type Engine interface {
 	// fails and when its inputs' workers change, until the Engine shuts down.
 	Install(name string, manifold Manifold) error
 
+	// Report describes the worker for each installed manifold, ordered
+	// by manifold name.
+	Report() []worker.WorkerReport
+
 	// Engine is just another Worker.
 	worker.Worker
 }
@@ -0,0 +1,47 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/juju/worker"
+)
+
+// FormatReports returns the given worker reports as a table, with the
+// workers run by each worker indented below it.
+func FormatReports(reports []worker.WorkerReport) []byte {
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, 0, 1, 2, ' ', 0)
+	fmt.Fprintf(tw, "WORKER\tSTATE\tSTARTS\tINPUTS\tLAST ERROR\n")
+	writeReports(tw, reports, 0)
+	tw.Flush()
+	return out.Bytes()
+}
+
+func writeReports(tw *tabwriter.Writer, reports []worker.WorkerReport, depth int) {
+	for _, r := range reports {
+		fmt.Fprintf(tw, "%s%s\t%s\t%d\t%s\t%s\n",
+			strings.Repeat("  ", depth),
+			r.Name,
+			r.State,
+			r.StartCount,
+			strings.Join(r.Inputs, ","),
+			firstLine(r.LastError),
+		)
+		writeReports(tw, r.Workers, depth+1)
+	}
+}
+
+// firstLine returns the first line of s, so that a multi-line error
+// does not break up the table.
+func firstLine(s string) string {
+	if i := strings.Index(s, "\n"); i >= 0 {
+		return s[:i] + "..."
+	}
+	return s
+}
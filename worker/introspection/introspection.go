@@ -0,0 +1,146 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package introspection implements a worker which serves reports of
+// the workers run by an agent over a local socket, so they can be
+// inspected while the agent runs.
+package introspection
+
+import (
+	"fmt"
+	"net"
+	"net/rpc"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names"
+	"launchpad.net/tomb"
+
+	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/version"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.introspection")
+
+// ReportEndpoint is the name of the RPC call that returns the reports
+// of an agent's workers.
+const ReportEndpoint = "Introspection.Report"
+
+// SocketPath returns the path of the socket on which the agent with
+// the given tag serves the reports of its workers.
+func SocketPath(dataDir string, tag names.Tag) string {
+	if version.Current.OS == version.Windows {
+		return fmt.Sprintf(`\\.\pipe\%s-introspection`, tag)
+	}
+	return filepath.Join(dataDir, "agents", tag.String(), "introspection.socket")
+}
+
+// ReportArgs holds the arguments for a Report call.
+type ReportArgs struct {
+	// Worker, if not empty, restricts the reports to those of the
+	// agent's top level worker with the given name.
+	Worker string
+}
+
+// Introspection is the entity whose methods are called over the RPC
+// connection.
+type Introspection struct {
+	reporter worker.Reporter
+}
+
+// Report returns the reports of the agent's workers.
+func (i *Introspection) Report(args ReportArgs, result *[]worker.WorkerReport) error {
+	reports := i.reporter.Report()
+	if args.Worker == "" {
+		*result = reports
+		return nil
+	}
+	for _, report := range reports {
+		if report.Name == args.Worker {
+			*result = []worker.WorkerReport{report}
+			return nil
+		}
+	}
+	return errors.NotFoundf("worker %q", args.Worker)
+}
+
+// NewWorker returns a worker which serves the reports of the given
+// reporter's workers on the given socket.
+func NewWorker(reporter worker.Reporter, socketPath string) (worker.Worker, error) {
+	server := rpc.NewServer()
+	if err := server.Register(&Introspection{reporter}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	listener, err := sockets.Listen(socketPath)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot listen for introspection requests")
+	}
+	w := &introspectionWorker{
+		listener: listener,
+		server:   server,
+	}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.loop())
+	}()
+	return w, nil
+}
+
+type introspectionWorker struct {
+	tomb     tomb.Tomb
+	listener net.Listener
+	server   *rpc.Server
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *introspectionWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *introspectionWorker) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *introspectionWorker) loop() error {
+	go func() {
+		<-w.tomb.Dying()
+		w.listener.Close()
+	}()
+	for {
+		conn, err := w.listener.Accept()
+		if err != nil {
+			select {
+			case <-w.tomb.Dying():
+				// The listener was closed because we are stopping.
+				return tomb.ErrDying
+			default:
+			}
+			return errors.Annotate(err, "cannot accept introspection request")
+		}
+		logger.Debugf("serving introspection request")
+		// Each client makes a single request and hangs up, so
+		// connections are not tracked.
+		go w.server.ServeConn(conn)
+	}
+}
+
+// Report connects to the socket at the given path and returns the
+// reports of the workers of the agent serving it. If workerName is
+// not empty, only the report of the agent's top level worker with that
+// name is returned.
+func Report(socketPath, workerName string) ([]worker.WorkerReport, error) {
+	client, err := sockets.Dial(socketPath)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot connect to agent")
+	}
+	defer client.Close()
+	var reports []worker.WorkerReport
+	err = client.Call(ReportEndpoint, ReportArgs{Worker: workerName}, &reports)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return reports, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/version"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/introspection"
+)
+
+type introspectionSuite struct {
+	coretesting.BaseSuite
+	socketPath string
+}
+
+var _ = gc.Suite(&introspectionSuite{})
+
+func (s *introspectionSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.socketPath = filepath.Join(c.MkDir(), "introspection.socket")
+	if runtime.GOOS == "windows" {
+		s.socketPath = `\\.\pipe` + s.socketPath[2:]
+	}
+}
+
+type fakeReporter []worker.WorkerReport
+
+func (r fakeReporter) Report() []worker.WorkerReport {
+	return r
+}
+
+var testReports = fakeReporter{{
+	Name:       "api",
+	State:      worker.StateStarted,
+	StartCount: 1,
+	Workers: []worker.WorkerReport{{
+		Name:       "machiner",
+		Inputs:     []string{"api"},
+		State:      worker.StateStarting,
+		StartCount: 3,
+		LastError:  "boom",
+	}},
+}, {
+	Name:       "termination",
+	State:      worker.StateStarted,
+	StartCount: 1,
+}}
+
+func (s *introspectionSuite) startWorker(c *gc.C) {
+	w, err := introspection.NewWorker(testReports, s.socketPath)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	})
+}
+
+func (s *introspectionSuite) TestSocketPath(c *gc.C) {
+	s.PatchValue(&version.Current.OS, version.Ubuntu)
+	path := introspection.SocketPath("/var/lib/juju", names.NewMachineTag("0"))
+	c.Assert(path, gc.Equals, filepath.Join("/var/lib/juju", "agents", "machine-0", "introspection.socket"))
+
+	s.PatchValue(&version.Current.OS, version.Windows)
+	path = introspection.SocketPath("/var/lib/juju", names.NewMachineTag("0"))
+	c.Assert(path, gc.Equals, `\\.\pipe\machine-0-introspection`)
+}
+
+func (s *introspectionSuite) TestReport(c *gc.C) {
+	s.startWorker(c)
+	reports, err := introspection.Report(s.socketPath, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, []worker.WorkerReport(testReports))
+}
+
+func (s *introspectionSuite) TestReportWorker(c *gc.C) {
+	s.startWorker(c)
+	reports, err := introspection.Report(s.socketPath, "termination")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, []worker.WorkerReport{testReports[1]})
+}
+
+func (s *introspectionSuite) TestReportWorkerNotFound(c *gc.C) {
+	s.startWorker(c)
+	_, err := introspection.Report(s.socketPath, "uniter")
+	c.Assert(err, gc.ErrorMatches, `worker "uniter" not found`)
+}
+
+func (s *introspectionSuite) TestReportNotServing(c *gc.C) {
+	_, err := introspection.Report(s.socketPath, "")
+	c.Assert(err, gc.ErrorMatches, "cannot connect to agent: .*")
+}
+
+type fakeSetter chan []params.WorkerReport
+
+func (s fakeSetter) SetWorkerReports(reports []params.WorkerReport) error {
+	s <- reports
+	return nil
+}
+
+func (s *introspectionSuite) TestPublisher(c *gc.C) {
+	setter := make(fakeSetter, 1)
+	w := introspection.NewPublisher(setter, testReports, time.Hour)
+	defer func() {
+		c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	}()
+	select {
+	case reports := <-setter:
+		c.Assert(reports, jc.DeepEquals, []params.WorkerReport{{
+			Name:       "api",
+			State:      "started",
+			StartCount: 1,
+			Workers: []params.WorkerReport{{
+				Name:       "machiner",
+				Inputs:     []string{"api"},
+				State:      "starting",
+				StartCount: 3,
+				LastError:  "boom",
+			}},
+		}, {
+			Name:       "termination",
+			State:      "started",
+			StartCount: 1,
+		}})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for reports to be published")
+	}
+}
+
+func (s *introspectionSuite) TestFormatReports(c *gc.C) {
+	out := introspection.FormatReports(testReports)
+	c.Assert(string(out), gc.Equals, ""+
+		"WORKER       STATE     STARTS  INPUTS  LAST ERROR\n"+
+		"api          started   1               \n"+
+		"  machiner   starting  3       api     boom\n"+
+		"termination  started   1               \n",
+	)
+}
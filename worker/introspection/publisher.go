@@ -0,0 +1,48 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker"
+)
+
+// PublishPeriod is how often the reports of an agent's workers are
+// published.
+const PublishPeriod = time.Minute
+
+// ReportSetter is the interface the publisher uses to publish the
+// reports of an agent's workers.
+type ReportSetter interface {
+	SetWorkerReports(reports []params.WorkerReport) error
+}
+
+// NewPublisher returns a worker which publishes the reports of the
+// given reporter's workers through the given setter, once straight
+// away and then after every period.
+func NewPublisher(setter ReportSetter, reporter worker.Reporter, period time.Duration) worker.Worker {
+	return worker.NewPeriodicWorker(func(stop <-chan struct{}) error {
+		err := setter.SetWorkerReports(paramsWorkerReports(reporter.Report()))
+		return errors.Annotate(err, "cannot publish worker reports")
+	}, period)
+}
+
+func paramsWorkerReports(reports []worker.WorkerReport) []params.WorkerReport {
+	var result []params.WorkerReport
+	for _, r := range reports {
+		result = append(result, params.WorkerReport{
+			Name:       r.Name,
+			Inputs:     r.Inputs,
+			State:      r.State,
+			StartCount: r.StartCount,
+			LastError:  r.LastError,
+			Workers:    paramsWorkerReports(r.Workers),
+		})
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// formatter returns a log record as a message ready to be written
+// to a collector.
+type formatter func(envUUID string, rec *state.LogRecord) ([]byte, error)
+
+// newFormatter returns the formatter for the given log forwarding
+// format.
+func newFormatter(format string) (formatter, error) {
+	switch format {
+	case config.LogForwardSyslog:
+		return formatSyslog, nil
+	case config.LogForwardJSON:
+		return formatJSON, nil
+	}
+	return nil, errors.NotValidf("log forwarding format %q", format)
+}
+
+const (
+	// syslogFacility is the facility of forwarded syslog messages
+	// ("user-level messages").
+	syslogFacility = 1
+
+	// syslogEnterpriseID is the private enterprise number used to
+	// name the structured data element of forwarded syslog
+	// messages.
+	syslogEnterpriseID = 28978
+
+	// syslogTimeFormat is the RFC5424 timestamp format, with
+	// microsecond precision.
+	syslogTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+	// syslogMaxMsgIDLen is the maximum length of an RFC5424 MSGID.
+	syslogMaxMsgIDLen = 32
+)
+
+// syslogSeverity returns the syslog severity for a log level.
+func syslogSeverity(level loggo.Level) int {
+	switch level {
+	case loggo.CRITICAL:
+		return 2
+	case loggo.ERROR:
+		return 3
+	case loggo.WARNING:
+		return 4
+	case loggo.INFO:
+		return 6
+	}
+	return 7
+}
+
+// syslogParamEscaper escapes the characters which may not appear
+// unescaped in an RFC5424 structured data parameter value.
+var syslogParamEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// syslogHeaderField returns a value suitable for use in an RFC5424
+// header field: printable ASCII without spaces, of at most maxLen
+// characters, or the nil value "-".
+func syslogHeaderField(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, value)
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// formatSyslog returns a log record as an RFC5424 syslog message,
+// framed by octet counting as described in RFC6587.
+func formatSyslog(envUUID string, rec *state.LogRecord) ([]byte, error) {
+	msg := fmt.Sprintf("<%d>1 %s %s juju - %s [juju@%d env=\"%s\" location=\"%s\"] %s",
+		syslogFacility*8+syslogSeverity(rec.Level),
+		rec.Time.UTC().Format(syslogTimeFormat),
+		syslogHeaderField(rec.Entity, 255),
+		syslogHeaderField(rec.Module, syslogMaxMsgIDLen),
+		syslogEnterpriseID,
+		syslogParamEscaper.Replace(envUUID),
+		syslogParamEscaper.Replace(rec.Location),
+		rec.Message,
+	)
+	return []byte(fmt.Sprintf("%d %s", len(msg), msg)), nil
+}
+
+// jsonRecord holds a log record as forwarded in JSON format.
+type jsonRecord struct {
+	Time     time.Time `json:"time"`
+	EnvUUID  string    `json:"env-uuid"`
+	Entity   string    `json:"entity"`
+	Module   string    `json:"module"`
+	Location string    `json:"location"`
+	Level    string    `json:"level"`
+	Message  string    `json:"message"`
+}
+
+// formatJSON returns a log record as a single line JSON object.
+func formatJSON(envUUID string, rec *state.LogRecord) ([]byte, error) {
+	data, err := json.Marshal(jsonRecord{
+		Time:     rec.Time.UTC(),
+		EnvUUID:  envUUID,
+		Entity:   rec.Entity,
+		Module:   rec.Module,
+		Location: rec.Location,
+		Level:    rec.Level.String(),
+		Message:  rec.Message,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type formatSuite struct{}
+
+var _ = gc.Suite(&formatSuite{})
+
+var testRecord = &state.LogRecord{
+	Time:     time.Date(2015, 5, 1, 10, 0, 0, 123456789, time.UTC),
+	Entity:   "unit-mysql-0",
+	Module:   "juju.worker.uniter",
+	Location: "uniter.go:42",
+	Level:    loggo.WARNING,
+	Message:  "hook failed: \"config-changed\"",
+}
+
+func (*formatSuite) TestSyslog(c *gc.C) {
+	msg, err := formatSyslog("deadbeef", testRecord)
+	c.Assert(err, jc.ErrorIsNil)
+	expected := `<12>1 2015-05-01T10:00:00.123456Z unit-mysql-0 juju - juju.worker.uniter ` +
+		`[juju@28978 env="deadbeef" location="uniter.go:42"] hook failed: "config-changed"`
+	c.Assert(string(msg), gc.Equals, "154 "+expected)
+	c.Assert(len(expected), gc.Equals, 154)
+}
+
+func (*formatSuite) TestSyslogEscaping(c *gc.C) {
+	rec := *testRecord
+	rec.Level = loggo.TRACE
+	rec.Module = ""
+	rec.Location = `a"b\c]d`
+	msg, err := formatSyslog("deadbeef", &rec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(msg), jc.Contains,
+		`<15>1 2015-05-01T10:00:00.123456Z unit-mysql-0 juju - - [juju@28978 env="deadbeef" location="a\"b\\c\]d"] `)
+}
+
+func (*formatSuite) TestSyslogSeverity(c *gc.C) {
+	for level, severity := range map[loggo.Level]int{
+		loggo.CRITICAL: 2,
+		loggo.ERROR:    3,
+		loggo.WARNING:  4,
+		loggo.INFO:     6,
+		loggo.DEBUG:    7,
+		loggo.TRACE:    7,
+	} {
+		c.Check(syslogSeverity(level), gc.Equals, severity, gc.Commentf("level %v", level))
+	}
+}
+
+func (*formatSuite) TestJSON(c *gc.C) {
+	msg, err := formatJSON("deadbeef", testRecord)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(msg), gc.Equals, `{"time":"2015-05-01T10:00:00.123456789Z","env-uuid":"deadbeef",`+
+		`"entity":"unit-mysql-0","module":"juju.worker.uniter","location":"uniter.go:42",`+
+		`"level":"WARNING","message":"hook failed: \"config-changed\""}`+"\n")
+}
+
+func (*formatSuite) TestNewFormatter(c *gc.C) {
+	_, err := newFormatter("syslog")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = newFormatter("json")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = newFormatter("xml")
+	c.Assert(err, gc.ErrorMatches, `log forwarding format "xml" not valid`)
+}
@@ -0,0 +1,249 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logforwarder implements a worker which forwards the log
+// records stored in the database to external collectors.
+package logforwarder
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/mgo.v2/bson"
+	"launchpad.net/tomb"
+
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.logforwarder")
+
+// dialTimeout is how long to wait for a connection to a collector.
+const dialTimeout = 30 * time.Second
+
+// bookmarkInterval is how often the progress of forwarding to a
+// collector is saved. If the worker stops abruptly, records
+// forwarded since the progress was last saved are forwarded again.
+var bookmarkInterval = time.Second
+
+// New returns a worker which forwards the log records of the
+// environment to the collectors named by the log-forward-targets
+// environment setting. Forwarding to each collector resumes after
+// the last record forwarded to it, including records stored late, out
+// of order, shortly before it; records stored more than a few seconds
+// before a collector was first configured are not forwarded. This
+// worker is intended to run just once, on the MongoDB master.
+func New(st *state.State) worker.Worker {
+	w := &logForwarder{
+		st:      st,
+		targets: make(map[string]config.LogForwardTarget),
+	}
+	return worker.NewSimpleWorker(w.loop)
+}
+
+type logForwarder struct {
+	st      *state.State
+	targets map[string]config.LogForwardTarget
+	caCert  string
+}
+
+func (w *logForwarder) loop(stopCh <-chan struct{}) error {
+	// Forwarding to a collector is retried when it fails, for
+	// example because the collector is unavailable.
+	isFatal := func(error) bool {
+		return false
+	}
+	moreImportant := func(error, error) bool {
+		return false
+	}
+	runner := worker.NewRunner(isFatal, moreImportant)
+	defer func() {
+		runner.Kill()
+		runner.Wait()
+	}()
+
+	configWatcher := w.st.WatchForEnvironConfigChanges()
+	defer configWatcher.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return tomb.ErrDying
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return watcher.EnsureErr(configWatcher)
+			}
+			cfg, err := w.st.EnvironConfig()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := w.updateTargets(runner, cfg); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// updateTargets starts forwarding to the collectors that have been
+// added to the configuration and stops forwarding to those that have
+// been removed.
+func (w *logForwarder) updateTargets(runner worker.Runner, cfg *config.Config) error {
+	caCert, _ := cfg.LogForwardCACert()
+	caCertChanged := caCert != w.caCert
+	w.caCert = caCert
+
+	targets := make(map[string]config.LogForwardTarget)
+	for _, target := range cfg.LogForwardTargets() {
+		targets[target.String()] = target
+	}
+	for id := range w.targets {
+		if _, ok := targets[id]; !ok {
+			logger.Infof("stopping log forwarding to %s", id)
+			if err := runner.StopWorker(id); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	for id, target := range targets {
+		_, running := w.targets[id]
+		if running && target.TLS && caCertChanged {
+			// Reconnect to the collector using the new certificate.
+			if err := runner.StopWorker(id); err != nil {
+				return errors.Trace(err)
+			}
+		} else if running {
+			continue
+		}
+		logger.Infof("starting log forwarding to %s", id)
+		target := target
+		err := runner.StartWorker(id, func() (worker.Worker, error) {
+			return newTargetForwarder(w.st, target, caCert)
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	w.targets = targets
+	return nil
+}
+
+// targetForwarder forwards log records to a single collector.
+type targetForwarder struct {
+	st     *state.State
+	target config.LogForwardTarget
+	caCert string
+	format formatter
+}
+
+func newTargetForwarder(st *state.State, target config.LogForwardTarget, caCert string) (worker.Worker, error) {
+	format, err := newFormatter(target.Format)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f := &targetForwarder{
+		st:     st,
+		target: target,
+		caCert: caCert,
+		format: format,
+	}
+	return worker.NewSimpleWorker(f.loop), nil
+}
+
+// dial connects to the collector.
+func (f *targetForwarder) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	if !f.target.TLS {
+		return dialer.Dial("tcp", f.target.Address)
+	}
+	tlsConfig := &tls.Config{}
+	if f.caCert != "" {
+		caCert, err := cert.ParseCert(f.caCert)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot parse CA certificate")
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AddCert(caCert)
+	}
+	return tls.DialWithDialer(dialer, "tcp", f.target.Address, tlsConfig)
+}
+
+func (f *targetForwarder) loop(stopCh <-chan struct{}) error {
+	id := f.target.String()
+	bookmark, err := f.st.LogForwardBookmark(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if bookmark.Last == "" {
+		// Nothing has been forwarded to the collector yet, so
+		// start with the records stored from now on.
+		bookmark.Last = bson.NewObjectIdWithTime(time.Now())
+		if err := f.st.SetLogForwardBookmark(id, bookmark); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	conn, err := f.dial()
+	if err != nil {
+		return errors.Annotatef(err, "cannot connect to %s", id)
+	}
+	defer conn.Close()
+
+	tailer := state.NewLogTailer(f.st, &state.LogTailerParams{
+		StartID: bookmark.Last,
+		SeenIDs: bookmark.Recent,
+	})
+	defer tailer.Stop()
+
+	// The bookmark records the most recently stored record forwarded,
+	// which is not necessarily the last one forwarded, as records may
+	// be stored out of order.
+	changed := false
+	saveBookmark := func() error {
+		if !changed {
+			return nil
+		}
+		if err := f.st.SetLogForwardBookmark(id, bookmark); err != nil {
+			return errors.Trace(err)
+		}
+		changed = false
+		return nil
+	}
+	defer func() {
+		if err := saveBookmark(); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}()
+
+	envUUID := f.st.EnvironUUID()
+	bookmarkTicker := time.NewTicker(bookmarkInterval)
+	defer bookmarkTicker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return tomb.ErrDying
+		case rec, ok := <-tailer.Logs():
+			if !ok {
+				return watcher.EnsureErr(tailer)
+			}
+			msg, err := f.format(envUUID, rec)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if _, err := conn.Write(msg); err != nil {
+				return errors.Annotatef(err, "cannot forward logs to %s", id)
+			}
+			bookmark.Add(rec.Id)
+			changed = true
+		case <-bookmarkTicker.C:
+			if err := saveBookmark(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
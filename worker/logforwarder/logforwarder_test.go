@@ -0,0 +1,209 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	stdtesting "testing"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+func TestPackage(t *stdtesting.T) {
+	testing.MgoTestPackage(t)
+}
+
+var _ = gc.Suite(&suite{})
+
+type suite struct {
+	statetesting.StateSuite
+	listener net.Listener
+	lines    chan string
+	closed   chan struct{}
+}
+
+func (s *suite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.PatchValue(logforwarder.BookmarkInterval, 10*time.Millisecond)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.listener = listener
+	s.AddCleanup(func(*gc.C) { listener.Close() })
+	s.lines = make(chan string, 100)
+	s.closed = make(chan struct{}, 10)
+	go s.serve()
+}
+
+// serve accepts connections, passing on the lines received and
+// noting when each connection is closed.
+func (s *suite) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer func() {
+				conn.Close()
+				s.closed <- struct{}{}
+			}()
+			scanner := bufio.NewScanner(conn)
+			for scanner.Scan() {
+				s.lines <- scanner.Text()
+			}
+		}()
+	}
+}
+
+func (s *suite) setTargets(c *gc.C, targets string) {
+	err := s.State.UpdateEnvironConfig(map[string]interface{}{
+		"log-forward-targets": targets,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *suite) startWorker(c *gc.C) worker.Worker {
+	w := logforwarder.New(s.State)
+	s.AddCleanup(func(*gc.C) { worker.Stop(w) })
+	return w
+}
+
+func (s *suite) log(c *gc.C, msg string) {
+	logger := state.NewDbLogger(s.State, names.NewMachineTag("0"))
+	defer logger.Close()
+	err := logger.Log(time.Now(), "juju.test", "test.go:1", loggo.INFO, msg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// waitForBookmark waits until forwarding to the given target has
+// started.
+func (s *suite) waitForBookmark(c *gc.C, target string) {
+	for a := testing.LongAttempt.Start(); a.Next(); {
+		bookmark, err := s.State.LogForwardBookmark(target)
+		c.Assert(err, jc.ErrorIsNil)
+		if bookmark.Last != "" {
+			return
+		}
+	}
+	c.Fatalf("log forwarding to %s not started", target)
+}
+
+func (s *suite) assertMessages(c *gc.C, expected ...string) {
+	for _, msg := range expected {
+		select {
+		case line := <-s.lines:
+			var rec struct {
+				Entity  string `json:"entity"`
+				Module  string `json:"module"`
+				Level   string `json:"level"`
+				Message string `json:"message"`
+			}
+			err := json.Unmarshal([]byte(line), &rec)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(rec.Entity, gc.Equals, "machine-0")
+			c.Assert(rec.Module, gc.Equals, "juju.test")
+			c.Assert(rec.Level, gc.Equals, "INFO")
+			c.Assert(rec.Message, gc.Equals, msg)
+		case <-time.After(testing.LongWait):
+			c.Fatalf("timed out waiting for %q", msg)
+		}
+	}
+}
+
+func (s *suite) assertNoMore(c *gc.C) {
+	select {
+	case line := <-s.lines:
+		c.Fatalf("unexpected record forwarded: %s", line)
+	case <-time.After(testing.ShortWait):
+	}
+}
+
+func (s *suite) TestForwardsNewRecords(c *gc.C) {
+	target := "json://" + s.listener.Addr().String()
+	s.setTargets(c, target)
+	s.startWorker(c)
+	s.waitForBookmark(c, target)
+
+	s.log(c, "one")
+	s.log(c, "two")
+	s.assertMessages(c, "one", "two")
+	s.assertNoMore(c)
+}
+
+func (s *suite) TestResumesAfterRestart(c *gc.C) {
+	target := "json://" + s.listener.Addr().String()
+	s.setTargets(c, target)
+	w := s.startWorker(c)
+	s.waitForBookmark(c, target)
+	s.log(c, "one")
+	s.assertMessages(c, "one")
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+
+	s.log(c, "two")
+	s.startWorker(c)
+	s.log(c, "three")
+	s.assertMessages(c, "two", "three")
+	s.assertNoMore(c)
+}
+
+func (s *suite) TestForwardsRecordsStoredLateAfterRestart(c *gc.C) {
+	target := "json://" + s.listener.Addr().String()
+	s.setTargets(c, target)
+	w := s.startWorker(c)
+	s.waitForBookmark(c, target)
+	s.log(c, "one")
+	s.assertMessages(c, "one")
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+
+	// A record stored by another API server, with an id from just
+	// before that of the last record forwarded, is still forwarded,
+	// and the last record is not forwarded again.
+	bookmark, err := s.State.LogForwardBookmark(target)
+	c.Assert(err, jc.ErrorIsNil)
+	logs := s.State.MongoSession().DB("logs").C("logs")
+	err = logs.Insert(bson.D{
+		{"_id", bson.NewObjectIdWithTime(bookmark.Last.Time().Add(-time.Second))},
+		{"t", time.Now()},
+		{"e", s.State.EnvironUUID()},
+		{"n", "machine-0"},
+		{"m", "juju.test"},
+		{"l", "test.go:1"},
+		{"v", loggo.INFO},
+		{"x", "late"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.startWorker(c)
+	s.assertMessages(c, "late")
+	s.assertNoMore(c)
+}
+
+func (s *suite) TestTargetRemoved(c *gc.C) {
+	target := "json://" + s.listener.Addr().String()
+	s.setTargets(c, target)
+	s.startWorker(c)
+	s.waitForBookmark(c, target)
+	s.log(c, "one")
+	s.assertMessages(c, "one")
+
+	s.setTargets(c, "")
+	select {
+	case <-s.closed:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for forwarding to stop")
+	}
+}
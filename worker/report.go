@@ -0,0 +1,73 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package worker
+
+// The states a reported worker may be in.
+const (
+	// StateStarting is the state of a worker that is being started,
+	// or is waiting to be restarted.
+	StateStarting = "starting"
+
+	// StateStarted is the state of a running worker.
+	StateStarted = "started"
+
+	// StateStopping is the state of a worker that has been asked
+	// to stop.
+	StateStopping = "stopping"
+
+	// StateStopped is the state of a worker that is not running and
+	// will not be started until something changes.
+	StateStopped = "stopped"
+)
+
+// WorkerReport describes a worker run by a Runner or by a dependency
+// engine.
+type WorkerReport struct {
+	// Name is the name the worker was started with.
+	Name string `json:"name" yaml:"name"`
+
+	// Inputs holds the names of the workers this worker depends on,
+	// if known.
+	Inputs []string `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+
+	// State holds the state of the worker.
+	State string `json:"state" yaml:"state"`
+
+	// StartCount holds the number of times the worker has been
+	// started.
+	StartCount int `json:"start-count" yaml:"start-count"`
+
+	// LastError holds the last error the worker stopped with, if any.
+	LastError string `json:"last-error,omitempty" yaml:"last-error,omitempty"`
+
+	// Workers describes the workers run by this worker, if it runs
+	// any.
+	Workers []WorkerReport `json:"workers,omitempty" yaml:"workers,omitempty"`
+}
+
+// Reporter is implemented by workers that can describe the workers
+// they run.
+type Reporter interface {
+	// Report returns a description of each worker, ordered by name.
+	Report() []WorkerReport
+}
+
+// reportWorkers fills in the reports of workers which themselves run
+// other workers. The workers slice holds the worker described by each
+// report, which may be nil.
+func reportWorkers(reports []WorkerReport, workers []Worker) {
+	for i, w := range workers {
+		reports[i].Workers = ReportWorker(w)
+	}
+}
+
+// ReportWorker returns the reports of the workers run by the given
+// worker, if it implements Reporter, or nil otherwise. Workers that
+// wrap another worker may use it to implement Reporter.
+func ReportWorker(w Worker) []WorkerReport {
+	if reporter, ok := w.(Reporter); ok {
+		return reporter.Report()
+	}
+	return nil
+}
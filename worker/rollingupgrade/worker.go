@@ -0,0 +1,175 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrade
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"launchpad.net/tomb"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.rollingupgrade")
+
+// RollingUpgradeParams specifies how often services with rolling charm
+// upgrades should be checked for units to release.
+type RollingUpgradeParams struct {
+	CheckInterval time.Duration
+}
+
+// DefaultCheckInterval is how often rolling upgrades are checked by
+// default.
+const DefaultCheckInterval = 10 * time.Second
+
+// NewRollingUpgradeParams returns a RollingUpgradeParams initialized
+// with default parameters.
+func NewRollingUpgradeParams() *RollingUpgradeParams {
+	return &RollingUpgradeParams{
+		CheckInterval: DefaultCheckInterval,
+	}
+}
+
+type upgradeWorker struct {
+	st     *state.State
+	params *RollingUpgradeParams
+}
+
+// New returns a worker.Worker that drives the rolling charm upgrades of
+// services, releasing their units to upgrade in batches once the units
+// already released are healthy, and pausing or rolling back an upgrade
+// if a released unit fails.
+func New(st *state.State, params *RollingUpgradeParams) worker.Worker {
+	w := &upgradeWorker{
+		st:     st,
+		params: params,
+	}
+	return worker.NewSimpleWorker(w.loop)
+}
+
+func (w *upgradeWorker) loop(stopCh <-chan struct{}) error {
+	for {
+		if err := w.checkServices(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-stopCh:
+			return tomb.ErrDying
+		case <-time.After(w.params.CheckInterval):
+		}
+	}
+}
+
+// checkServices advances the rolling upgrades of all services.
+func (w *upgradeWorker) checkServices() error {
+	services, err := w.st.AllServices()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, service := range services {
+		rolling := service.RollingUpgrade()
+		if rolling == nil || rolling.Status != state.RollingUpgradeRunning {
+			continue
+		}
+		if err := w.checkService(service, rolling); err != nil {
+			// The service may have been upgraded again or removed
+			// in the meantime; failing to advance one upgrade must
+			// not stop the others.
+			logger.Warningf("cannot advance rolling upgrade of service %q: %v", service.Name(), err)
+		}
+	}
+	return nil
+}
+
+// checkService advances the given rolling upgrade of the service.
+func (w *upgradeWorker) checkService(service *state.Service, rolling *state.RollingUpgrade) error {
+	units, err := service.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sort.Sort(unitsByName(units))
+	serviceURL, _ := service.CharmURL()
+
+	var waiting bool
+	var held []string
+	for _, unit := range units {
+		if unit.Life() != state.Alive {
+			continue
+		}
+		if !rolling.IsReleased(unit.Name()) {
+			held = append(held, unit.Name())
+			continue
+		}
+		status, err := unit.Status()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		unitURL, _ := unit.CharmURL()
+		upgraded := unitURL != nil && unitURL.String() == serviceURL.String()
+		if status.Status == state.StatusError || upgraded && status.Status == state.StatusBlocked {
+			return w.fail(service, rolling, unitFailure(unit, status))
+		}
+		if !upgraded || status.Status != state.StatusActive {
+			waiting = true
+			continue
+		}
+		agentStatus, err := unit.AgentStatus()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if agentStatus.Status != state.StatusIdle {
+			waiting = true
+		}
+	}
+	if waiting {
+		return nil
+	}
+	if len(held) == 0 {
+		logger.Infof("rolling upgrade of service %q to %q completed", service.Name(), serviceURL)
+		return service.CompleteRollingUpgrade()
+	}
+	if len(held) > rolling.BatchSize {
+		held = held[:rolling.BatchSize]
+	}
+	logger.Infof("releasing units %v of service %q to upgrade to %q", held, service.Name(), serviceURL)
+	return service.ReleaseRollingUpgradeUnits(held...)
+}
+
+// fail pauses or rolls back the rolling upgrade of the service, as the
+// upgrade requires.
+func (w *upgradeWorker) fail(service *state.Service, rolling *state.RollingUpgrade, message string) error {
+	if rolling.Rollback {
+		logger.Warningf("rolling back upgrade of service %q: %s", service.Name(), message)
+		return service.RollBackRollingUpgrade(message)
+	}
+	logger.Warningf("pausing upgrade of service %q: %s", service.Name(), message)
+	return service.PauseRollingUpgrade(message)
+}
+
+// unitFailure describes why the unit failed its upgrade.
+func unitFailure(unit *state.Unit, status state.StatusInfo) string {
+	message := fmt.Sprintf("unit %q is in %s state", unit.Name(), status.Status)
+	if status.Message != "" {
+		message += ": " + status.Message
+	}
+	return message
+}
+
+// unitsByName sorts the units of a service in order of unit number.
+type unitsByName []*state.Unit
+
+func (u unitsByName) Len() int      { return len(u) }
+func (u unitsByName) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByName) Less(i, j int) bool {
+	ni, nj := u[i].Name(), u[j].Name()
+	if len(ni) != len(nj) {
+		return len(ni) < len(nj)
+	}
+	return ni < nj
+}
@@ -0,0 +1,142 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrade_test
+
+import (
+	stdtesting "testing"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/worker/rollingupgrade"
+)
+
+func TestPackage(t *stdtesting.T) {
+	testing.MgoTestPackage(t)
+}
+
+var _ = gc.Suite(&suite{})
+
+type suite struct {
+	statetesting.StateSuite
+	oldCharm *state.Charm
+	newCharm *state.Charm
+	service  *state.Service
+	units    []*state.Unit
+}
+
+func (s *suite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.oldCharm = s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"})
+	s.newCharm = s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"})
+	s.service = s.Factory.MakeService(c, &factory.ServiceParams{Charm: s.oldCharm})
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit := s.Factory.MakeUnit(c, &factory.UnitParams{
+			Service:     s.service,
+			SetCharmURL: true,
+		})
+		s.units = append(s.units, unit)
+	}
+}
+
+func (s *suite) startUpgrader(c *gc.C) {
+	upgrader := rollingupgrade.New(s.State, &rollingupgrade.RollingUpgradeParams{
+		CheckInterval: time.Millisecond, // Speed up checking for testing
+	})
+	s.AddCleanup(func(c *gc.C) {
+		upgrader.Kill()
+		c.Assert(upgrader.Wait(), jc.ErrorIsNil)
+	})
+}
+
+// upgradeUnit simulates the unit upgrading to the service's charm and
+// its workload reporting the given status.
+func (s *suite) upgradeUnit(c *gc.C, unit *state.Unit, status state.Status) {
+	err := unit.SetCharmURL(s.newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetAgentStatus(state.StatusIdle, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetStatus(status, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *suite) waitForRollingUpgrade(c *gc.C, check func(*state.RollingUpgrade) bool) *state.RollingUpgrade {
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		err := s.service.Refresh()
+		c.Assert(err, jc.ErrorIsNil)
+		if rolling := s.service.RollingUpgrade(); check(rolling) {
+			return rolling
+		}
+	}
+	c.Fatalf("rolling upgrade did not progress: %#v", s.service.RollingUpgrade())
+	return nil
+}
+
+func released(n int) func(*state.RollingUpgrade) bool {
+	return func(rolling *state.RollingUpgrade) bool {
+		return len(rolling.Released) == n
+	}
+}
+
+func (s *suite) TestReleasesHealthyBatches(c *gc.C) {
+	err := s.service.SetCharmRolling(s.newCharm, false, 2, false)
+	c.Assert(err, jc.ErrorIsNil)
+	s.startUpgrader(c)
+
+	rolling := s.waitForRollingUpgrade(c, released(2))
+	c.Assert(rolling.Released, jc.DeepEquals, []string{"dummy/0", "dummy/1"})
+
+	// The next batch is not released until the first is healthy.
+	s.upgradeUnit(c, s.units[0], state.StatusActive)
+	time.Sleep(testing.ShortWait)
+	c.Assert(s.waitForRollingUpgrade(c, released(2)).Status, gc.Equals, state.RollingUpgradeRunning)
+	s.upgradeUnit(c, s.units[1], state.StatusActive)
+	rolling = s.waitForRollingUpgrade(c, released(3))
+	c.Assert(rolling.Released[2], gc.Equals, "dummy/2")
+
+	s.upgradeUnit(c, s.units[2], state.StatusActive)
+	rolling = s.waitForRollingUpgrade(c, func(rolling *state.RollingUpgrade) bool {
+		return rolling.Status == state.RollingUpgradeCompleted
+	})
+	c.Assert(rolling.Message, gc.Equals, "")
+}
+
+func (s *suite) TestPausesOnFailure(c *gc.C) {
+	err := s.service.SetCharmRolling(s.newCharm, false, 1, false)
+	c.Assert(err, jc.ErrorIsNil)
+	s.startUpgrader(c)
+
+	s.waitForRollingUpgrade(c, released(1))
+	s.upgradeUnit(c, s.units[0], state.StatusBlocked)
+	rolling := s.waitForRollingUpgrade(c, func(rolling *state.RollingUpgrade) bool {
+		return rolling.Status == state.RollingUpgradePaused
+	})
+	c.Assert(rolling.Message, gc.Equals, `unit "dummy/0" is in blocked state`)
+	c.Assert(rolling.Released, jc.DeepEquals, []string{"dummy/0"})
+}
+
+func (s *suite) TestRollsBackOnFailure(c *gc.C) {
+	err := s.service.SetCharmRolling(s.newCharm, false, 1, true)
+	c.Assert(err, jc.ErrorIsNil)
+	s.startUpgrader(c)
+
+	s.waitForRollingUpgrade(c, released(1))
+	err = s.units[0].SetCharmURL(s.newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].SetAgentStatus(state.StatusError, "hook failed: \"upgrade-charm\"", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rolling := s.waitForRollingUpgrade(c, func(rolling *state.RollingUpgrade) bool {
+		return rolling.Status == state.RollingUpgradeRolledBack
+	})
+	c.Assert(rolling.Message, gc.Equals, `unit "dummy/0" is in error state: hook failed: "upgrade-charm"`)
+	url, force := s.service.CharmURL()
+	c.Assert(url, gc.DeepEquals, s.oldCharm.URL())
+	c.Assert(force, jc.IsTrue)
+}
@@ -5,11 +5,24 @@ package worker
 
 import (
 	"errors"
+	"sort"
 	"time"
 
 	"launchpad.net/tomb"
+
+	"github.com/juju/juju/utils/prometheus"
+)
+
+var workerRestarts = prometheus.NewCounterVec(
+	"juju_worker_restarts_total",
+	"Number of times workers were restarted after failing, by worker.",
+	"worker",
 )
 
+func init() {
+	prometheus.MustRegister(workerRestarts)
+}
+
 // RestartDelay holds the length of time that a worker
 // will wait between exiting and restarting.
 var RestartDelay = 3 * time.Second
@@ -27,6 +40,7 @@ type Worker interface {
 // Runner is implemented by instances capable of starting and stopping workers.
 type Runner interface {
 	Worker
+	Reporter
 	StartWorker(id string, startFunc func() (Worker, error)) error
 	StopWorker(id string) error
 }
@@ -39,6 +53,7 @@ type runner struct {
 	stopc         chan string
 	donec         chan doneInfo
 	startedc      chan startInfo
+	reportc       chan chan<- runnerReport
 	isFatal       func(error) bool
 	moreImportant func(err0, err1 error) bool
 }
@@ -60,6 +75,13 @@ type doneInfo struct {
 	err error
 }
 
+// runnerReport holds a snapshot of the workers of a runner, taken by
+// the runner's loop goroutine.
+type runnerReport struct {
+	reports []WorkerReport
+	workers []Worker
+}
+
 // NewRunner creates a new Runner.  When a worker finishes, if its error
 // is deemed fatal (determined by calling isFatal), all the other workers
 // will be stopped and the runner itself will finish.  Of all the fatal errors
@@ -76,6 +98,7 @@ func NewRunner(isFatal func(error) bool, moreImportant func(err0, err1 error) bo
 		stopc:         make(chan string),
 		donec:         make(chan doneInfo),
 		startedc:      make(chan startInfo),
+		reportc:       make(chan chan<- runnerReport),
 		isFatal:       isFatal,
 		moreImportant: moreImportant,
 	}
@@ -118,6 +141,26 @@ func (runner *runner) StopWorker(id string) error {
 	return ErrDead
 }
 
+// Report implements Reporter. It describes the workers that are
+// running, being started or restarted, or being stopped; workers
+// that have finished without error are not reported.
+//
+// Report returns nil if the runner is not running.
+func (runner *runner) Report() []WorkerReport {
+	resultc := make(chan runnerReport, 1)
+	select {
+	case runner.reportc <- resultc:
+	case <-runner.tomb.Dead():
+		return nil
+	}
+	result := <-resultc
+	// The reports of any workers run by our own workers are collected
+	// here rather than in the loop goroutine, so a slow worker cannot
+	// hold up the runner.
+	reportWorkers(result.reports, result.workers)
+	return result.reports
+}
+
 func (runner *runner) Wait() error {
 	return runner.tomb.Wait()
 }
@@ -138,6 +181,38 @@ type workerInfo struct {
 	worker       Worker
 	restartDelay time.Duration
 	stopping     bool
+	startCount   int
+	lastError    error
+}
+
+// report returns a snapshot of the given workers.
+func report(workers map[string]*workerInfo) runnerReport {
+	ids := make([]string, 0, len(workers))
+	for id := range workers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var result runnerReport
+	for _, id := range ids {
+		info := workers[id]
+		r := WorkerReport{
+			Name:       id,
+			State:      StateStarting,
+			StartCount: info.startCount,
+		}
+		switch {
+		case info.stopping:
+			r.State = StateStopping
+		case info.worker != nil:
+			r.State = StateStarted
+		}
+		if info.lastError != nil {
+			r.LastError = info.lastError.Error()
+		}
+		result.reports = append(result.reports, r)
+		result.workers = append(result.workers, info.worker)
+	}
+	return result
 }
 
 func (runner *runner) run() error {
@@ -194,6 +269,7 @@ func (runner *runner) run() error {
 		case info := <-runner.startedc:
 			workerInfo := workers[info.id]
 			workerInfo.worker = info.worker
+			workerInfo.startCount++
 			if isDying {
 				killWorker(info.id, workerInfo)
 			}
@@ -204,6 +280,7 @@ func (runner *runner) run() error {
 				break
 			}
 			if info.err != nil {
+				workerInfo.lastError = info.err
 				if runner.isFatal(info.err) {
 					logger.Errorf("fatal %q: %v", info.id, info.err)
 					if finalError == nil || runner.moreImportant(info.err, finalError) {
@@ -225,8 +302,14 @@ func (runner *runner) run() error {
 				delete(workers, info.id)
 				break
 			}
+			if info.err != nil && workerInfo.restartDelay > 0 {
+				workerRestarts.Inc(info.id)
+			}
+			workerInfo.worker = nil
 			go runner.runWorker(workerInfo.restartDelay, info.id, workerInfo.start)
 			workerInfo.restartDelay = RestartDelay
+		case resultc := <-runner.reportc:
+			resultc <- report(workers)
 		}
 	}
 }
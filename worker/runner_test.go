@@ -6,6 +6,7 @@ package worker_test
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"sync/atomic"
 	"time"
 
@@ -312,6 +313,76 @@ func (*runnerSuite) TestFatalErrorWhileSelfStartWorker(c *gc.C) {
 	c.Assert(err, gc.Equals, fatalStarter.startErr)
 }
 
+func (*runnerSuite) TestReport(c *gc.C) {
+	runner := worker.NewRunner(noneFatal, noImportance)
+	defer worker.Stop(runner)
+	starter := newTestWorkerStarter()
+	err := runner.StartWorker("id", testWorkerStart(starter))
+	c.Assert(err, jc.ErrorIsNil)
+	starter.assertStarted(c, true)
+	waitForReport(c, runner, []worker.WorkerReport{{
+		Name:       "id",
+		State:      worker.StateStarted,
+		StartCount: 1,
+	}})
+
+	starter.die <- fmt.Errorf("boom")
+	starter.assertStarted(c, false)
+	starter.assertStarted(c, true)
+	waitForReport(c, runner, []worker.WorkerReport{{
+		Name:       "id",
+		State:      worker.StateStarted,
+		StartCount: 2,
+		LastError:  "boom",
+	}})
+}
+
+func (*runnerSuite) TestReportNested(c *gc.C) {
+	runner := worker.NewRunner(noneFatal, noImportance)
+	defer worker.Stop(runner)
+	nested := worker.NewRunner(noneFatal, noImportance)
+	err := runner.StartWorker("nested", func() (worker.Worker, error) {
+		return nested, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	starter := newTestWorkerStarter()
+	err = nested.StartWorker("id", testWorkerStart(starter))
+	c.Assert(err, jc.ErrorIsNil)
+	starter.assertStarted(c, true)
+	waitForReport(c, runner, []worker.WorkerReport{{
+		Name:       "nested",
+		State:      worker.StateStarted,
+		StartCount: 1,
+		Workers: []worker.WorkerReport{{
+			Name:       "id",
+			State:      worker.StateStarted,
+			StartCount: 1,
+		}},
+	}})
+}
+
+func (*runnerSuite) TestReportWhenDead(c *gc.C) {
+	runner := worker.NewRunner(allFatal, noImportance)
+	c.Assert(worker.Stop(runner), gc.IsNil)
+	c.Assert(runner.Report(), gc.IsNil)
+}
+
+// waitForReport waits for the runner to report the given workers.
+func waitForReport(c *gc.C, runner worker.Runner, expect []worker.WorkerReport) {
+	timeout := time.After(testing.LongWait)
+	for {
+		reports := runner.Report()
+		if reflect.DeepEqual(reports, expect) {
+			return
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("timed out waiting for report %#v; got %#v", expect, reports)
+		case <-time.After(testing.ShortWait / 10):
+		}
+	}
+}
+
 type testWorkerStarter struct {
 	startCount int32
 
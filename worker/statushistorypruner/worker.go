@@ -10,24 +10,24 @@ import (
 	"launchpad.net/tomb"
 
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/worker"
 )
 
-// HistoryPrunerParams specifies how history logs should be prunned.
+// HistoryPrunerParams specifies how often history logs should be prunned.
+// The maximum age and number of entries kept for each entity are read
+// from the environment configuration, and changes to them take effect
+// without restarting the worker.
 type HistoryPrunerParams struct {
-	// TODO(perrito666) We might want to have some sort of limitation of the collection size too.
-	MaxLogsPerState int
-	PruneInterval   time.Duration
+	PruneInterval time.Duration
 }
 
-const DefaultMaxLogsPerState = 100
 const DefaultPruneInterval = 5 * time.Minute
 
 // NewHistoryPrunerParams returns a HistoryPrunerParams initialized with default parameter.
 func NewHistoryPrunerParams() *HistoryPrunerParams {
 	return &HistoryPrunerParams{
-		MaxLogsPerState: DefaultMaxLogsPerState,
-		PruneInterval:   DefaultPruneInterval,
+		PruneInterval: DefaultPruneInterval,
 	}
 }
 
@@ -45,18 +45,40 @@ func New(st *state.State, params *HistoryPrunerParams) worker.Worker {
 	return worker.NewSimpleWorker(w.loop)
 }
 
-// TODO(perrito666) Adda comprehensive test for the worker features
 func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
-	p := w.params
+	configWatcher := w.st.WatchForEnvironConfigChanges()
+	defer configWatcher.Stop()
+
+	var (
+		maxAge          time.Duration
+		maxLogsPerState int
+		pruneCh         <-chan time.Time
+	)
 	for {
 		select {
 		case <-stopCh:
 			return tomb.ErrDying
-		case <-time.After(p.PruneInterval):
-			err := state.PruneStatusHistory(w.st, p.MaxLogsPerState)
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return watcher.EnsureErr(configWatcher)
+			}
+			config, err := w.st.EnvironConfig()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			maxAge = config.StatusHistoryMaxAge()
+			maxLogsPerState = config.StatusHistoryMaxEntries()
+			if pruneCh == nil {
+				// Start pruning once the retention settings are known.
+				pruneCh = time.After(w.params.PruneInterval)
+			}
+		case <-pruneCh:
+			minHistoryTime := time.Now().Add(-maxAge)
+			err := state.PruneStatusHistory(w.st, minHistoryTime, maxLogsPerState)
 			if err != nil {
 				return errors.Trace(err)
 			}
+			pruneCh = time.After(w.params.PruneInterval)
 		}
 	}
 }
@@ -0,0 +1,108 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statushistorypruner_test
+
+import (
+	"fmt"
+	stdtesting "testing"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/statushistorypruner"
+)
+
+func TestPackage(t *stdtesting.T) {
+	testing.MgoTestPackage(t)
+}
+
+var _ = gc.Suite(&suite{})
+
+type suite struct {
+	statetesting.StateSuite
+	pruner  worker.Worker
+	machine *state.Machine
+}
+
+func (s *suite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+}
+
+func (s *suite) setRetention(c *gc.C, maxAge time.Duration, maxEntries int) {
+	err := s.State.UpdateEnvironConfig(map[string]interface{}{
+		"status-history-max-age":     maxAge.String(),
+		"status-history-max-entries": maxEntries,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *suite) StartWorker(c *gc.C, maxAge time.Duration, maxEntries int) {
+	s.setRetention(c, maxAge, maxEntries)
+	params := &statushistorypruner.HistoryPrunerParams{
+		PruneInterval: time.Millisecond, // Speed up pruning interval for testing
+	}
+	s.pruner = statushistorypruner.New(s.State, params)
+	s.AddCleanup(func(*gc.C) {
+		s.pruner.Kill()
+		c.Assert(s.pruner.Wait(), jc.ErrorIsNil)
+	})
+}
+
+func (s *suite) addHistory(c *gc.C, count int) {
+	for i := 0; i < count; i++ {
+		err := s.machine.SetStatus(state.StatusStarted, fmt.Sprintf("status %d", i), nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *suite) historyLen(c *gc.C) int {
+	history, err := s.machine.StatusHistory(1000)
+	c.Assert(err, jc.ErrorIsNil)
+	return len(history)
+}
+
+func (s *suite) waitForHistoryLen(c *gc.C, expected int) {
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		s.State.StartSync()
+		if s.historyLen(c) == expected {
+			return
+		}
+	}
+	c.Fatalf("status history was not pruned to %d entries", expected)
+}
+
+func (s *suite) TestPrunesByEntries(c *gc.C) {
+	s.addHistory(c, 10)
+	c.Assert(s.historyLen(c), jc.GreaterThan, 3)
+
+	s.StartWorker(c, 999*time.Hour, 3)
+	s.waitForHistoryLen(c, 3)
+}
+
+func (s *suite) TestRetentionConfigChange(c *gc.C) {
+	s.StartWorker(c, 999*time.Hour, 100)
+	s.addHistory(c, 5)
+	count := s.historyLen(c)
+	c.Assert(count, jc.GreaterThan, 2)
+
+	// The history is kept while it is within the retention settings.
+	s.State.StartSync()
+	time.Sleep(testing.ShortWait)
+	c.Assert(s.historyLen(c), gc.Equals, count)
+
+	// Reducing the number of entries kept takes effect without
+	// restarting the worker.
+	s.setRetention(c, 999*time.Hour, 2)
+	s.waitForHistoryLen(c, 2)
+
+	// As does reducing the maximum age.
+	s.setRetention(c, time.Millisecond, 2)
+	s.waitForHistoryLen(c, 0)
+}
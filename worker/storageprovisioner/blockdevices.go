@@ -13,12 +13,11 @@ import (
 
 // machineBlockDevicesChanged is called when the block devices of the scoped
 // machine have been seen to have changed. This triggers a refresh of all
-// block devices for attached volumes backing pending filesystems.
+// block devices for attached volumes backing pending and provisioned
+// filesystems, and grows any provisioned filesystems whose backing
+// volumes have been grown.
 func machineBlockDevicesChanged(ctx *context) error {
-	if len(ctx.pendingFilesystems) == 0 {
-		return nil
-	}
-	volumeTags := make([]names.VolumeTag, 0, len(ctx.pendingFilesystems))
+	volumeTags := make([]names.VolumeTag, 0, len(ctx.pendingFilesystems)+len(ctx.filesystems))
 	for _, params := range ctx.pendingFilesystems {
 		if params.Volume == (names.VolumeTag{}) {
 			// Filesystem is not volume-backed.
@@ -30,10 +29,23 @@ func machineBlockDevicesChanged(ctx *context) error {
 		}
 		volumeTags = append(volumeTags, params.Volume)
 	}
+	for _, filesystem := range ctx.filesystems {
+		if filesystem.Volume == (names.VolumeTag{}) {
+			// Filesystem is not volume-backed.
+			continue
+		}
+		// Always refresh the block devices of provisioned
+		// filesystems' backing-volumes, in case they have
+		// been grown.
+		volumeTags = append(volumeTags, filesystem.Volume)
+	}
 	if len(volumeTags) == 0 {
 		return nil
 	}
-	return refreshVolumeBlockDevices(ctx, volumeTags)
+	if err := refreshVolumeBlockDevices(ctx, volumeTags); err != nil {
+		return errors.Trace(err)
+	}
+	return growFilesystems(ctx)
 }
 
 // processPendingVolumeBlockDevices is called before waiting for any events,
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/registry"
 )
 
 // filesystemsChanged is called when the lifecycle states of the filesystems
@@ -150,27 +151,35 @@ func processDyingFilesystemAttachments(
 	ids []params.MachineStorageId,
 	filesystemAttachmentResults []params.FilesystemAttachmentResult,
 ) error {
-	filesystemAttachments := make([]params.FilesystemAttachment, len(filesystemAttachmentResults))
+	for _, id := range ids {
+		delete(ctx.pendingFilesystemAttachments, id)
+	}
+	attached := make([]params.MachineStorageId, 0, len(ids))
+	detached := make([]params.MachineStorageId, 0, len(ids))
 	for i, result := range filesystemAttachmentResults {
-		if result.Error != nil {
+		if result.Error == nil {
+			attached = append(attached, ids[i])
+			continue
+		}
+		if !params.IsCodeNotProvisioned(result.Error) {
 			return errors.Annotatef(result.Error, "getting information for filesystem attachment %v", ids[i])
 		}
-		filesystemAttachments[i] = result.Result
+		// The filesystem was never attached, so there
+		// is nothing to detach.
+		detached = append(detached, ids[i])
 	}
-	if len(filesystemAttachments) == 0 {
-		return nil
-	}
-	errorResults, err := detachFilesystems(filesystemAttachments)
-	if err != nil {
-		return errors.Annotate(err, "detaching filesystems")
-	}
-	detached := make([]params.MachineStorageId, 0, len(ids))
-	for i, id := range ids {
-		if err := errorResults[i]; err != nil {
-			logger.Errorf("detaching %v from %v: %v", ids[i].AttachmentTag, ids[i].MachineTag, err)
-			continue
+	if len(attached) > 0 {
+		attachmentsDetached, err := detachFilesystems(ctx, attached)
+		if err != nil {
+			return errors.Annotate(err, "detaching filesystems")
 		}
-		detached = append(detached, id)
+		for _, id := range attachmentsDetached {
+			delete(ctx.filesystemAttachments, id)
+		}
+		detached = append(detached, attachmentsDetached...)
+	}
+	if len(detached) == 0 {
+		return nil
 	}
 	if err := removeAttachments(ctx, detached); err != nil {
 		return errors.Annotate(err, "removing attachments from state")
@@ -362,6 +371,9 @@ func processAliveFilesystemAttachments(
 		if err != nil {
 			return errors.Annotate(err, "getting filesystem attachment parameters")
 		}
+		if !attachesFilesystem(ctx, params) {
+			continue
+		}
 		ctx.pendingFilesystemAttachments[pending[i]] = params
 	}
 	return nil
@@ -373,24 +385,30 @@ func processPendingFilesystemAttachments(ctx *context) error {
 	}
 	ready := make([]storage.FilesystemAttachmentParams, 0, len(ctx.pendingFilesystemAttachments))
 	for id, params := range ctx.pendingFilesystemAttachments {
-		filesystem, ok := ctx.filesystems[params.Filesystem]
-		if !ok {
-			logger.Debugf("filesystem %v has not been provisioned yet", params.Filesystem.Id())
-			continue
-		}
-		if filesystem.Volume != (names.VolumeTag{}) {
-			// The filesystem is volume-backed: if the filesystem
-			// was created in another session, then the block device
-			// may not have been seen yet. We must wait for the block
-			// device watcher to trigger.
-			if _, ok := ctx.volumeBlockDevices[filesystem.Volume]; !ok {
-				logger.Debugf(
-					"filesystem %v backing-volume %v is not attached yet",
-					filesystem.Tag.Id(),
-					filesystem.Volume.Id(),
-				)
+		// Shared filesystems are provisioned when they are added
+		// to state, and are not watched by machine-scoped storage
+		// provisioners; their IDs come with the attachment params.
+		if !isSharedFilesystemProvider(params.Provider) {
+			filesystem, ok := ctx.filesystems[params.Filesystem]
+			if !ok {
+				logger.Debugf("filesystem %v has not been provisioned yet", params.Filesystem.Id())
 				continue
 			}
+			if filesystem.Volume != (names.VolumeTag{}) {
+				// The filesystem is volume-backed: if the filesystem
+				// was created in another session, then the block device
+				// may not have been seen yet. We must wait for the block
+				// device watcher to trigger.
+				if _, ok := ctx.volumeBlockDevices[filesystem.Volume]; !ok {
+					logger.Debugf(
+						"filesystem %v backing-volume %v is not attached yet",
+						filesystem.Tag.Id(),
+						filesystem.Volume.Id(),
+					)
+					continue
+				}
+			}
+			params.FilesystemId = filesystem.FilesystemId
 		}
 		// TODO(axw) watch machines in storageprovisioner
 		if params.InstanceId == "" {
@@ -400,7 +418,6 @@ func processPendingFilesystemAttachments(ctx *context) error {
 		if params.Path == "" {
 			params.Path = filepath.Join(ctx.storageDir, params.Filesystem.Id())
 		}
-		params.FilesystemId = filesystem.FilesystemId
 		ready = append(ready, params)
 		delete(ctx.pendingFilesystemAttachments, id)
 	}
@@ -548,8 +565,84 @@ func destroyFilesystems(filesystems []params.Filesystem) ([]error, error) {
 	panic("not implemented")
 }
 
-func detachFilesystems(attachments []params.FilesystemAttachment) ([]error, error) {
-	panic("not implemented")
+// detachFilesystems detaches the filesystems of the attachments with the
+// specified IDs, returning the IDs of the attachments that were detached.
+// Attachments that are managed by another storage provisioner are ignored.
+func detachFilesystems(ctx *context, ids []params.MachineStorageId) ([]params.MachineStorageId, error) {
+	paramsResults, err := ctx.filesystemAccessor.FilesystemAttachmentParams(ids)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem attachment parameters")
+	}
+	filesystemSources := make(map[string]storage.FilesystemSource)
+	paramsBySource := make(map[string][]storage.FilesystemAttachmentParams)
+	idsBySource := make(map[string][]params.MachineStorageId)
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			return nil, errors.Annotatef(
+				result.Error, "getting parameters for filesystem attachment %v", ids[i],
+			)
+		}
+		params, err := filesystemAttachmentParamsFromParams(result.Result)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting filesystem attachment parameters")
+		}
+		if !attachesFilesystem(ctx, params) {
+			continue
+		}
+		sourceName := string(params.Provider)
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], params)
+		idsBySource[sourceName] = append(idsBySource[sourceName], ids[i])
+		if _, ok := filesystemSources[sourceName]; ok {
+			continue
+		}
+		filesystem := ctx.filesystems[params.Filesystem]
+		if filesystem.Volume != (names.VolumeTag{}) {
+			filesystemSources[sourceName] = ctx.managedFilesystemSource
+			continue
+		}
+		filesystemSource, err := filesystemSource(
+			ctx.environConfig, ctx.storageDir, sourceName, params.Provider,
+		)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting filesystem source")
+		}
+		filesystemSources[sourceName] = filesystemSource
+	}
+	var detached []params.MachineStorageId
+	for sourceName, params := range paramsBySource {
+		filesystemSource := filesystemSources[sourceName]
+		if err := filesystemSource.DetachFilesystems(params); err != nil {
+			logger.Errorf("detaching filesystems from source %q: %v", sourceName, err)
+			continue
+		}
+		detached = append(detached, idsBySource[sourceName]...)
+	}
+	return detached, nil
+}
+
+// attachesFilesystem reports whether or not the storage provisioner is
+// responsible for attaching and detaching the filesystem attachment with
+// the specified parameters. Shared filesystems are attached by the storage
+// provisioner of each machine that they are attached to; all others are
+// attached by the storage provisioner that manages the filesystem.
+func attachesFilesystem(ctx *context, params storage.FilesystemAttachmentParams) bool {
+	_, machineScoped := ctx.scope.(names.MachineTag)
+	if isSharedFilesystemProvider(params.Provider) {
+		return machineScoped
+	}
+	_, filesystemMachineScoped := names.FilesystemMachine(params.Filesystem)
+	return machineScoped == filesystemMachineScoped
+}
+
+// isSharedFilesystemProvider reports whether or not the storage provider
+// with the specified type provides shared filesystems.
+func isSharedFilesystemProvider(providerType storage.ProviderType) bool {
+	provider, err := registry.StorageProvider(providerType)
+	if err != nil {
+		return false
+	}
+	_, ok := provider.(storage.SharedFilesystemProvider)
+	return ok
 }
 
 func filesystemsFromStorage(in []storage.Filesystem) []params.Filesystem {
@@ -654,7 +747,8 @@ func filesystemAttachmentParamsFromParams(in params.FilesystemAttachmentParams)
 			Machine:    machineTag,
 			InstanceId: instance.Id(in.InstanceId),
 		},
-		Filesystem: filesystemTag,
-		Path:       in.MountPoint,
+		Filesystem:   filesystemTag,
+		FilesystemId: in.FilesystemId,
+		Path:         in.MountPoint,
 	}, nil
 }
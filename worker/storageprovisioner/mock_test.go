@@ -6,6 +6,7 @@ package storageprovisioner_test
 import (
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -33,6 +34,11 @@ var missingVolumeAttachmentId = params.MachineStorageId{
 	AttachmentTag: "volume-1",
 }
 
+var dyingFilesystemAttachmentId = params.MachineStorageId{
+	MachineTag:    "machine-0",
+	AttachmentTag: "filesystem-0",
+}
+
 type mockNotifyWatcher struct {
 	changes chan struct{}
 }
@@ -120,8 +126,15 @@ type mockVolumeAccessor struct {
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
 
+	volumeSnapshotsWatcher *mockStringsWatcher
+	volumeSnapshots        map[string]params.VolumeSnapshotParams
+	volumeResizesWatcher   *mockStringsWatcher
+	volumeResizes          map[string]params.VolumeResizeParams
+
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	setVolumeSnapshotInfo   func([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+	removeVolumeSnapshots   func([]string) ([]params.ErrorResult, error)
 }
 
 func (w *mockVolumeAccessor) WatchVolumes() (apiwatcher.StringsWatcher, error) {
@@ -210,19 +223,15 @@ func (v *mockVolumeAccessor) VolumeParams(volumes []names.VolumeTag) ([]params.V
 func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error) {
 	var result []params.VolumeAttachmentParamsResult
 	for _, id := range ids {
-		if _, ok := v.provisionedAttachments[id]; ok {
-			result = append(result, params.VolumeAttachmentParamsResult{
-				Error: &params.Error{Message: "already provisioned"},
-			})
-		} else {
-			instanceId, _ := v.provisionedMachines[id.MachineTag]
-			result = append(result, params.VolumeAttachmentParamsResult{Result: params.VolumeAttachmentParams{
-				MachineTag: id.MachineTag,
-				VolumeTag:  id.AttachmentTag,
-				InstanceId: string(instanceId),
-				Provider:   "dummy",
-			}})
-		}
+		// Parameters are returned for provisioned attachments
+		// too, so that they may be detached.
+		instanceId, _ := v.provisionedMachines[id.MachineTag]
+		result = append(result, params.VolumeAttachmentParamsResult{Result: params.VolumeAttachmentParams{
+			MachineTag: id.MachineTag,
+			VolumeTag:  id.AttachmentTag,
+			InstanceId: string(instanceId),
+			Provider:   "dummy",
+		}})
 	}
 	return result, nil
 }
@@ -235,6 +244,50 @@ func (v *mockVolumeAccessor) SetVolumeAttachmentInfo(volumeAttachments []params.
 	return v.setVolumeAttachmentInfo(volumeAttachments)
 }
 
+func (w *mockVolumeAccessor) WatchVolumeSnapshots() (apiwatcher.StringsWatcher, error) {
+	return w.volumeSnapshotsWatcher, nil
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	var result []params.VolumeSnapshotParamsResult
+	for _, id := range ids {
+		if snapshot, ok := v.volumeSnapshots[id]; ok {
+			result = append(result, params.VolumeSnapshotParamsResult{Result: snapshot})
+		} else {
+			result = append(result, params.VolumeSnapshotParamsResult{
+				Error: common.ServerError(errors.NotFoundf("volume snapshot %q", id)),
+			})
+		}
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	return v.setVolumeSnapshotInfo(snapshots)
+}
+
+func (v *mockVolumeAccessor) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	return v.removeVolumeSnapshots(ids)
+}
+
+func (w *mockVolumeAccessor) WatchVolumeResizes() (apiwatcher.StringsWatcher, error) {
+	return w.volumeResizesWatcher, nil
+}
+
+func (v *mockVolumeAccessor) VolumeResizeParams(volumes []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	var result []params.VolumeResizeParamsResult
+	for _, tag := range volumes {
+		if resize, ok := v.volumeResizes[tag.String()]; ok {
+			result = append(result, params.VolumeResizeParamsResult{Result: resize})
+		} else {
+			result = append(result, params.VolumeResizeParamsResult{
+				Error: common.ServerError(errors.NotFoundf("volume %q", tag.Id())),
+			})
+		}
+	}
+	return result, nil
+}
+
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         &mockStringsWatcher{make(chan []string, 1)},
@@ -244,6 +297,10 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		volumeSnapshotsWatcher: &mockStringsWatcher{make(chan []string, 1)},
+		volumeSnapshots:        make(map[string]params.VolumeSnapshotParams),
+		volumeResizesWatcher:   &mockStringsWatcher{make(chan []string, 1)},
+		volumeResizes:          make(map[string]params.VolumeResizeParams),
 	}
 }
 
@@ -254,6 +311,10 @@ type mockFilesystemAccessor struct {
 	provisionedFilesystems map[string]params.Filesystem
 	provisionedAttachments map[params.MachineStorageId]params.FilesystemAttachment
 
+	// sharedFilesystems maps the tags of filesystems provided
+	// by the "shared" provider to their filesystem IDs.
+	sharedFilesystems map[string]string
+
 	setFilesystemInfo           func([]params.Filesystem) ([]params.ErrorResult, error)
 	setFilesystemAttachmentInfo func([]params.FilesystemAttachment) ([]params.ErrorResult, error)
 }
@@ -321,19 +382,23 @@ func (v *mockFilesystemAccessor) FilesystemParams(filesystems []names.Filesystem
 func (f *mockFilesystemAccessor) FilesystemAttachmentParams(ids []params.MachineStorageId) ([]params.FilesystemAttachmentParamsResult, error) {
 	var result []params.FilesystemAttachmentParamsResult
 	for _, id := range ids {
-		if _, ok := f.provisionedAttachments[id]; ok {
-			result = append(result, params.FilesystemAttachmentParamsResult{
-				Error: &params.Error{Message: "already provisioned"},
-			})
-		} else {
-			instanceId, _ := f.provisionedMachines[id.MachineTag]
-			result = append(result, params.FilesystemAttachmentParamsResult{Result: params.FilesystemAttachmentParams{
-				MachineTag:    id.MachineTag,
-				FilesystemTag: id.AttachmentTag,
-				InstanceId:    string(instanceId),
-				Provider:      "dummy",
-			}})
+		// Parameters are returned for provisioned attachments
+		// too, so that they may be detached.
+		instanceId, _ := f.provisionedMachines[id.MachineTag]
+		attachmentParams := params.FilesystemAttachmentParams{
+			MachineTag:    id.MachineTag,
+			FilesystemTag: id.AttachmentTag,
+			InstanceId:    string(instanceId),
+			Provider:      "dummy",
+		}
+		if attachment, ok := f.provisionedAttachments[id]; ok {
+			attachmentParams.MountPoint = attachment.MountPoint
 		}
+		if filesystemId, ok := f.sharedFilesystems[id.AttachmentTag]; ok {
+			attachmentParams.Provider = "shared"
+			attachmentParams.FilesystemId = filesystemId
+		}
+		result = append(result, params.FilesystemAttachmentParamsResult{Result: attachmentParams})
 	}
 	return result, nil
 }
@@ -353,10 +418,12 @@ func newMockFilesystemAccessor() *mockFilesystemAccessor {
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedFilesystems: make(map[string]params.Filesystem),
 		provisionedAttachments: make(map[params.MachineStorageId]params.FilesystemAttachment),
+		sharedFilesystems:      make(map[string]string),
 	}
 }
 
 type mockLifecycleManager struct {
+	removeAttachments func([]params.MachineStorageId) ([]params.ErrorResult, error)
 }
 
 func (m *mockLifecycleManager) Life(volumes []names.Tag) ([]params.LifeResult, error) {
@@ -376,7 +443,7 @@ func (m *mockLifecycleManager) AttachmentLife(ids []params.MachineStorageId) ([]
 	var result []params.LifeResult
 	for _, id := range ids {
 		switch id {
-		case dyingVolumeAttachmentId:
+		case dyingVolumeAttachmentId, dyingFilesystemAttachmentId:
 			result = append(result, params.LifeResult{Life: params.Dying})
 		case missingVolumeAttachmentId:
 			result = append(result, params.LifeResult{
@@ -397,7 +464,10 @@ func (m *mockLifecycleManager) Remove([]names.Tag) ([]params.ErrorResult, error)
 	return nil, nil
 }
 
-func (m *mockLifecycleManager) RemoveAttachments([]params.MachineStorageId) ([]params.ErrorResult, error) {
+func (m *mockLifecycleManager) RemoveAttachments(ids []params.MachineStorageId) ([]params.ErrorResult, error) {
+	if m.removeAttachments != nil {
+		return m.removeAttachments(ids)
+	}
 	return nil, nil
 }
 
@@ -413,10 +483,92 @@ type dummyVolumeSource struct {
 	storage.VolumeSource
 }
 
+// dummySnapshottingVolumeSource is a dummyVolumeSource that can take
+// volume snapshots.
+type dummySnapshottingVolumeSource struct {
+	dummyVolumeSource
+	destroyed []string
+}
+
+var snapshotCreated = time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC)
+
+func (*dummySnapshottingVolumeSource) CreateSnapshots(args []storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error) {
+	snapshots := make([]storage.VolumeSnapshot, len(args))
+	for i, arg := range args {
+		snapshots[i] = storage.VolumeSnapshot{
+			SnapshotId: "snap-" + arg.VolumeId,
+			VolumeId:   arg.VolumeId,
+			Size:       1024,
+			Created:    snapshotCreated,
+		}
+	}
+	return snapshots, nil
+}
+
+func (*dummySnapshottingVolumeSource) ListSnapshots(volIds []string) ([]storage.VolumeSnapshot, error) {
+	return nil, errors.NotImplementedf("ListSnapshots")
+}
+
+func (s *dummySnapshottingVolumeSource) DestroySnapshots(snapshotIds []string) []error {
+	s.destroyed = append(s.destroyed, snapshotIds...)
+	return make([]error, len(snapshotIds))
+}
+
+// dummyResizingVolumeSource is a dummyVolumeSource that can resize
+// volumes.
+type dummyResizingVolumeSource struct {
+	dummyVolumeSource
+	resized []storage.VolumeResizeParams
+}
+
+func (s *dummyResizingVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) []error {
+	s.resized = append(s.resized, args...)
+	return make([]error, len(args))
+}
+
+// dummyDetachingVolumeSource is a dummyVolumeSource that records
+// the volume attachments it is asked to detach.
+type dummyDetachingVolumeSource struct {
+	dummyVolumeSource
+	detached []storage.VolumeAttachmentParams
+}
+
+func (s *dummyDetachingVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) error {
+	s.detached = append(s.detached, args...)
+	return nil
+}
+
 type dummyFilesystemSource struct {
 	storage.FilesystemSource
 }
 
+// dummySharedProvider is a dummyProvider that provides shared
+// filesystems, such as network filesystems.
+type dummySharedProvider struct {
+	dummyProvider
+	filesystemSource *dummyDetachingFilesystemSource
+}
+
+func (p *dummySharedProvider) FilesystemSource(environConfig *config.Config, providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	return p.filesystemSource, nil
+}
+
+func (*dummySharedProvider) SharedFilesystemId(cfg *storage.Config) (string, error) {
+	return cfg.Name(), nil
+}
+
+// dummyDetachingFilesystemSource is a dummyFilesystemSource that
+// records the filesystem attachments it is asked to detach.
+type dummyDetachingFilesystemSource struct {
+	dummyFilesystemSource
+	detached []storage.FilesystemAttachmentParams
+}
+
+func (s *dummyDetachingFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) error {
+	s.detached = append(s.detached, args...)
+	return nil
+}
+
 func (p *dummyProvider) VolumeSource(environConfig *config.Config, providerConfig *storage.Config) (storage.VolumeSource, error) {
 	if p.volumeSourceFunc != nil {
 		return p.volumeSourceFunc(environConfig, providerConfig)
@@ -569,6 +721,23 @@ func (s *mockManagedFilesystemSource) AttachFilesystems(args []storage.Filesyste
 	return filesystemAttachments, nil
 }
 
+func (s *mockManagedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]storage.Filesystem, error) {
+	var filesystems []storage.Filesystem
+	for _, arg := range args {
+		blockDevice, ok := s.blockDevices[arg.Volume]
+		if !ok {
+			return nil, errors.Errorf("filesystem %v's backing-volume is not attached", arg.Tag.Id())
+		}
+		filesystems = append(filesystems, storage.Filesystem{
+			Tag:          arg.Tag,
+			Volume:       arg.Volume,
+			FilesystemId: arg.FilesystemId,
+			Size:         blockDevice.Size,
+		})
+	}
+	return filesystems, nil
+}
+
 func (s *mockManagedFilesystemSource) DetachFilesystems(params []storage.FilesystemAttachmentParams) error {
 	return errors.NotImplementedf("DetachFilesystems")
 }
@@ -0,0 +1,168 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+)
+
+// volumeResizesChanged is called when the desired sizes of the volumes
+// with the provided IDs have been seen to have changed.
+func volumeResizesChanged(ctx *context, ids []string) error {
+	tags := make([]names.VolumeTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewVolumeTag(id)
+	}
+	results, err := ctx.volumeAccessor.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize parameters")
+	}
+	paramsBySource := make(map[string][]storage.VolumeResizeParams)
+	for i, result := range results {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The volume has been removed.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for volume %q", ids[i],
+			)
+		}
+		if result.Result.Size == 0 {
+			// The volume has already been resized.
+			continue
+		}
+		paramsBySource[result.Result.Provider] = append(
+			paramsBySource[result.Result.Provider], storage.VolumeResizeParams{
+				Tag:      tags[i],
+				VolumeId: result.Result.VolumeId,
+				Size:     result.Result.Size,
+			},
+		)
+	}
+	if err := resizeVolumes(ctx, paramsBySource); err != nil {
+		return errors.Annotate(err, "resizing volumes")
+	}
+	return nil
+}
+
+// resizeVolumes grows the specified volumes, and records their new
+// sizes in state.
+func resizeVolumes(ctx *context, paramsBySource map[string][]storage.VolumeResizeParams) error {
+	var resized []names.VolumeTag
+	sizes := make(map[names.VolumeTag]uint64)
+	for sourceName, args := range paramsBySource {
+		logger.Debugf("volumes to resize from source %q: %v", sourceName, len(args))
+		resizer, err := volumeResizer(ctx, sourceName)
+		if errors.IsNotSupported(err) {
+			// TODO we should set an error status on the volumes.
+			logger.Errorf("cannot resize volumes: %v", err)
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		errs := resizer.ResizeVolumes(args)
+		for i, err := range errs {
+			if err != nil {
+				logger.Errorf("resizing volume %q: %v", args[i].Tag.Id(), err)
+				continue
+			}
+			resized = append(resized, args[i].Tag)
+			sizes[args[i].Tag] = args[i].Size
+		}
+	}
+	if len(resized) == 0 {
+		return nil
+	}
+	volumeResults, err := ctx.volumeAccessor.Volumes(resized)
+	if err != nil {
+		return errors.Annotate(err, "getting volume information")
+	}
+	volumes := make([]params.Volume, len(resized))
+	for i, result := range volumeResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting information for volume %q", resized[i].Id(),
+			)
+		}
+		volumes[i] = result.Result
+		volumes[i].Size = sizes[resized[i]]
+	}
+	errorResults, err := ctx.volumeAccessor.SetVolumeInfo(volumes)
+	if err != nil {
+		return errors.Annotate(err, "publishing volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing volume %s to state", resized[i].Id(),
+			)
+		}
+		if volume, ok := ctx.volumes[resized[i]]; ok {
+			volume.Size = volumes[i].Size
+			ctx.volumes[resized[i]] = volume
+		}
+	}
+	return nil
+}
+
+// volumeResizer returns the storage.VolumeResizer for the volume source
+// with the given name, or an error satisfying errors.IsNotSupported if
+// the source cannot resize volumes.
+func volumeResizer(ctx *context, sourceName string) (storage.VolumeResizer, error) {
+	providerType := storage.ProviderType(sourceName)
+	source, err := volumeSource(ctx.environConfig, ctx.storageDir, sourceName, providerType)
+	if errors.Cause(err) == errNonDynamic {
+		return nil, errors.NotSupportedf("resizing non-dynamic %q volumes", sourceName)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting volume source")
+	}
+	resizer, ok := source.(storage.VolumeResizer)
+	if !ok {
+		return nil, errors.NotSupportedf("resizing %q volumes", sourceName)
+	}
+	return resizer, nil
+}
+
+// growFilesystems grows the volume-backed filesystems whose backing
+// volumes' block devices have been seen to be larger than the
+// filesystems, and records their new sizes in state.
+func growFilesystems(ctx *context) error {
+	resizer, ok := ctx.managedFilesystemSource.(storage.FilesystemResizer)
+	if !ok {
+		return nil
+	}
+	var args []storage.FilesystemResizeParams
+	for _, filesystem := range ctx.filesystems {
+		if filesystem.Volume == (names.VolumeTag{}) {
+			// Filesystem is not volume-backed.
+			continue
+		}
+		blockDevice, ok := ctx.volumeBlockDevices[filesystem.Volume]
+		if !ok || blockDevice.Size <= filesystem.Size {
+			continue
+		}
+		args = append(args, storage.FilesystemResizeParams{
+			Tag:          filesystem.Tag,
+			Volume:       filesystem.Volume,
+			FilesystemId: filesystem.FilesystemId,
+			Size:         blockDevice.Size,
+		})
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	filesystems, err := resizer.ResizeFilesystems(args)
+	if err != nil {
+		// The filesystems will be grown when the block
+		// devices are next seen to have changed.
+		logger.Errorf("growing filesystems: %v", err)
+		return nil
+	}
+	return setFilesystemInfo(ctx, filesystems)
+}
@@ -63,6 +63,30 @@ type VolumeAccessor interface {
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	// WatchVolumeSnapshots watches for changes to snapshots of volumes
+	// that this storage provisioner is responsible for.
+	WatchVolumeSnapshots() (apiwatcher.StringsWatcher, error)
+
+	// VolumeSnapshotParams returns the parameters for taking, or
+	// destroying, the volume snapshots with the specified IDs.
+	VolumeSnapshotParams([]string) ([]params.VolumeSnapshotParamsResult, error)
+
+	// SetVolumeSnapshotInfo records the details of newly taken volume
+	// snapshots.
+	SetVolumeSnapshotInfo([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+
+	// RemoveVolumeSnapshots removes the specified destroyed volume
+	// snapshots from state.
+	RemoveVolumeSnapshots([]string) ([]params.ErrorResult, error)
+
+	// WatchVolumeResizes watches for requests to grow volumes that
+	// this storage provisioner is responsible for.
+	WatchVolumeResizes() (apiwatcher.StringsWatcher, error)
+
+	// VolumeResizeParams returns the parameters for growing the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
 }
 
 // FilesystemAccessor defines an interface used to allow a storage provisioner
@@ -197,6 +221,10 @@ func (w *storageprovisioner) loop() error {
 	var filesystemAttachmentsChanges <-chan []params.MachineStorageId
 	var machineBlockDevicesWatcher apiwatcher.NotifyWatcher
 	var machineBlockDevicesChanges <-chan struct{}
+	var volumeSnapshotsWatcher apiwatcher.StringsWatcher
+	var volumeSnapshotsChanges <-chan []string
+	var volumeResizesWatcher apiwatcher.StringsWatcher
+	var volumeResizesChanges <-chan []string
 
 	environConfigWatcher, err := w.environ.WatchForEnvironConfigChanges()
 	if err != nil {
@@ -221,6 +249,8 @@ func (w *storageprovisioner) loop() error {
 	defer w.maybeStopWatcher(volumeAttachmentsWatcher)
 	defer w.maybeStopWatcher(filesystemsWatcher)
 	defer w.maybeStopWatcher(filesystemAttachmentsWatcher)
+	defer w.maybeStopWatcher(volumeSnapshotsWatcher)
+	defer w.maybeStopWatcher(volumeResizesWatcher)
 
 	startWatchers := func() error {
 		var err error
@@ -240,6 +270,22 @@ func (w *storageprovisioner) loop() error {
 		if err != nil {
 			return errors.Annotate(err, "watching filesystem attachments")
 		}
+		volumeSnapshotsWatcher, err = w.volumes.WatchVolumeSnapshots()
+		if errors.IsNotImplemented(err) {
+			logger.Infof("API server does not support volume snapshots")
+		} else if err != nil {
+			return errors.Annotate(err, "watching volume snapshots")
+		} else {
+			volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
+		}
+		volumeResizesWatcher, err = w.volumes.WatchVolumeResizes()
+		if errors.IsNotImplemented(err) {
+			logger.Infof("API server does not support resizing volumes")
+		} else if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		} else {
+			volumeResizesChanges = volumeResizesWatcher.Changes()
+		}
 		volumesChanges = volumesWatcher.Changes()
 		filesystemsChanges = filesystemsWatcher.Changes()
 		volumeAttachmentsChanges = volumeAttachmentsWatcher.Changes()
@@ -320,6 +366,20 @@ func (w *storageprovisioner) loop() error {
 			if err := filesystemAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return watcher.EnsureErr(volumeSnapshotsWatcher)
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return watcher.EnsureErr(volumeResizesWatcher)
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-machineBlockDevicesChanges:
 			if !ok {
 				return watcher.EnsureErr(machineBlockDevicesWatcher)
@@ -519,6 +519,408 @@ func (s *storageProvisionerSuite) TestUpdateEnvironConfig(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `provisioning volumes: creating volumes: getting volume source: getting storage source "dummy": zinga`)
 }
 
+func (s *storageProvisionerSuite) TestVolumeSnapshotTaken(c *gc.C) {
+	source := &dummySnapshottingVolumeSource{}
+	s.provider.volumeSourceFunc = func(*config.Config, *storage.Config) (storage.VolumeSource, error) {
+		return source, nil
+	}
+
+	snapshotInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeSnapshots["1@0"] = params.VolumeSnapshotParams{
+		Id: "1@0", VolumeTag: "volume-1", VolumeId: "id-1",
+		Provider: "dummy", Life: params.Alive,
+	}
+	volumeAccessor.volumeSnapshots["2@1"] = params.VolumeSnapshotParams{
+		Id: "2@1", VolumeTag: "volume-2", VolumeId: "id-2",
+		Provider: "dummy", Life: params.Alive, SnapshotId: "snap-id-2",
+	}
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+		defer close(snapshotInfoSet)
+		c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshot{{
+			Id:         "1@0",
+			VolumeTag:  "volume-1",
+			SnapshotId: "snap-id-1",
+			Size:       1024,
+			Created:    snapshotCreated,
+		}})
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		volumeAccessor,
+		newMockFilesystemAccessor(),
+		&mockLifecycleManager{},
+		environAccessor,
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Snapshot "2@1" has already been taken, so only "1@0" is.
+	volumeAccessor.volumeSnapshotsWatcher.changes <- []string{"1@0", "2@1"}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, snapshotInfoSet, "waiting for volume snapshot info to be set")
+}
+
+func (s *storageProvisionerSuite) TestVolumeSnapshotDestroyed(c *gc.C) {
+	source := &dummySnapshottingVolumeSource{}
+	s.provider.volumeSourceFunc = func(*config.Config, *storage.Config) (storage.VolumeSource, error) {
+		return source, nil
+	}
+
+	snapshotsRemoved := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeSnapshots["1@0"] = params.VolumeSnapshotParams{
+		Id: "1@0", VolumeTag: "volume-1", VolumeId: "id-1",
+		Provider: "dummy", Life: params.Dying, SnapshotId: "snap-id-1",
+	}
+	volumeAccessor.volumeSnapshots["2@1"] = params.VolumeSnapshotParams{
+		Id: "2@1", VolumeTag: "volume-2", VolumeId: "id-2",
+		Provider: "dummy", Life: params.Dying,
+	}
+	volumeAccessor.removeVolumeSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		defer close(snapshotsRemoved)
+		c.Assert(ids, jc.SameContents, []string{"1@0", "2@1"})
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		volumeAccessor,
+		newMockFilesystemAccessor(),
+		&mockLifecycleManager{},
+		environAccessor,
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Snapshot "3@2" has already been removed, and is ignored. Snapshot
+	// "2@1" was never taken, so it is removed without being destroyed.
+	volumeAccessor.volumeSnapshotsWatcher.changes <- []string{"1@0", "2@1", "3@2"}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, snapshotsRemoved, "waiting for volume snapshots to be removed")
+	c.Assert(source.destroyed, jc.DeepEquals, []string{"snap-id-1"})
+}
+
+func (s *storageProvisionerSuite) TestVolumeResized(c *gc.C) {
+	source := &dummyResizingVolumeSource{}
+	s.provider.volumeSourceFunc = func(*config.Config, *storage.Config) (storage.VolumeSource, error) {
+		return source, nil
+	}
+
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedVolumes["volume-1"] = params.Volume{
+		VolumeTag: "volume-1", VolumeId: "id-1", HardwareId: "serial-1", Size: 1024,
+	}
+	volumeAccessor.provisionedVolumes["volume-3"] = params.Volume{
+		VolumeTag: "volume-3", VolumeId: "id-3", Size: 2048,
+	}
+	volumeAccessor.volumeResizes["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1", VolumeId: "id-1", Provider: "dummy", Size: 2048,
+	}
+	volumeAccessor.volumeResizes["volume-3"] = params.VolumeResizeParams{
+		VolumeTag: "volume-3", VolumeId: "id-3", Provider: "dummy",
+	}
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+			VolumeTag:  "volume-1",
+			VolumeId:   "id-1",
+			HardwareId: "serial-1",
+			Size:       2048,
+		}})
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		volumeAccessor,
+		newMockFilesystemAccessor(),
+		&mockLifecycleManager{},
+		environAccessor,
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Volume "2" has been removed, and volume "3" has already been
+	// resized, so only volume "1" is resized.
+	volumeAccessor.volumeResizesWatcher.changes <- []string{"1", "2", "3"}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(source.resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "id-1",
+		Size:     2048,
+	}})
+}
+
+func (s *storageProvisionerSuite) TestVolumeAttachmentDetached(c *gc.C) {
+	source := &dummyDetachingVolumeSource{}
+	s.provider.volumeSourceFunc = func(*config.Config, *storage.Config) (storage.VolumeSource, error) {
+		return source, nil
+	}
+
+	attachmentRemoved := make(chan interface{})
+	lifecycleManager := &mockLifecycleManager{}
+	lifecycleManager.removeAttachments = func(ids []params.MachineStorageId) ([]params.ErrorResult, error) {
+		defer close(attachmentRemoved)
+		c.Assert(ids, jc.DeepEquals, []params.MachineStorageId{dyingVolumeAttachmentId})
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedMachines["machine-0"] = instance.Id("already-provisioned-0")
+	volumeAccessor.provisionedVolumes["volume-0"] = params.Volume{
+		VolumeTag: "volume-0", VolumeId: "vol-0",
+	}
+	volumeAccessor.provisionedAttachments[dyingVolumeAttachmentId] = params.VolumeAttachment{
+		MachineTag: "machine-0", VolumeTag: "volume-0", DeviceName: "/dev/sda0",
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		volumeAccessor,
+		newMockFilesystemAccessor(),
+		lifecycleManager,
+		environAccessor,
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.attachmentsWatcher.changes <- []params.MachineStorageId{dyingVolumeAttachmentId}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, attachmentRemoved, "waiting for attachment to be removed")
+	c.Assert(source.detached, jc.DeepEquals, []storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Provider:   "dummy",
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "already-provisioned-0",
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+	}})
+}
+
+func (s *storageProvisionerSuite) TestFilesystemGrown(c *gc.C) {
+	attachmentInfoSet := make(chan interface{})
+	filesystemInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemAttachmentInfo = func(attachments []params.FilesystemAttachment) ([]params.ErrorResult, error) {
+		close(attachmentInfoSet)
+		return nil, nil
+	}
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		filesystemInfoSet <- filesystems
+		return make([]params.ErrorResult, len(filesystems)), nil
+	}
+
+	volumeAccessor := newMockVolumeAccessor()
+	environAccessor := newMockEnvironAccessor(c)
+
+	worker := storageprovisioner.NewStorageProvisioner(
+		names.NewMachineTag("0"),
+		"storage-dir",
+		volumeAccessor,
+		filesystemAccessor,
+		&mockLifecycleManager{},
+		environAccessor,
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.provisionedFilesystems["filesystem-0-0"] = params.Filesystem{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		FilesystemId:  "whatever",
+		Size:          123,
+	}
+	filesystemAccessor.provisionedMachines["machine-0"] = instance.Id("already-provisioned-0")
+
+	blockDeviceId := params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}
+	volumeAccessor.blockDevices[blockDeviceId] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       123,
+	}
+	filesystemAccessor.attachmentsWatcher.changes <- []params.MachineStorageId{{
+		MachineTag:    "machine-0",
+		AttachmentTag: "filesystem-0-0",
+	}}
+	environAccessor.watcher.changes <- struct{}{}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"0/0"}
+	waitChannel(c, attachmentInfoSet, "waiting for filesystem attachment info to be set")
+
+	// The backing-volume is grown, so the filesystem is grown to match.
+	volumeAccessor.blockDevices[blockDeviceId] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       456,
+	}
+	volumeAccessor.blockDevicesWatcher.changes <- struct{}{}
+	info := waitChannel(
+		c, filesystemInfoSet, "waiting for filesystem info to be set",
+	).([]params.Filesystem)
+	c.Assert(info, jc.DeepEquals, []params.Filesystem{{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		FilesystemId:  "whatever",
+		Size:          456,
+	}})
+
+	// The filesystem is not grown again until the
+	// backing-volume is next grown.
+	volumeAccessor.blockDevicesWatcher.changes <- struct{}{}
+	assertNoEvent(c, filesystemInfoSet, "filesystem info set")
+}
+
+func (s *storageProvisionerSuite) registerSharedProvider() *dummyDetachingFilesystemSource {
+	source := &dummyDetachingFilesystemSource{}
+	registry.RegisterProvider("shared", &dummySharedProvider{
+		dummyProvider:    dummyProvider{dynamic: true},
+		filesystemSource: source,
+	})
+	s.AddCleanup(func(*gc.C) {
+		registry.RegisterProvider("shared", nil)
+	})
+	return source
+}
+
+func (s *storageProvisionerSuite) TestSharedFilesystemAttachmentAdded(c *gc.C) {
+	s.registerSharedProvider()
+
+	filesystemAttachmentInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemAttachmentInfo = func(filesystemAttachments []params.FilesystemAttachment) ([]params.ErrorResult, error) {
+		filesystemAttachmentInfoSet <- filesystemAttachments
+		return nil, nil
+	}
+	filesystemAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+	filesystemAccessor.sharedFilesystems["filesystem-5"] = "nfs-5"
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		names.NewMachineTag("1"),
+		"storage-dir",
+		newMockVolumeAccessor(),
+		filesystemAccessor,
+		&mockLifecycleManager{},
+		environAccessor,
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// The shared filesystem is not watched by the machine-scoped
+	// storage provisioner; the attachment is made using the
+	// filesystem ID in the attachment parameters.
+	filesystemAccessor.attachmentsWatcher.changes <- []params.MachineStorageId{{
+		MachineTag: "machine-1", AttachmentTag: "filesystem-5",
+	}}
+	environAccessor.watcher.changes <- struct{}{}
+	info := waitChannel(
+		c, filesystemAttachmentInfoSet, "waiting for filesystem attachment info to be set",
+	).([]params.FilesystemAttachment)
+	c.Assert(info, jc.DeepEquals, []params.FilesystemAttachment{{
+		FilesystemTag: "filesystem-5",
+		MachineTag:    "machine-1",
+		MountPoint:    "/srv/nfs-5",
+	}})
+}
+
+func (s *storageProvisionerSuite) TestSharedFilesystemAttachmentEnvironScoped(c *gc.C) {
+	s.registerSharedProvider()
+
+	filesystemAttachmentInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemAttachmentInfo = func(filesystemAttachments []params.FilesystemAttachment) ([]params.ErrorResult, error) {
+		filesystemAttachmentInfoSet <- filesystemAttachments
+		return nil, nil
+	}
+	filesystemAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+	filesystemAccessor.provisionedFilesystems["filesystem-5"] = params.Filesystem{
+		FilesystemTag: "filesystem-5",
+		FilesystemId:  "nfs-5",
+	}
+	filesystemAccessor.sharedFilesystems["filesystem-5"] = "nfs-5"
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"",
+		newMockVolumeAccessor(),
+		filesystemAccessor,
+		&mockLifecycleManager{},
+		environAccessor,
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Shared filesystems are attached by the storage
+	// provisioner of the machine that they are attached
+	// to, and not by the environment storage provisioner.
+	filesystemAccessor.attachmentsWatcher.changes <- []params.MachineStorageId{{
+		MachineTag: "machine-1", AttachmentTag: "filesystem-5",
+	}}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"5"}
+	environAccessor.watcher.changes <- struct{}{}
+	assertNoEvent(c, filesystemAttachmentInfoSet, "filesystem attachment info set")
+}
+
+func (s *storageProvisionerSuite) TestFilesystemAttachmentDetached(c *gc.C) {
+	source := s.registerSharedProvider()
+
+	attachmentRemoved := make(chan interface{})
+	lifecycleManager := &mockLifecycleManager{}
+	lifecycleManager.removeAttachments = func(ids []params.MachineStorageId) ([]params.ErrorResult, error) {
+		defer close(attachmentRemoved)
+		c.Assert(ids, jc.DeepEquals, []params.MachineStorageId{dyingFilesystemAttachmentId})
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionedMachines["machine-0"] = instance.Id("already-provisioned-0")
+	filesystemAccessor.sharedFilesystems["filesystem-0"] = "nfs-0"
+	filesystemAccessor.provisionedAttachments[dyingFilesystemAttachmentId] = params.FilesystemAttachment{
+		MachineTag: "machine-0", FilesystemTag: "filesystem-0", MountPoint: "/srv/data",
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		names.NewMachineTag("0"),
+		"storage-dir",
+		newMockVolumeAccessor(),
+		filesystemAccessor,
+		lifecycleManager,
+		environAccessor,
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.attachmentsWatcher.changes <- []params.MachineStorageId{dyingFilesystemAttachmentId}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, attachmentRemoved, "waiting for attachment to be removed")
+	c.Assert(source.detached, jc.DeepEquals, []storage.FilesystemAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Provider:   "shared",
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "already-provisioned-0",
+		},
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "nfs-0",
+		Path:         "/srv/data",
+	}})
+}
+
 func waitChannel(c *gc.C, ch <-chan interface{}, activity string) interface{} {
 	select {
 	case v := <-ch:
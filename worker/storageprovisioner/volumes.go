@@ -149,27 +149,36 @@ func processDyingVolumeAttachments(
 	for _, id := range ids {
 		delete(ctx.pendingVolumeAttachments, id)
 	}
-	volumeAttachments := make([]params.VolumeAttachment, len(volumeAttachmentResults))
+	attached := make([]params.MachineStorageId, 0, len(ids))
+	detached := make([]params.MachineStorageId, 0, len(ids))
 	for i, result := range volumeAttachmentResults {
-		if result.Error != nil {
+		if result.Error == nil {
+			attached = append(attached, ids[i])
+			continue
+		}
+		if !params.IsCodeNotProvisioned(result.Error) {
 			return errors.Annotatef(result.Error, "getting information for volume attachment %v", ids[i])
 		}
-		volumeAttachments[i] = result.Result
+		// The volume was never attached, so there
+		// is nothing to detach.
+		detached = append(detached, ids[i])
 	}
-	if len(volumeAttachments) == 0 {
-		return nil
-	}
-	errorResults, err := detachVolumes(volumeAttachments)
-	if err != nil {
-		return errors.Annotate(err, "detaching volumes")
-	}
-	detached := make([]params.MachineStorageId, 0, len(ids))
-	for i, id := range ids {
-		if err := errorResults[i]; err != nil {
-			logger.Errorf("detaching %v from %v: %v", ids[i].AttachmentTag, ids[i].MachineTag, err)
-			continue
+	if len(attached) > 0 {
+		errs, err := detachVolumes(ctx, attached)
+		if err != nil {
+			return errors.Annotate(err, "detaching volumes")
+		}
+		for i, id := range attached {
+			if err := errs[i]; err != nil {
+				logger.Errorf("detaching %v from %v: %v", id.AttachmentTag, id.MachineTag, err)
+				continue
+			}
+			delete(ctx.volumeAttachments, id)
+			detached = append(detached, id)
 		}
-		detached = append(detached, id)
+	}
+	if len(detached) == 0 {
+		return nil
 	}
 	if err := removeAttachments(ctx, detached); err != nil {
 		return errors.Annotate(err, "removing attachments from state")
@@ -499,8 +508,70 @@ func destroyVolumes(volumes []params.Volume) ([]error, error) {
 	panic("not implemented")
 }
 
-func detachVolumes(attachments []params.VolumeAttachment) ([]error, error) {
-	panic("not implemented")
+// detachVolumes detaches the volumes from the machines identified by
+// the specified attachment IDs, returning an error for each attachment
+// that could not be detached.
+func detachVolumes(ctx *context, ids []params.MachineStorageId) ([]error, error) {
+	paramsResults, err := ctx.volumeAccessor.VolumeAttachmentParams(ids)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting volume attachment parameters")
+	}
+	args := make([]storage.VolumeAttachmentParams, len(ids))
+	volumeTags := make([]names.VolumeTag, len(ids))
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			return nil, errors.Annotatef(
+				result.Error, "getting parameters for volume attachment %v", ids[i],
+			)
+		}
+		args[i], err = volumeAttachmentParamsFromParams(result.Result)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting volume attachment parameters")
+		}
+		volumeTags[i] = args[i].Volume
+	}
+	volumeResults, err := ctx.volumeAccessor.Volumes(volumeTags)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting volume information")
+	}
+	for i, result := range volumeResults {
+		if result.Error != nil {
+			return nil, errors.Annotatef(
+				result.Error, "getting information for volume %q", volumeTags[i].Id(),
+			)
+		}
+		args[i].VolumeId = result.Result.VolumeId
+	}
+
+	errs := make([]error, len(ids))
+	indicesBySource := make(map[string][]int)
+	for i, arg := range args {
+		sourceName := string(arg.Provider)
+		indicesBySource[sourceName] = append(indicesBySource[sourceName], i)
+	}
+	for sourceName, indices := range indicesBySource {
+		source, err := volumeSource(
+			ctx.environConfig, ctx.storageDir, sourceName, args[indices[0]].Provider,
+		)
+		if errors.Cause(err) == errNonDynamic {
+			// Volumes from non-dynamic sources are detached
+			// along with the machine, so there is nothing to do.
+			continue
+		} else if err != nil {
+			return nil, errors.Annotate(err, "getting volume source")
+		}
+		sourceArgs := make([]storage.VolumeAttachmentParams, len(indices))
+		for i, index := range indices {
+			sourceArgs[i] = args[index]
+		}
+		if err := source.DetachVolumes(sourceArgs); err != nil {
+			err = errors.Annotatef(err, "detaching volumes from source %q", sourceName)
+			for _, index := range indices {
+				errs[index] = err
+			}
+		}
+	}
+	return errs, nil
 }
 
 func volumesFromStorage(in []storage.Volume) []params.Volume {
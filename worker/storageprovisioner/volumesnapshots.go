@@ -0,0 +1,171 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+)
+
+// volumeSnapshotsChanged is called when the lifecycle states of the
+// volume snapshots with the provided IDs have been seen to have changed.
+func volumeSnapshotsChanged(ctx *context, ids []string) error {
+	results, err := ctx.volumeAccessor.VolumeSnapshotParams(ids)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot parameters")
+	}
+	var alive, dying []params.VolumeSnapshotParams
+	for i, result := range results {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The snapshot has been removed.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting parameters for volume snapshot %q", ids[i],
+			)
+		}
+		switch result.Result.Life {
+		case params.Alive:
+			if result.Result.SnapshotId == "" {
+				alive = append(alive, result.Result)
+			}
+		default:
+			dying = append(dying, result.Result)
+		}
+	}
+	logger.Debugf("volume snapshots to take: %v, to destroy: %v", len(alive), len(dying))
+	if err := createVolumeSnapshots(ctx, alive); err != nil {
+		return errors.Annotate(err, "taking volume snapshots")
+	}
+	if err := destroyVolumeSnapshots(ctx, dying); err != nil {
+		return errors.Annotate(err, "destroying volume snapshots")
+	}
+	return nil
+}
+
+// createVolumeSnapshots takes the specified volume snapshots, and
+// records their details in state.
+func createVolumeSnapshots(ctx *context, snapshotParams []params.VolumeSnapshotParams) error {
+	paramsBySource := make(map[string][]storage.VolumeSnapshotParams)
+	for _, p := range snapshotParams {
+		volumeTag, err := names.ParseVolumeTag(p.VolumeTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		paramsBySource[p.Provider] = append(paramsBySource[p.Provider], storage.VolumeSnapshotParams{
+			Id:       p.Id,
+			Volume:   volumeTag,
+			VolumeId: p.VolumeId,
+		})
+	}
+	var taken []params.VolumeSnapshot
+	for sourceName, args := range paramsBySource {
+		snapshotter, err := volumeSnapshotter(ctx, sourceName)
+		if errors.IsNotSupported(err) {
+			// TODO(axw) we should set an error status on the snapshots.
+			logger.Errorf("cannot take volume snapshots: %v", err)
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		snapshots, err := snapshotter.CreateSnapshots(args)
+		if err != nil {
+			return errors.Annotatef(err, "creating snapshots from source %q", sourceName)
+		}
+		for i, snapshot := range snapshots {
+			taken = append(taken, params.VolumeSnapshot{
+				Id:         args[i].Id,
+				VolumeTag:  args[i].Volume.String(),
+				SnapshotId: snapshot.SnapshotId,
+				Size:       snapshot.Size,
+				Created:    snapshot.Created,
+			})
+		}
+	}
+	if len(taken) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.volumeAccessor.SetVolumeSnapshotInfo(taken)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing volume snapshot %q to state", taken[i].Id,
+			)
+		}
+	}
+	return nil
+}
+
+// destroyVolumeSnapshots destroys the specified volume snapshots, and
+// then removes them from state.
+func destroyVolumeSnapshots(ctx *context, snapshotParams []params.VolumeSnapshotParams) error {
+	// Snapshots that were never taken may be removed immediately.
+	destroyed := make([]string, 0, len(snapshotParams))
+	snapshotsBySource := make(map[string][]params.VolumeSnapshotParams)
+	for _, p := range snapshotParams {
+		if p.SnapshotId == "" {
+			destroyed = append(destroyed, p.Id)
+			continue
+		}
+		snapshotsBySource[p.Provider] = append(snapshotsBySource[p.Provider], p)
+	}
+	for sourceName, snapshots := range snapshotsBySource {
+		snapshotter, err := volumeSnapshotter(ctx, sourceName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		snapshotIds := make([]string, len(snapshots))
+		for i, snapshot := range snapshots {
+			snapshotIds[i] = snapshot.SnapshotId
+		}
+		errs := snapshotter.DestroySnapshots(snapshotIds)
+		for i, err := range errs {
+			if err != nil {
+				logger.Errorf("destroying volume snapshot %q: %v", snapshots[i].Id, err)
+				continue
+			}
+			destroyed = append(destroyed, snapshots[i].Id)
+		}
+	}
+	if len(destroyed) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.volumeAccessor.RemoveVolumeSnapshots(destroyed)
+	if err != nil {
+		return errors.Annotate(err, "removing volume snapshots from state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "removing volume snapshot %q from state", destroyed[i],
+			)
+		}
+	}
+	return nil
+}
+
+// volumeSnapshotter returns the storage.VolumeSnapshotter for the
+// volume source with the given name, or an error satisfying
+// errors.IsNotSupported if the source cannot take snapshots.
+func volumeSnapshotter(ctx *context, sourceName string) (storage.VolumeSnapshotter, error) {
+	providerType := storage.ProviderType(sourceName)
+	source, err := volumeSource(ctx.environConfig, ctx.storageDir, sourceName, providerType)
+	if errors.Cause(err) == errNonDynamic {
+		return nil, errors.NotSupportedf("snapshots of non-dynamic %q volumes", sourceName)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting volume source")
+	}
+	snapshotter, ok := source.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf("snapshots of %q volumes", sourceName)
+	}
+	return snapshotter, nil
+}
@@ -13,9 +13,13 @@ func SetUniterObserver(u *Uniter, observer UniterExecutionObserver) {
 }
 
 var (
-	ActiveMetricsTimer  = &activeMetricsTimer
-	IdleWaitTime        = &idleWaitTime
-	LeadershipGuarantee = &leadershipGuarantee
+	ActiveMetricsTimer    = &activeMetricsTimer
+	IdleWaitTime          = &idleWaitTime
+	LeadershipGuarantee   = &leadershipGuarantee
+	HookRunsBatchSize     = &hookRunsBatchSize
+	HookRunsFlushInterval = &hookRunsFlushInterval
+	MaxBufferedHookRuns   = &maxBufferedHookRuns
+	NewHookRunRecorder    = newHookRunRecorder
 )
 
 // manualTicker will be used to generate collect-metrics events
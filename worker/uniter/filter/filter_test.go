@@ -289,6 +289,36 @@ func (s *FilterSuite) TestCharmUpgradeEvents(c *gc.C) {
 	upgradeC.AssertOneValue(newCharm.URL())
 }
 
+func (s *FilterSuite) TestCharmRollbackEvents(c *gc.C) {
+	oldCharm := s.AddTestingCharm(c, "upgrade1")
+	svc := s.AddTestingService(c, "upgradetest", oldCharm)
+	unit, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.APILogin(c, unit)
+
+	f, err := filter.NewFilter(s.uniter, unit.Tag().(names.UnitTag))
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertStop(c, f)
+	upgradeC := s.contentAsserterC(c, f.UpgradeEvents())
+
+	// Upgrade the unit to a new charm.
+	newCharm := s.AddTestingCharm(c, "upgrade2")
+	err = svc.SetCharm(newCharm, false)
+	c.Assert(err, jc.ErrorIsNil)
+	upgradeC.AssertOneValue(newCharm.URL())
+	err = f.SetCharm(newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	upgradeC.AssertNoReceive()
+
+	// Rolling the charm back is an upgrade to the older revision.
+	err = svc.RollbackCharm(false)
+	c.Assert(err, jc.ErrorIsNil)
+	upgradeC.AssertOneValue(oldCharm.URL())
+}
+
 func (s *FilterSuite) TestConfigEvents(c *gc.C) {
 	f, err := filter.NewFilter(s.uniter, s.unit.Tag().(names.UnitTag))
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,97 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+var (
+	// hookRunsBatchSize is how many runs are buffered before they are
+	// sent to the state server.
+	hookRunsBatchSize = 20
+
+	// hookRunsFlushInterval is how long runs are buffered for, at most,
+	// before they are sent to the state server once another is recorded.
+	hookRunsFlushInterval = 5 * time.Minute
+
+	// maxBufferedHookRuns is how many runs are kept while they cannot be
+	// sent; beyond that, the oldest are discarded.
+	maxBufferedHookRuns = 1000
+)
+
+// hookRunSender sends runs of hooks, actions and commands to the state
+// server.
+type hookRunSender interface {
+	AddHookRuns(runs []params.HookRun) error
+}
+
+// hookRunRecorder buffers the runs recorded by the operation executor
+// and sends them to the state server in batches.
+type hookRunRecorder struct {
+	sender    hookRunSender
+	runs      []params.HookRun
+	lastFlush time.Time
+	disabled  bool
+}
+
+func newHookRunRecorder(sender hookRunSender) *hookRunRecorder {
+	return &hookRunRecorder{
+		sender:    sender,
+		lastFlush: time.Now(),
+	}
+}
+
+// Record is an operation.RecordRunFunc. It buffers the run, and sends
+// the buffered runs once there are enough of them, or they have been
+// held for long enough.
+func (r *hookRunRecorder) Record(run operation.Run) {
+	if r.disabled {
+		return
+	}
+	status := "completed"
+	if run.Failed {
+		status = "failed"
+	}
+	r.runs = append(r.runs, params.HookRun{
+		Kind:     string(run.Kind),
+		Name:     run.Name,
+		Started:  run.Started,
+		Finished: run.Finished,
+		Status:   status,
+	})
+	if len(r.runs) > maxBufferedHookRuns {
+		r.runs = r.runs[len(r.runs)-maxBufferedHookRuns:]
+	}
+	if len(r.runs) >= hookRunsBatchSize || time.Since(r.lastFlush) >= hookRunsFlushInterval {
+		if err := r.Flush(); err != nil {
+			logger.Warningf("cannot record hook runs: %v", err)
+		}
+	}
+}
+
+// Flush sends any buffered runs to the state server. If they cannot be
+// sent, they are kept to be sent with the next batch.
+func (r *hookRunRecorder) Flush() error {
+	if r.disabled || len(r.runs) == 0 {
+		return nil
+	}
+	r.lastFlush = time.Now()
+	err := r.sender.AddHookRuns(r.runs)
+	if errors.IsNotImplemented(err) {
+		logger.Infof("state server cannot record hook runs")
+		r.disabled = true
+		r.runs = nil
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	r.runs = nil
+	return nil
+}
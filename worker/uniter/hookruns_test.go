@@ -0,0 +1,129 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type HookRunRecorderSuite struct {
+	testing.IsolationSuite
+	sender *fakeHookRunSender
+}
+
+var _ = gc.Suite(&HookRunRecorderSuite{})
+
+func (s *HookRunRecorderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.sender = &fakeHookRunSender{}
+	s.PatchValue(uniter.HookRunsBatchSize, 2)
+	s.PatchValue(uniter.MaxBufferedHookRuns, 3)
+}
+
+type fakeHookRunSender struct {
+	sent [][]params.HookRun
+	err  error
+}
+
+func (f *fakeHookRunSender) AddHookRuns(runs []params.HookRun) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, runs)
+	return nil
+}
+
+var hookRunStarted = time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC)
+
+func opRun(name string, failed bool) operation.Run {
+	return operation.Run{
+		Kind:     operation.RunKindHook,
+		Name:     name,
+		Started:  hookRunStarted,
+		Finished: hookRunStarted.Add(time.Second),
+		Failed:   failed,
+	}
+}
+
+func paramsRun(name, status string) params.HookRun {
+	return params.HookRun{
+		Kind:     "hook",
+		Name:     name,
+		Started:  hookRunStarted,
+		Finished: hookRunStarted.Add(time.Second),
+		Status:   status,
+	}
+}
+
+func (s *HookRunRecorderSuite) TestBatches(c *gc.C) {
+	recorder := uniter.NewHookRunRecorder(s.sender)
+	recorder.Record(opRun("install", false))
+	c.Assert(s.sender.sent, gc.HasLen, 0)
+	recorder.Record(opRun("config-changed", true))
+	c.Assert(s.sender.sent, jc.DeepEquals, [][]params.HookRun{{
+		paramsRun("install", "completed"),
+		paramsRun("config-changed", "failed"),
+	}})
+
+	recorder.Record(opRun("start", false))
+	err := recorder.Flush()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.sender.sent, gc.HasLen, 2)
+	c.Assert(s.sender.sent[1], jc.DeepEquals, []params.HookRun{paramsRun("start", "completed")})
+}
+
+func (s *HookRunRecorderSuite) TestFlushInterval(c *gc.C) {
+	s.PatchValue(uniter.HookRunsFlushInterval, time.Duration(0))
+	recorder := uniter.NewHookRunRecorder(s.sender)
+	recorder.Record(opRun("install", false))
+	c.Assert(s.sender.sent, jc.DeepEquals, [][]params.HookRun{{
+		paramsRun("install", "completed"),
+	}})
+}
+
+func (s *HookRunRecorderSuite) TestKeepsRunsOnError(c *gc.C) {
+	s.sender.err = errors.New("boom")
+	recorder := uniter.NewHookRunRecorder(s.sender)
+	for _, name := range []string{"install", "config-changed", "start", "update-status"} {
+		recorder.Record(opRun(name, false))
+	}
+	c.Assert(s.sender.sent, gc.HasLen, 0)
+	err := recorder.Flush()
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	// Only the latest runs are kept.
+	s.sender.err = nil
+	err = recorder.Flush()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.sender.sent, jc.DeepEquals, [][]params.HookRun{{
+		paramsRun("config-changed", "completed"),
+		paramsRun("start", "completed"),
+		paramsRun("update-status", "completed"),
+	}})
+}
+
+func (s *HookRunRecorderSuite) TestDisabledWhenNotImplemented(c *gc.C) {
+	s.sender.err = errors.NotImplementedf("AddHookRuns() (need V2+)")
+	recorder := uniter.NewHookRunRecorder(s.sender)
+	recorder.Record(opRun("install", false))
+	recorder.Record(opRun("start", false))
+	err := recorder.Flush()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.sender.err = nil
+	recorder.Record(opRun("config-changed", false))
+	recorder.Record(opRun("config-changed", false))
+	err = recorder.Flush()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.sender.sent, gc.HasLen, 0)
+}
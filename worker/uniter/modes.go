@@ -448,6 +448,10 @@ func ModeHookError(u *Uniter) (next Mode, err error) {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if opState.HookTimedOut > 0 {
+		statusData["timeout"] = opState.HookTimedOut.String()
+		statusMessage = fmt.Sprintf("hook timed out after %v: %q", opState.HookTimedOut, hookName)
+	}
 
 	// Run the select loop.
 	u.f.WantResolvedEvent()
@@ -5,6 +5,7 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	corecharm "gopkg.in/juju/charm.v5"
@@ -25,16 +26,43 @@ var (
 	stepCommit  = executorStep{"committing", Operation.Commit}
 )
 
+// RunKind describes what an operation ran.
+type RunKind string
+
+const (
+	RunKindHook     RunKind = "hook"
+	RunKindAction   RunKind = "action"
+	RunKindCommands RunKind = "commands"
+)
+
+// Run records a single run of a hook, action or juju-run commands by
+// an Executor.
+type Run struct {
+	Kind     RunKind
+	Name     string
+	Started  time.Time
+	Finished time.Time
+	Failed   bool
+}
+
+// RecordRunFunc is called by an Executor after it runs a hook, action
+// or juju-run commands.
+type RecordRunFunc func(Run)
+
 type executor struct {
-	file  *StateFile
-	state *State
+	file      *StateFile
+	state     *State
+	recordRun RecordRunFunc
 }
 
 // NewExecutor returns an Executor which takes its starting state from the
 // supplied path, and records state changes there. If no state file exists,
 // the executor's starting state will include a queued Install hook, for
-// the charm identified by the supplied func.
-func NewExecutor(stateFilePath string, getInstallCharm func() (*corecharm.URL, error)) (Executor, error) {
+// the charm identified by the supplied func. If recordRun is not nil, it
+// is called after every hook, action or juju-run commands is executed.
+func NewExecutor(
+	stateFilePath string, getInstallCharm func() (*corecharm.URL, error), recordRun RecordRunFunc,
+) (Executor, error) {
 	file := NewStateFile(stateFilePath)
 	state, err := file.Read()
 	if err == ErrNoStateFile {
@@ -51,8 +79,9 @@ func NewExecutor(stateFilePath string, getInstallCharm func() (*corecharm.URL, e
 		return nil, err
 	}
 	return &executor{
-		file:  file,
-		state: state,
+		file:      file,
+		state:     state,
+		recordRun: recordRun,
 	}, nil
 }
 
@@ -67,7 +96,10 @@ func (x *executor) Run(op Operation) error {
 	switch err := x.do(op, stepPrepare); errors.Cause(err) {
 	case ErrSkipExecute:
 	case nil:
-		if err := x.do(op, stepExecute); err != nil {
+		started := time.Now()
+		err := x.do(op, stepExecute)
+		x.record(op, started, err)
+		if err != nil {
 			return err
 		}
 	default:
@@ -82,6 +114,41 @@ func (x *executor) Skip(op Operation) error {
 	return x.do(op, stepCommit)
 }
 
+// record reports the execution of op, if it ran a hook, action or
+// commands, to the executor's recordRun func.
+func (x *executor) record(op Operation, started time.Time, err error) {
+	if x.recordRun == nil {
+		return
+	}
+	kind, name, ok := runDetails(op)
+	if !ok {
+		return
+	}
+	x.recordRun(Run{
+		Kind:     kind,
+		Name:     name,
+		Started:  started,
+		Finished: time.Now(),
+		Failed:   err != nil && errors.Cause(err) != ErrNeedsReboot,
+	})
+}
+
+// runDetails returns what op ran, and false if it did not run a hook,
+// action or commands.
+func runDetails(op Operation) (RunKind, string, bool) {
+	switch op := op.(type) {
+	case *resolvedOperation:
+		return runDetails(op.Operation)
+	case *runHook:
+		return RunKindHook, op.name, !op.missing
+	case *runAction:
+		return RunKindAction, op.name, true
+	case *runCommands:
+		return RunKindCommands, "juju-run", true
+	}
+	return "", "", false
+}
+
 func (x *executor) do(op Operation, step executorStep) (err error) {
 	message := step.message(op)
 	logger.Infof(message)
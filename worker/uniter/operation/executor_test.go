@@ -16,6 +16,7 @@ import (
 
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/runner"
 )
 
 type NewExecutorSuite struct {
@@ -39,14 +40,14 @@ func (s *NewExecutorSuite) path(path string) string {
 }
 
 func (s *NewExecutorSuite) TestNewExecutorNoFileNoCharm(c *gc.C) {
-	executor, err := operation.NewExecutor(s.path("missing"), failGetInstallCharm)
+	executor, err := operation.NewExecutor(s.path("missing"), failGetInstallCharm, nil)
 	c.Assert(executor, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, "lol!")
 }
 
 func (s *NewExecutorSuite) TestNewExecutorInvalidFile(c *gc.C) {
 	ft.File{"existing", "", 0666}.Create(c, s.basePath)
-	executor, err := operation.NewExecutor(s.path("existing"), failGetInstallCharm)
+	executor, err := operation.NewExecutor(s.path("existing"), failGetInstallCharm, nil)
 	c.Assert(executor, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, `cannot read ".*": invalid operation state: .*`)
 }
@@ -56,7 +57,7 @@ func (s *NewExecutorSuite) TestNewExecutorNoFile(c *gc.C) {
 	getInstallCharm := func() (*corecharm.URL, error) {
 		return charmURL, nil
 	}
-	executor, err := operation.NewExecutor(s.path("missing"), getInstallCharm)
+	executor, err := operation.NewExecutor(s.path("missing"), getInstallCharm, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(executor.State(), gc.DeepEquals, operation.State{
 		Kind:     operation.Install,
@@ -76,7 +77,7 @@ started: true
 op: continue
 opstep: pending
 `[1:], 0666}.Create(c, s.basePath)
-	executor, err := operation.NewExecutor(s.path("existing"), failGetInstallCharm)
+	executor, err := operation.NewExecutor(s.path("existing"), failGetInstallCharm, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(executor.State(), gc.DeepEquals, operation.State{
 		Kind:    operation.Continue,
@@ -101,7 +102,7 @@ func newExecutor(c *gc.C, st *operation.State) (operation.Executor, string) {
 	path := filepath.Join(c.MkDir(), "state")
 	err := operation.NewStateFile(path).Write(st)
 	c.Assert(err, jc.ErrorIsNil)
-	executor, err := operation.NewExecutor(path, failGetInstallCharm)
+	executor, err := operation.NewExecutor(path, failGetInstallCharm, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	return executor, path
 }
@@ -320,6 +321,88 @@ func (s *ExecutorSuite) TestFailCommitWithStateChange(c *gc.C) {
 	c.Assert(executor.State(), gc.DeepEquals, *op.commit.newState)
 }
 
+type recordingHookCallbacks struct {
+	*ExecuteHookCallbacks
+	commitHook *MockCommitHook
+}
+
+func (cb *recordingHookCallbacks) CommitHook(hookInfo hook.Info) error {
+	return cb.commitHook.Call(hookInfo)
+}
+
+func newRecordingExecutor(c *gc.C) (operation.Executor, *[]operation.Run) {
+	initialState := justInstalledState()
+	path := filepath.Join(c.MkDir(), "state")
+	err := operation.NewStateFile(path).Write(&initialState)
+	c.Assert(err, jc.ErrorIsNil)
+	var runs []operation.Run
+	executor, err := operation.NewExecutor(path, failGetInstallCharm, func(run operation.Run) {
+		runs = append(runs, run)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return executor, &runs
+}
+
+func newRecordedHook(c *gc.C, runErr error) operation.Operation {
+	callbacks := &recordingHookCallbacks{
+		ExecuteHookCallbacks: &ExecuteHookCallbacks{
+			PrepareHookCallbacks:     NewPrepareHookCallbacks(),
+			MockAcquireExecutionLock: &MockAcquireExecutionLock{},
+			MockNotifyHookCompleted:  &MockNotify{},
+			MockNotifyHookFailed:     &MockNotify{},
+		},
+		commitHook: &MockCommitHook{},
+	}
+	factory := operation.NewFactory(nil, NewRunHookRunnerFactory(runErr), callbacks, nil, nil)
+	op, err := factory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	return op
+}
+
+func (s *ExecutorSuite) TestRecordsHookRun(c *gc.C) {
+	executor, runs := newRecordingExecutor(c)
+
+	err := executor.Run(newRecordedHook(c, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*runs, gc.HasLen, 1)
+	run := (*runs)[0]
+	c.Check(run.Kind, gc.Equals, operation.RunKindHook)
+	c.Check(run.Name, gc.Equals, "some-hook-name")
+	c.Check(run.Failed, jc.IsFalse)
+	c.Check(run.Finished.Before(run.Started), jc.IsFalse)
+}
+
+func (s *ExecutorSuite) TestRecordsFailedHookRun(c *gc.C) {
+	executor, runs := newRecordingExecutor(c)
+
+	err := executor.Run(newRecordedHook(c, errors.New("pow")))
+	c.Assert(errors.Cause(err), gc.Equals, operation.ErrHookFailed)
+	c.Assert(*runs, gc.HasLen, 1)
+	c.Check((*runs)[0].Name, gc.Equals, "some-hook-name")
+	c.Check((*runs)[0].Failed, jc.IsTrue)
+}
+
+func (s *ExecutorSuite) TestDoesNotRecordMissingHook(c *gc.C) {
+	executor, runs := newRecordingExecutor(c)
+
+	err := executor.Run(newRecordedHook(c, runner.NewMissingHookError("some-hook-name")))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*runs, gc.HasLen, 0)
+}
+
+func (s *ExecutorSuite) TestDoesNotRecordOtherOperations(c *gc.C) {
+	executor, runs := newRecordingExecutor(c)
+	op := &mockOperation{
+		prepare: newStep(nil, nil),
+		execute: newStep(nil, nil),
+		commit:  newStep(nil, nil),
+	}
+
+	err := executor.Run(op)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*runs, gc.HasLen, 0)
+}
+
 type mockStep struct {
 	gotState operation.State
 	newState *operation.State
@@ -24,6 +24,9 @@ type runHook struct {
 
 	name   string
 	runner runner.Runner
+
+	// missing records that the charm does not implement the hook.
+	missing bool
 }
 
 // String is part of the Operation interface.
@@ -36,7 +39,7 @@ func (rh *runHook) String() string {
 		} else {
 			suffix = fmt.Sprintf(" (%d; %s)", rh.info.RelationId, rh.info.RemoteUnit)
 		}
-	case rh.info.Kind.IsStorage():
+	case hook.IsStorage(rh.info.Kind):
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
@@ -96,6 +99,7 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	switch {
 	case runner.IsMissingHookError(cause):
 		ranHook = false
+		rh.missing = true
 		err = nil
 	case cause == runner.ErrRequeueAndReboot:
 		step = Queued
@@ -106,6 +110,16 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		if cause == runner.ErrHookTimedOut {
+			// Record the timeout, so that the unit's status can
+			// say why the hook failed.
+			return stateChange{
+				Kind:         RunHook,
+				Step:         Pending,
+				Hook:         &rh.info,
+				HookTimedOut: rh.runner.Context().HookTimeout(),
+			}.apply(state), ErrHookFailed
+		}
 		return nil, ErrHookFailed
 	}
 
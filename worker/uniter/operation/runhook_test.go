@@ -320,6 +320,32 @@ func (s *RunHookSuite) TestExecuteOtherError_Retry(c *gc.C) {
 	s.testExecuteOtherError(c, (operation.Factory).NewRetryHook)
 }
 
+func (s *RunHookSuite) testExecuteTimedOut(c *gc.C, newHook newHook) {
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, newHook, hooks.ConfigChanged, runner.ErrHookTimedOut)
+	runnerFactory.MockNewHookRunner.runner.context.(*MockContext).hookTimeout = 10 * time.Minute
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(*midState)
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:         operation.RunHook,
+		Step:         operation.Pending,
+		Hook:         &hook.Info{Kind: hooks.ConfigChanged},
+		HookTimedOut: 10 * time.Minute,
+	})
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+}
+
+func (s *RunHookSuite) TestExecuteTimedOut_Run(c *gc.C) {
+	s.testExecuteTimedOut(c, (operation.Factory).NewRunHook)
+}
+
+func (s *RunHookSuite) TestExecuteTimedOut_Retry(c *gc.C) {
+	s.testExecuteTimedOut(c, (operation.Factory).NewRetryHook)
+}
+
 func (s *RunHookSuite) testExecuteSuccess(
 	c *gc.C, newHook newHook, before, after operation.State, setStatusCalled bool,
 ) {
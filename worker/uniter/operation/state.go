@@ -96,6 +96,10 @@ type State struct {
 	// It's set to nil if the hook was not run at all. Recording time as int64
 	// because the yaml encoder cannot encode the time.Time struct.
 	CollectMetricsTime int64 `yaml:"collectmetricstime,omitempty"`
+
+	// HookTimedOut records the timeout after which the running hook was
+	// killed, if it was. It is only set when Kind is RunHook.
+	HookTimedOut time.Duration `yaml:"hook-timed-out,omitempty"`
 }
 
 // validate returns an error if the state violates expectations.
@@ -149,6 +153,9 @@ func (st State) validate() (err error) {
 	default:
 		return errors.Errorf("unknown operation %q", st.Kind)
 	}
+	if st.HookTimedOut != 0 && st.Kind != RunHook {
+		return errors.Errorf("unexpected hook timeout with Kind %v", st.Kind)
+	}
 	switch st.Step {
 	case Queued, Pending, Done:
 	default:
@@ -172,6 +179,7 @@ type stateChange struct {
 	ActionId        *string
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
+	HookTimedOut    time.Duration
 }
 
 func (change stateChange) apply(state State) *State {
@@ -181,6 +189,7 @@ func (change stateChange) apply(state State) *State {
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
+	state.HookTimedOut = change.HookTimedOut
 	return &state
 }
 
@@ -5,6 +5,7 @@ package operation_test
 
 import (
 	"path/filepath"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -135,6 +136,13 @@ var stateTests = []struct {
 			Step: operation.Pending,
 			Hook: relhook,
 		},
+	}, {
+		st: operation.State{
+			Kind:         operation.RunHook,
+			Step:         operation.Pending,
+			Hook:         &hook.Info{Kind: hooks.ConfigChanged},
+			HookTimedOut: 5 * time.Minute,
+		},
 	},
 	// Upgrade operation.
 	{
@@ -180,6 +188,13 @@ var stateTests = []struct {
 			ActionId: &someActionId,
 		},
 		err: `unexpected action id`,
+	}, {
+		st: operation.State{
+			Kind:         operation.Continue,
+			Step:         operation.Pending,
+			HookTimedOut: 5 * time.Minute,
+		},
+		err: `unexpected hook timeout with Kind continue`,
 	}, {
 		st: operation.State{
 			Kind:               operation.Continue,
@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	utilexec "github.com/juju/utils/exec"
 	corecharm "gopkg.in/juju/charm.v5"
@@ -326,6 +328,7 @@ type MockContext struct {
 	actionData      *runner.ActionData
 	setStatusCalled bool
 	status          jujuc.StatusInfo
+	hookTimeout     time.Duration
 }
 
 func (mock *MockContext) ActionData() (*runner.ActionData, error) {
@@ -335,6 +338,10 @@ func (mock *MockContext) ActionData() (*runner.ActionData, error) {
 	return mock.actionData, nil
 }
 
+func (mock *MockContext) HookTimeout() time.Duration {
+	return mock.hookTimeout
+}
+
 func (mock *MockContext) HasExecutionSetUnitStatus() bool {
 	return mock.setStatusCalled
 }
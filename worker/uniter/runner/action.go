@@ -4,7 +4,14 @@
 package runner
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
 	"github.com/juju/names"
+	goyaml "gopkg.in/yaml.v1"
 )
 
 // ActionData contains the tag, parameters, and results of an Action.
@@ -12,6 +19,7 @@ type ActionData struct {
 	ActionName     string
 	ActionTag      names.ActionTag
 	ActionParams   map[string]interface{}
+	ActionTimeout  time.Duration
 	ActionFailed   bool
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
@@ -19,13 +27,42 @@ type ActionData struct {
 
 // NewActionData builds a suitable ActionData struct with no nil members.
 // this should only be called in the event that an Action hook is being requested.
-func newActionData(name string, tag *names.ActionTag, params map[string]interface{}) *ActionData {
+func newActionData(name string, tag *names.ActionTag, params map[string]interface{}, timeout time.Duration) *ActionData {
 	return &ActionData{
-		ActionName:   name,
-		ActionTag:    *tag,
-		ActionParams: params,
-		ResultsMap:   map[string]interface{}{},
+		ActionName:    name,
+		ActionTag:     *tag,
+		ActionParams:  params,
+		ActionTimeout: timeout,
+		ResultsMap:    map[string]interface{}{},
+	}
+}
+
+// actionSpecTimeout returns the timeout declared for the named action
+// in the actions.yaml of the charm in charmDir, or zero if the action
+// declares none. The charm package does not know about action
+// timeouts, so actions.yaml is read directly.
+func actionSpecTimeout(charmDir, name string) (time.Duration, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "actions.yaml"))
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var specs map[string]struct {
+		Timeout string `yaml:"timeout"`
+	}
+	if err := goyaml.Unmarshal(data, &specs); err != nil {
+		return 0, errors.Annotate(err, "cannot parse actions.yaml")
+	}
+	spec := specs[name].Timeout
+	if spec == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(spec)
+	if err != nil || timeout < 0 {
+		return 0, errors.Errorf("invalid timeout %q for action %q in actions.yaml", spec, name)
 	}
+	return timeout, nil
 }
 
 // actionStatus messages define the possible states of a completed Action.
@@ -80,6 +80,10 @@ type HookContext struct {
 	// its tag, its parameters, and its results.
 	actionData *ActionData
 
+	// hookTimeout is how long the hook may run before it is killed.
+	// Zero means the hook is never killed.
+	hookTimeout time.Duration
+
 	// uuid is the universally unique identifier of the environment.
 	uuid string
 
@@ -343,6 +347,15 @@ func (ctx *HookContext) SetActionFailed() error {
 	return nil
 }
 
+// LogActionMessage records a progress message for the action with the
+// state server, so that it can be followed while the action runs.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.state.LogActionMessage(ctx.actionData.ActionTag, message)
+}
+
 // UpdateActionResults inserts new values for use with action-set and
 // action-fail.  The results struct will be delivered to the state server
 // upon completion of the Action.  It returns an error if not called on an
@@ -613,19 +626,56 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 	// and discard the error state.  Actions should not error the uniter.
 	if err != nil {
 		message = err.Error()
-		if IsMissingHookError(err) {
+		switch cause := errors.Cause(err); {
+		case cause == ErrActionCancelled:
+			// The action was finished when it was cancelled.
+			return unhandledErr
+		case cause == ErrActionTimedOut:
+			message = fmt.Sprintf("action timed out after %v", ctx.actionData.ActionTimeout)
+			status = params.ActionTimedOut
+		case IsMissingHookError(cause):
 			message = fmt.Sprintf("action not implemented on unit %q", ctx.unitName)
+			status = params.ActionFailed
+		default:
+			status = params.ActionFailed
 		}
-		status = params.ActionFailed
 	}
 
 	callErr := ctx.state.ActionFinish(tag, status, results, message)
+	if callErr != nil && ctx.actionCancelled() {
+		// The action was cancelled after it finished running, but
+		// before its results were recorded; they are discarded.
+		logger.Infof("action %q cancelled, discarding its results", ctx.actionData.ActionName)
+		callErr = nil
+	}
 	if callErr != nil {
 		unhandledErr = errors.Wrap(unhandledErr, callErr)
 	}
 	return unhandledErr
 }
 
+// ActionStatus returns the status of the action being run in the
+// context, as recorded by the state server.
+func (ctx *HookContext) ActionStatus() (string, error) {
+	if ctx.actionData == nil {
+		return "", errors.New("not running an action")
+	}
+	return ctx.state.ActionStatus(ctx.actionData.ActionTag)
+}
+
+// HookTimeout returns how long the hook run in the context may run
+// before it is killed. Zero means the hook is never killed.
+func (ctx *HookContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
+// actionCancelled reports whether the action being run in the context
+// has been cancelled.
+func (ctx *HookContext) actionCancelled() bool {
+	status, err := ctx.ActionStatus()
+	return err == nil && status == params.ActionCancelled
+}
+
 // killCharmHook tries to kill the current running charm hook.
 func (ctx *HookContext) killCharmHook() error {
 	proc := ctx.GetProcess()
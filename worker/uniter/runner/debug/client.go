@@ -11,16 +11,49 @@ import (
 )
 
 type hookArgs struct {
-	Hooks []string `yaml:"hooks,omitempty"`
+	Hooks     []string `yaml:"hooks,omitempty"`
+	Actions   []string `yaml:"actions,omitempty"`
+	Relations []string `yaml:"relations,omitempty"`
+}
+
+// Filter narrows down the hooks and actions intercepted by a
+// debug-hooks session. An empty Filter intercepts every hook and
+// action.
+type Filter struct {
+	// Hooks holds the names of the hooks to intercept. If any is "*",
+	// all hooks are intercepted.
+	Hooks []string
+
+	// Actions holds the names of the actions to intercept. If any is
+	// "*", all actions are intercepted.
+	Actions []string
+
+	// Relations holds filters of the form "<relation name>" or
+	// "<relation name>:<remote unit>". If any are given, only relation
+	// hooks run for a matching relation and remote unit are
+	// intercepted.
+	Relations []string
 }
 
 // ClientScript returns a bash script suitable for executing
 // on the unit system to intercept hooks via tmux shell.
 func ClientScript(c *HooksContext, hooks []string) string {
-	// If any hook is "*", then the client is interested in all.
-	for _, hook := range hooks {
+	return FilteredClientScript(c, Filter{Hooks: hooks})
+}
+
+// FilteredClientScript returns a bash script suitable for executing on
+// the unit system to intercept the hooks and actions matching the
+// filter via tmux shell.
+func FilteredClientScript(c *HooksContext, filter Filter) string {
+	// If any hook is "*", then the client is interested in all hooks;
+	// that is recorded as no hooks at all unless there are other
+	// filters, which would otherwise narrow down the hooks intercepted.
+	for _, hook := range filter.Hooks {
 		if hook == "*" {
-			hooks = nil
+			filter.Hooks = nil
+			if len(filter.Actions) > 0 || len(filter.Relations) > 0 {
+				filter.Hooks = []string{"*"}
+			}
 			break
 		}
 	}
@@ -30,15 +63,19 @@ func ClientScript(c *HooksContext, hooks []string) string {
 	s = strings.Replace(s, "{entry_flock}", c.ClientFileLock(), -1)
 	s = strings.Replace(s, "{exit_flock}", c.ClientExitFileLock(), -1)
 
-	yamlArgs := encodeArgs(hooks)
+	yamlArgs := encodeArgs(hookArgs{
+		Hooks:     filter.Hooks,
+		Actions:   filter.Actions,
+		Relations: filter.Relations,
+	})
 	base64Args := base64.StdEncoding.EncodeToString(yamlArgs)
 	s = strings.Replace(s, "{hook_args}", base64Args, 1)
 	return s
 }
 
-func encodeArgs(hooks []string) []byte {
+func encodeArgs(args hookArgs) []byte {
 	// Marshal to YAML, then encode in base64 to avoid shell escapes.
-	yamlArgs, err := goyaml.Marshal(hookArgs{Hooks: hooks})
+	yamlArgs, err := goyaml.Marshal(args)
 	if err != nil {
 		// This should not happen: we're in full control.
 		panic(err)
@@ -4,9 +4,11 @@
 package debug_test
 
 import (
+	"encoding/base64"
 	"fmt"
 	"regexp"
 
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/worker/uniter/runner/debug"
@@ -45,3 +47,36 @@ func (*DebugHooksClientSuite) TestClientScript(c *gc.C) {
 	)
 	c.Assert(debug.ClientScript(ctx, []string{"something somethingelse"}), gc.Matches, expected)
 }
+
+func (*DebugHooksClientSuite) TestFilteredClientScript(c *gc.C) {
+	ctx := debug.NewHooksContext("foo/8")
+
+	// A filter with only hooks is the same as ClientScript, including
+	// the treatment of "*".
+	c.Assert(debug.FilteredClientScript(ctx, debug.Filter{}), gc.Equals, debug.ClientScript(ctx, nil))
+	c.Assert(
+		debug.FilteredClientScript(ctx, debug.Filter{Hooks: []string{"*", "start"}}),
+		gc.Equals, debug.ClientScript(ctx, nil),
+	)
+
+	for i, test := range []struct {
+		filter debug.Filter
+		args   string
+	}{{
+		filter: debug.Filter{Actions: []string{"backup"}},
+		args:   "actions:\n- backup\n",
+	}, {
+		filter: debug.Filter{Hooks: []string{"*"}, Actions: []string{"backup"}},
+		args:   "hooks:\n- '*'\nactions:\n- backup\n",
+	}, {
+		filter: debug.Filter{Relations: []string{"db:mysql/1"}},
+		args:   "relations:\n- db:mysql/1\n",
+	}} {
+		c.Logf("test %d: %#v", i, test.filter)
+		script := debug.FilteredClientScript(ctx, test.filter)
+		encoded := regexp.MustCompile(`echo "([A-Za-z0-9+/=]+)" \| base64 -d`).FindStringSubmatch(script)[1]
+		args, err := base64.StdEncoding.DecodeString(encoded)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(string(args), gc.Equals, test.args)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/juju/utils/set"
 	goyaml "gopkg.in/yaml.v1"
@@ -17,13 +18,86 @@ import (
 // ServerSession represents a "juju debug-hooks" session.
 type ServerSession struct {
 	*HooksContext
-	hooks set.Strings
+	hooks     set.Strings
+	actions   set.Strings
+	relations []relationFilter
+}
+
+// HookInfo describes a hook or action that may be intercepted by a
+// debug-hooks session.
+type HookInfo struct {
+	// Name is the name of the hook or action.
+	Name string
+
+	// IsAction is true if Name is the name of an action.
+	IsAction bool
+
+	// RelationName is the name of the relation the hook is run for,
+	// if any.
+	RelationName string
+
+	// RemoteUnit is the name of the remote unit the hook is run for,
+	// if any.
+	RemoteUnit string
+}
+
+// relationFilter matches hooks run for a relation, and optionally for
+// one remote unit in it.
+type relationFilter struct {
+	relation   string
+	remoteUnit string
+}
+
+// parseRelationFilter parses a filter of the form "<relation name>" or
+// "<relation name>:<remote unit>".
+func parseRelationFilter(filter string) relationFilter {
+	parts := strings.SplitN(filter, ":", 2)
+	f := relationFilter{relation: parts[0]}
+	if len(parts) == 2 {
+		f.remoteUnit = parts[1]
+	}
+	return f
+}
+
+func (f relationFilter) match(info HookInfo) bool {
+	if info.RelationName != f.relation {
+		return false
+	}
+	return f.remoteUnit == "" || f.remoteUnit == info.RemoteUnit
 }
 
 // MatchHook returns true if the specified hook name matches
 // the hook specified by the debug-hooks client.
 func (s *ServerSession) MatchHook(hookName string) bool {
-	return s.hooks.IsEmpty() || s.hooks.Contains(hookName)
+	return s.Match(HookInfo{Name: hookName})
+}
+
+// Match returns true if the specified hook or action matches the
+// filters specified by the debug-hooks client. A session with no
+// filters matches every hook and action.
+func (s *ServerSession) Match(info HookInfo) bool {
+	if s.hooks.IsEmpty() && s.actions.IsEmpty() && len(s.relations) == 0 {
+		return true
+	}
+	if info.IsAction {
+		return s.actions.Contains("*") || s.actions.Contains(info.Name)
+	}
+	if s.hooks.IsEmpty() && len(s.relations) == 0 {
+		// Only actions are being debugged.
+		return false
+	}
+	if !s.hooks.IsEmpty() && !s.hooks.Contains("*") && !s.hooks.Contains(info.Name) {
+		return false
+	}
+	if len(s.relations) == 0 {
+		return true
+	}
+	for _, f := range s.relations {
+		if f.match(info) {
+			return true
+		}
+	}
+	return false
 }
 
 // waitClientExit executes flock, waiting for the SSH client to exit.
@@ -75,8 +149,14 @@ func (c *HooksContext) FindSession() (*ServerSession, error) {
 	if err != nil {
 		return nil, err
 	}
-	hooks := set.NewStrings(args.Hooks...)
-	session := &ServerSession{c, hooks}
+	session := &ServerSession{
+		HooksContext: c,
+		hooks:        set.NewStrings(args.Hooks...),
+		actions:      set.NewStrings(args.Actions...),
+	}
+	for _, filter := range args.Relations {
+		session.relations = append(session.relations, parseRelationFilter(filter))
+	}
 	return session, nil
 }
 
@@ -95,6 +95,64 @@ func (s *DebugHooksServerSuite) TestFindSession(c *gc.C) {
 	c.Assert(session.MatchHook("foo bar baz"), jc.IsFalse)
 }
 
+func (s *DebugHooksServerSuite) TestMatchFilters(c *gc.C) {
+	relationChanged := HookInfo{Name: "db-relation-changed", RelationName: "db", RemoteUnit: "mysql/1"}
+	otherUnitChanged := HookInfo{Name: "db-relation-changed", RelationName: "db", RemoteUnit: "mysql/2"}
+	relationJoined := HookInfo{Name: "db-relation-joined", RelationName: "db", RemoteUnit: "mysql/1"}
+	otherRelation := HookInfo{Name: "cache-relation-changed", RelationName: "cache", RemoteUnit: "memcached/0"}
+	start := HookInfo{Name: "start"}
+	backup := HookInfo{Name: "backup", IsAction: true}
+	restore := HookInfo{Name: "restore", IsAction: true}
+	all := []HookInfo{relationChanged, otherUnitChanged, relationJoined, otherRelation, start, backup, restore}
+
+	for i, test := range []struct {
+		args    string
+		matches []HookInfo
+	}{{
+		args:    ``,
+		matches: all,
+	}, {
+		args:    `hooks: [start]`,
+		matches: []HookInfo{start},
+	}, {
+		args:    `actions: [backup]`,
+		matches: []HookInfo{backup},
+	}, {
+		args:    `actions: ["*"]`,
+		matches: []HookInfo{backup, restore},
+	}, {
+		args:    `{hooks: ["*"], actions: [backup]}`,
+		matches: []HookInfo{relationChanged, otherUnitChanged, relationJoined, otherRelation, start, backup},
+	}, {
+		args:    `relations: [db]`,
+		matches: []HookInfo{relationChanged, otherUnitChanged, relationJoined},
+	}, {
+		args:    `relations: ["db:mysql/1"]`,
+		matches: []HookInfo{relationChanged, relationJoined},
+	}, {
+		args:    `{hooks: [db-relation-changed], relations: ["db:mysql/1", "cache"]}`,
+		matches: []HookInfo{relationChanged},
+	}, {
+		args:    `{actions: [restore], relations: ["db:mysql/1"]}`,
+		matches: []HookInfo{relationChanged, relationJoined, restore},
+	}} {
+		c.Logf("test %d: %s", i, test.args)
+		err := ioutil.WriteFile(s.ctx.ClientFileLock(), []byte(test.args), 0777)
+		c.Assert(err, jc.ErrorIsNil)
+		session, err := s.ctx.FindSession()
+		c.Assert(err, jc.ErrorIsNil)
+		for _, info := range all {
+			expect := false
+			for _, match := range test.matches {
+				if match == info {
+					expect = true
+				}
+			}
+			c.Check(session.Match(info), gc.Equals, expect, gc.Commentf("%#v", info))
+		}
+	}
+}
+
 func (s *DebugHooksServerSuite) TestRunHookExceptional(c *gc.C) {
 	err := ioutil.WriteFile(s.ctx.ClientFileLock(), []byte{}, 0777)
 	c.Assert(err, jc.ErrorIsNil)
@@ -13,6 +13,9 @@ var ErrRequeueAndReboot = errors.New("reboot now")
 var ErrReboot = errors.New("reboot after hook")
 var ErrNoProcess = errors.New("no process to kill")
 var ErrActionNotAvailable = errors.New("action no longer available")
+var ErrActionTimedOut = errors.New("action timed out")
+var ErrActionCancelled = errors.New("action cancelled")
+var ErrHookTimedOut = errors.New("hook timed out")
 
 type missingHookError struct {
 	hookName string
@@ -24,6 +24,7 @@ var (
 	TryOpenPorts            = tryOpenPorts
 	TryClosePorts           = tryClosePorts
 	LockTimeout             = lockTimeout
+	ActionPollInterval      = &actionPollInterval
 )
 
 func RunnerPaths(rnr Runner) Paths {
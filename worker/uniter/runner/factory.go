@@ -95,6 +95,7 @@ func NewFactory(
 	}
 	return &factory{
 		unit:             unit,
+		service:          service,
 		state:            state,
 		tracker:          tracker,
 		paths:            paths,
@@ -112,6 +113,7 @@ func NewFactory(
 type factory struct {
 	// API connection fields; unit should be deprecated, but isn't yet.
 	unit    *uniter.Unit
+	service *uniter.Service
 	state   *uniter.State
 	tracker leadership.Tracker
 
@@ -175,7 +177,7 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
 	}
-	if hookInfo.Kind.IsStorage() {
+	if hook.IsStorage(hookInfo.Kind) {
 		ctx.storageTag = names.NewStorageTag(hookInfo.StorageId)
 		if _, found := ctx.storage.Storage(ctx.storageTag); !found {
 			return nil, errors.Errorf("unknown storage id: %v", hookInfo.StorageId)
@@ -208,6 +210,13 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 			return nil, errors.Trace(err)
 		}
 	}
+	ctx.hookTimeout, err = f.service.HookTimeout()
+	if errors.IsNotImplemented(err) {
+		// The state server cannot tell us, so hooks are never killed.
+		ctx.hookTimeout = 0
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
 	ctx.id = f.newId(hookName)
 	runner := NewRunner(ctx, f.paths)
 	return runner, nil
@@ -253,7 +262,7 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	ctx.actionData = newActionData(name, &tag, params)
+	ctx.actionData = newActionData(name, &tag, params, action.Timeout())
 	ctx.id = f.newId(name)
 	runner := NewRunner(ctx, f.paths)
 	return runner, nil
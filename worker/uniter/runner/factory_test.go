@@ -273,6 +273,18 @@ func (s *FactorySuite) TestNewHookRunner(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *FactorySuite) TestNewHookRunnerHookTimeout(c *gc.C) {
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rnr.Context().HookTimeout(), gc.Equals, time.Duration(0))
+
+	err = s.service.SetHookTimeout(15 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	rnr, err = s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rnr.Context().HookTimeout(), gc.Equals, 15*time.Minute)
+}
+
 func (s *FactorySuite) TestNewHookRunnerWithBadHook(c *gc.C) {
 	rnr, err := s.factory.NewHookRunner(hook.Info{})
 	c.Assert(rnr, gc.IsNil)
@@ -583,6 +595,68 @@ func (s *FactorySuite) TestNewActionRunnerGood(c *gc.C) {
 	c.Assert(combined, gc.Matches, `(^|.*\|)JUJU_ACTION_TAG=`+action.Tag().String()+`(\|.*|$)`)
 }
 
+func (s *FactorySuite) TestNewActionRunnerTimeout(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	action, err := s.State.EnqueueActionWithTimeout(s.unit.Tag(), "snapshot", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	rnr, err := s.factory.NewActionRunner(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := rnr.Context().ActionData()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.ActionTimeout, gc.Equals, time.Minute)
+
+	// An action that times out is recorded as such.
+	err = rnr.Context().FlushContext("snapshot", errors.Trace(runner.ErrActionTimedOut))
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Status(), gc.Equals, state.ActionTimedOut)
+	_, message := action.Results()
+	c.Assert(message, gc.Equals, "action timed out after 1m0s")
+}
+
+func (s *FactorySuite) TestNewActionRunnerCancelled(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	action, err := s.State.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rnr, err := s.factory.NewActionRunner(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit.CancelAction(action)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := rnr.Context().ActionStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, params.ActionCancelled)
+
+	// The results of a cancelled action are discarded, whether it was
+	// killed or finished running before it noticed.
+	err = rnr.Context().FlushContext("snapshot", errors.Trace(runner.ErrActionCancelled))
+	c.Assert(err, jc.ErrorIsNil)
+	err = rnr.Context().FlushContext("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Status(), gc.Equals, state.ActionCancelled)
+}
+
+func (s *FactorySuite) TestNewActionRunnerLog(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	action, err := s.State.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rnr, err := s.factory.NewActionRunner(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = rnr.Context().LogActionMessage("migrating table 1 of 2")
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "migrating table 1 of 2")
+}
+
 func (s *FactorySuite) TestNewActionRunnerBadCharm(c *gc.C) {
 	rnr, err := s.factory.NewActionRunner("irrelevant")
 	c.Assert(rnr, gc.IsNil)
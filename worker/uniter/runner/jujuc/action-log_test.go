@@ -0,0 +1,90 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ActionLogSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&ActionLogSuite{})
+
+type actionLogContext struct {
+	jujuc.Context
+	messages []string
+}
+
+func (ctx *actionLogContext) LogActionMessage(message string) error {
+	ctx.messages = append(ctx.messages, message)
+	return nil
+}
+
+func (s *ActionLogSuite) TestActionLog(c *gc.C) {
+	for i, t := range []struct {
+		summary  string
+		command  []string
+		messages []string
+		errMsg   string
+		code     int
+	}{{
+		summary: "a message is required",
+		command: []string{},
+		errMsg:  "error: no message specified\n",
+		code:    2,
+	}, {
+		summary:  "a message is logged",
+		command:  []string{"migrating table 1 of 2"},
+		messages: []string{"migrating table 1 of 2"},
+	}, {
+		summary:  "several arguments are joined into one message",
+		command:  []string{"migrating", "table", "2", "of", "2"},
+		messages: []string{"migrating table 2 of 2"},
+	}} {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx := &actionLogContext{}
+		com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.command)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.errMsg)
+		c.Check(hctx.messages, jc.DeepEquals, t.messages)
+	}
+}
+
+func (s *ActionLogSuite) TestNonActionLogFails(c *gc.C) {
+	hctx := &Context{}
+	com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"progress"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: not running an action\n")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
+
+func (s *ActionLogSuite) TestHelp(c *gc.C) {
+	hctx := &Context{}
+	com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"--help"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, `usage: action-log <message>
+purpose: record a progress message for the action
+
+action-log records a progress message for the running action. Messages are
+timestamped and stored by the state server as they are logged, and can be
+followed with "juju action fetch --follow" while the action runs.
+`)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+}
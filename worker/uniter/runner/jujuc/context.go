@@ -95,6 +95,10 @@ type Context interface {
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
 
+	// LogActionMessage records a progress message for the running
+	// Action.
+	LogActionMessage(string) error
+
 	// HookRelation returns the ContextRelation associated with the executing
 	// hook if it was found, and whether it was found.
 	HookRelation() (ContextRelation, bool)
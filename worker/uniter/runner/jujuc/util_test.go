@@ -221,6 +221,10 @@ func (c *Context) SetActionMessage(message string) error {
 	return fmt.Errorf("not running an action")
 }
 
+func (c *Context) LogActionMessage(message string) error {
+	return fmt.Errorf("not running an action")
+}
+
 func (c *Context) HookRelation() (jujuc.ContextRelation, bool) {
 	return c.Relation(c.relid)
 }
@@ -0,0 +1,18 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup does nothing on windows, where processes are not
+// grouped.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills proc. Processes it started are not killed.
+func killProcessGroup(proc *os.Process) error {
+	return proc.Kill()
+}
@@ -8,12 +8,14 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	utilexec "github.com/juju/utils/exec"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -41,6 +43,8 @@ type Context interface {
 	Id() string
 	HookVars(paths Paths) []string
 	ActionData() (*ActionData, error)
+	ActionStatus() (string, error)
+	HookTimeout() time.Duration
 	SetProcess(process *os.Process)
 	FlushContext(badge string, failure error) error
 	HasExecutionSetUnitStatus() bool
@@ -137,7 +141,7 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 	}
 
 	debugctx := debug.NewHooksContext(runner.context.UnitName())
-	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
+	if session, _ := debugctx.FindSession(); session != nil && session.Match(runner.debugHookInfo(hookName, charmLocation)) {
 		logger.Infof("executing %s via debug-hooks", hookName)
 		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
 	} else {
@@ -146,6 +150,23 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 	return runner.context.FlushContext(hookName, err)
 }
 
+// debugHookInfo describes the hook or action to be run, so that a
+// debug-hooks session can decide whether to intercept it.
+func (runner *runner) debugHookInfo(hookName, charmLocation string) debug.HookInfo {
+	info := debug.HookInfo{
+		Name:     hookName,
+		IsAction: charmLocation == "actions",
+	}
+	if info.IsAction {
+		return info
+	}
+	if relation, found := runner.context.HookRelation(); found {
+		info.RelationName = relation.Name()
+	}
+	info.RemoteUnit, _ = runner.context.RemoteUnitName()
+	return info
+}
+
 func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
@@ -160,6 +181,27 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	var actionData *ActionData
+	var hookTimeout time.Duration
+	if charmLocation == "actions" {
+		if actionData, err = runner.context.ActionData(); err != nil {
+			return errors.Trace(err)
+		}
+		// A timeout given when the action was enqueued overrides
+		// the one declared by the charm.
+		if actionData.ActionTimeout == 0 {
+			timeout, err := actionSpecTimeout(charmDir, hookName)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			actionData.ActionTimeout = timeout
+		}
+		// Run the action in its own process group, so that any
+		// processes it starts are killed along with it.
+		setProcessGroup(ps)
+	} else if hookTimeout = runner.context.HookTimeout(); hookTimeout > 0 {
+		setProcessGroup(ps)
+	}
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -178,12 +220,89 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(ps.Process)
 		// Block until execution finishes
-		err = ps.Wait()
+		if actionData != nil {
+			err = runner.waitAction(ps, actionData)
+		} else if hookTimeout > 0 {
+			err = waitHook(ps, hookName, hookTimeout)
+		} else {
+			err = ps.Wait()
+		}
 	}
 	hookLogger.stop()
 	return errors.Trace(err)
 }
 
+// actionPollInterval is how often a running action is checked for
+// cancellation. A cancelled action may therefore keep running for up
+// to this long before it is killed.
+var actionPollInterval = 5 * time.Second
+
+// waitAction waits for the process running an action to finish. The
+// process group of the action is killed if the action runs for longer
+// than its timeout, or if it is cancelled while running, in which case
+// ErrActionTimedOut or ErrActionCancelled is returned.
+func (runner *runner) waitAction(ps *exec.Cmd, data *ActionData) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	var timeout <-chan time.Time
+	if data.ActionTimeout > 0 {
+		timeout = time.After(data.ActionTimeout)
+	}
+	poll := time.After(actionPollInterval)
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-timeout:
+			logger.Infof("action %q timed out after %v", data.ActionName, data.ActionTimeout)
+			return killProcess(ps, done, ErrActionTimedOut)
+		case <-poll:
+			status, err := runner.context.ActionStatus()
+			if errors.IsNotImplemented(err) {
+				// The state server cannot tell us, so stop asking.
+				logger.Debugf("cannot check for cancellation of action %q: %v", data.ActionName, err)
+				poll = nil
+				continue
+			} else if err != nil {
+				logger.Warningf("cannot check for cancellation of action %q: %v", data.ActionName, err)
+			} else if status == params.ActionCancelled {
+				logger.Infof("action %q cancelled", data.ActionName)
+				return killProcess(ps, done, ErrActionCancelled)
+			}
+			poll = time.After(actionPollInterval)
+		}
+	}
+}
+
+// waitHook waits for the process running a hook to finish. The process
+// group of the hook is killed if the hook runs for longer than timeout,
+// in which case ErrHookTimedOut is returned.
+func waitHook(ps *exec.Cmd, hookName string, timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		logger.Warningf("hook %q timed out after %v", hookName, timeout)
+		return killProcess(ps, done, ErrHookTimedOut)
+	}
+}
+
+// killProcess kills the process group of the hook or action run by ps,
+// waits for it to finish, and returns reason.
+func killProcess(ps *exec.Cmd, done <-chan error, reason error) error {
+	if err := killProcessGroup(ps.Process); err != nil {
+		logger.Warningf("cannot kill process %d: %v", ps.Process.Pid, err)
+	}
+	<-done
+	return reason
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
@@ -147,6 +149,8 @@ func (s *RunHookSuite) TestRunHook(c *gc.C) {
 type MockContext struct {
 	runner.Context
 	actionData   *runner.ActionData
+	actionStatus string
+	hookTimeout  time.Duration
 	expectPid    int
 	flushBadge   string
 	flushFailure error
@@ -168,6 +172,14 @@ func (ctx *MockContext) ActionData() (*runner.ActionData, error) {
 	return ctx.actionData, nil
 }
 
+func (ctx *MockContext) ActionStatus() (string, error) {
+	return ctx.actionStatus, nil
+}
+
+func (ctx *MockContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 func (ctx *MockContext) SetProcess(process *os.Process) {
 	ctx.expectPid = process.Pid
 }
@@ -270,6 +282,161 @@ func (s *RunMockContextSuite) TestRunActionFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) assertChildKilled(c *gc.C) {
+	path := filepath.Join(s.paths.charm, "child")
+	content, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	pid, err := strconv.Atoi(strings.TrimRight(string(content), "\r\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if !processExists(pid) {
+			return
+		}
+	}
+	c.Fatalf("child process %d still running", pid)
+}
+
+func (s *RunMockContextSuite) TestRunActionTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("actions are run in their own process group only on unix")
+	}
+	ctx := &MockContext{
+		actionData: &runner.ActionData{
+			ActionName:    "something-happened",
+			ActionTimeout: 100 * time.Millisecond,
+		},
+		actionStatus: "running",
+	}
+	makeCharm(c, hookSpec{
+		dir:   "actions",
+		name:  hookName,
+		perm:  0700,
+		sleep: 100,
+	}, s.paths.charm)
+	actualErr := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(actualErr, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(errors.Cause(ctx.flushFailure), gc.Equals, runner.ErrActionTimedOut)
+	s.assertRecordedPid(c, ctx.expectPid)
+	s.assertChildKilled(c)
+}
+
+func (s *RunMockContextSuite) TestRunActionSpecTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("actions are run in their own process group only on unix")
+	}
+	ctx := &MockContext{
+		actionData: &runner.ActionData{
+			ActionName: "something-happened",
+		},
+		actionStatus: "running",
+	}
+	makeCharm(c, hookSpec{
+		dir:   "actions",
+		name:  hookName,
+		perm:  0700,
+		sleep: 100,
+	}, s.paths.charm)
+	s.writeActionsYaml(c, "something-happened:\n  timeout: 100ms\n")
+	actualErr := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(actualErr, jc.ErrorIsNil)
+	c.Assert(errors.Cause(ctx.flushFailure), gc.Equals, runner.ErrActionTimedOut)
+	c.Assert(ctx.actionData.ActionTimeout, gc.Equals, 100*time.Millisecond)
+	s.assertChildKilled(c)
+}
+
+func (s *RunMockContextSuite) TestRunActionTimeoutOverridesSpec(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("actions are run in their own process group only on unix")
+	}
+	ctx := &MockContext{
+		actionData: &runner.ActionData{
+			ActionName:    "something-happened",
+			ActionTimeout: 100 * time.Millisecond,
+		},
+		actionStatus: "running",
+	}
+	makeCharm(c, hookSpec{
+		dir:   "actions",
+		name:  hookName,
+		perm:  0700,
+		sleep: 100,
+	}, s.paths.charm)
+	s.writeActionsYaml(c, "something-happened:\n  timeout: 1h\n")
+	actualErr := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(actualErr, jc.ErrorIsNil)
+	c.Assert(errors.Cause(ctx.flushFailure), gc.Equals, runner.ErrActionTimedOut)
+	c.Assert(ctx.actionData.ActionTimeout, gc.Equals, 100*time.Millisecond)
+	s.assertChildKilled(c)
+}
+
+func (s *RunMockContextSuite) TestRunActionInvalidSpecTimeout(c *gc.C) {
+	ctx := &MockContext{
+		actionData: &runner.ActionData{
+			ActionName: "something-happened",
+		},
+	}
+	makeCharm(c, hookSpec{
+		dir:  "actions",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.charm)
+	s.writeActionsYaml(c, "something-happened:\n  timeout: soon\n")
+	actualErr := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(actualErr, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `invalid timeout "soon" for action "something-happened" in actions.yaml`)
+}
+
+func (s *RunMockContextSuite) writeActionsYaml(c *gc.C, content string) {
+	err := ioutil.WriteFile(filepath.Join(s.paths.charm, "actions.yaml"), []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hooks are run in their own process group only on unix")
+	}
+	ctx := &MockContext{
+		hookTimeout: 100 * time.Millisecond,
+	}
+	makeCharm(c, hookSpec{
+		dir:   "hooks",
+		name:  hookName,
+		perm:  0700,
+		sleep: 100,
+	}, s.paths.charm)
+	actualErr := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(actualErr, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(errors.Cause(ctx.flushFailure), gc.Equals, runner.ErrHookTimedOut)
+	s.assertRecordedPid(c, ctx.expectPid)
+	s.assertChildKilled(c)
+}
+
+func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("actions are run in their own process group only on unix")
+	}
+	s.PatchValue(runner.ActionPollInterval, 10*time.Millisecond)
+	ctx := &MockContext{
+		actionData: &runner.ActionData{
+			ActionName: "something-happened",
+		},
+		actionStatus: "cancelled",
+	}
+	makeCharm(c, hookSpec{
+		dir:   "actions",
+		name:  hookName,
+		perm:  0700,
+		sleep: 100,
+	}, s.paths.charm)
+	actualErr := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(actualErr, jc.ErrorIsNil)
+	c.Assert(errors.Cause(ctx.flushFailure), gc.Equals, runner.ErrActionCancelled)
+	s.assertRecordedPid(c, ctx.expectPid)
+	s.assertChildKilled(c)
+}
+
 func (s *RunMockContextSuite) TestRunCommandsFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
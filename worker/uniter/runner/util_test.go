@@ -284,6 +284,10 @@ type hookSpec struct {
 	stderr string
 	// background holds a string to print in the background after 0.2s.
 	background string
+	// sleep holds a number of seconds to wait, before exiting, for a
+	// child process that sleeps; the pid of the child is written to
+	// the "child" file.
+	sleep int
 }
 
 // makeCharm constructs a fake charm dir containing a single named hook
@@ -328,6 +332,11 @@ func makeCharm(c *gc.C, spec hookSpec, charmDir string) {
 		// expected.
 		printf("(sleep 0.2; echo %s; sleep 10) &", spec.background)
 	}
+	if spec.sleep != 0 {
+		printf("sleep %d &", spec.sleep)
+		printf("echo $! > child")
+		printf("wait")
+	}
 	printf("exit %d", spec.code)
 }
 
@@ -38,6 +38,10 @@ type storageHookQueue struct {
 	// hook has been executed.
 	attached bool
 
+	// size records the size of the storage, in MiB, when the
+	// storage-attached or storage-resized hook was last queued.
+	size uint64
+
 	// hookInfo is the next hook.Info to return, if non-nil.
 	hookInfo *hook.Info
 
@@ -171,10 +175,19 @@ func (s *storageHookQueue) Update(attachment params.StorageAttachment) error {
 	case params.Alive:
 		if s.attached {
 			// Storage attachments currently do not change
-			// (apart from lifecycle) after being provisioned.
-			// We don't process unprovisioned storage here,
-			// so there's nothing to do.
-			return nil
+			// (apart from lifecycle and growth of the storage)
+			// after being provisioned. We don't process
+			// unprovisioned storage here, so there's nothing
+			// to do unless the storage has been grown. If we
+			// have not yet seen the size, because the agent
+			// has restarted, we just record it.
+			grown := s.size != 0 && attachment.Size > s.size
+			if attachment.Size > s.size {
+				s.size = attachment.Size
+			}
+			if !grown {
+				return nil
+			}
 		}
 	case params.Dying:
 		if !s.attached {
@@ -207,10 +220,14 @@ func (s *storageHookQueue) Update(attachment params.StorageAttachment) error {
 			StorageId: s.storageTag.Id(),
 		}
 	}
-	if attachment.Life == params.Alive {
-		s.hookInfo.Kind = hooks.StorageAttached
-	} else {
+	switch {
+	case attachment.Life != params.Alive:
 		s.hookInfo.Kind = hooks.StorageDetaching
+	case s.attached:
+		s.hookInfo.Kind = hook.StorageResized
+	default:
+		s.hookInfo.Kind = hooks.StorageAttached
+		s.size = attachment.Size
 	}
 	logger.Debugf("queued hook: %v", s.hookInfo)
 	return nil
@@ -104,6 +104,64 @@ func (s *storageHookQueueSuite) TestStorageHookQueueAttachDetach(c *gc.C) {
 	})
 }
 
+func (s *storageHookQueueSuite) TestStorageHookQueueResized(c *gc.C) {
+	q := newHookQueue(initiallyUnattached)
+	resizeHookQueue(c, q, 1024)
+	q.Pop()
+
+	// An unchanged size does not cause any hook to be queued.
+	resizeHookQueue(c, q, 1024)
+	c.Assert(q.Empty(), jc.IsTrue)
+
+	resizeHookQueue(c, q, 2048)
+	c.Assert(q.Empty(), jc.IsFalse)
+	c.Assert(q.Next(), gc.Equals, hook.Info{
+		Kind:      hook.StorageResized,
+		StorageId: "data/0",
+	})
+	q.Pop()
+	c.Assert(q.Empty(), jc.IsTrue)
+}
+
+func (s *storageHookQueueSuite) TestStorageHookQueueResizedAfterRestart(c *gc.C) {
+	q := newHookQueue(initiallyAttached)
+	// The size is not known when the agent restarts,
+	// so the first update only records it.
+	resizeHookQueue(c, q, 1024)
+	c.Assert(q.Empty(), jc.IsTrue)
+
+	resizeHookQueue(c, q, 2048)
+	c.Assert(q.Next(), gc.Equals, hook.Info{
+		Kind:      hook.StorageResized,
+		StorageId: "data/0",
+	})
+	_, ok := q.Context()
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *storageHookQueueSuite) TestStorageHookQueueResizedUnconsumedDetach(c *gc.C) {
+	q := newHookQueue(initiallyUnattached)
+	resizeHookQueue(c, q, 1024)
+	q.Pop()
+	resizeHookQueue(c, q, 2048)
+	// don't consume the storage-resized hook; it should be replaced
+	updateHookQueue(c, q, params.Dying)
+	c.Assert(q.Next(), gc.Equals, hook.Info{
+		Kind:      hooks.StorageDetaching,
+		StorageId: "data/0",
+	})
+}
+
+func resizeHookQueue(c *gc.C, q storage.StorageHookQueue, size uint64) {
+	err := q.Update(params.StorageAttachment{
+		Life:     params.Alive,
+		Kind:     params.StorageKindBlock,
+		Location: "/dev/sdb",
+		Size:     size,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *storageHookQueueSuite) TestStorageHookQueueDead(c *gc.C) {
 	q := newHookQueue(initiallyAttached)
 	updateHookQueue(c, q, params.Dying)
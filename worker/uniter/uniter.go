@@ -261,13 +261,21 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		u.tomb.Dying(),
 	)
 
+	hookRuns := newHookRunRecorder(u.unit)
 	operationExecutor, err := operation.NewExecutor(
-		u.paths.State.OperationsFile, u.getServiceCharmURL,
+		u.paths.State.OperationsFile, u.getServiceCharmURL, hookRuns.Record,
 	)
 	if err != nil {
 		return err
 	}
 	u.operationExecutor = operationExecutor
+	u.addCleanup(func() error {
+		// Failing to record hook runs should not stop the uniter.
+		if err := hookRuns.Flush(); err != nil {
+			logger.Warningf("cannot record hook runs: %v", err)
+		}
+		return nil
+	})
 
 	logger.Debugf("starting juju-run listener on unix:%s", u.paths.Runtime.JujuRunSocket)
 	u.runListener, err = NewRunListener(u, u.paths.Runtime.JujuRunSocket)